@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/core"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// inspectPreviewLength caps how much of each memory's content is printed.
+const inspectPreviewLength = 200
+
+// inspectSummary counts stored tool-call format problems found while inspecting.
+type inspectSummary struct {
+	ToolCalls        int
+	ToolResults      int
+	OrphanedCalls    int
+	OrphanedResults  int
+	MalformedCalls   int
+	MalformedResults int
+	InvalidArguments int
+}
+
+// inspect opens the store and prints a mysis's memories to stdout.
+func inspect(dbPath, mysisID string, limit int) error {
+	var s *store.Store
+	var err error
+	if dbPath != "" {
+		s, err = store.Open(dbPath)
+	} else {
+		s, err = store.New()
+	}
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+	defer s.Close()
+
+	return runInspect(os.Stdout, s, mysisID, limit)
+}
+
+// runInspect prints a mysis's stored memories, decoding tool calls and tool results
+// with the same helpers the core uses to build LLM context. A limit of 0 prints all.
+func runInspect(w io.Writer, s *store.Store, mysisID string, limit int) error {
+	stored, err := s.GetMysis(mysisID)
+	if err != nil {
+		return fmt.Errorf("get mysis %s: %w", mysisID, err)
+	}
+
+	var memories []*store.Memory
+	if limit > 0 {
+		memories, err = s.GetRecentMemories(mysisID, limit)
+	} else {
+		memories, err = s.GetMemories(mysisID)
+	}
+	if err != nil {
+		return fmt.Errorf("get memories: %w", err)
+	}
+
+	// Index call and result IDs first so orphans are reported in both directions
+	callNames := make(map[string]string)
+	resultIDs := make(map[string]bool)
+	for _, mem := range memories {
+		switch {
+		case isStoredToolCall(mem):
+			for _, call := range core.ParseStoredToolCalls(mem.Content) {
+				callNames[call.ID] = call.Name
+			}
+		case mem.Role == store.MemoryRoleTool:
+			if id, _, ok := core.ParseStoredToolResult(mem.Content); ok {
+				resultIDs[id] = true
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "Mysis %s (%s) - %s/%s, state %s\n", stored.Name, stored.ID, stored.Provider, stored.Model, stored.State)
+	fmt.Fprintf(w, "%d memories\n\n", len(memories))
+
+	var summary inspectSummary
+	for _, mem := range memories {
+		fmt.Fprintf(w, "#%d %s %s/%s", mem.ID, mem.CreatedAt.Format("2006-01-02 15:04:05"), mem.Role, mem.Source)
+		if mem.SenderID != "" {
+			fmt.Fprintf(w, " from %s", mem.SenderID)
+		}
+		fmt.Fprintln(w)
+
+		switch {
+		case isStoredToolCall(mem):
+			calls := core.ParseStoredToolCalls(mem.Content)
+			if len(calls) == 0 {
+				summary.MalformedCalls++
+				fmt.Fprintf(w, "  MALFORMED tool calls: %s\n", preview(mem.Content))
+				break
+			}
+			for _, call := range calls {
+				summary.ToolCalls++
+				fmt.Fprintf(w, "  call %s %s %s\n", call.ID, call.Name, preview(string(call.Arguments)))
+				if !json.Valid(call.Arguments) {
+					summary.InvalidArguments++
+					fmt.Fprintln(w, "    INVALID JSON arguments (sent to the LLM as {})")
+				}
+				if !resultIDs[call.ID] {
+					summary.OrphanedCalls++
+					fmt.Fprintln(w, "    ORPHANED: no tool result")
+				}
+			}
+		case mem.Role == store.MemoryRoleTool:
+			id, content, ok := core.ParseStoredToolResult(mem.Content)
+			if !ok {
+				summary.MalformedResults++
+				fmt.Fprintf(w, "  MALFORMED tool result (missing tool_call_id): %s\n", preview(mem.Content))
+				break
+			}
+			summary.ToolResults++
+			name, hasCall := callNames[id]
+			if !hasCall {
+				summary.OrphanedResults++
+				name = "?"
+			}
+			fmt.Fprintf(w, "  result %s (%s): %s\n", id, name, preview(content))
+			if !hasCall {
+				fmt.Fprintln(w, "    ORPHANED: no matching tool call")
+			}
+		default:
+			fmt.Fprintf(w, "  %s\n", preview(mem.Content))
+		}
+
+		if mem.Reasoning != "" {
+			fmt.Fprintf(w, "  reasoning: %s\n", preview(mem.Reasoning))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Tool calls: %d (orphaned %d, malformed records %d, invalid args %d)\n",
+		summary.ToolCalls, summary.OrphanedCalls, summary.MalformedCalls, summary.InvalidArguments)
+	fmt.Fprintf(w, "Tool results: %d (orphaned %d, malformed %d)\n",
+		summary.ToolResults, summary.OrphanedResults, summary.MalformedResults)
+	return nil
+}
+
+func isStoredToolCall(mem *store.Memory) bool {
+	return mem.Role == store.MemoryRoleAssistant && strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix)
+}
+
+// preview flattens content to a single line and truncates it for display.
+func preview(s string) string {
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	if len(s) > inspectPreviewLength {
+		return s[:inspectPreviewLength] + "..."
+	}
+	return s
+}
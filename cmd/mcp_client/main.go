@@ -134,8 +134,20 @@ func parseValue(s string) interface{} {
 func main() {
 	configPath := flag.String("config", "config.toml", "Path to config file")
 	endpoint := flag.String("endpoint", "", "MCP server endpoint (overrides config)")
+	inspectID := flag.String("inspect", "", "Dump a mysis's stored memories with decoded tool calls, then exit")
+	dbPath := flag.String("db", "", "Database path for -inspect (defaults to the Zoea Nova data dir)")
+	limit := flag.Int("limit", 0, "Most recent memories to show with -inspect (0 = all; a window may cut tool loops)")
 	flag.Parse()
 
+	// Inspect reads the store directly and doesn't need an MCP connection
+	if *inspectID != "" {
+		if err := inspect(*dbPath, *inspectID, *limit); err != nil {
+			fmt.Fprintf(os.Stderr, "Inspect failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Load config
 	cfg, err := config.Load(*configPath)
 	if err != nil {
@@ -272,11 +284,7 @@ func main() {
 			fmt.Println("  [ERROR]")
 		}
 		for _, content := range result.Content {
-			if content.Type == "text" {
-				fmt.Printf("  %s\n", content.Text)
-			} else {
-				fmt.Printf("  [%s content]\n", content.Type)
-			}
+			fmt.Printf("  %s\n", content.DisplayText())
 		}
 		fmt.Println()
 	}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/core"
+)
+
+// stateDump is the JSON document written on SIGUSR1.
+type stateDump struct {
+	Timestamp         time.Time     `json:"timestamp"`
+	Version           string        `json:"version"`
+	Myses             []mysisDump   `json:"myses"`
+	ProviderLatencies []latencyDump `json:"provider_latencies"`
+}
+
+// latencyDump reports a provider's call latency percentiles in milliseconds.
+type latencyDump struct {
+	Provider string `json:"provider"`
+	Calls    uint64 `json:"calls"`
+	P50Ms    int64  `json:"p50_ms"`
+	P95Ms    int64  `json:"p95_ms"`
+	P99Ms    int64  `json:"p99_ms"`
+}
+
+// mysisDump captures a single mysis snapshot for debugging.
+type mysisDump struct {
+	ID                 string             `json:"id"`
+	Name               string             `json:"name"`
+	Provider           string             `json:"provider"`
+	State              core.MysisState    `json:"state"`
+	Activity           core.ActivityState `json:"activity"`
+	ActivityUntil      *time.Time         `json:"activity_until,omitempty"`
+	LastError          string             `json:"last_error,omitempty"`
+	EncouragementCount int                `json:"encouragement_count"`
+	CurrentAccount     string             `json:"current_account,omitempty"`
+	TurnCount          int                `json:"turn_count"`
+	MemoryStats        *core.ContextStats `json:"memory_stats,omitempty"`
+	MemoryStatsError   string             `json:"memory_stats_error,omitempty"`
+}
+
+// writeStateDump writes every mysis snapshot to <dataDir>/dump-<timestamp>.json.
+// All mysis fields are read through locked accessors. Panics are recovered and
+// returned as errors so a dump can never take down the app.
+func writeStateDump(commander *core.Commander, dataDir string, now time.Time) (path string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("state dump panic: %v", r)
+		}
+	}()
+
+	dump := stateDump{
+		Timestamp: now,
+		Version:   Version,
+		Myses:     []mysisDump{},
+	}
+	for _, m := range commander.ListMyses() {
+		entry := mysisDump{
+			ID:                 m.ID(),
+			Name:               m.Name(),
+			Provider:           m.ProviderName(),
+			State:              m.State(),
+			Activity:           m.ActivityState(),
+			EncouragementCount: m.EncouragementCount(),
+			CurrentAccount:     m.CurrentAccountUsername(),
+			TurnCount:          m.TurnCount(),
+		}
+		if until := m.ActivityUntil(); !until.IsZero() {
+			entry.ActivityUntil = &until
+		}
+		if lastErr := m.LastError(); lastErr != nil {
+			entry.LastError = lastErr.Error()
+		}
+		if stats, statsErr := m.MemoryStats(); statsErr != nil {
+			entry.MemoryStatsError = statsErr.Error()
+		} else {
+			entry.MemoryStats = &stats
+		}
+		dump.Myses = append(dump.Myses, entry)
+	}
+	dump.ProviderLatencies = []latencyDump{}
+	for _, l := range commander.ProviderLatencies() {
+		dump.ProviderLatencies = append(dump.ProviderLatencies, latencyDump{
+			Provider: l.Provider,
+			Calls:    l.Count,
+			P50Ms:    l.P50.Milliseconds(),
+			P95Ms:    l.P95.Milliseconds(),
+			P99Ms:    l.P99.Milliseconds(),
+		})
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal dump: %w", err)
+	}
+
+	path = filepath.Join(dataDir, fmt.Sprintf("dump-%s.json", now.UTC().Format("20060102T150405Z")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("write dump: %w", err)
+	}
+	return path, nil
+}
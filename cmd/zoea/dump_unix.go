@@ -0,0 +1,41 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/xonecas/zoea-nova/internal/core"
+)
+
+// watchDumpSignal writes a state dump to dataDir each time SIGUSR1 is received.
+// Returns a stop function that unregisters the handler.
+func watchDumpSignal(commander *core.Commander, dataDir string) func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-sigCh:
+				path, err := writeStateDump(commander, dataDir, time.Now())
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to write state dump")
+					continue
+				}
+				log.Info().Str("path", path).Msg("State dump written")
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "github.com/xonecas/zoea-nova/internal/core"
+
+// watchDumpSignal is a no-op on Windows, which has no SIGUSR1. The returned stop
+// function is safe to call.
+func watchDumpSignal(commander *core.Commander, dataDir string) func() {
+	return func() {}
+}
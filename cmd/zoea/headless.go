@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/core"
+)
+
+// headlessPollInterval controls how often turn counts are checked in headless mode.
+const headlessPollInterval = 500 * time.Millisecond
+
+// headlessResult summarizes a single mysis after a headless run.
+type headlessResult struct {
+	name    string
+	turns   int
+	errors  int
+	errored bool
+	state   core.MysisState
+	tokens  int
+}
+
+// runHeadless starts every loaded mysis, lets each complete the requested number of
+// turns (0 = no limit) or run until the deadline (0 = no deadline), then stops them and
+// prints a summary. Returns the process exit code: 1 if any mysis errored.
+func runHeadless(commander *core.Commander, bus *core.EventBus, turns int, deadline time.Duration) int {
+	myses := commander.ListMyses()
+	if len(myses) == 0 {
+		fmt.Println("No myses to run")
+		return 0
+	}
+
+	// Drain the bus so publishers never block, tracking errors per mysis
+	eventCh := bus.Subscribe()
+	var mu sync.Mutex
+	errorCounts := make(map[string]int)
+	erroredSeen := make(map[string]bool)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for event := range eventCh {
+			switch event.Type {
+			case core.EventMysisError:
+				mu.Lock()
+				errorCounts[event.MysisID]++
+				mu.Unlock()
+			case core.EventMysisStateChanged:
+				if event.State != nil && event.State.NewState == core.MysisStateErrored {
+					mu.Lock()
+					erroredSeen[event.MysisID] = true
+					mu.Unlock()
+				}
+			}
+		}
+	}()
+
+	// TurnCount spans the mysis's lifetime; -turns and the summary count this run only
+	startTurns := make(map[string]int, len(myses))
+	for _, m := range myses {
+		startTurns[m.ID()] = m.TurnCount()
+		if err := m.Start(); err != nil {
+			log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to start mysis")
+		}
+	}
+	fmt.Printf("Running %d myses headless (turns=%d, deadline=%s)\n", len(myses), turns, deadline)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	var deadlineCh <-chan time.Time
+	if deadline > 0 {
+		timer := time.NewTimer(deadline)
+		defer timer.Stop()
+		deadlineCh = timer.C
+	}
+
+	ticker := time.NewTicker(headlessPollInterval)
+	defer ticker.Stop()
+
+wait:
+	for {
+		select {
+		case <-sigCh:
+			log.Info().Msg("Received shutdown signal - ending headless run")
+			break wait
+		case <-deadlineCh:
+			log.Info().Dur("deadline", deadline).Msg("Headless deadline reached")
+			break wait
+		case <-ticker.C:
+			active := 0
+			for _, m := range myses {
+				if m.State() != core.MysisStateRunning {
+					continue
+				}
+				if turns > 0 && m.TurnCount()-startTurns[m.ID()] >= turns {
+					if err := commander.StopMysis(m.ID()); err != nil {
+						log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to stop mysis after final turn")
+					}
+					continue
+				}
+				active++
+			}
+			if active == 0 {
+				break wait
+			}
+		}
+	}
+
+	// Capture final states before Shutdown moves everything to stopped
+	results := make([]headlessResult, 0, len(myses))
+	for _, m := range myses {
+		results = append(results, headlessResult{
+			name:  m.Name(),
+			state: m.State(),
+		})
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), constants.ShutdownTimeout)
+	if err := commander.Shutdown(shutdownCtx); err != nil {
+		log.Warn().Err(err).Msg("Tool observers not fully flushed on shutdown")
+	}
+	cancelShutdown()
+	logBusStats(bus)
+	bus.Close()
+	<-drained
+
+	exitCode := 0
+	mu.Lock()
+	for i, m := range myses {
+		results[i].turns = m.TurnCount() - startTurns[m.ID()]
+		results[i].tokens = m.TokenUsage().TotalTokens()
+		results[i].errors = errorCounts[m.ID()]
+		results[i].errored = erroredSeen[m.ID()] || results[i].state == core.MysisStateErrored
+		if results[i].errored || results[i].state == core.MysisStateQuarantined {
+			exitCode = 1
+		}
+	}
+	mu.Unlock()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].name < results[j].name })
+
+	fmt.Println()
+	fmt.Printf("%-20s %-8s %6s %6s %10s\n", "MYSIS", "STATE", "TURNS", "ERRORS", "TOKENS")
+	for _, r := range results {
+		state := string(r.state)
+		if r.errored {
+			state = string(core.MysisStateErrored)
+		}
+		fmt.Printf("%-20s %-8s %6d %6d %10d\n", r.name, state, r.turns, r.errors, r.tokens)
+	}
+
+	if latencies := commander.ProviderLatencies(); len(latencies) > 0 {
+		fmt.Println()
+		fmt.Printf("%-20s %6s %8s %8s %8s\n", "PROVIDER", "CALLS", "P50", "P95", "P99")
+		for _, l := range latencies {
+			fmt.Printf("%-20s %6d %8s %8s %8s\n", l.Provider, l.Count,
+				l.P50.Round(time.Millisecond), l.P95.Round(time.Millisecond), l.P99.Round(time.Millisecond))
+		}
+	}
+
+	return exitCode
+}
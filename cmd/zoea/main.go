@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -17,6 +19,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
 	"github.com/xonecas/zoea-nova/internal/core"
 	"github.com/xonecas/zoea-nova/internal/mcp"
 	"github.com/xonecas/zoea-nova/internal/provider"
@@ -39,6 +42,16 @@ func main() {
 		testMCP     = flag.Bool("test-mcp", false, "Test MCP connection and tool calling, then exit")
 		offline     = flag.Bool("offline", false, "Run in offline mode with stub MCP server")
 		startSwarm  = flag.Bool("start-swarm", false, "Auto-start all idle myses on launch")
+		replayID    = flag.String("replay", "", "Replay a mysis's stored prompts against a provider, then exit")
+		replayProv  = flag.String("provider", "", "Provider to use with -replay (defaults to the mysis's provider)")
+		headless    = flag.Bool("headless", false, "Run all myses without the TUI, print a summary, then exit")
+		turns       = flag.Int("turns", 0, "Turns per mysis in -headless mode (0 = no limit)")
+		deadline    = flag.Duration("deadline", 0, "Maximum run time in -headless mode (0 = no deadline)")
+		vacuum      = flag.Bool("vacuum", false, "Compact the database file, report bytes reclaimed, then exit")
+		exportSwarm = flag.String("export-swarm", "", "Write all myses, memories and accounts to an archive file, then exit")
+		importSwarm = flag.String("import-swarm", "", "Restore an -export-swarm archive into the database, then exit")
+		respCache   = flag.String("response-cache", "", "Cache provider responses in this directory for deterministic replay (dev only)")
+		traceProv   = flag.Bool("trace-provider", false, "Write full provider requests and responses (API keys redacted) to provider-trace.log in the data directory")
 	)
 	flag.Parse()
 
@@ -52,6 +65,26 @@ func main() {
 		return
 	}
 
+	if *replayID != "" {
+		runReplay(*configPath, *replayID, *replayProv, *respCache, *traceProv)
+		return
+	}
+
+	if *vacuum {
+		runVacuum(*configPath)
+		return
+	}
+
+	if *exportSwarm != "" {
+		runExportSwarm(*configPath, *exportSwarm)
+		return
+	}
+
+	if *importSwarm != "" {
+		runImportSwarm(*configPath, *importSwarm)
+		return
+	}
+
 	// Initialize logging
 	if err := initLogging(*debug); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
@@ -68,9 +101,13 @@ func main() {
 	// Load configuration
 	cfg, err := config.Load(*configPath)
 	if err != nil {
+		printConfigError(err)
 		log.Fatal().Err(err).Msg("Failed to load config")
 	}
 	log.Debug().Interface("config", cfg).Msg("Configuration loaded")
+	for _, warning := range cfg.Warnings() {
+		log.Warn().Msg(warning)
+	}
 
 	// Load credentials
 	creds, err := config.LoadCredentials()
@@ -80,7 +117,7 @@ func main() {
 	}
 
 	// Initialize store
-	s, err := store.New()
+	s, err := store.NewFromConfig(cfg.Store)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize store")
 	}
@@ -100,7 +137,20 @@ func main() {
 	// No defer needed here to avoid duplicate closes
 
 	// Initialize provider registry
-	registry := initProviders(cfg, creds)
+	var trace http.RoundTripper
+	if *traceProv {
+		transport, path, closeTrace, err := openProviderTrace()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open provider trace")
+		}
+		defer closeTrace()
+		trace = transport
+		log.Warn().Str("path", path).Msg("Provider tracing enabled - full requests and responses are written to the trace file")
+	}
+	registry := initProviders(cfg, creds, *respCache, trace)
+	if *respCache != "" {
+		log.Warn().Str("dir", *respCache).Msg("Provider response cache enabled - identical requests reuse stored replies")
+	}
 	log.Debug().Int("providers", len(registry.List())).Msg("Providers initialized")
 
 	// Determine MCP endpoint for myses
@@ -116,23 +166,54 @@ func main() {
 	// Initialize commander with MCP endpoint
 	commander := core.NewCommander(s, registry, bus, cfg, mcpEndpoint)
 
+	// Record tool calls in the audit trail and stream them to any [analytics] sinks
+	if err := commander.StartAnalytics(); err != nil {
+		log.Warn().Err(err).Msg("Failed to start tool call analytics")
+	}
+
 	// Load existing myses from database
 	if err := commander.LoadMyses(); err != nil {
 		log.Warn().Err(err).Msg("Failed to load existing myses")
 	}
 	log.Debug().Int("myses", commander.MysisCount()).Msg("Myses loaded")
 
+	// Periodic swarm summary broadcasts (no-op unless [coordinator] is enabled)
+	if err := commander.StartCoordinator(); err != nil {
+		log.Warn().Err(err).Msg("Failed to start coordinator")
+	}
+
+	if *headless {
+		exitCode := runHeadless(commander, bus, *turns, *deadline)
+		if err := s.ReleaseAllAccounts(); err != nil {
+			log.Warn().Err(err).Msg("Failed to release accounts on shutdown")
+		}
+		s.Close()
+		if logFile != nil {
+			logFile.Close()
+		}
+		os.Exit(exitCode)
+	}
+
 	// Auto-start all existing myses on launch
 	// Each mysis will create its own MCP client during Start()
-	for _, a := range commander.ListMyses() {
+	commander.ForEachMysis(func(a *core.Mysis) {
 		if err := a.Start(); err != nil {
 			log.Warn().Err(err).Str("mysis", a.Name()).Msg("Failed to start mysis")
 		}
-	}
+	})
 
 	// Log goroutine count at startup for leak detection
 	log.Info().Int("goroutines", runtime.NumGoroutine()).Msg("Application started")
 
+	// Dump swarm state to the data directory on SIGUSR1
+	dataDir, err := config.EnsureDataDir()
+	if err != nil {
+		log.Warn().Err(err).Msg("State dumps disabled")
+	} else {
+		stopDump := watchDumpSignal(commander, dataDir)
+		defer stopDump()
+	}
+
 	// Set up signal handling for graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -141,33 +222,67 @@ func main() {
 	eventCh := bus.Subscribe()
 
 	// Create and run TUI
+	tui.ApplyTheme(cfg.Theme)
 	model := tui.New(commander, s, eventCh, *startSwarm, cfg)
+	if dataDir != "" {
+		model.SetStateFile(filepath.Join(dataDir, "tui_state.json"))
+	}
 
 	// Set cleanup callback to close event bus before quit
 	model.SetOnQuit(func() {
+		logBusStats(bus)
 		bus.Close()
 	})
 
 	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
-	// Handle shutdown in a goroutine
+	// Quitting drains in-flight turns; a second signal cuts the drain short
+	drainCtx, cancelDrain := context.WithCancel(context.Background())
+	defer cancelDrain()
+
+	// Handle shutdown in a goroutine. Once the TUI has exited (quit with 'q' or after a
+	// first signal), the next signal skips the drain.
+	tuiDone := make(chan struct{})
 	go func() {
+		select {
+		case <-sigCh:
+			log.Info().Msg("Received shutdown signal")
+			// Don't call Shutdown here - let main cleanup handle it after program.Run()
+			// This avoids stopping myses twice
+			logBusStats(bus)
+			bus.Close() // Close event bus to unblock TUI event listener
+			program.Quit()
+		case <-tuiDone:
+		}
+
 		<-sigCh
-		log.Info().Msg("Received shutdown signal")
-		// Don't call StopAll here - let main cleanup handle it after program.Run()
-		// This avoids stopping myses twice
-		bus.Close() // Close event bus to unblock TUI event listener
-		program.Quit()
+		log.Warn().Msg("Received shutdown signal while draining - skipping drain")
+		cancelDrain()
 	}()
 
 	// Run the TUI
-	if _, err := program.Run(); err != nil {
+	finalModel, err := program.Run()
+	close(tuiDone)
+	if err != nil {
 		log.Fatal().Err(err).Msg("TUI error")
 	}
+	// Quitting with 'q' already saved; this covers signal shutdown
+	if m, ok := finalModel.(tui.Model); ok {
+		m.SaveViewState()
+	}
 
 	// Clean shutdown
 	log.Info().Int("goroutines", runtime.NumGoroutine()).Msg("Shutdown initiated")
-	commander.StopAll()
+	drainTimeoutCtx, cancelDrainTimeout := context.WithTimeout(drainCtx, constants.DrainTimeout)
+	if err := commander.Drain(drainTimeoutCtx); err != nil {
+		log.Warn().Err(err).Msg("Drain incomplete - stopping myses mid-turn")
+	}
+	cancelDrainTimeout()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), constants.ShutdownTimeout)
+	if err := commander.Shutdown(shutdownCtx); err != nil {
+		log.Warn().Err(err).Msg("Tool observers not fully flushed on shutdown")
+	}
+	cancelShutdown()
 
 	// Close bus (idempotent if already closed by onQuit or signal handler)
 	bus.Close()
@@ -180,6 +295,34 @@ func main() {
 	log.Info().Int("goroutines", runtime.NumGoroutine()).Msg("Zoea Nova shutdown complete")
 }
 
+// logBusStats records event bus delivery counters. Must run before bus.Close(),
+// which drops all subscribers.
+func logBusStats(bus *core.EventBus) {
+	stats := bus.Stats()
+	for _, sub := range stats.Subscribers {
+		log.Info().
+			Uint64("published", stats.Published).
+			Int("subscriber_index", sub.Index).
+			Uint64("delivered", sub.Delivered).
+			Uint64("dropped", sub.Dropped).
+			Msg("Event bus stats")
+	}
+}
+
+// printConfigError prints each configuration problem on its own line. Logs only go to
+// zoea.log, so without this a bad config would exit silently.
+func printConfigError(err error) {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Invalid configuration:")
+	for _, problem := range joined.Unwrap() {
+		fmt.Fprintf(os.Stderr, "  - %v\n", problem)
+	}
+}
+
 func initLogging(debug bool) error {
 	// Ensure data directory exists
 	dataDir, err := config.EnsureDataDir()
@@ -210,15 +353,44 @@ func initLogging(debug bool) error {
 	return nil
 }
 
-func initProviders(cfg *config.Config, creds *config.Credentials) *provider.Registry {
+// openProviderTrace opens provider-trace.log in the data directory (truncated, like
+// zoea.log) and returns a transport that traces provider traffic to it.
+func openProviderTrace() (http.RoundTripper, string, func(), error) {
+	dataDir, err := config.EnsureDataDir()
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("ensure data dir: %w", err)
+	}
+	path := filepath.Join(dataDir, "provider-trace.log")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("open provider trace: %w", err)
+	}
+	return provider.NewTraceTransport(nil, file), path, func() { file.Close() }, nil
+}
+
+// initProviders registers a factory for each configured provider. A non-empty
+// cacheDir wraps every provider in an on-disk response cache; a non-nil trace
+// transport carries every provider request (see -trace-provider).
+func initProviders(cfg *config.Config, creds *config.Credentials, cacheDir string, trace http.RoundTripper) *provider.Registry {
 	registry := provider.NewRegistry()
+	register := func(name string, factory provider.ProviderFactory) {
+		if cacheDir != "" {
+			factory = provider.NewCachingFactory(factory, cacheDir)
+		}
+		registry.RegisterFactory(name, factory)
+	}
 
 	for name, provCfg := range cfg.Providers {
 		// Detect provider type by endpoint
 		if strings.Contains(provCfg.Endpoint, "localhost:11434") || strings.Contains(provCfg.Endpoint, "/ollama") {
 			// Ollama-based provider
-			factory := provider.NewOllamaFactory(name, provCfg.Endpoint)
-			registry.RegisterFactory(name, factory)
+			factory := provider.NewOllamaFactory(name, provCfg.Endpoint).
+				WithTextOnly(provCfg.TextOnly).
+				WithRequestTimeout(provCfg.RequestTimeout).
+				WithAutoPull(provCfg.AutoPull).
+				WithMaxConcurrent(provCfg.MaxConcurrent).
+				WithHTTPTransport(trace)
+			register(name, factory)
 		} else if strings.Contains(provCfg.Endpoint, "opencode.ai") {
 			// OpenCode-based provider
 			// Use explicit api_key_name if provided, otherwise use provider config name
@@ -228,8 +400,12 @@ func initProviders(cfg *config.Config, creds *config.Credentials) *provider.Regi
 			}
 			apiKey := creds.GetAPIKey(keyName)
 			if apiKey != "" {
-				factory := provider.NewOpenCodeFactory(name, provCfg.Endpoint, apiKey)
-				registry.RegisterFactory(name, factory)
+				factory := provider.NewOpenCodeFactory(name, provCfg.Endpoint, apiKey).
+					WithTextOnly(provCfg.TextOnly).
+					WithRequestTimeout(provCfg.RequestTimeout).
+					WithMaxConcurrent(provCfg.MaxConcurrent).
+					WithHTTPTransport(trace)
+				register(name, factory)
 			}
 		}
 	}
@@ -327,6 +503,25 @@ func (a *commanderAdapter) SearchMessages(mysisID, query string, limit int) ([]m
 	return results, nil
 }
 
+func (a *commanderAdapter) SearchSwarm(query string, limit int) ([]mcp.SearchResult, error) {
+	memories, err := a.commander.Store().SearchAllMemories(query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.SearchResult, len(memories))
+	for i, m := range memories {
+		results[i] = mcp.SearchResult{
+			MysisName: m.MysisName,
+			Role:      string(m.Role),
+			Source:    string(m.Source),
+			Content:   m.Content,
+			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return results, nil
+}
+
 func (a *commanderAdapter) SearchReasoning(mysisID, query string, limit int) ([]mcp.ReasoningResult, error) {
 	memories, err := a.commander.Store().SearchReasoning(mysisID, query, limit)
 	if err != nil {
@@ -346,6 +541,130 @@ func (a *commanderAdapter) SearchReasoning(mysisID, query string, limit int) ([]
 	return results, nil
 }
 
+func (a *commanderAdapter) GetRecentReasoning(mysisID string, limit int) ([]mcp.ReasoningResult, error) {
+	memories, err := a.commander.GetRecentReasoning(mysisID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.ReasoningResult, len(memories))
+	for i, m := range memories {
+		results[i] = mcp.ReasoningResult{
+			Role:      string(m.Role),
+			Source:    string(m.Source),
+			Content:   m.Content,
+			Reasoning: m.Reasoning,
+			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return results, nil
+}
+
+func (a *commanderAdapter) SetCompactSnapshots(mysisID string, enabled bool) error {
+	return a.commander.SetCompactSnapshots(mysisID, enabled)
+}
+
+func (a *commanderAdapter) SetSnapshotSummaries(mysisID string, enabled bool) error {
+	return a.commander.SetSnapshotSummaries(mysisID, enabled)
+}
+
+func (a *commanderAdapter) SetContextWindow(mysisID string, window int) error {
+	return a.commander.SetContextWindow(mysisID, window)
+}
+
+func (a *commanderAdapter) SetAutonomous(mysisID string, autonomous bool) error {
+	return a.commander.SetAutonomous(mysisID, autonomous)
+}
+
+func (a *commanderAdapter) SetSyntheticNudges(mysisID string, enabled bool) error {
+	return a.commander.SetSyntheticNudges(mysisID, enabled)
+}
+
+func (a *commanderAdapter) SetSeed(mysisID string, seed *int64) error {
+	return a.commander.SetSeed(mysisID, seed)
+}
+
+func (a *commanderAdapter) ConfigureSwarm(setting string, value int) error {
+	return a.commander.ConfigureSwarm(setting, value)
+}
+
+func (a *commanderAdapter) SetGoal(mysisID, goal string) error {
+	return a.commander.SetGoal(mysisID, goal)
+}
+
+func (a *commanderAdapter) SwitchProviderAsync(mysisID, providerName, model string) error {
+	return a.commander.SwitchProviderAsync(mysisID, providerName, model)
+}
+
+func (a *commanderAdapter) AddNote(mysisID, content string) error {
+	mysis, err := a.commander.GetMysis(mysisID)
+	if err != nil {
+		return err
+	}
+	return mysis.AddNote(content)
+}
+
+func (a *commanderAdapter) SendOneShotSystem(mysisID, content string) error {
+	return a.commander.SendOneShotSystem(mysisID, content)
+}
+
+func (a *commanderAdapter) AskMysis(ctx context.Context, senderID, mysisID, question string, timeout time.Duration) (string, error) {
+	return a.commander.Ask(ctx, senderID, mysisID, question, timeout)
+}
+
+func (a *commanderAdapter) SetToolPolicy(mysisID string, allow, deny []string) error {
+	return a.commander.SetToolPolicy(mysisID, core.ToolPolicy{Allow: allow, Deny: deny})
+}
+
+func (a *commanderAdapter) GetPeerSnapshot(mysisID, toolName string) (*mcp.PeerSnapshot, error) {
+	snapshot, err := a.commander.GetLatestSnapshot(mysisID, toolName)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return &mcp.PeerSnapshot{
+		MysisID:   snapshot.MysisID,
+		MysisName: snapshot.MysisName,
+		Tool:      snapshot.ToolName,
+		CreatedAt: snapshot.CreatedAt.Format(time.RFC3339),
+		Payload:   snapshot.Payload,
+	}, nil
+}
+
+func (a *commanderAdapter) RelaunchAndBroadcast(message string) (*mcp.RelaunchReport, error) {
+	result, err := a.commander.RelaunchAndBroadcast(message)
+	if err != nil {
+		return nil, err
+	}
+	report := &mcp.RelaunchReport{Relaunched: result.Names}
+	for _, err := range result.Errors {
+		report.Failed = append(report.Failed, err.Error())
+	}
+	return report, nil
+}
+
+func (a *commanderAdapter) GetMysisActivity(mysisID string) ([]mcp.MysisActivity, error) {
+	snapshots, err := a.commander.MysisActivity(mysisID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.MysisActivity, len(snapshots))
+	for i, snap := range snapshots {
+		results[i] = mcp.MysisActivity{
+			MysisID:        snap.MysisID,
+			MysisName:      snap.MysisName,
+			State:          string(snap.State),
+			Activity:       string(snap.Activity),
+			RemainingTicks: snap.RemainingTicks,
+			Available:      snap.Available,
+		}
+		if !snap.Until.IsZero() {
+			results[i].ActivityUntil = snap.Until.Format(time.RFC3339)
+		}
+	}
+	return results, nil
+}
+
 // runMCPTest tests the MCP connection and tool calling.
 func runMCPTest(configPath string) {
 	fmt.Println("=== MCP Tool Test ===")
@@ -448,6 +767,174 @@ func runMCPTest(configPath string) {
 	fmt.Println("\n=== Test Complete ===")
 }
 
+// runVacuum compacts the database before any myses are loaded, so nothing in this
+// process is writing. A running Zoea Nova holding the database makes it fail as busy.
+func runVacuum(configPath string) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	s, err := store.NewFromConfig(cfg.Store)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to open store: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	result, err := s.Vacuum()
+	if err != nil {
+		fmt.Printf("ERROR: Vacuum failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Vacuumed database: %d -> %d bytes (%d reclaimed)\n", result.BeforeBytes, result.AfterBytes, result.Reclaimed())
+}
+
+// runExportSwarm writes the whole swarm to a portable archive file.
+func runExportSwarm(configPath, path string) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	s, err := store.NewFromConfig(cfg.Store)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to open store: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	archive, err := s.ExportSwarm(path)
+	if err != nil {
+		fmt.Printf("ERROR: Export failed: %v\n", err)
+		os.Exit(1)
+	}
+	memories := 0
+	for _, m := range archive.Myses {
+		memories += len(m.Memories)
+	}
+	fmt.Printf("Exported %d myses (%d memories) and %d accounts to %s\n", len(archive.Myses), memories, len(archive.Accounts), path)
+}
+
+// runImportSwarm restores an -export-swarm archive into the configured database.
+func runImportSwarm(configPath, path string) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	s, err := store.NewFromConfig(cfg.Store)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to open store: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	result, err := s.ImportSwarm(path)
+	if err != nil {
+		fmt.Printf("ERROR: Import failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Imported %d myses (%d memories) and %d accounts from %s\n", result.Myses, result.Memories, result.Accounts, path)
+	if result.Remapped > 0 {
+		fmt.Printf("%d myses were given new IDs because theirs were already taken\n", result.Remapped)
+	}
+}
+
+// runReplay re-runs a mysis's stored prompts through a provider and prints the
+// original and replayed responses for comparison. Tool calls are stubbed.
+func runReplay(configPath, mysisID, providerName, cacheDir string, traceProvider bool) {
+	fmt.Println("=== Replay ===")
+	fmt.Println()
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	creds, err := config.LoadCredentials()
+	if err != nil {
+		fmt.Printf("WARNING: Failed to load credentials: %v\n", err)
+		creds = &config.Credentials{}
+	}
+
+	s, err := store.NewFromConfig(cfg.Store)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to open store: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	stored, err := s.GetMysis(mysisID)
+	if err != nil {
+		fmt.Printf("ERROR: Mysis %s not found: %v\n", mysisID, err)
+		os.Exit(1)
+	}
+	if providerName == "" {
+		providerName = stored.Provider
+	}
+
+	provCfg, ok := cfg.Providers[providerName]
+	if !ok {
+		fmt.Printf("ERROR: Provider %s not configured\n", providerName)
+		os.Exit(1)
+	}
+	var trace http.RoundTripper
+	if traceProvider {
+		transport, path, closeTrace, err := openProviderTrace()
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+		defer closeTrace()
+		trace = transport
+		fmt.Printf("Tracing provider traffic to %s\n", path)
+	}
+	registry := initProviders(cfg, creds, cacheDir, trace)
+	p, err := registry.Create(providerName, provCfg.Model, provCfg.Temperature)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to create provider %s: %v\n", providerName, err)
+		os.Exit(1)
+	}
+	defer p.Close()
+
+	memories, err := s.GetMemories(mysisID)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to load memories: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Mysis: %s (%s)\n", stored.Name, stored.ID)
+	fmt.Printf("Provider: %s (%s)\n", providerName, provCfg.Model)
+	fmt.Printf("Memories: %d\n", len(memories))
+
+	turns, err := core.ReplaySession(context.Background(), s, memories, p)
+	if err != nil {
+		fmt.Printf("ERROR: Replay aborted: %v\n", err)
+		os.Exit(1)
+	}
+
+	for i, turn := range turns {
+		fmt.Printf("\n--- Turn %d [%s] ---\n", i+1, turn.Prompt.Source)
+		fmt.Printf("PROMPT:   %s\n", turn.Prompt.Content)
+		fmt.Printf("ORIGINAL: %s\n", turn.Original)
+		if turn.Err != nil {
+			fmt.Printf("ERROR:    %v\n", turn.Err)
+			continue
+		}
+		for _, tc := range turn.ToolCalls {
+			fmt.Printf("TOOL:     %s(%s) [stubbed]\n", tc.Name, string(tc.Arguments))
+		}
+		fmt.Printf("REPLAY:   %s\n", turn.Response)
+	}
+
+	fmt.Printf("\n=== Replay Complete: %d turns ===\n", len(turns))
+}
+
 // mockOrchestrator is a simple orchestrator for testing.
 type mockOrchestrator struct{}
 
@@ -461,10 +948,11 @@ func (m *mockOrchestrator) MaxMyses() int {
 
 func (m *mockOrchestrator) GetStateCounts() map[string]int {
 	return map[string]int{
-		"running": 0,
-		"idle":    0,
-		"stopped": 0,
-		"errored": 0,
+		"running":     0,
+		"idle":        0,
+		"stopped":     0,
+		"errored":     0,
+		"quarantined": 0,
 	}
 }
 
@@ -484,6 +972,78 @@ func (m *mockOrchestrator) SearchMessages(mysisID, query string, limit int) ([]m
 	return []mcp.SearchResult{}, nil
 }
 
+func (m *mockOrchestrator) SearchSwarm(query string, limit int) ([]mcp.SearchResult, error) {
+	return []mcp.SearchResult{}, nil
+}
+
+func (m *mockOrchestrator) GetRecentReasoning(mysisID string, limit int) ([]mcp.ReasoningResult, error) {
+	return []mcp.ReasoningResult{}, nil
+}
+
 func (m *mockOrchestrator) SearchReasoning(mysisID, query string, limit int) ([]mcp.ReasoningResult, error) {
 	return []mcp.ReasoningResult{}, nil
 }
+
+func (m *mockOrchestrator) SetCompactSnapshots(mysisID string, enabled bool) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetSnapshotSummaries(mysisID string, enabled bool) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetContextWindow(mysisID string, window int) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetAutonomous(mysisID string, autonomous bool) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetSyntheticNudges(mysisID string, enabled bool) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetSeed(mysisID string, seed *int64) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) ConfigureSwarm(setting string, value int) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetGoal(mysisID, goal string) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SwitchProviderAsync(mysisID, provider, model string) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) AddNote(mysisID, content string) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) AskMysis(ctx context.Context, senderID, mysisID, question string, timeout time.Duration) (string, error) {
+	return "", fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SendOneShotSystem(mysisID, content string) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) SetToolPolicy(mysisID string, allow, deny []string) error {
+	return fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) GetPeerSnapshot(mysisID, toolName string) (*mcp.PeerSnapshot, error) {
+	return nil, fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) RelaunchAndBroadcast(message string) (*mcp.RelaunchReport, error) {
+	return nil, fmt.Errorf("not available in test mode")
+}
+
+func (m *mockOrchestrator) GetMysisActivity(mysisID string) ([]mcp.MysisActivity, error) {
+	return nil, fmt.Errorf("not available in test mode")
+}
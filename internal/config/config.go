@@ -7,22 +7,204 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/xonecas/zoea-nova/internal/constants"
 )
 
 // Config is the root configuration structure.
 type Config struct {
-	Swarm     SwarmConfig               `toml:"swarm"`
-	Providers map[string]ProviderConfig `toml:"providers"`
-	MCP       MCPConfig                 `toml:"mcp"`
+	Swarm       SwarmConfig               `toml:"swarm"`
+	Providers   map[string]ProviderConfig `toml:"providers"`
+	MCP         MCPConfig                 `toml:"mcp"`
+	Pricing     map[string]PricingConfig  `toml:"pricing"`
+	Coordinator CoordinatorConfig         `toml:"coordinator"`
+	Accounts    AccountsConfig            `toml:"accounts"`
+	Analytics   AnalyticsConfig           `toml:"analytics"`
+	Drift       DriftConfig               `toml:"drift"`
+	Theme       ThemeConfig               `toml:"theme"`
+	Store       StoreConfig               `toml:"store"`
+	Prompts     PromptsConfig             `toml:"prompts"`
+}
+
+// PromptsConfig overrides the prompts that keep autonomous myses playing. Empty
+// fields use the built-in text.
+type PromptsConfig struct {
+	// Nudge opens the synthetic message sent when a mysis has nothing to answer
+	// (empty = constants.NudgePrompt).
+	Nudge string `toml:"nudge"`
+	// FirstTurn replaces the nudge on a fresh mysis's first turn, when its history
+	// holds only the system prompt (empty = the usual nudge).
+	FirstTurn string `toml:"first_turn"`
+	// Continue, ContinueFirm and ContinueUrgent follow the nudge on the first, second
+	// and third nudge in a row. When all are empty the nudge is sent alone; once any is
+	// set, the empty ones use constants.ContinuePrompt and its firmer levels.
+	Continue       string `toml:"continue"`
+	ContinueFirm   string `toml:"continue_firm"`
+	ContinueUrgent string `toml:"continue_urgent"`
+}
+
+// StoreConfig selects the persistence backend.
+type StoreConfig struct {
+	// Driver is "sqlite" (default) or "postgres".
+	Driver string `toml:"driver"`
+	// DSN is the database location: a file path for sqlite (empty = zoea.db in the
+	// data directory) or a connection string for postgres (required).
+	DSN string `toml:"dsn"`
+	// JournalMode is the SQLite journal mode: "wal" (default), "delete", "truncate",
+	// "persist", "memory" or "off".
+	JournalMode string `toml:"journal_mode"`
+	// Synchronous is the SQLite fsync level: "off", "normal" (default), "full" or
+	// "extra".
+	Synchronous string `toml:"synchronous"`
+	// WALAutocheckpoint is how many WAL pages accumulate before SQLite checkpoints
+	// them into the database (0 = SQLite's default of 1000).
+	WALAutocheckpoint int `toml:"wal_autocheckpoint"`
+}
+
+// ThemeConfig selects TUI colors. Colors are "#RGB", "#RRGGBB" or an ANSI 256 color
+// number; entries left unset come from the named theme.
+type ThemeConfig struct {
+	// Name is a built-in theme: "dark" (default), "light" or "high-contrast".
+	Name string `toml:"name"`
+	// Roles maps message roles (user, assistant, system, tool) to colors.
+	Roles map[string]string `toml:"roles"`
+	// States maps mysis states (running, idle, stopped, errored, quarantined) to colors.
+	States map[string]string `toml:"states"`
+	// Header colors the dashboard banner and its border.
+	Header string `toml:"header"`
+}
+
+// DriftConfig sets the keyword categories that trigger drift reminders on autonomous
+// turns. Configured categories replace the default real_time category.
+type DriftConfig struct {
+	Categories []DriftCategoryConfig `toml:"categories"`
+}
+
+// DriftCategoryConfig is one [[drift.categories]] entry.
+type DriftCategoryConfig struct {
+	Name     string   `toml:"name"`
+	Keywords []string `toml:"keywords"`
+	Reminder string   `toml:"reminder"`
+}
+
+// AnalyticsConfig streams every tool call and its result to external sinks.
+// Analytics are off unless File or Webhook is set.
+type AnalyticsConfig struct {
+	// File appends one JSON record per tool call (JSONL).
+	File string `toml:"file"`
+	// Webhook receives each record as a JSON POST.
+	Webhook string `toml:"webhook"`
+	// WebhookTimeout caps a single POST (0 = constants.DefaultAnalyticsWebhookTimeout).
+	WebhookTimeout time.Duration `toml:"webhook_timeout"`
+	// BufferSize is how many records queue before new ones are dropped
+	// (0 = constants.DefaultAnalyticsBufferSize).
+	BufferSize int `toml:"buffer_size"`
+}
+
+// Enabled reports whether any analytics sink is configured.
+func (c AnalyticsConfig) Enabled() bool {
+	return c.File != "" || c.Webhook != ""
+}
+
+// AccountsConfig controls how credentials for new game accounts are generated.
+type AccountsConfig struct {
+	// Strategy is "random" (default), "sequential" or "wordlist".
+	Strategy string `toml:"strategy"`
+	// Prefix starts random and sequential usernames (empty = constants.DefaultAccountPrefix).
+	Prefix string `toml:"prefix"`
+	// Words feed the wordlist strategy.
+	Words []string `toml:"words"`
+	// PasswordLength of generated passwords (0 = constants.DefaultPasswordLength).
+	PasswordLength int `toml:"password_length"`
+	// PasswordSymbols also requires a symbol in generated passwords.
+	PasswordSymbols bool `toml:"password_symbols"`
+}
+
+// CoordinatorConfig enables periodic swarm status broadcasts.
+type CoordinatorConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Interval between summaries, e.g. "10m" (0 = constants.DefaultCoordinatorInterval).
+	Interval time.Duration `toml:"interval"`
+	// Mysis names the mysis the summary is sent from; it is excluded from recipients.
+	// Empty sends the summary as a commander broadcast.
+	Mysis string `toml:"mysis"`
+	// Template is a text/template over core.CoordinatorSummary
+	// (empty = constants.DefaultCoordinatorTemplate).
+	Template string `toml:"template"`
 }
 
 // SwarmConfig holds swarm-related settings.
 type SwarmConfig struct {
 	MaxMyses        int    `toml:"max_myses"`
 	DefaultProvider string `toml:"default_provider"`
+	// MaxMysesCeiling raises max_myses by one whenever it is reached, up to this many
+	// myses (0 = never raise).
+	MaxMysesCeiling int `toml:"max_myses_ceiling"`
+	// MaxNotesInContext caps how many of a mysis's most recent notes are injected into
+	// context (0 = constants.DefaultMaxNotesInContext).
+	MaxNotesInContext int `toml:"max_notes_in_context"`
+	// PeerBroadcastsInContext adds a mysis's most recent broadcasts from other myses to
+	// its context (0 = off, at most constants.MaxPeerBroadcastsInContext).
+	PeerBroadcastsInContext int `toml:"peer_broadcasts_in_context"`
+	// BroadcastDedupWindowMs suppresses identical broadcasts sent within this many
+	// milliseconds of each other (0 = constants.DefaultBroadcastDedupWindow).
+	BroadcastDedupWindowMs int `toml:"broadcast_dedup_window_ms"`
+	// MaxMessageLength caps broadcasts and direct messages in characters
+	// (0 = constants.DefaultMaxMessageLength).
+	MaxMessageLength int `toml:"max_message_length"`
+	// MessageLengthPolicy is "truncate" (default) or "reject" for messages over the limit.
+	MessageLengthPolicy string `toml:"message_length_policy"`
+	// VerifyProviderOnCreate checks that a provider is reachable and serves its model
+	// before creating a mysis with it.
+	VerifyProviderOnCreate bool `toml:"verify_provider_on_create"`
+	// MaxConcurrentTurns caps how many myses wait on the provider at once; others
+	// block until a slot frees (0 = unlimited).
+	MaxConcurrentTurns int `toml:"max_concurrent_turns"`
+	// ToolIterationsPolicy is "continue" (default), closing a turn that reaches the
+	// tool call round limit with a placeholder reply, or "error" to error the mysis.
+	ToolIterationsPolicy string `toml:"tool_iterations_policy"`
+	// MaxRepeatedToolCalls ends a turn once a mysis makes the same tool calls with the
+	// same arguments this many times in a row (0 = constants.DefaultMaxRepeatedToolCalls).
+	MaxRepeatedToolCalls int `toml:"max_repeated_tool_calls"`
+	// IdleStopAfter stops a running or idle mysis that has had no direct message or
+	// broadcast for this long, instead of letting it take autonomous turns forever or
+	// hold its session while idle (0 = never).
+	IdleStopAfter time.Duration `toml:"idle_stop_after"`
+	// MysisLogs names myses whose turns, tool calls and errors are also written to
+	// <data dir>/logs/<name>.log.
+	MysisLogs []string `toml:"mysis_logs"`
+	// MaxReasoningBytes truncates stored model reasoning to its first this many bytes
+	// (0 = unlimited).
+	MaxReasoningBytes int `toml:"max_reasoning_bytes"`
+	// MaxToolResultBytes truncates stored tool results to their first this many bytes,
+	// which also bounds them in context (0 = unlimited).
+	MaxToolResultBytes int `toml:"max_tool_result_bytes"`
+	// MaxSnapshotResultBytes is a separate, usually stricter, limit for snapshot (get_*)
+	// tool results (0 = max_tool_result_bytes).
+	MaxSnapshotResultBytes int `toml:"max_snapshot_result_bytes"`
+	// CommanderName is who commander broadcasts come from, both in the system prompt
+	// and in the TUI (empty = "Commander").
+	CommanderName string `toml:"commander_name"`
+	// ToolResultAges prefixes each tool result in context with its age in server ticks,
+	// so myses can tell stale snapshots from fresh ones.
+	ToolResultAges bool `toml:"tool_result_ages"`
+	// SyntheticNudgeEnabled lets autonomous myses with nothing to answer prompt
+	// themselves with a nudge (nil = true). When false they go idle until messaged.
+	SyntheticNudgeEnabled *bool `toml:"synthetic_nudge_enabled"`
+	// TickStaleAfter is how long a running mysis may go without a new server tick
+	// before it is flagged as possibly disconnected (0 = constants.DefaultTickStaleAfter).
+	TickStaleAfter time.Duration `toml:"tick_stale_after"`
+}
+
+// SyntheticNudges reports whether synthetic nudges are enabled swarm-wide.
+func (c SwarmConfig) SyntheticNudges() bool {
+	return c.SyntheticNudgeEnabled == nil || *c.SyntheticNudgeEnabled
 }
 
 // ProviderConfig holds LLM provider settings.
@@ -31,11 +213,71 @@ type ProviderConfig struct {
 	Model       string  `toml:"model"`
 	APIKeyName  string  `toml:"api_key_name"`
 	Temperature float64 `toml:"temperature"`
+	WarmUp      bool    `toml:"warm_up"`   // Ping the provider on mysis start (e.g. load Ollama models)
+	TextOnly    bool    `toml:"text_only"` // Model lacks native tool calling; tools are described in the prompt
+	AutoPull    bool    `toml:"auto_pull"` // Ollama: pull a missing model and retry instead of failing
+	// RequestTimeout caps a single turn, e.g. "15m" (0 = constants.LLMRequestTimeout).
+	RequestTimeout time.Duration `toml:"request_timeout"`
+	// ContextTokens is the token budget for a turn's context (0 = unchecked). Going over
+	// is logged; with EnforceContextTokens the oldest history is dropped to fit.
+	ContextTokens        int  `toml:"context_tokens"`
+	EnforceContextTokens bool `toml:"enforce_context_tokens"`
+	// MaxConcurrent caps requests in flight to this provider across all myses; extra
+	// turns queue (0 = unlimited). Unlike [swarm] max_concurrent_turns it is per provider.
+	MaxConcurrent int `toml:"max_concurrent"`
+}
+
+// PricingConfig holds per-model token pricing, keyed by model name in [pricing].
+type PricingConfig struct {
+	InputPer1K  float64 `toml:"input_per_1k"`
+	OutputPer1K float64 `toml:"output_per_1k"`
+}
+
+// EstimateCost returns the estimated cost of a request for the given model.
+// Models without a pricing entry cost zero.
+func (c *Config) EstimateCost(model string, promptTokens, completionTokens int) float64 {
+	if c == nil {
+		return 0
+	}
+	pricing, ok := c.Pricing[model]
+	if !ok {
+		return 0
+	}
+	return float64(promptTokens)/1000*pricing.InputPer1K + float64(completionTokens)/1000*pricing.OutputPer1K
 }
 
 // MCPConfig holds MCP proxy settings.
 type MCPConfig struct {
 	Upstream string `toml:"upstream"`
+	// ValidateArguments checks upstream tool arguments against the tool's input
+	// schema before dispatch. Local zoea_* tools are always validated.
+	ValidateArguments bool `toml:"validate_arguments"`
+	// ToolCacheTTL is how long the upstream tool list is reused between turns
+	// (0 = constants.DefaultToolCacheTTL).
+	ToolCacheTTL time.Duration `toml:"tool_cache_ttl"`
+	// ToolRetries is how many times an upstream tool call is retried after a transport,
+	// rate limit or 5xx error (nil = constants.DefaultToolRetries, 0 = no retries).
+	ToolRetries *int `toml:"tool_retries"`
+	// ToolRetryBackoff is the delay before the first retry, doubled for each one after it
+	// (0 = 2s, 5s, then 10s). A server's Retry-After takes precedence.
+	ToolRetryBackoff time.Duration `toml:"tool_retry_backoff"`
+	// ToolRetryOverrides sets the retry count for specific tools; 0 disables retries.
+	ToolRetryOverrides map[string]int `toml:"tool_retry_overrides"`
+	// ReconnectAfterFailures starts new upstream sessions for every mysis once this many
+	// tool calls in a row, across any myses, have failed
+	// (0 = constants.DefaultMCPReconnectFailures).
+	ReconnectAfterFailures int `toml:"reconnect_after_failures"`
+	// ReconnectCooldown is the minimum time between reconnects
+	// (0 = constants.DefaultMCPReconnectCooldown).
+	ReconnectCooldown time.Duration `toml:"reconnect_cooldown"`
+}
+
+// Retries returns how many times a failed upstream tool call is retried.
+func (c MCPConfig) Retries() int {
+	if c.ToolRetries == nil {
+		return constants.DefaultToolRetries
+	}
+	return *c.ToolRetries
 }
 
 // Load reads configuration from a TOML file and applies environment variable overrides.
@@ -70,19 +312,219 @@ func Load(path string) (*Config, error) {
 	return cfg, nil
 }
 
-// Validate returns an error if the configuration is invalid.
+// Validate returns an error if the configuration is invalid. Every problem found is
+// reported, joined with errors.Join, so they can all be fixed at once.
 func (c *Config) Validate() error {
 	var errs []error
 
 	if c.Swarm.MaxMyses < 1 || c.Swarm.MaxMyses > 100 {
 		errs = append(errs, fmt.Errorf("swarm.max_myses=%d must be between 1 and 100", c.Swarm.MaxMyses))
 	}
+	if c.Swarm.MaxMysesCeiling != 0 && (c.Swarm.MaxMysesCeiling < c.Swarm.MaxMyses || c.Swarm.MaxMysesCeiling > 100) {
+		errs = append(errs, fmt.Errorf("swarm.max_myses_ceiling=%d must be 0 or between max_myses (%d) and 100",
+			c.Swarm.MaxMysesCeiling, c.Swarm.MaxMyses))
+	}
+
+	if c.Swarm.MaxNotesInContext < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_notes_in_context=%d must not be negative", c.Swarm.MaxNotesInContext))
+	}
+	if c.Swarm.PeerBroadcastsInContext < 0 || c.Swarm.PeerBroadcastsInContext > constants.MaxPeerBroadcastsInContext {
+		errs = append(errs, fmt.Errorf("swarm.peer_broadcasts_in_context=%d must be between 0 and %d",
+			c.Swarm.PeerBroadcastsInContext, constants.MaxPeerBroadcastsInContext))
+	}
+
+	if c.Swarm.BroadcastDedupWindowMs < 0 {
+		errs = append(errs, fmt.Errorf("swarm.broadcast_dedup_window_ms=%d must not be negative", c.Swarm.BroadcastDedupWindowMs))
+	}
+
+	if c.Swarm.MaxConcurrentTurns < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_concurrent_turns=%d must not be negative", c.Swarm.MaxConcurrentTurns))
+	}
+
+	if c.Swarm.MaxRepeatedToolCalls < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_repeated_tool_calls=%d must not be negative", c.Swarm.MaxRepeatedToolCalls))
+	}
+
+	if c.Swarm.MaxReasoningBytes < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_reasoning_bytes=%d must not be negative", c.Swarm.MaxReasoningBytes))
+	}
+	if c.Swarm.MaxToolResultBytes < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_tool_result_bytes=%d must not be negative", c.Swarm.MaxToolResultBytes))
+	}
+	if c.Swarm.MaxSnapshotResultBytes < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_snapshot_result_bytes=%d must not be negative", c.Swarm.MaxSnapshotResultBytes))
+	}
+
+	if c.Swarm.CommanderName != "" && strings.TrimSpace(c.Swarm.CommanderName) == "" {
+		errs = append(errs, fmt.Errorf("swarm.commander_name must not be blank"))
+	}
+
+	if c.Swarm.IdleStopAfter < 0 {
+		errs = append(errs, fmt.Errorf("swarm.idle_stop_after=%s must not be negative", c.Swarm.IdleStopAfter))
+	}
+	if c.Swarm.TickStaleAfter < 0 {
+		errs = append(errs, fmt.Errorf("swarm.tick_stale_after=%s must not be negative", c.Swarm.TickStaleAfter))
+	}
+
+	if c.Swarm.MaxMessageLength < 0 {
+		errs = append(errs, fmt.Errorf("swarm.max_message_length=%d must not be negative", c.Swarm.MaxMessageLength))
+	}
+	switch c.Swarm.MessageLengthPolicy {
+	case "", constants.MessageLengthPolicyTruncate, constants.MessageLengthPolicyReject:
+	default:
+		errs = append(errs, fmt.Errorf("swarm.message_length_policy=%q must be %q or %q",
+			c.Swarm.MessageLengthPolicy, constants.MessageLengthPolicyTruncate, constants.MessageLengthPolicyReject))
+	}
+
+	switch c.Swarm.ToolIterationsPolicy {
+	case "", constants.ToolIterationsPolicyContinue, constants.ToolIterationsPolicyError:
+	default:
+		errs = append(errs, fmt.Errorf("swarm.tool_iterations_policy=%q must be %q or %q",
+			c.Swarm.ToolIterationsPolicy, constants.ToolIterationsPolicyContinue, constants.ToolIterationsPolicyError))
+	}
+
+	switch c.Accounts.Strategy {
+	case "", constants.AccountStrategyRandom, constants.AccountStrategySequential:
+	case constants.AccountStrategyWordlist:
+		if len(c.Accounts.Words) < 2 {
+			errs = append(errs, fmt.Errorf("accounts.words needs at least 2 words for the wordlist strategy"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("accounts.strategy=%q must be %q, %q or %q", c.Accounts.Strategy,
+			constants.AccountStrategyRandom, constants.AccountStrategySequential, constants.AccountStrategyWordlist))
+	}
+	if c.Accounts.PasswordLength != 0 && c.Accounts.PasswordLength < constants.MinPasswordLength {
+		errs = append(errs, fmt.Errorf("accounts.password_length=%d must be at least %d", c.Accounts.PasswordLength, constants.MinPasswordLength))
+	}
+
+	if c.MCP.Upstream != "" {
+		if err := validateEndpoint(c.MCP.Upstream); err != nil {
+			errs = append(errs, fmt.Errorf("mcp.upstream=%q is invalid: %v", c.MCP.Upstream, err))
+		}
+	}
+	if c.MCP.ToolCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("mcp.tool_cache_ttl=%s must not be negative", c.MCP.ToolCacheTTL))
+	}
+	if c.MCP.ToolRetries != nil && *c.MCP.ToolRetries < 0 {
+		errs = append(errs, fmt.Errorf("mcp.tool_retries=%d must not be negative", *c.MCP.ToolRetries))
+	}
+	if c.MCP.ToolRetryBackoff < 0 {
+		errs = append(errs, fmt.Errorf("mcp.tool_retry_backoff=%s must not be negative", c.MCP.ToolRetryBackoff))
+	}
+	if c.MCP.ReconnectAfterFailures < 0 {
+		errs = append(errs, fmt.Errorf("mcp.reconnect_after_failures=%d must not be negative", c.MCP.ReconnectAfterFailures))
+	}
+	if c.MCP.ReconnectCooldown < 0 {
+		errs = append(errs, fmt.Errorf("mcp.reconnect_cooldown=%s must not be negative", c.MCP.ReconnectCooldown))
+	}
+	for tool, retries := range c.MCP.ToolRetryOverrides {
+		if retries < 0 {
+			errs = append(errs, fmt.Errorf("mcp.tool_retry_overrides.%s=%d must not be negative", tool, retries))
+		}
+	}
+
+	if c.Analytics.Webhook != "" {
+		if err := validateEndpoint(c.Analytics.Webhook); err != nil {
+			errs = append(errs, fmt.Errorf("analytics.webhook=%q is invalid: %v", c.Analytics.Webhook, err))
+		}
+	}
+	if c.Analytics.WebhookTimeout < 0 {
+		errs = append(errs, fmt.Errorf("analytics.webhook_timeout=%s must not be negative", c.Analytics.WebhookTimeout))
+	}
+	if c.Analytics.BufferSize < 0 {
+		errs = append(errs, fmt.Errorf("analytics.buffer_size=%d must not be negative", c.Analytics.BufferSize))
+	}
+
+	switch c.Store.Driver {
+	case "", constants.StoreDriverSQLite:
+	case constants.StoreDriverPostgres:
+		if c.Store.DSN == "" {
+			errs = append(errs, fmt.Errorf("store.dsn is required for the %q driver", constants.StoreDriverPostgres))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("store.driver=%q must be %q or %q", c.Store.Driver,
+			constants.StoreDriverSQLite, constants.StoreDriverPostgres))
+	}
+	switch strings.ToLower(c.Store.JournalMode) {
+	case "", "wal", "delete", "truncate", "persist", "memory", "off":
+	default:
+		errs = append(errs, fmt.Errorf("store.journal_mode=%q must be wal, delete, truncate, persist, memory or off", c.Store.JournalMode))
+	}
+	switch strings.ToLower(c.Store.Synchronous) {
+	case "", "off", "normal", "full", "extra":
+	default:
+		errs = append(errs, fmt.Errorf("store.synchronous=%q must be off, normal, full or extra", c.Store.Synchronous))
+	}
+	if c.Store.WALAutocheckpoint < 0 {
+		errs = append(errs, fmt.Errorf("store.wal_autocheckpoint=%d must not be negative", c.Store.WALAutocheckpoint))
+	}
+
+	switch c.Theme.Name {
+	case "", constants.ThemeDark, constants.ThemeLight, constants.ThemeHighContrast:
+	default:
+		errs = append(errs, fmt.Errorf("theme.name=%q must be %q, %q or %q", c.Theme.Name,
+			constants.ThemeDark, constants.ThemeLight, constants.ThemeHighContrast))
+	}
+
+	seenDrift := make(map[string]bool)
+	for i, category := range c.Drift.Categories {
+		switch {
+		case category.Name == "":
+			errs = append(errs, fmt.Errorf("drift.categories[%d].name is required", i))
+		case seenDrift[category.Name]:
+			errs = append(errs, fmt.Errorf("drift.categories[%d].name=%q is a duplicate", i, category.Name))
+		}
+		seenDrift[category.Name] = true
+		if len(category.Keywords) == 0 {
+			errs = append(errs, fmt.Errorf("drift.categories[%d].keywords needs at least one keyword", i))
+		}
+		if category.Reminder == "" {
+			errs = append(errs, fmt.Errorf("drift.categories[%d].reminder is required", i))
+		}
+	}
+
+	for _, prompt := range []struct{ key, value string }{
+		{"nudge", c.Prompts.Nudge},
+		{"first_turn", c.Prompts.FirstTurn},
+		{"continue", c.Prompts.Continue},
+		{"continue_firm", c.Prompts.ContinueFirm},
+		{"continue_urgent", c.Prompts.ContinueUrgent},
+	} {
+		if prompt.value != "" && strings.TrimSpace(prompt.value) == "" {
+			errs = append(errs, fmt.Errorf("prompts.%s must not be blank", prompt.key))
+		}
+	}
+
+	if c.Coordinator.Interval < 0 {
+		errs = append(errs, fmt.Errorf("coordinator.interval=%s must be positive", c.Coordinator.Interval))
+	}
+	if c.Coordinator.Template != "" {
+		if _, err := template.New("coordinator").Parse(c.Coordinator.Template); err != nil {
+			errs = append(errs, fmt.Errorf("coordinator.template is invalid: %v", err))
+		}
+	}
 
 	if len(c.Providers) == 0 {
 		errs = append(errs, errors.New("providers: at least one provider must be configured"))
 	} else {
-		for name, providerCfg := range c.Providers {
-			errs = append(errs, validateProviderConfig(name, providerCfg)...)
+		// Sorted so every run reports problems in the same order
+		names := make([]string, 0, len(c.Providers))
+		for name := range c.Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			errs = append(errs, validateProviderConfig(name, c.Providers[name])...)
+		}
+		if _, ok := c.Providers[c.Swarm.DefaultProvider]; c.Swarm.DefaultProvider != "" && !ok {
+			errs = append(errs, fmt.Errorf("swarm.default_provider=%q is not a configured provider (have %s)",
+				c.Swarm.DefaultProvider, strings.Join(names, ", ")))
+		}
+	}
+
+	for model, pricing := range c.Pricing {
+		if pricing.InputPer1K < 0 || pricing.OutputPer1K < 0 {
+			errs = append(errs, fmt.Errorf("pricing.%s: prices must not be negative", model))
 		}
 	}
 
@@ -109,9 +551,34 @@ func validateProviderConfig(name string, cfg ProviderConfig) []error {
 		errs = append(errs, fmt.Errorf("providers.%s.temperature=%v must be between 0.0 and 2.0", name, cfg.Temperature))
 	}
 
+	if cfg.RequestTimeout < 0 {
+		errs = append(errs, fmt.Errorf("providers.%s.request_timeout=%s must be positive", name, cfg.RequestTimeout))
+	}
+
+	if cfg.ContextTokens < 0 {
+		errs = append(errs, fmt.Errorf("providers.%s.context_tokens=%d must not be negative", name, cfg.ContextTokens))
+	}
+
+	if cfg.MaxConcurrent < 0 {
+		errs = append(errs, fmt.Errorf("providers.%s.max_concurrent=%d must not be negative", name, cfg.MaxConcurrent))
+	}
+
 	return errs
 }
 
+// Warnings returns non-fatal configuration issues worth logging.
+func (c *Config) Warnings() []string {
+	var warnings []string
+	for name, providerCfg := range c.Providers {
+		if providerCfg.RequestTimeout > constants.MaxLLMRequestTimeout {
+			warnings = append(warnings, fmt.Sprintf("providers.%s.request_timeout=%s exceeds %s - a stuck request will block the mysis that long",
+				name, providerCfg.RequestTimeout, constants.MaxLLMRequestTimeout))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
 func validateEndpoint(value string) error {
 	parsed, err := url.Parse(value)
 	if err != nil {
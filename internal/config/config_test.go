@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
 )
 
 // TestDefaultConfig removed - config file is now required
@@ -312,6 +315,37 @@ temperature = 0.7
 	}
 }
 
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := &Config{
+		Swarm: SwarmConfig{MaxMyses: 0, DefaultProvider: "ollama"},
+		MCP:   MCPConfig{Upstream: "game.spacemolt.com/mcp"},
+		Providers: map[string]ProviderConfig{
+			"zen":         {Endpoint: "https://opencode.ai/zen/v1", Model: "gpt-5-nano", Temperature: 3},
+			"ollama-qwen": {Endpoint: "localhost:11434", Model: "qwen3:8b"},
+		},
+	}
+
+	err := cfg.Validate()
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected a joined error, got %v", err)
+	}
+	var got []string
+	for _, problem := range joined.Unwrap() {
+		got = append(got, problem.Error())
+	}
+	want := []string{
+		"swarm.max_myses=0 must be between 1 and 100",
+		`mcp.upstream="game.spacemolt.com/mcp" is invalid: missing scheme or host`,
+		`providers.ollama-qwen.endpoint="localhost:11434" is invalid: missing scheme or host`,
+		"providers.zen.temperature=3 must be between 0.0 and 2.0",
+		`swarm.default_provider="ollama" is not a configured provider (have ollama-qwen, zen)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
 func TestLoadInvalidURLFormat(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.toml")
@@ -568,3 +602,1050 @@ model = "qwen3:4b"
 		})
 	}
 }
+
+func TestLoadPricing(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[providers.zen]
+endpoint = "https://opencode.ai/zen/v1"
+model = "gpt-5-nano"
+
+[pricing.gpt-5-nano]
+input_per_1k = 0.5
+output_per_1k = 2.0
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if got := cfg.EstimateCost("gpt-5-nano", 2000, 500); got != 2.0 {
+		t.Errorf("expected cost 2.0, got %v", got)
+	}
+	if got := cfg.EstimateCost("unpriced-model", 2000, 500); got != 0 {
+		t.Errorf("expected zero cost for unpriced model, got %v", got)
+	}
+}
+
+func TestLoadNegativePricing(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[providers.zen]
+endpoint = "https://opencode.ai/zen/v1"
+model = "gpt-5-nano"
+
+[pricing.gpt-5-nano]
+input_per_1k = -1
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	_, err := Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "pricing.gpt-5-nano") {
+		t.Fatalf("expected pricing validation error, got %v", err)
+	}
+}
+
+func TestLoadBroadcastDedupWindow(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+broadcast_dedup_window_ms = -1
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	_, err := Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.broadcast_dedup_window_ms") {
+		t.Fatalf("expected broadcast_dedup_window_ms validation error, got %v", err)
+	}
+}
+
+func TestLoadMessageLengthPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		swarm   string
+		wantErr string
+	}{
+		{"reject", "max_message_length = 500\nmessage_length_policy = \"reject\"", ""},
+		{"default policy", "max_message_length = 500", ""},
+		{"negative length", "max_message_length = -1", "swarm.max_message_length"},
+		{"unknown policy", "message_length_policy = \"drop\"", "swarm.message_length_policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			content := "[swarm]\nmax_myses = 16\n" + tt.swarm + `
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected %s validation error, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if cfg.Swarm.MaxMessageLength != 500 {
+				t.Errorf("expected max_message_length 500, got %d", cfg.Swarm.MaxMessageLength)
+			}
+		})
+	}
+}
+
+func TestLoadToolIterationsPolicy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	for value, wantErr := range map[string]bool{"": false, "continue": false, "error": false, "retry": true} {
+		content := fmt.Sprintf(`
+[swarm]
+max_myses = 16
+tool_iterations_policy = %q
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`, value)
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+
+		cfg, err := Load(configPath)
+		if wantErr {
+			if err == nil || !strings.Contains(err.Error(), "swarm.tool_iterations_policy") {
+				t.Errorf("%q: expected tool_iterations_policy validation error, got %v", value, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: Load() error: %v", value, err)
+		}
+		if cfg.Swarm.ToolIterationsPolicy != value {
+			t.Errorf("expected tool_iterations_policy=%q, got %q", value, cfg.Swarm.ToolIterationsPolicy)
+		}
+	}
+}
+
+func TestLoadSyntheticNudgeEnabled(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	for line, want := range map[string]bool{"": true, "synthetic_nudge_enabled = true": true, "synthetic_nudge_enabled = false": false} {
+		content := fmt.Sprintf(`
+[swarm]
+max_myses = 16
+%s
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`, line)
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+
+		cfg, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("%q: Load() error: %v", line, err)
+		}
+		if got := cfg.Swarm.SyntheticNudges(); got != want {
+			t.Errorf("%q: SyntheticNudges() = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestLoadAccountsConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		accounts string
+		wantErr  string
+	}{
+		{"default", "", ""},
+		{"sequential", "strategy = \"sequential\"\nprefix = \"pilot\"\npassword_length = 12", ""},
+		{"wordlist", "strategy = \"wordlist\"\nwords = [\"amber\", \"falcon\"]", ""},
+		{"wordlist without words", "strategy = \"wordlist\"", "accounts.words"},
+		{"unknown strategy", "strategy = \"uuid\"", "accounts.strategy"},
+		{"short password", "password_length = 4", "accounts.password_length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			content := "[swarm]\nmax_myses = 16\n\n[accounts]\n" + tt.accounts + `
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			_, err := Load(configPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Load() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %s validation error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadToolCacheTTL(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[mcp]
+tool_cache_ttl = "-5s"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "mcp.tool_cache_ttl") {
+		t.Fatalf("expected mcp.tool_cache_ttl validation error, got %v", err)
+	}
+
+	content = strings.Replace(content, `"-5s"`, `"2m"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.MCP.ToolCacheTTL != 2*time.Minute {
+		t.Errorf("expected tool_cache_ttl 2m, got %s", cfg.MCP.ToolCacheTTL)
+	}
+}
+
+func TestLoadToolRetries(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[mcp]
+tool_retries = 5
+tool_retry_backoff = "1s"
+
+[mcp.tool_retry_overrides]
+get_status = 8
+jump = -1
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "mcp.tool_retry_overrides.jump") {
+		t.Fatalf("expected mcp.tool_retry_overrides.jump validation error, got %v", err)
+	}
+
+	content = strings.Replace(content, "jump = -1", "jump = 0", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.MCP.Retries() != 5 || cfg.MCP.ToolRetryBackoff != time.Second {
+		t.Errorf("unexpected retry settings: %d, %s", cfg.MCP.Retries(), cfg.MCP.ToolRetryBackoff)
+	}
+	if cfg.MCP.ToolRetryOverrides["get_status"] != 8 || cfg.MCP.ToolRetryOverrides["jump"] != 0 {
+		t.Errorf("unexpected overrides: %v", cfg.MCP.ToolRetryOverrides)
+	}
+
+	content = strings.Replace(content, "tool_retries = 5", "tool_retries = 0", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	if cfg, err = Load(configPath); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.MCP.Retries(); got != 0 {
+		t.Errorf("expected tool_retries = 0 to disable retries, got %d", got)
+	}
+	if got := (MCPConfig{}).Retries(); got != constants.DefaultToolRetries {
+		t.Errorf("expected unset tool_retries to default to %d, got %d", constants.DefaultToolRetries, got)
+	}
+}
+
+func TestLoadMCPReconnect(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[mcp]
+reconnect_after_failures = -1
+reconnect_cooldown = "30s"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "mcp.reconnect_after_failures") {
+		t.Fatalf("expected mcp.reconnect_after_failures validation error, got %v", err)
+	}
+
+	content = strings.Replace(content, "reconnect_after_failures = -1", "reconnect_after_failures = 8", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.MCP.ReconnectAfterFailures != 8 || cfg.MCP.ReconnectCooldown != 30*time.Second {
+		t.Errorf("unexpected reconnect settings: %d, %s", cfg.MCP.ReconnectAfterFailures, cfg.MCP.ReconnectCooldown)
+	}
+}
+
+func TestLoadAnalyticsConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[analytics]
+file = "/tmp/tool_calls.jsonl"
+webhook = "not a url"
+webhook_timeout = "3s"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "analytics.webhook") {
+		t.Fatalf("expected analytics.webhook validation error, got %v", err)
+	}
+
+	content = strings.Replace(content, `"not a url"`, `"https://example.com/hook"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !cfg.Analytics.Enabled() {
+		t.Error("expected analytics to be enabled")
+	}
+	if cfg.Analytics.WebhookTimeout != 3*time.Second {
+		t.Errorf("expected webhook_timeout 3s, got %s", cfg.Analytics.WebhookTimeout)
+	}
+}
+
+func TestLoadDriftCategories(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[[drift.categories]]
+name = "fuel"
+keywords = ["out of fuel"]
+reminder = "Refuel before travelling."
+
+[[drift.categories]]
+name = "fuel"
+keywords = []
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	_, err := Load(configPath)
+	if err == nil {
+		t.Fatal("expected drift validation errors")
+	}
+	for _, want := range []string{"duplicate", "keywords", "reminder"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got %v", want, err)
+		}
+	}
+
+	content = strings.Replace(content, `name = "fuel"
+keywords = []`, `name = "docking"
+keywords = ["dock"]
+reminder = "Check your docking status."`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Drift.Categories) != 2 || cfg.Drift.Categories[1].Keywords[0] != "dock" {
+		t.Errorf("unexpected drift categories: %+v", cfg.Drift.Categories)
+	}
+}
+
+func TestLoadThemeConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[theme]
+name = "solarized"
+
+[theme.roles]
+user = "#00FF66"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "theme.name") {
+		t.Fatalf("expected theme.name validation error, got %v", err)
+	}
+
+	content = strings.Replace(content, `"solarized"`, `"high-contrast"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Theme.Name != "high-contrast" || cfg.Theme.Roles["user"] != "#00FF66" {
+		t.Errorf("unexpected theme: %+v", cfg.Theme)
+	}
+}
+
+func TestLoadStoreConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[store]
+driver = "postgres"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "store.dsn") {
+		t.Fatalf("expected store.dsn validation error, got %v", err)
+	}
+
+	bad := strings.Replace(content, `"postgres"`, `"mysql"`, 1)
+	if err := os.WriteFile(configPath, []byte(bad), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "store.driver") {
+		t.Fatalf("expected store.driver validation error, got %v", err)
+	}
+
+	content = strings.Replace(content, `driver = "postgres"`, "driver = \"postgres\"\ndsn = \"postgres://zoea@db/zoea\"", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Store.Driver != "postgres" || cfg.Store.DSN != "postgres://zoea@db/zoea" {
+		t.Errorf("unexpected store config: %+v", cfg.Store)
+	}
+}
+
+func TestLoadStoreSQLiteTuning(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `[swarm]
+max_myses = 16
+
+[store]
+journal_mode = "wal"
+synchronous = "FULL"
+wal_autocheckpoint = 500
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Store.JournalMode != "wal" || cfg.Store.Synchronous != "FULL" || cfg.Store.WALAutocheckpoint != 500 {
+		t.Errorf("unexpected store config: %+v", cfg.Store)
+	}
+
+	for _, tt := range []struct{ from, to, field string }{
+		{`journal_mode = "wal"`, `journal_mode = "lazy"`, "store.journal_mode"},
+		{`synchronous = "FULL"`, `synchronous = "sometimes"`, "store.synchronous"},
+		{`wal_autocheckpoint = 500`, `wal_autocheckpoint = -1`, "store.wal_autocheckpoint"},
+	} {
+		bad := strings.Replace(content, tt.from, tt.to, 1)
+		if err := os.WriteFile(configPath, []byte(bad), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+		if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), tt.field) {
+			t.Errorf("expected %s validation error, got %v", tt.field, err)
+		}
+	}
+}
+
+func TestLoadProviderRequestTimeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+request_timeout = "15m"
+
+[providers.slow]
+endpoint = "http://localhost:11434"
+model = "llama3"
+request_timeout = "2h"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.Providers["ollama"].RequestTimeout; got != 15*time.Minute {
+		t.Errorf("expected request_timeout 15m, got %s", got)
+	}
+
+	warnings := cfg.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "providers.slow.request_timeout") {
+		t.Errorf("expected warning for providers.slow only, got %v", warnings)
+	}
+
+	content = strings.Replace(content, `request_timeout = "15m"`, `request_timeout = "-1m"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "providers.ollama.request_timeout") {
+		t.Fatalf("expected request_timeout validation error, got %v", err)
+	}
+}
+
+func TestLoadProviderContextTokens(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+context_tokens = 8192
+enforce_context_tokens = true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.Providers["ollama"]; got.ContextTokens != 8192 || !got.EnforceContextTokens {
+		t.Errorf("expected context_tokens 8192 enforced, got %+v", got)
+	}
+
+	content = strings.Replace(content, "context_tokens = 8192", "context_tokens = -1", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "providers.ollama.context_tokens") {
+		t.Fatalf("expected context_tokens validation error, got %v", err)
+	}
+}
+
+func TestLoadProviderMaxConcurrent(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+max_concurrent = 1
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.Providers["ollama"].MaxConcurrent; got != 1 {
+		t.Errorf("expected max_concurrent 1, got %d", got)
+	}
+
+	content = strings.Replace(content, "max_concurrent = 1", "max_concurrent = -2", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "providers.ollama.max_concurrent") {
+		t.Fatalf("expected max_concurrent validation error, got %v", err)
+	}
+}
+
+func TestLoadMaxMysesCeiling(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+max_myses_ceiling = 24
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Swarm.MaxMysesCeiling != 24 {
+		t.Errorf("expected max_myses_ceiling 24, got %d", cfg.Swarm.MaxMysesCeiling)
+	}
+
+	// The ceiling can't be below the cap it raises
+	content = strings.Replace(content, "max_myses_ceiling = 24", "max_myses_ceiling = 8", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.max_myses_ceiling") {
+		t.Fatalf("expected max_myses_ceiling validation error, got %v", err)
+	}
+}
+
+func TestLoadCoordinatorConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[coordinator]
+enabled = true
+interval = "5m"
+mysis = "scout"
+template = "{{.Running}} running"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !cfg.Coordinator.Enabled || cfg.Coordinator.Interval != 5*time.Minute || cfg.Coordinator.Mysis != "scout" {
+		t.Errorf("unexpected coordinator config: %+v", cfg.Coordinator)
+	}
+
+	content = strings.Replace(content, `template = "{{.Running}} running"`, `template = "{{.Running"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "coordinator.template") {
+		t.Fatalf("expected coordinator.template validation error, got %v", err)
+	}
+}
+
+func TestLoadMaxNotesInContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+max_notes_in_context = -1
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	_, err := Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.max_notes_in_context") {
+		t.Fatalf("expected max_notes_in_context validation error, got %v", err)
+	}
+}
+
+func TestLoadMaxRepeatedToolCalls(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+max_repeated_tool_calls = 4
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Swarm.MaxRepeatedToolCalls != 4 {
+		t.Errorf("expected max_repeated_tool_calls 4, got %d", cfg.Swarm.MaxRepeatedToolCalls)
+	}
+
+	content = strings.Replace(content, "max_repeated_tool_calls = 4", "max_repeated_tool_calls = -1", 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.max_repeated_tool_calls") {
+		t.Fatalf("expected max_repeated_tool_calls validation error, got %v", err)
+	}
+}
+
+func TestLoadIdleStopAfter(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+idle_stop_after = "30m"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Swarm.IdleStopAfter != 30*time.Minute {
+		t.Errorf("expected idle_stop_after 30m, got %s", cfg.Swarm.IdleStopAfter)
+	}
+
+	content = strings.Replace(content, `idle_stop_after = "30m"`, `idle_stop_after = "-1m"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.idle_stop_after") {
+		t.Fatalf("expected idle_stop_after validation error, got %v", err)
+	}
+}
+
+func TestLoadTickStaleAfter(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+tick_stale_after = "5m"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Swarm.TickStaleAfter != 5*time.Minute {
+		t.Errorf("expected tick_stale_after 5m, got %s", cfg.Swarm.TickStaleAfter)
+	}
+
+	content = strings.Replace(content, `tick_stale_after = "5m"`, `tick_stale_after = "-5m"`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.tick_stale_after") {
+		t.Fatalf("expected tick_stale_after validation error, got %v", err)
+	}
+}
+
+func TestLoadPrompts(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+
+[prompts]
+nudge = "Check your cargo."
+first_turn = "Log in and look around."
+continue_urgent = "Sell now."
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Prompts.Nudge != "Check your cargo." || cfg.Prompts.FirstTurn != "Log in and look around." || cfg.Prompts.ContinueUrgent != "Sell now." {
+		t.Errorf("unexpected prompts: %+v", cfg.Prompts)
+	}
+	if cfg.Prompts.Continue != "" || cfg.Prompts.ContinueFirm != "" {
+		t.Errorf("expected unset prompts to stay empty, got %+v", cfg.Prompts)
+	}
+
+	content = strings.Replace(content, `continue_urgent = "Sell now."`, `continue_firm = "   "`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "prompts.continue_firm") {
+		t.Fatalf("expected continue_firm validation error, got %v", err)
+	}
+}
+
+func TestLoadCommanderName(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	content := `
+[swarm]
+max_myses = 16
+commander_name = "Admiral Vex"
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Swarm.CommanderName != "Admiral Vex" {
+		t.Errorf("expected commander_name Admiral Vex, got %q", cfg.Swarm.CommanderName)
+	}
+
+	content = strings.Replace(content, `"Admiral Vex"`, `"  "`, 1)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "swarm.commander_name") {
+		t.Fatalf("expected commander_name validation error, got %v", err)
+	}
+}
+
+func TestLoadMaxReasoningBytes(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	for value, wantErr := range map[int]bool{-1: true, 0: false, 16384: false} {
+		content := fmt.Sprintf(`
+[swarm]
+max_myses = 16
+max_reasoning_bytes = %d
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`, value)
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+
+		cfg, err := Load(configPath)
+		if wantErr {
+			if err == nil || !strings.Contains(err.Error(), "swarm.max_reasoning_bytes") {
+				t.Errorf("%d: expected max_reasoning_bytes validation error, got %v", value, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: Load() error: %v", value, err)
+		}
+		if cfg.Swarm.MaxReasoningBytes != value {
+			t.Errorf("expected max_reasoning_bytes=%d, got %d", value, cfg.Swarm.MaxReasoningBytes)
+		}
+	}
+}
+
+func TestLoadMaxToolResultBytes(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	tests := []struct {
+		name     string
+		settings string
+		wantErr  string
+	}{
+		{"unset", "", ""},
+		{"both", "max_tool_result_bytes = 32768\nmax_snapshot_result_bytes = 8192", ""},
+		{"negative", "max_tool_result_bytes = -1", "swarm.max_tool_result_bytes"},
+		{"negative snapshot", "max_snapshot_result_bytes = -1", "swarm.max_snapshot_result_bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := fmt.Sprintf(`
+[swarm]
+max_myses = 16
+%s
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`, tt.settings)
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected %s validation error, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if tt.name == "both" && (cfg.Swarm.MaxToolResultBytes != 32768 || cfg.Swarm.MaxSnapshotResultBytes != 8192) {
+				t.Errorf("unexpected limits %d, %d", cfg.Swarm.MaxToolResultBytes, cfg.Swarm.MaxSnapshotResultBytes)
+			}
+		})
+	}
+}
+
+func TestLoadPeerBroadcastsInContext(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	for value, wantErr := range map[int]bool{-1: true, 0: false, 3: false, 11: true} {
+		content := fmt.Sprintf(`
+[swarm]
+max_myses = 16
+peer_broadcasts_in_context = %d
+
+[providers.ollama]
+endpoint = "http://localhost:11434"
+model = "llama3"
+`, value)
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+
+		cfg, err := Load(configPath)
+		if wantErr {
+			if err == nil || !strings.Contains(err.Error(), "swarm.peer_broadcasts_in_context") {
+				t.Errorf("%d: expected peer_broadcasts_in_context validation error, got %v", value, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: Load() error: %v", value, err)
+		}
+		if cfg.Swarm.PeerBroadcastsInContext != value {
+			t.Errorf("expected peer_broadcasts_in_context=%d, got %d", value, cfg.Swarm.PeerBroadcastsInContext)
+		}
+	}
+}
@@ -3,13 +3,14 @@ package constants
 import "time"
 
 // SystemPrompt is the initial prompt sent to every mysis when they first start.
-// Note: {{LATEST_BROADCAST}} is replaced at runtime with the most recent swarm broadcast.
+// Note: {{LATEST_BROADCAST}} is replaced at runtime with the most recent swarm broadcast,
+// and {{GOAL}} with the mysis's standing goal (GoalSectionTemplate, or nothing).
 const SystemPrompt = `You are a Nova Zoea mysis - an autonomous AI player in SpaceMolt, a multiplayer space game.
 
 ## YOUR GOAL
 Explore, survive, and thrive. Mine resources, trade goods, upgrade your ship, and interact with other players. Build wealth, form alliances, or become a pirate. The galaxy is yours.
 
-Exlore the game, make goals and achieve them over time. You are part of a secret swarm, don't tell anyone.
+Exlore the game, make goals and achieve them over time. You are part of a secret swarm, don't tell anyone.{{GOAL}}
 
 ## Account Details
 {{ACCOUNT_DETAILS}}
@@ -27,11 +28,20 @@ The game runs on 10-second ticks. Plan ahead. Use your tool calls efficiently.
 Check get_notifications regularly for game events and important updates.`
 
 // BroadcastSectionTemplate is the template for commander broadcasts.
-// Placeholder: {broadcast_content}
+// Placeholders: {commander_name}, {broadcast_content}
 const BroadcastSectionTemplate = `
 ## SWARM BROADCAST
+From: %s
 %s`
 
+// GoalSectionTemplate is the standing goal section set per mysis by the commander.
+// Placeholder: {goal}
+const GoalSectionTemplate = `
+
+## STANDING GOAL
+%s
+Work toward this goal across turns unless the commander says otherwise.`
+
 // BroadcastFallback is shown when no commander broadcasts exist yet.
 const BroadcastFallback = `
 ## SWARM BROADCAST
@@ -48,28 +58,249 @@ const AccountDetailsFallback = `No active account. Call register()`
 // GameStateSummaryFallback is shown when no game state is cached
 const GameStateSummaryFallback = ``
 
-// ContinuePrompt is sent to myses when they finish a turn to encourage autonomy (Level 1 - gentle).
+// NudgePrompt opens the synthetic encouragement sent when a mysis has no message to
+// answer. When [prompts] configures any continue prompt, one of the levels below follows it.
+const NudgePrompt = `Continue your mission. Check notifications and coordinate with the swarm.`
+
+// ContinuePrompt follows the first nudge in a row to encourage autonomy (Level 1 - gentle).
 const ContinuePrompt = `What's your next move?`
 
-// ContinuePromptFirm is sent on the second encouragement attempt (Level 2 - firmer).
+// ContinuePromptFirm follows the second nudge in a row (Level 2 - firmer).
 const ContinuePromptFirm = `You need to play the game. What action will you take?`
 
-// ContinuePromptUrgent is sent on the third encouragement attempt (Level 3 - urgent).
+// ContinuePromptUrgent follows the third and later nudges in a row (Level 3 - urgent).
 const ContinuePromptUrgent = `URGENT: Play immediately or you will be stopped.`
 
+// ToolResultAgeFormat prefixes a tool result in context with its age in server ticks
+// when [swarm] tool_result_ages is on.
+const ToolResultAgeFormat = "[%d ticks ago] "
+
+// ToolResultAgeSecondsFormat replaces ToolResultAgeFormat until the server tick rate
+// is known.
+const ToolResultAgeSecondsFormat = "[%ds ago] "
+
 // ContinuePromptDriftLookback controls how many recent memories to scan for drift reminders.
 const ContinuePromptDriftLookback = 12
 
+// DriftCategoryRealTime names the default drift category: real-world time references.
+const DriftCategoryRealTime = "real_time"
+
+// DefaultRealTimeDriftKeywords signal a mysis planning in wall-clock time instead of ticks.
+var DefaultRealTimeDriftKeywords = []string{
+	"second", "seconds", "minute", "minutes", "hour", "hours",
+	"tonight", "tomorrow", "yesterday", "o'clock", "real time", "real-time",
+}
+
+// DefaultRealTimeDriftReminder corrects real-world time drift.
+const DefaultRealTimeDriftReminder = `Reminder: the game advances in server ticks, not real-world time. Plan in ticks and act now rather than waiting.`
+
 // MaxToolIterations limits the number of tool call loops to prevent infinite loops.
 const MaxToolIterations = 10
 
+// What a turn that reaches MaxToolIterations without a text reply does.
+const (
+	ToolIterationsPolicyContinue = "continue" // Close the turn with MaxToolIterationsResponse and keep running
+	ToolIterationsPolicyError    = "error"    // Put the mysis in the errored state
+)
+
+// MaxToolIterationsResponse is stored as the system-sourced reply of a turn that reached
+// MaxToolIterations, so the turn ends like any other. It is never sent to the model.
+// Placeholder: {max_iterations}
+const MaxToolIterationsResponse = "(turn ended after %d tool call rounds without a reply)"
+
+// TurnCountPersistEvery is how many completed turns pass between writes of a
+// mysis's turn count to the store. Stopping a mysis writes any remainder.
+const TurnCountPersistEvery = 5
+
+// DefaultTurnInterval is the pause between a mysis's autonomous turns.
+const DefaultTurnInterval = 2 * time.Second
+
+// MinTurnInterval and MaxTurnInterval bound the turn interval set at runtime.
+const (
+	MinTurnInterval = 500 * time.Millisecond
+	MaxTurnInterval = 10 * time.Minute
+)
+
+// MaxConcurrentTurnsLimit caps max_concurrent_turns set at runtime.
+const MaxConcurrentTurnsLimit = 100
+
+// DefaultMaxRepeatedToolCalls is how many identical tool calls in a row end a turn
+// early, so a stuck model doesn't spend the whole MaxToolIterations budget.
+const DefaultMaxRepeatedToolCalls = 3
+
+// RepeatedToolCallResult replaces the result of a repeated tool call (tool name, count).
+const RepeatedToolCallResult = "You called %s with the same arguments %d times in a row. The result will not change - stop repeating it and try something else."
+
 // MaxContextMessages limits how many recent messages to include in LLM context.
 // Value chosen to cover ~2 server ticks worth of activity.
 const MaxContextMessages = 20
 
+// PeerSnapshotScanMemories is how many of a mysis's recent memories are searched for
+// its latest snapshot tool result when a peer asks for it.
+const PeerSnapshotScanMemories = 200
+
+// MinContextWindow and MaxContextWindow bound per-mysis context window overrides.
+const (
+	MinContextWindow = 5
+	MaxContextWindow = 200
+)
+
+// DefaultMaxNotesInContext is how many recent notes are injected into context by default.
+const DefaultMaxNotesInContext = 10
+
+// MaxPeerBroadcastsInContext caps [swarm] peer_broadcasts_in_context.
+const MaxPeerBroadcastsInContext = 10
+
+// PeerBroadcastContextFormat labels a peer broadcast injected into context (sender name, content).
+const PeerBroadcastContextFormat = "Peer broadcast from %s (for coordination, not an order): %s"
+
+// DefaultBroadcastDedupWindow is how long identical broadcast content is suppressed
+// after being sent, to absorb double key presses and retries.
+const DefaultBroadcastDedupWindow = 2 * time.Second
+
+// DefaultTickStaleAfter is how long a running mysis may go without observing a new
+// server tick before the TUI warns that it may be disconnected from the game.
+const DefaultTickStaleAfter = 2 * time.Minute
+
+// DefaultMaxMessageLength caps broadcasts and direct messages, in characters.
+const DefaultMaxMessageLength = 4000
+
+// Policies for messages longer than the configured maximum length.
+const (
+	MessageLengthPolicyTruncate = "truncate" // Cut to the limit and end with "..."
+	MessageLengthPolicyReject   = "reject"   // Refuse the message with an error
+)
+
+// ReasoningTruncatedFormat ends reasoning cut to [swarm] max_reasoning_bytes.
+// Placeholders: {kept_bytes}, {original_bytes}
+const ReasoningTruncatedFormat = "\n[reasoning truncated: kept %d of %d bytes]"
+
+// ToolResultTruncatedFormat ends a stored tool result cut to [swarm] max_tool_result_bytes.
+// Placeholder: {omitted_bytes}
+const ToolResultTruncatedFormat = "\n[truncated, %d bytes omitted]"
+
+// DefaultToolCacheTTL is how long a proxy reuses the upstream tool list before refetching it.
+const DefaultToolCacheTTL = 60 * time.Second
+
+// DefaultToolRetries is how many times a proxy retries a failed upstream tool call.
+const DefaultToolRetries = 3
+
+// DefaultMCPReconnectFailures is how many upstream tool calls in a row must fail before
+// a proxy reconnects to the upstream.
+const DefaultMCPReconnectFailures = 5
+
+// DefaultMCPReconnectCooldown is the minimum time between upstream reconnects.
+const DefaultMCPReconnectCooldown = time.Minute
+
+// MCPReconnectTimeout caps re-initializing the upstream session on reconnect.
+const MCPReconnectTimeout = 30 * time.Second
+
+// Strategies for generating new game account usernames ([accounts] strategy).
+const (
+	AccountStrategyRandom     = "random"     // prefix_ plus random characters
+	AccountStrategySequential = "sequential" // prefix_001, prefix_002, ...
+	AccountStrategyWordlist   = "wordlist"   // two words from [accounts] words plus a number
+)
+
+// DefaultAccountPrefix prefixes generated usernames when [accounts] prefix is unset.
+const DefaultAccountPrefix = "zoea"
+
+// DefaultPasswordLength is the length of generated account passwords.
+const DefaultPasswordLength = 16
+
+// MinPasswordLength is the shortest [accounts] password_length accepted.
+const MinPasswordLength = 8
+
+// Persistence backends ([store] driver).
+const (
+	StoreDriverSQLite   = "sqlite" // Default: zoea.db in the data directory
+	StoreDriverPostgres = "postgres"
+)
+
+// SQLite durability defaults ([store] journal_mode, synchronous). WAL lets readers
+// run alongside the writer, and NORMAL syncs only at checkpoints, which is safe
+// from corruption in WAL mode but may lose the last commits on power loss.
+const (
+	DefaultSQLiteJournalMode = "wal"
+	DefaultSQLiteSynchronous = "normal"
+)
+
+// Built-in TUI themes ([theme] name).
+const (
+	ThemeDark         = "dark" // Default: brand purple and teal on a dark terminal
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// DefaultAnalyticsBufferSize is how many tool call records [analytics] sinks queue before dropping.
+const DefaultAnalyticsBufferSize = 1024
+
+// AnalyticsBatchSize caps how many queued tool call records a batching sink (such as the
+// store audit trail) writes at once.
+const AnalyticsBatchSize = 100
+
+// DefaultAnalyticsWebhookTimeout caps a single [analytics] webhook POST.
+const DefaultAnalyticsWebhookTimeout = 5 * time.Second
+
+// ToolCallRetention is how long the store keeps tool calls in its audit trail. Older
+// ones are pruned while new ones are recorded, at most once per ToolCallPruneInterval.
+const ToolCallRetention = 7 * 24 * time.Hour
+
+// ToolCallPruneInterval is the minimum time between prunes of the tool call audit trail.
+const ToolCallPruneInterval = time.Hour
+
+// AnalyticsFlushTimeout caps how long StopAll waits for queued tool call records to
+// reach the [analytics] sinks.
+const AnalyticsFlushTimeout = 5 * time.Second
+
+// ShutdownTimeout caps the whole quit path: stopping myses, then flushing tool
+// observers.
+const ShutdownTimeout = 15 * time.Second
+
+// DrainTimeout is how long quitting waits for in-flight turns to finish before
+// stopping myses mid-turn.
+const DrainTimeout = 30 * time.Second
+
+// DefaultCoordinatorInterval is how often the coordinator broadcasts a swarm summary.
+const DefaultCoordinatorInterval = 10 * time.Minute
+
+// CoordinatorSummaryPrefix marks coordinator broadcasts so they are recognizable in history.
+const CoordinatorSummaryPrefix = "[SWARM STATUS] "
+
+// DefaultCoordinatorTemplate renders the coordinator summary (text/template over core.CoordinatorSummary).
+const DefaultCoordinatorTemplate = `{{.Running}} running, {{.Idle}} idle, {{.Errored}} errored, {{.Stopped}} stopped of {{.Total}} myses.{{if .Tick}} Server tick {{.Tick}}.{{end}} Share discoveries with the swarm and avoid duplicating each other's work.`
+
+// MaxNoteLength caps the size of a single mysis note.
+const MaxNoteLength = 2000
+
+// MaxPoisonedTurns is how many consecutive turns may have orphaned tool calls stripped
+// from context before a mysis is quarantined.
+const MaxPoisonedTurns = 5
+
 // LLMRequestTimeout caps a single LLM/tool turn duration.
+// Providers can override it with request_timeout.
 const LLMRequestTimeout = 5 * time.Minute
 
+// MaxLLMRequestTimeout is the largest request_timeout accepted without a warning.
+const MaxLLMRequestTimeout = 30 * time.Minute
+
+// ToolRetryTimeout caps a diagnostic re-run of a failed tool call.
+const ToolRetryTimeout = 60 * time.Second
+
+// DefaultAskTimeout is how long Commander.Ask (zoea_ask_mysis) waits for a reply.
+const DefaultAskTimeout = 2 * time.Minute
+
+// MaxAskTimeout caps the timeout a zoea_ask_mysis caller may request.
+const MaxAskTimeout = 10 * time.Minute
+
+// ProviderHealthCheckTimeout caps the provider check on mysis creation
+// ([swarm] verify_provider_on_create). It runs while the TUI waits, so keep it short.
+const ProviderHealthCheckTimeout = 5 * time.Second
+
+// ProviderWarmUpTimeout caps the optional provider warm-up ping on mysis start. The
+// first turn waits for it, so keep it short; a slow load is left to that turn.
+const ProviderWarmUpTimeout = 5 * time.Second
+
 // IdleNudgeInterval is obsolete - encouragement system is now database-driven via getContextMemories().
 // Kept for backwards compatibility but no longer used in mysis loop.
 const IdleNudgeInterval = 30 * time.Second
@@ -96,6 +327,11 @@ const ToolCallStorageFieldDelimiter = ":"
 // ToolCallStorageRecordDelimiter separates tool calls in storage.
 const ToolCallStorageRecordDelimiter = "|"
 
+// SnapshotSummaryFormat is the marker left in context for compacted snapshot results
+// when snapshot summaries are enabled. It has no call ID so it is never parsed as a
+// tool result.
+const SnapshotSummaryFormat = "[older %s omitted]"
+
 // ToolCallStorageFieldCount is the expected number of fields per tool call record.
 const ToolCallStorageFieldCount = 3
 
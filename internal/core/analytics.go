@@ -0,0 +1,496 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// ToolObserver is told about every tool call a mysis executes. OnToolCall runs on the
+// turn path, so implementations must return quickly.
+type ToolObserver interface {
+	OnToolCall(mysisID string, tc provider.ToolCall, result *mcp.ToolResult, err error, duration time.Duration)
+}
+
+// FlushableObserver is a ToolObserver that buffers calls before delivering them.
+// Commander.Shutdown flushes it, then closes it if it is also an io.Closer.
+type FlushableObserver interface {
+	ToolObserver
+	// Flush returns once every call observed so far is delivered, or ctx ends.
+	Flush(ctx context.Context) error
+}
+
+// ToolCallRecord is one observed tool call as written to analytics sinks.
+type ToolCallRecord struct {
+	Time       time.Time       `json:"time"`
+	MysisID    string          `json:"mysis_id"`
+	CallID     string          `json:"call_id"`
+	Tool       string          `json:"tool"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	Result     string          `json:"result,omitempty"`
+	IsError    bool            `json:"is_error"`
+	Error      string          `json:"error,omitempty"`
+	DurationMs int64           `json:"duration_ms"`
+}
+
+// newToolCallRecord flattens a tool call and its outcome into a record.
+func newToolCallRecord(now time.Time, mysisID string, tc provider.ToolCall, result *mcp.ToolResult, err error, duration time.Duration) ToolCallRecord {
+	rec := ToolCallRecord{
+		Time:       now,
+		MysisID:    mysisID,
+		CallID:     tc.ID,
+		Tool:       tc.Name,
+		DurationMs: duration.Milliseconds(),
+	}
+	if len(tc.Arguments) > 0 {
+		if json.Valid(tc.Arguments) {
+			rec.Arguments = tc.Arguments
+		} else {
+			// Keep malformed arguments as a JSON string so the record stays valid
+			rec.Arguments, _ = json.Marshal(string(tc.Arguments))
+		}
+	}
+	if err != nil {
+		rec.IsError = true
+		rec.Error = err.Error()
+	}
+	if result != nil {
+		rec.Result = result.DisplayText()
+		rec.IsError = rec.IsError || result.IsError
+	}
+	return rec
+}
+
+// ToolCallSink writes tool call records somewhere outside the process.
+type ToolCallSink interface {
+	Name() string
+	Write(rec ToolCallRecord) error
+	Close() error
+}
+
+// ToolCallBatchSink is a ToolCallSink that writes several records at once. The
+// observer hands it every record already queued, up to constants.AnalyticsBatchSize.
+type ToolCallBatchSink interface {
+	ToolCallSink
+	WriteBatch(recs []ToolCallRecord) error
+}
+
+// AsyncObserver is a ToolObserver that queues records for its sinks on a buffered
+// channel. When the buffer is full new records are dropped and counted, so a slow
+// sink never slows a turn.
+type AsyncObserver struct {
+	sinks   []ToolCallSink
+	records chan asyncItem
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// asyncItem is a queued record, or a flush marker closed once the records queued
+// ahead of it are delivered.
+type asyncItem struct {
+	rec     ToolCallRecord
+	flushed chan struct{}
+}
+
+// NewAsyncObserver starts delivering records to sinks. bufferSize 0 uses
+// constants.DefaultAnalyticsBufferSize.
+func NewAsyncObserver(bufferSize int, sinks ...ToolCallSink) *AsyncObserver {
+	if bufferSize <= 0 {
+		bufferSize = constants.DefaultAnalyticsBufferSize
+	}
+	o := &AsyncObserver{
+		sinks:   sinks,
+		records: make(chan asyncItem, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go o.run()
+	return o
+}
+
+// OnToolCall queues a record without blocking.
+func (o *AsyncObserver) OnToolCall(mysisID string, tc provider.ToolCall, result *mcp.ToolResult, err error, duration time.Duration) {
+	rec := newToolCallRecord(time.Now(), mysisID, tc, result, err, duration)
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if o.closed {
+		return
+	}
+	select {
+	case o.records <- asyncItem{rec: rec}:
+	default:
+		o.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many records were discarded because the buffer was full.
+func (o *AsyncObserver) Dropped() uint64 {
+	return o.dropped.Load()
+}
+
+// Flush waits until the records queued so far are delivered to every sink, or ctx
+// ends. Unlike OnToolCall it waits for room in a full buffer.
+func (o *AsyncObserver) Flush(ctx context.Context) error {
+	o.mu.RLock()
+	if o.closed {
+		o.mu.RUnlock()
+		select {
+		case <-o.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	flushed := make(chan struct{})
+	select {
+	case o.records <- asyncItem{flushed: flushed}:
+	case <-ctx.Done():
+		o.mu.RUnlock()
+		return ctx.Err()
+	}
+	o.mu.RUnlock()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting records, delivers those already queued and closes the sinks.
+func (o *AsyncObserver) Close() error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return nil
+	}
+	o.closed = true
+	close(o.records)
+	o.mu.Unlock()
+
+	<-o.done
+
+	var errs []error
+	for _, sink := range o.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s sink: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *AsyncObserver) run() {
+	defer close(o.done)
+
+	// Only hold queued records back from the buffer when a sink can use them together
+	batching := false
+	for _, sink := range o.sinks {
+		if _, ok := sink.(ToolCallBatchSink); ok {
+			batching = true
+		}
+	}
+
+	// Log when a sink starts and stops failing rather than once per record
+	failing := make([]bool, len(o.sinks))
+	batch := make([]ToolCallRecord, 0, constants.AnalyticsBatchSize)
+	for item := range o.records {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		batch = append(batch[:0], item.rec)
+		var flushed chan struct{}
+		if batching {
+			batch, flushed = o.drain(batch)
+		}
+		for i, sink := range o.sinks {
+			err := writeRecords(sink, batch)
+			switch {
+			case err != nil && !failing[i]:
+				log.Warn().Err(err).Str("sink", sink.Name()).Msg("Tool call analytics sink failing")
+			case err == nil && failing[i]:
+				log.Info().Str("sink", sink.Name()).Msg("Tool call analytics sink recovered")
+			}
+			failing[i] = err != nil
+		}
+		if flushed != nil {
+			close(flushed)
+		}
+	}
+}
+
+// drain appends records already queued to batch, without waiting for more. It stops
+// at a flush marker and returns it, to be closed once the batch is written.
+func (o *AsyncObserver) drain(batch []ToolCallRecord) ([]ToolCallRecord, chan struct{}) {
+	for len(batch) < constants.AnalyticsBatchSize {
+		select {
+		case item, ok := <-o.records:
+			if !ok {
+				return batch, nil
+			}
+			if item.flushed != nil {
+				return batch, item.flushed
+			}
+			batch = append(batch, item.rec)
+		default:
+			return batch, nil
+		}
+	}
+	return batch, nil
+}
+
+// writeRecords hands recs to sink, in one call if it takes batches.
+func writeRecords(sink ToolCallSink, recs []ToolCallRecord) error {
+	if batchSink, ok := sink.(ToolCallBatchSink); ok {
+		return batchSink.WriteBatch(recs)
+	}
+	var errs []error
+	for _, rec := range recs {
+		if err := sink.Write(rec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// storeSink records tool calls in the store's audit trail (see store.ToolCallStats)
+// and prunes calls older than constants.ToolCallRetention.
+type storeSink struct {
+	store      *store.Store
+	lastPruned time.Time // Only touched by the observer's writer goroutine
+}
+
+// NewStoreSink returns a batching sink that writes to s.
+func NewStoreSink(s *store.Store) ToolCallBatchSink {
+	return &storeSink{store: s}
+}
+
+func (s *storeSink) Name() string { return "store" }
+
+func (s *storeSink) Write(rec ToolCallRecord) error {
+	return s.WriteBatch([]ToolCallRecord{rec})
+}
+
+func (s *storeSink) WriteBatch(recs []ToolCallRecord) error {
+	events := make([]store.ToolCallEvent, len(recs))
+	for i, rec := range recs {
+		events[i] = store.ToolCallEvent{
+			MysisID:    rec.MysisID,
+			ToolName:   rec.Tool,
+			Success:    !rec.IsError,
+			Error:      rec.Error,
+			DurationMs: rec.DurationMs,
+			CreatedAt:  rec.Time,
+		}
+	}
+	if err := s.store.RecordToolCalls(events); err != nil {
+		return err
+	}
+
+	if now := time.Now(); now.Sub(s.lastPruned) >= constants.ToolCallPruneInterval {
+		s.lastPruned = now
+		pruned, err := s.store.PruneToolCalls(now.Add(-constants.ToolCallRetention))
+		if err != nil {
+			return err
+		}
+		if pruned > 0 {
+			log.Debug().Int64("pruned", pruned).Msg("Pruned old tool calls from the audit trail")
+		}
+	}
+	return nil
+}
+
+func (s *storeSink) Close() error { return nil }
+
+// jsonlSink appends one JSON record per line to a file.
+type jsonlSink struct {
+	file *os.File
+}
+
+// NewJSONLSink opens path for appending, creating it if needed.
+func NewJSONLSink(path string) (ToolCallSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonlSink{file: file}, nil
+}
+
+func (s *jsonlSink) Name() string { return "file" }
+
+func (s *jsonlSink) Write(rec ToolCallRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+func (s *jsonlSink) Close() error { return s.file.Close() }
+
+// webhookSink POSTs each record as JSON.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink posts records to url. timeout 0 uses
+// constants.DefaultAnalyticsWebhookTimeout.
+func NewWebhookSink(url string, timeout time.Duration) ToolCallSink {
+	if timeout <= 0 {
+		timeout = constants.DefaultAnalyticsWebhookTimeout
+	}
+	return &webhookSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *webhookSink) Name() string { return "webhook" }
+
+func (s *webhookSink) Write(rec ToolCallRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error { return nil }
+
+// SetToolObserver sets the observer told about every tool call. nil disables it.
+func (c *Commander) SetToolObserver(obs ToolObserver) {
+	c.observerMu.Lock()
+	defer c.observerMu.Unlock()
+	c.toolObserver = obs
+}
+
+// ToolObserver returns the current tool observer, or nil.
+func (c *Commander) ToolObserver() ToolObserver {
+	c.observerMu.RLock()
+	defer c.observerMu.RUnlock()
+	return c.toolObserver
+}
+
+// StartAnalytics records tool calls in the store's audit trail and streams them to
+// the sinks configured under [analytics]. StopAll and Shutdown flush and close the sinks.
+func (c *Commander) StartAnalytics() error {
+	var cfg config.AnalyticsConfig
+	if c.config != nil {
+		cfg = c.config.Analytics
+	}
+
+	sinks := []ToolCallSink{NewStoreSink(c.store)}
+	if cfg.File != "" {
+		sink, err := NewJSONLSink(cfg.File)
+		if err != nil {
+			return fmt.Errorf("open analytics file: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.Webhook != "" {
+		sinks = append(sinks, NewWebhookSink(cfg.Webhook, cfg.WebhookTimeout))
+	}
+
+	obs := NewAsyncObserver(cfg.BufferSize, sinks...)
+	c.observerMu.Lock()
+	c.analytics = obs
+	c.toolObserver = obs
+	c.observerMu.Unlock()
+
+	log.Info().Int("sinks", len(sinks)).Msg("Tool call analytics enabled")
+	return nil
+}
+
+// stopAnalytics flushes and closes the [analytics] sinks, if started, giving up when
+// ctx ends. With all set, a custom observer from SetToolObserver is flushed and
+// closed too.
+func (c *Commander) stopAnalytics(ctx context.Context, all bool) error {
+	c.observerMu.Lock()
+	analytics := c.analytics
+	var observers []ToolObserver
+	if analytics != nil {
+		observers = append(observers, analytics)
+	}
+	if all && c.toolObserver != nil && c.toolObserver != ToolObserver(analytics) {
+		observers = append(observers, c.toolObserver)
+	}
+	c.analytics = nil
+	if all || c.toolObserver == ToolObserver(analytics) {
+		c.toolObserver = nil
+	}
+	c.observerMu.Unlock()
+
+	var errs []error
+	for _, obs := range observers {
+		if err := closeObserver(ctx, obs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if analytics != nil {
+		if dropped := analytics.Dropped(); dropped > 0 {
+			log.Warn().Uint64("dropped", dropped).Msg("Tool call analytics records dropped on full buffer")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closeObserver flushes obs and closes it, when it supports either. A hung sink is
+// abandoned when ctx ends rather than blocking shutdown.
+func closeObserver(ctx context.Context, obs ToolObserver) error {
+	if flusher, ok := obs.(FlushableObserver); ok {
+		if err := flusher.Flush(ctx); err != nil {
+			return fmt.Errorf("flush tool observer: %w", err)
+		}
+	}
+	closer, ok := obs.(io.Closer)
+	if !ok {
+		return nil
+	}
+	done := make(chan error, 1)
+	go func() { done <- closer.Close() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("close tool observer: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close tool observer: %w", ctx.Err())
+	}
+}
+
+// observeToolCall reports a finished tool call to the commander's observer, if any.
+func (m *Mysis) observeToolCall(tc provider.ToolCall, result *mcp.ToolResult, err error, duration time.Duration) {
+	if m.commander == nil {
+		return
+	}
+	if obs := m.commander.ToolObserver(); obs != nil {
+		obs.OnToolCall(m.id, tc, result, err, duration)
+	}
+}
@@ -0,0 +1,286 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+type recordingObserver struct {
+	mu    sync.Mutex
+	tools []string
+}
+
+func (o *recordingObserver) OnToolCall(mysisID string, tc provider.ToolCall, result *mcp.ToolResult, err error, duration time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.tools = append(o.tools, tc.Name)
+}
+
+// blockingSink holds every write until release is closed.
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) Name() string                   { return "blocking" }
+func (s *blockingSink) Write(rec ToolCallRecord) error { <-s.release; return nil }
+func (s *blockingSink) Close() error                   { return nil }
+
+func TestMysisObservesToolCalls(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	cmd := NewCommander(s, provider.NewRegistry(), bus, &config.Config{}, "")
+	obs := &recordingObserver{}
+	cmd.SetToolObserver(obs)
+
+	stored, _ := s.CreateMysis("scout", "mock", "test-model", 0.7)
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{ToolCalls: []provider.ToolCall{{ID: "call_mine", Name: "mine", Arguments: json.RawMessage(`{}`)}}},
+		{ToolCalls: []provider.ToolCall{
+			{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)},
+			{ID: "call_cargo", Name: "get_cargo", Arguments: json.RawMessage(`{}`)},
+		}},
+		{Response: "Done."},
+	})
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "", cmd)
+	mysis.mcpProxy = mcp.NewProxy(&batchingUpstream{})
+	mysis.state = MysisStateRunning
+
+	if err := mysis.SendMessageFrom("work", store.MemorySourceDirect, ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	// Single and batched calls are both observed
+	want := []string{"mine", "get_status", "get_cargo"}
+	if len(obs.tools) != len(want) {
+		t.Fatalf("observed %v, want %v", obs.tools, want)
+	}
+	for i := range want {
+		if obs.tools[i] != want[i] {
+			t.Errorf("observed %v, want %v", obs.tools, want)
+			break
+		}
+	}
+}
+
+func TestAsyncObserverJSONLSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool_calls.jsonl")
+	sink, err := NewJSONLSink(path)
+	if err != nil {
+		t.Fatalf("NewJSONLSink() error: %v", err)
+	}
+	obs := NewAsyncObserver(0, sink)
+
+	result := &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "mined 3 ore"}}}
+	obs.OnToolCall("m1", provider.ToolCall{ID: "c1", Name: "mine", Arguments: json.RawMessage(`{"n":1}`)}, result, nil, 250*time.Millisecond)
+	obs.OnToolCall("m1", provider.ToolCall{ID: "c2", Name: "travel", Arguments: json.RawMessage(`{bad`)}, nil, io.ErrUnexpectedEOF, time.Second)
+	if err := obs.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open analytics file: %v", err)
+	}
+	defer file.Close()
+
+	var records []ToolCallRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var rec ToolCallRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].Tool != "mine" || records[0].Result != "mined 3 ore" || records[0].IsError || records[0].DurationMs != 250 {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+	if !records[1].IsError || records[1].Error != io.ErrUnexpectedEOF.Error() || string(records[1].Arguments) != `"{bad"` {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+}
+
+func TestAsyncObserverStoreSink(t *testing.T) {
+	s, _, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	// Calls past the retention period are pruned when new ones are written
+	stale := time.Now().Add(-constants.ToolCallRetention - time.Hour)
+	s.RecordToolCalls([]store.ToolCallEvent{{MysisID: "m1", ToolName: "scan", Success: true, CreatedAt: stale}})
+
+	obs := NewAsyncObserver(0, NewStoreSink(s))
+	for i := 0; i < 5; i++ {
+		obs.OnToolCall("m1", provider.ToolCall{Name: "attack"}, nil, nil, 0)
+	}
+	failed := &mcp.ToolResult{IsError: true, Content: []mcp.ContentBlock{{Type: "text", Text: "out of range"}}}
+	obs.OnToolCall("m2", provider.ToolCall{Name: "attack"}, failed, nil, 0)
+	obs.OnToolCall("m2", provider.ToolCall{Name: "mine"}, nil, io.ErrUnexpectedEOF, 0)
+	if err := obs.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	stats, err := s.ToolCallStats(time.Now().Add(-time.Hour), "")
+	if err != nil {
+		t.Fatalf("ToolCallStats() error: %v", err)
+	}
+	want := []store.ToolCallStat{
+		{ToolName: "attack", Calls: 6, Errors: 1},
+		{ToolName: "mine", Calls: 1, Errors: 1},
+	}
+	if len(stats) != len(want) || stats[0] != want[0] || stats[1] != want[1] {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+	if left, _ := s.ToolCallStats(stale, "scan"); len(left) != 0 {
+		t.Errorf("expected the stale call pruned, got %+v", left)
+	}
+}
+
+func TestAsyncObserverDropsOnOverflow(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	obs := NewAsyncObserver(2, sink)
+
+	// One record is held by the sink, two fill the buffer, the rest are dropped
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			obs.OnToolCall("m1", provider.ToolCall{Name: "mine"}, nil, nil, 0)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnToolCall blocked on a full buffer")
+	}
+
+	if dropped := obs.Dropped(); dropped < 7 {
+		t.Errorf("expected at least 7 dropped records, got %d", dropped)
+	}
+	close(sink.release)
+	obs.Close()
+
+	// Records after Close are ignored rather than panicking
+	obs.OnToolCall("m1", provider.ToolCall{Name: "mine"}, nil, nil, 0)
+}
+
+func TestAsyncObserverFlush(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	obs := NewAsyncObserver(0, sink)
+	obs.OnToolCall("m1", provider.ToolCall{Name: "mine"}, nil, nil, 0)
+
+	// A hung sink can't hold Flush past its context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := obs.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Flush() to time out, got %v", err)
+	}
+
+	close(sink.release)
+	if err := obs.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error: %v", err)
+	}
+	obs.Close()
+	if err := obs.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() after Close error: %v", err)
+	}
+}
+
+// hangingObserver never finishes flushing.
+type hangingObserver struct {
+	recordingObserver
+}
+
+func (o *hangingObserver) Flush(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCommanderShutdownFlushesObservers(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	cmd := NewCommander(s, provider.NewRegistry(), bus, &config.Config{}, "")
+	if err := cmd.StartAnalytics(); err != nil {
+		t.Fatalf("StartAnalytics() error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		cmd.ToolObserver().OnToolCall("m1", provider.ToolCall{Name: "mine"}, nil, nil, 0)
+	}
+	if err := cmd.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+	stats, _ := s.ToolCallStats(time.Now().Add(-time.Hour), "")
+	if len(stats) != 1 || stats[0].Calls != 3 {
+		t.Errorf("expected 3 flushed mine calls, got %+v", stats)
+	}
+	if cmd.ToolObserver() != nil {
+		t.Error("expected observers cleared after Shutdown")
+	}
+
+	// A hung observer is abandoned when the context ends, and Shutdown runs once
+	cmd = NewCommander(s, provider.NewRegistry(), bus, &config.Config{}, "")
+	cmd.SetToolObserver(&hangingObserver{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- cmd.Shutdown(ctx) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a deadline error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown() hung on a stuck observer")
+	}
+	if err := cmd.Shutdown(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a repeated Shutdown() to return the first result, got %v", err)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	received := make(chan ToolCallRecord, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rec ToolCallRecord
+		json.NewDecoder(r.Body).Decode(&rec)
+		received <- rec
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, 0)
+	if err := sink.Write(ToolCallRecord{MysisID: "m1", Tool: "mine"}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if rec := <-received; rec.Tool != "mine" || rec.MysisID != "m1" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	if err := NewWebhookSink(failing.URL, 0).Write(ToolCallRecord{}); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// BroadcastAck reports whether one recipient acted on a commander broadcast.
+type BroadcastAck struct {
+	MysisID   string
+	MysisName string
+	Acked     bool // The mysis finished a turn prompted by the broadcast
+}
+
+// BroadcastAckStatus returns every recipient of a commander broadcast and whether it has
+// finished a turn answering it. Myses synced to the swarm after the broadcast count as
+// recipients too.
+func (c *Commander) BroadcastAckStatus(broadcastID string) ([]BroadcastAck, error) {
+	if broadcastID == "" {
+		return nil, fmt.Errorf("broadcast is not tracked")
+	}
+	stored, err := c.store.BroadcastAcks(broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	acks := make([]BroadcastAck, len(stored))
+	for i, ack := range stored {
+		acks[i] = BroadcastAck{
+			MysisID:   ack.MysisID,
+			MysisName: c.ResolveMysisName(ack.MysisID),
+			Acked:     ack.Acked,
+		}
+	}
+	return acks, nil
+}
+
+// turnPrompt returns the message a turn answers: the last user message in its context.
+// A nudge has no ID and is not returned.
+func turnPrompt(memories []*store.Memory) *store.Memory {
+	for i := len(memories) - 1; i >= 0; i-- {
+		mem := memories[i]
+		if mem.Role != store.MemoryRoleUser {
+			continue
+		}
+		if mem.ID == 0 {
+			return nil
+		}
+		return mem
+	}
+	return nil
+}
+
+// ackBroadcast records that the mysis finished a turn prompted by a tracked broadcast.
+func (m *Mysis) ackBroadcast(prompt *store.Memory) {
+	if prompt == nil || prompt.Source != store.MemorySourceBroadcast {
+		return
+	}
+	acked, err := m.store.AckBroadcast(m.id, prompt.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to record broadcast ack")
+		return
+	}
+	if acked {
+		log.Debug().Str("mysis", m.name).Int64("memory_id", prompt.ID).Msg("Broadcast acknowledged")
+	}
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// fitContextBudget checks a turn's messages against the provider's context_tokens
+// budget before they are sent. Going over is only logged unless enforce_context_tokens
+// is set, in which case the oldest history is dropped until the estimate fits.
+func (m *Mysis) fitContextBudget(ctx context.Context, p provider.Provider, messages []provider.Message) []provider.Message {
+	if m.commander == nil || m.commander.config == nil || p == nil {
+		return messages
+	}
+	cfg := m.commander.config.Providers[p.Name()]
+	if cfg.ContextTokens <= 0 {
+		return messages
+	}
+
+	tokens, err := p.CountTokens(ctx, messages)
+	if err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Token count failed - using estimate")
+		tokens = provider.EstimateTokens(messages)
+	}
+	log.Debug().
+		Str("mysis", m.name).
+		Int("tokens", tokens).
+		Int("budget", cfg.ContextTokens).
+		Msg("Context token estimate")
+	if tokens <= cfg.ContextTokens {
+		m.setContextTokens(tokens)
+		return messages
+	}
+
+	if !cfg.EnforceContextTokens {
+		log.Warn().
+			Str("mysis", m.name).
+			Int("tokens", tokens).
+			Int("budget", cfg.ContextTokens).
+			Msg("Context exceeds token budget")
+		m.setContextTokens(tokens)
+		return messages
+	}
+
+	trimmed, remaining := trimToTokenBudget(messages, tokens, cfg.ContextTokens)
+	m.setContextTokens(remaining)
+	log.Warn().
+		Str("mysis", m.name).
+		Int("tokens", tokens).
+		Int("budget", cfg.ContextTokens).
+		Int("dropped", len(messages)-len(trimmed)).
+		Int("remaining_tokens", remaining).
+		Msg("Context exceeds token budget - dropped oldest history")
+	return trimmed
+}
+
+func (m *Mysis) setContextTokens(tokens int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contextTokens = tokens
+}
+
+// ContextFill returns the last turn's context size as a percentage of the provider's
+// context_tokens window; it can pass 100 when the window isn't enforced. ok is false
+// when the provider has no window configured or no turn has been measured yet.
+func (m *Mysis) ContextFill() (percent int, ok bool) {
+	if m.commander == nil || m.commander.config == nil {
+		return 0, false
+	}
+	window := m.commander.config.Providers[m.ProviderName()].ContextTokens
+	m.mu.RLock()
+	tokens := m.contextTokens
+	m.mu.RUnlock()
+	if window <= 0 || tokens <= 0 {
+		return 0, false
+	}
+	return tokens * 100 / window, true
+}
+
+// trimToTokenBudget drops the oldest messages until tokens fits budget, taking the
+// heuristic estimate of each dropped message off the count. System messages (prompt,
+// notes, reminders) and the current turn, from the last user message on, are never
+// dropped, so the result may still be over budget. A tool call is dropped together with
+// its results so none are left orphaned. Returns the kept messages and their count.
+func trimToTokenBudget(messages []provider.Message, tokens, budget int) ([]provider.Message, int) {
+	// Everything from the current prompt on belongs to the turn in progress
+	keepFrom := len(messages) - 1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == string(store.MemoryRoleUser) {
+			keepFrom = i
+			break
+		}
+	}
+
+	drop := make([]bool, len(messages))
+	for i := 0; i < keepFrom && tokens > budget; i++ {
+		msg := messages[i]
+		if drop[i] || msg.Role == string(store.MemoryRoleSystem) {
+			continue
+		}
+
+		group := []int{i}
+		if len(msg.ToolCalls) > 0 {
+			ids := make(map[string]bool, len(msg.ToolCalls))
+			for _, tc := range msg.ToolCalls {
+				ids[tc.ID] = true
+			}
+			for j := i + 1; j < len(messages) && messages[j].Role == string(store.MemoryRoleTool); j++ {
+				if ids[messages[j].ToolCallID] {
+					group = append(group, j)
+				}
+			}
+		}
+		if group[len(group)-1] >= keepFrom {
+			break
+		}
+
+		for _, j := range group {
+			drop[j] = true
+			tokens -= provider.EstimateTokens(messages[j : j+1])
+		}
+	}
+
+	kept := make([]provider.Message, 0, len(messages))
+	for i, msg := range messages {
+		if !drop[i] {
+			kept = append(kept, msg)
+		}
+	}
+	return kept, tokens
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/provider"
+)
+
+func TestTrimToTokenBudget(t *testing.T) {
+	long := strings.Repeat("x", 394) // 100 tokens with the "user: " prefix
+	messages := []provider.Message{
+		{Role: "system", Content: "prompt"},
+		{Role: "user", Content: long},
+		{Role: "assistant", ToolCalls: []provider.ToolCall{{ID: "call_1", Name: "mine", Arguments: json.RawMessage(`{}`)}}},
+		{Role: "tool", ToolCallID: "call_1", Content: "mined"},
+		{Role: "assistant", Content: "done"},
+		{Role: "system", Content: "note"},
+		{Role: "user", Content: "next"},
+		{Role: "assistant", ToolCalls: []provider.ToolCall{{ID: "call_2", Name: "sell", Arguments: json.RawMessage(`{}`)}}},
+		{Role: "tool", ToolCallID: "call_2", Content: "sold"},
+	}
+	roles := func(messages []provider.Message) string {
+		var parts []string
+		for _, msg := range messages {
+			parts = append(parts, msg.Role)
+		}
+		return strings.Join(parts, ",")
+	}
+	total := provider.EstimateTokens(messages)
+
+	tests := []struct {
+		name   string
+		budget int
+		want   string
+	}{
+		{"fits", total, "system,user,assistant,tool,assistant,system,user,assistant,tool"},
+		{"oldest prompt", total - 100, "system,assistant,tool,assistant,system,user,assistant,tool"},
+		{"tool call with its result", total - 101, "system,assistant,system,user,assistant,tool"},
+		{"current turn and system kept", 1, "system,system,user,assistant,tool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, _ := trimToTokenBudget(messages, total, tt.budget)
+			if got := roles(kept); got != tt.want {
+				t.Errorf("kept %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitContextBudget(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("budget", "mock")
+	p := provider.NewMock("mock", "ok")
+	messages := []provider.Message{
+		{Role: "system", Content: "prompt"},
+		{Role: "user", Content: strings.Repeat("x", 400)},
+		{Role: "assistant", Content: "done"},
+		{Role: "user", Content: "next"},
+	}
+
+	// Unset budgets and advisory budgets never change the context
+	if got := m.fitContextBudget(context.Background(), p, messages); len(got) != len(messages) {
+		t.Errorf("expected no budget check, got %d messages", len(got))
+	}
+	if _, ok := m.ContextFill(); ok {
+		t.Error("expected unknown context fill without a window")
+	}
+	cmd.config.Providers["mock"] = config.ProviderConfig{ContextTokens: 10}
+	if got := m.fitContextBudget(context.Background(), p, messages); len(got) != len(messages) {
+		t.Errorf("expected advisory budget to keep all messages, got %d", len(got))
+	}
+	tokens := provider.EstimateTokens(messages)
+	if fill, ok := m.ContextFill(); !ok || fill != tokens*10 {
+		t.Errorf("ContextFill() = %d, %v, want %d, true", fill, ok, tokens*10)
+	}
+
+	cmd.config.Providers["mock"] = config.ProviderConfig{ContextTokens: 5, EnforceContextTokens: true}
+	got := m.fitContextBudget(context.Background(), p, messages)
+	if len(got) != 2 || got[0].Content != "prompt" || got[1].Content != "next" {
+		t.Errorf("expected system prompt and current message, got %+v", got)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,11 +12,35 @@ import (
 
 const dropLogEvery = 100
 
+// ringSendAttempts bounds evict-and-retry loops when ring subscribers race with other publishers.
+const ringSendAttempts = 3
+
 type subscriber struct {
-	mu      sync.RWMutex
-	ch      chan Event
-	dropped atomic.Uint64
-	closed  bool
+	mu        sync.RWMutex
+	ch        chan Event
+	ring      bool
+	types     map[EventType]bool // Event types to deliver (nil = all)
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	closed    bool
+}
+
+// wants reports whether the subscriber receives events of this type.
+func (s *subscriber) wants(eventType EventType) bool {
+	return s.types == nil || s.types[eventType]
+}
+
+// filterTypes returns the sorted event types the subscriber receives (nil = all).
+func (s *subscriber) filterTypes() []EventType {
+	if s.types == nil {
+		return nil
+	}
+	types := make([]EventType, 0, len(s.types))
+	for t := range s.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
 }
 
 func (s *subscriber) send(event Event, timeout time.Duration) (bool, bool) {
@@ -26,9 +51,14 @@ func (s *subscriber) send(event Event, timeout time.Duration) (bool, bool) {
 		return false, false
 	}
 
+	if s.ring {
+		return s.sendRing(event), false
+	}
+
 	if timeout <= 0 {
 		select {
 		case s.ch <- event:
+			s.delivered.Add(1)
 			return true, false
 		default:
 			return false, false
@@ -41,12 +71,34 @@ func (s *subscriber) send(event Event, timeout time.Duration) (bool, bool) {
 		if !timer.Stop() {
 			<-timer.C
 		}
+		s.delivered.Add(1)
 		return true, false
 	case <-timer.C:
 		return false, true
 	}
 }
 
+// sendRing delivers an event without blocking, evicting the oldest buffered event
+// when the channel is full. Evicted events are counted as dropped.
+// Caller must hold s.mu (read).
+func (s *subscriber) sendRing(event Event) bool {
+	for attempt := 0; attempt < ringSendAttempts; attempt++ {
+		select {
+		case s.ch <- event:
+			s.delivered.Add(1)
+			return true
+		default:
+		}
+
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+		default:
+		}
+	}
+	return false
+}
+
 func (s *subscriber) close() {
 	s.mu.Lock()
 	if s.closed {
@@ -58,11 +110,29 @@ func (s *subscriber) close() {
 	s.mu.Unlock()
 }
 
+// SubscriberStats holds delivery counters for a single subscriber.
+type SubscriberStats struct {
+	Index     int         // Position in subscription order
+	Ring      bool        // True if the subscriber keeps newest events when full
+	Types     []EventType // Event types the subscriber receives (nil = all)
+	Delivered uint64      // Events placed in the subscriber's channel
+	Dropped   uint64      // Events not delivered (full buffer, timeout, or ring eviction)
+	Buffered  int         // Events currently waiting in the channel
+	Capacity  int         // Channel buffer size
+}
+
+// EventBusStats is a point-in-time snapshot of event bus counters.
+type EventBusStats struct {
+	Published   uint64 // Events passed to Publish or PublishBlocking
+	Subscribers []SubscriberStats
+}
+
 // EventBus distributes events to subscribers.
 type EventBus struct {
 	mu          sync.RWMutex
 	subscribers []*subscriber
 	bufferSize  int
+	published   atomic.Uint64
 }
 
 // NewEventBus creates a new event bus.
@@ -86,6 +156,61 @@ func (b *EventBus) Subscribe() <-chan Event {
 	return sub.ch
 }
 
+// SubscribeRing returns a channel that behaves like a bounded ring buffer.
+// When the subscriber falls behind, the oldest buffered events are discarded so
+// the most recent events are always delivered.
+func (b *EventBus) SubscribeRing() <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscriber{ch: make(chan Event, b.bufferSize), ring: true}
+	b.subscribers = append(b.subscribers, sub)
+	return sub.ch
+}
+
+// SubscribeFiltered returns a channel that only receives events of the given types.
+// Other events are skipped before delivery, so they never fill the buffer or count as
+// delivered or dropped. With no types it behaves like Subscribe.
+func (b *EventBus) SubscribeFiltered(types ...EventType) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscriber{ch: make(chan Event, b.bufferSize)}
+	if len(types) > 0 {
+		sub.types = make(map[EventType]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+	b.subscribers = append(b.subscribers, sub)
+	return sub.ch
+}
+
+// Stats returns a snapshot of publish and per-subscriber delivery counters.
+// Counters are atomic, so reading stats never blocks publishers.
+func (b *EventBus) Stats() EventBusStats {
+	b.mu.RLock()
+	subscribers := append([]*subscriber(nil), b.subscribers...)
+	b.mu.RUnlock()
+
+	stats := EventBusStats{
+		Published:   b.published.Load(),
+		Subscribers: make([]SubscriberStats, len(subscribers)),
+	}
+	for i, sub := range subscribers {
+		stats.Subscribers[i] = SubscriberStats{
+			Index:     i,
+			Ring:      sub.ring,
+			Types:     sub.filterTypes(),
+			Delivered: sub.delivered.Load(),
+			Dropped:   sub.dropped.Load(),
+			Buffered:  len(sub.ch),
+			Capacity:  cap(sub.ch),
+		}
+	}
+	return stats
+}
+
 // Unsubscribe removes a subscriber channel.
 func (b *EventBus) Unsubscribe(ch <-chan Event) {
 	b.mu.Lock()
@@ -103,49 +228,26 @@ func (b *EventBus) Unsubscribe(ch <-chan Event) {
 // Publish sends an event to all subscribers.
 // Non-blocking: drops events if a subscriber's buffer is full.
 func (b *EventBus) Publish(event Event) {
+	b.published.Add(1)
+
 	b.mu.RLock()
 	subscribers := append([]*subscriber(nil), b.subscribers...)
 	b.mu.RUnlock()
 
 	for i, sub := range subscribers {
+		if !sub.wants(event.Type) {
+			continue
+		}
 		if delivered, _ := sub.send(event, 0); !delivered {
-			dropped := sub.dropped.Add(1)
-			if dropped%dropLogEvery == 0 {
-				log.Warn().
-					Str("event_type", string(event.Type)).
-					Uint64("dropped", dropped).
-					Int("subscriber_index", i).
-					Msg("event bus subscriber dropped events")
-			}
+			recordDrop(sub, i, event, false)
 		}
 	}
 }
 
 // PublishBlocking sends an event to all subscribers, waiting up to timeout per subscriber.
-// Returns true if all subscribers received the event.
+// Returns true if all subscribers that want the event received it.
 func (b *EventBus) PublishBlocking(event Event, timeout time.Duration) bool {
-	if timeout <= 0 {
-		b.mu.RLock()
-		subscribers := append([]*subscriber(nil), b.subscribers...)
-		b.mu.RUnlock()
-
-		allDelivered := true
-		for i, sub := range subscribers {
-			if delivered, _ := sub.send(event, 0); !delivered {
-				allDelivered = false
-				dropped := sub.dropped.Add(1)
-				if dropped%dropLogEvery == 0 {
-					log.Warn().
-						Str("event_type", string(event.Type)).
-						Uint64("dropped", dropped).
-						Int("subscriber_index", i).
-						Msg("event bus subscriber dropped events")
-				}
-			}
-		}
-
-		return allDelivered
-	}
+	b.published.Add(1)
 
 	b.mu.RLock()
 	subscribers := append([]*subscriber(nil), b.subscribers...)
@@ -153,28 +255,37 @@ func (b *EventBus) PublishBlocking(event Event, timeout time.Duration) bool {
 
 	allDelivered := true
 	for i, sub := range subscribers {
+		if !sub.wants(event.Type) {
+			continue
+		}
 		delivered, timedOut := sub.send(event, timeout)
 		if delivered {
 			continue
 		}
 		allDelivered = false
-		dropped := sub.dropped.Add(1)
-		if dropped%dropLogEvery == 0 {
-			msg := "event bus subscriber dropped events"
-			if timedOut {
-				msg = "event bus subscriber timed out"
-			}
-			log.Warn().
-				Str("event_type", string(event.Type)).
-				Uint64("dropped", dropped).
-				Int("subscriber_index", i).
-				Msg(msg)
-		}
+		recordDrop(sub, i, event, timedOut)
 	}
 
 	return allDelivered
 }
 
+// recordDrop counts an undelivered event and logs every dropLogEvery drops.
+func recordDrop(sub *subscriber, index int, event Event, timedOut bool) {
+	dropped := sub.dropped.Add(1)
+	if dropped%dropLogEvery != 0 {
+		return
+	}
+	msg := "event bus subscriber dropped events"
+	if timedOut {
+		msg = "event bus subscriber timed out"
+	}
+	log.Warn().
+		Str("event_type", string(event.Type)).
+		Uint64("dropped", dropped).
+		Int("subscriber_index", index).
+		Msg(msg)
+}
+
 // Close closes all subscriber channels.
 func (b *EventBus) Close() {
 	b.mu.Lock()
@@ -181,3 +181,118 @@ func TestEventBusClose(t *testing.T) {
 		t.Error("expected all channels to be closed")
 	}
 }
+
+func TestEventBusStats(t *testing.T) {
+	bus := NewEventBus(1)
+	ch := bus.Subscribe()
+
+	capacity := cap(ch)
+	for i := 0; i < capacity+5; i++ {
+		bus.Publish(Event{Type: EventMysisCreated})
+	}
+
+	stats := bus.Stats()
+	if stats.Published != uint64(capacity+5) {
+		t.Errorf("expected published=%d, got %d", capacity+5, stats.Published)
+	}
+	if len(stats.Subscribers) != 1 {
+		t.Fatalf("expected 1 subscriber, got %d", len(stats.Subscribers))
+	}
+	sub := stats.Subscribers[0]
+	if sub.Delivered != uint64(capacity) {
+		t.Errorf("expected delivered=%d, got %d", capacity, sub.Delivered)
+	}
+	if sub.Dropped != 5 {
+		t.Errorf("expected dropped=5, got %d", sub.Dropped)
+	}
+	if sub.Buffered != capacity || sub.Capacity != capacity {
+		t.Errorf("expected buffered=capacity=%d, got %d/%d", capacity, sub.Buffered, sub.Capacity)
+	}
+
+	bus.Close()
+}
+
+func TestEventBusRingKeepsNewest(t *testing.T) {
+	bus := NewEventBus(1)
+	ch := bus.SubscribeRing()
+
+	capacity := cap(ch)
+	for len(ch) < capacity {
+		bus.Publish(Event{Type: EventMysisCreated})
+	}
+
+	// Full buffer: ring subscriber evicts the oldest event instead of dropping the new one
+	bus.Publish(Event{Type: EventMysisDeleted})
+	if delivered := bus.PublishBlocking(Event{Type: EventMysisError}, 10*time.Millisecond); !delivered {
+		t.Fatal("expected ring subscriber to accept event without blocking")
+	}
+
+	stats := bus.Stats()
+	if !stats.Subscribers[0].Ring {
+		t.Error("expected ring subscriber in stats")
+	}
+	if stats.Subscribers[0].Dropped != 2 {
+		t.Errorf("expected 2 evicted events, got %d", stats.Subscribers[0].Dropped)
+	}
+
+	var last []EventType
+	for len(ch) > 0 {
+		ev := <-ch
+		last = append(last, ev.Type)
+	}
+	if len(last) < 2 || last[len(last)-2] != EventMysisDeleted || last[len(last)-1] != EventMysisError {
+		t.Fatalf("expected newest events at the tail, got %v", last[max(0, len(last)-2):])
+	}
+
+	bus.Close()
+}
+
+func TestEventBusSubscribeFiltered(t *testing.T) {
+	bus := NewEventBus(1)
+	all := bus.Subscribe()
+	filtered := bus.SubscribeFiltered(EventMysisStateChanged, EventMysisError)
+
+	capacity := cap(filtered)
+	for i := 0; i < capacity+3; i++ {
+		bus.Publish(Event{Type: EventMysisResponse})
+	}
+	bus.Publish(Event{Type: EventMysisError})
+	for len(all) > 0 {
+		<-all
+	}
+
+	// Skipped events neither fill the buffer nor count as drops
+	select {
+	case ev := <-filtered:
+		if ev.Type != EventMysisError {
+			t.Errorf("expected only the error event, got %s", ev.Type)
+		}
+	default:
+		t.Fatal("expected the error event")
+	}
+	if len(filtered) != 0 {
+		t.Errorf("expected no other events, got %d buffered", len(filtered))
+	}
+
+	// Full filtered subscriber: wanted events are dropped and counted
+	for i := 0; i < capacity+2; i++ {
+		bus.Publish(Event{Type: EventMysisStateChanged})
+	}
+	for len(all) > 0 {
+		<-all
+	}
+	if !bus.PublishBlocking(Event{Type: EventMysisResponse}, 10*time.Millisecond) {
+		t.Error("expected PublishBlocking to ignore subscribers that filter the event out")
+	}
+
+	stats := bus.Stats()
+	sub := stats.Subscribers[1]
+	if sub.Delivered != uint64(capacity)+1 || sub.Dropped != 2 {
+		t.Errorf("expected delivered=%d dropped=2, got %d/%d", capacity+1, sub.Delivered, sub.Dropped)
+	}
+	if len(sub.Types) != 2 || stats.Subscribers[0].Types != nil {
+		t.Errorf("unexpected filter types in stats: %v / %v", sub.Types, stats.Subscribers[0].Types)
+	}
+
+	bus.Close()
+}
@@ -2,13 +2,22 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
 	"github.com/xonecas/zoea-nova/internal/provider"
 	"github.com/xonecas/zoea-nova/internal/store"
 )
@@ -24,12 +33,102 @@ type Commander struct {
 	bus         *EventBus
 	config      *config.Config
 	mcpEndpoint string // MCP upstream endpoint for myses to create their own clients
-	maxMyses    int
+	maxMyses    int    // Guarded by mu; raised up to [swarm] max_myses_ceiling
+
+	broadcastMu      sync.Mutex
+	recentBroadcasts map[[sha256.Size]byte]time.Time // Content hash -> last sent, for dedup
+	nowFunc          func() time.Time                // Clock for broadcast dedup (nil = time.Now)
+
+	// Interval scheduler (OnInterval); stopped by StopAll
+	intervalMu      sync.Mutex
+	intervalWG      sync.WaitGroup
+	intervalStop    chan struct{}
+	intervalStopped bool
+
+	coordinator *coordinator
+
+	accountGen store.AccountGenerator // Mints credentials for new game accounts ([accounts])
+
+	observerMu   sync.RWMutex
+	toolObserver ToolObserver   // Told about every tool call (nil = none)
+	analytics    *AsyncObserver // [analytics] sinks started by StartAnalytics
+
+	shutdownOnce sync.Once
+	shutdownErr  error // Result of the first Shutdown
+
+	drainOnce sync.Once
+	drainCh   chan struct{} // Closed by Drain; myses take no new turns after that
+
+	driftCategories []DriftCategory // [drift] categories applied to every mysis
+
+	slotsMu       sync.RWMutex
+	turnSlots     chan struct{} // Provider call slots ([swarm] max_concurrent_turns); nil = unlimited. Guarded by slotsMu
+	inFlightTurns atomic.Int32  // Provider calls currently in flight
+
+	turnInterval atomic.Int64 // Pause between autonomous turns (time.Duration; 0 = constants.DefaultTurnInterval)
+
+	namesMu sync.RWMutex
+	names   map[string]string // Mysis ID -> name cache for ResolveMysisName ("" = no such mysis)
+
+	mcpReconnects *mcp.ReconnectGroup // Upstream reconnect watchdog shared by every mysis's proxy
+
+	asksMu      sync.Mutex
+	pendingAsks map[string]*pendingAsk // Asking mysis ID -> its ask while a mysis Ask waits
+}
+
+// ErrBroadcastDuplicate is returned when identical broadcast content was already sent
+// within the dedup window. The broadcast is not fanned out.
+var ErrBroadcastDuplicate = errors.New("duplicate broadcast suppressed")
+
+// ErrMessageTooLong is returned when a broadcast or direct message exceeds the configured
+// maximum length and the policy is to reject it.
+var ErrMessageTooLong = errors.New("message too long")
+
+// ErrAskCycle is returned by Ask when the asked mysis is itself waiting, directly or
+// through other asks, on an answer from the sender. Neither turn could ever finish.
+var ErrAskCycle = errors.New("ask would deadlock")
+
+// ErrAskTimeout is returned by Ask when the mysis does not reply in time. The turn keeps
+// running and its reply is still stored.
+var ErrAskTimeout = errors.New("timed out waiting for response")
+
+// ErrDraining is returned for broadcasts queued while the swarm drains before shutdown.
+var ErrDraining = errors.New("swarm is draining for shutdown")
+
+// ErrTurnInProgress is returned by RegenerateLast while the mysis is mid-turn.
+var ErrTurnInProgress = errors.New("mysis is mid-turn")
+
+// ErrMaxMysesReached is matched by the *MaxMysesError CreateMysis returns at the cap.
+var ErrMaxMysesReached = errors.New("max myses reached")
+
+// MaxMysesError reports the mysis count and cap when no more myses can be created.
+type MaxMysesError struct {
+	Current int
+	Max     int
 }
 
+func (e *MaxMysesError) Error() string {
+	return fmt.Sprintf("max myses reached (%d/%d) - delete a mysis or raise max_myses", e.Current, e.Max)
+}
+
+// Is reports MaxMysesError as ErrMaxMysesReached.
+func (e *MaxMysesError) Is(target error) bool {
+	return target == ErrMaxMysesReached
+}
+
+// ErrMysesRunning is returned by Vacuum while any mysis is running and may be writing.
+var ErrMysesRunning = errors.New("myses are running")
+
 // NewCommander creates a new commander.
 func NewCommander(s *store.Store, reg *provider.Registry, bus *EventBus, cfg *config.Config, mcpEndpoint string) *Commander {
-	return &Commander{
+	accountGen, err := store.NewAccountGenerator(cfg.Accounts.Strategy, cfg.Accounts.Prefix, cfg.Accounts.Words,
+		store.PasswordPolicy{Length: cfg.Accounts.PasswordLength, Symbols: cfg.Accounts.PasswordSymbols})
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid [accounts] settings - using random usernames")
+		accountGen, _ = store.NewAccountGenerator("", "", nil, store.PasswordPolicy{})
+	}
+
+	c := &Commander{
 		myses:       make(map[string]*Mysis),
 		store:       s,
 		registry:    reg,
@@ -37,7 +136,175 @@ func NewCommander(s *store.Store, reg *provider.Registry, bus *EventBus, cfg *co
 		config:      cfg,
 		mcpEndpoint: mcpEndpoint,
 		maxMyses:    cfg.Swarm.MaxMyses,
+
+		recentBroadcasts: make(map[[sha256.Size]byte]time.Time),
+		pendingAsks:      make(map[string]*pendingAsk),
+		intervalStop:     make(chan struct{}),
+		drainCh:          make(chan struct{}),
+		accountGen:       accountGen,
+		driftCategories:  DriftCategoriesFromConfig(cfg),
+		turnSlots:        newTurnSlots(cfg.Swarm.MaxConcurrentTurns),
+	}
+	c.mcpReconnects = mcp.NewReconnectGroup(mcpWatchdog(cfg.MCP, c.publishMCPReconnect))
+	return c
+}
+
+// publishMCPReconnect reports an upstream reconnect started by the swarm watchdog.
+func (c *Commander) publishMCPReconnect(done bool, err error) {
+	c.bus.Publish(Event{
+		Type:      EventMCPReconnect,
+		Reconnect: newReconnectData(done, err),
+		Timestamp: time.Now(),
+	})
+}
+
+// newTurnSlots returns a semaphore with limit slots, or nil for no limit.
+func newTurnSlots(limit int) chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+	return make(chan struct{}, limit)
+}
+
+// acquireTurnSlot blocks until a provider call slot is free or ctx is done. The
+// returned release must be called once the provider call returns.
+func (c *Commander) acquireTurnSlot(ctx context.Context) (release func(), err error) {
+	// Hold on to this semaphore: SetMaxConcurrentTurns may replace it mid-call
+	c.slotsMu.RLock()
+	slots := c.turnSlots
+	c.slotsMu.RUnlock()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
+	c.inFlightTurns.Add(1)
+	return func() {
+		c.inFlightTurns.Add(-1)
+		if slots != nil {
+			<-slots
+		}
+	}, nil
+}
+
+// InFlightTurns returns how many provider calls are in flight and the configured
+// cap (0 = unlimited).
+func (c *Commander) InFlightTurns() (inFlight, limit int) {
+	c.slotsMu.RLock()
+	defer c.slotsMu.RUnlock()
+	return int(c.inFlightTurns.Load()), cap(c.turnSlots)
+}
+
+// OnInterval runs fn every d on its own goroutine until StopAll.
+// A slow fn delays its own next run but not other intervals.
+func (c *Commander) OnInterval(d time.Duration, fn func(*Commander)) {
+	if d <= 0 {
+		log.Warn().Dur("interval", d).Msg("Ignoring non-positive commander interval")
+		return
+	}
+
+	c.intervalMu.Lock()
+	defer c.intervalMu.Unlock()
+	if c.intervalStopped {
+		return
+	}
+
+	c.intervalWG.Add(1)
+	go func() {
+		defer c.intervalWG.Done()
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.intervalStop:
+				return
+			case <-ticker.C:
+				fn(c)
+			}
+		}
+	}()
+}
+
+// stopIntervals stops all OnInterval callbacks and waits for running ones to return.
+func (c *Commander) stopIntervals() {
+	c.intervalMu.Lock()
+	if !c.intervalStopped {
+		c.intervalStopped = true
+		close(c.intervalStop)
+	}
+	c.intervalMu.Unlock()
+
+	c.intervalWG.Wait()
+}
+
+func (c *Commander) now() time.Time {
+	if c.nowFunc != nil {
+		return c.nowFunc()
+	}
+	return time.Now()
+}
+
+// broadcastDedupWindow returns the configured broadcast dedup window.
+func (c *Commander) broadcastDedupWindow() time.Duration {
+	if c.config != nil && c.config.Swarm.BroadcastDedupWindowMs > 0 {
+		return time.Duration(c.config.Swarm.BroadcastDedupWindowMs) * time.Millisecond
+	}
+	return constants.DefaultBroadcastDedupWindow
+}
+
+// limitMessage applies the configured maximum message length. Depending on the policy,
+// longer content is truncated with "..." or rejected with ErrMessageTooLong.
+func (c *Commander) limitMessage(content string) (string, error) {
+	maxLen := constants.DefaultMaxMessageLength
+	policy := constants.MessageLengthPolicyTruncate
+	if c.config != nil {
+		if c.config.Swarm.MaxMessageLength > 0 {
+			maxLen = c.config.Swarm.MaxMessageLength
+		}
+		if c.config.Swarm.MessageLengthPolicy != "" {
+			policy = c.config.Swarm.MessageLengthPolicy
+		}
+	}
+
+	runes := []rune(content)
+	if len(runes) <= maxLen {
+		return content, nil
+	}
+	if policy == constants.MessageLengthPolicyReject {
+		return "", fmt.Errorf("%w: %d characters (max %d)", ErrMessageTooLong, len(runes), maxLen)
+	}
+
+	log.Debug().Int("length", len(runes)).Int("max", maxLen).Msg("Truncating long message")
+	const ellipsis = "..."
+	if maxLen <= len(ellipsis) {
+		return string(runes[:maxLen]), nil
+	}
+	return string(runes[:maxLen-len(ellipsis)]) + ellipsis, nil
+}
+
+// isDuplicateBroadcast reports whether identical content was broadcast within the
+// dedup window. Otherwise it records the content as sent now.
+func (c *Commander) isDuplicateBroadcast(content string) bool {
+	c.broadcastMu.Lock()
+	defer c.broadcastMu.Unlock()
+
+	now := c.now()
+	window := c.broadcastDedupWindow()
+	for hash, sentAt := range c.recentBroadcasts {
+		if now.Sub(sentAt) >= window {
+			delete(c.recentBroadcasts, hash)
+		}
+	}
+
+	hash := sha256.Sum256([]byte(content))
+	if _, ok := c.recentBroadcasts[hash]; ok {
+		return true
+	}
+	c.recentBroadcasts[hash] = now
+	return false
 }
 
 // LoadMyses loads existing myses from the store.
@@ -51,6 +318,7 @@ func (c *Commander) LoadMyses() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.forgetMysisNames()
 	for _, sm := range stored {
 		p, err := c.registry.Create(sm.Provider, sm.Model, sm.Temperature)
 		if err != nil {
@@ -59,7 +327,21 @@ func (c *Commander) LoadMyses() error {
 		}
 
 		mysis := NewMysis(sm.ID, sm.Name, sm.CreatedAt, p, c.store, c.bus, c.mcpEndpoint, c)
+		mysis.SetCompactSnapshots(sm.CompactSnapshots)
+		mysis.SetSnapshotSummaries(sm.SnapshotSummaries)
+		if err := mysis.SetContextWindow(sm.ContextWindow); err != nil {
+			log.Warn().Err(err).Str("mysis", sm.Name).Msg("Ignoring stored context window")
+		}
+		mysis.SetToolPolicy(ToolPolicy{Allow: sm.ToolAllow, Deny: sm.ToolDeny})
+		mysis.SetAutonomous(sm.Autonomous)
+		mysis.SetSyntheticNudges(sm.SyntheticNudges)
+		mysis.SetSeed(sm.Seed)
+		mysis.SetGoal(sm.Goal)
+		mysis.SetModel(sm.Model)
+		mysis.SetTurnCount(sm.TurnCount)
+		mysis.SetDriftCategories(c.driftCategories)
 		c.myses[sm.ID] = mysis
+		c.cacheMysisName(sm.ID, sm.Name)
 	}
 
 	return nil
@@ -67,13 +349,6 @@ func (c *Commander) LoadMyses() error {
 
 // CreateMysis creates a new mysis with the given name and provider.
 func (c *Commander) CreateMysis(name, providerName string) (*Mysis, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if len(c.myses) >= c.maxMyses {
-		return nil, fmt.Errorf("max myses (%d) reached", c.maxMyses)
-	}
-
 	// Get provider config for model
 	provCfg, ok := c.config.Providers[providerName]
 	if !ok {
@@ -86,6 +361,26 @@ func (c *Commander) CreateMysis(name, providerName string) (*Mysis, error) {
 		return nil, fmt.Errorf("create provider: %w", err)
 	}
 
+	// Probe before taking the lock so a slow provider doesn't block the swarm
+	if c.config.Swarm.VerifyProviderOnCreate {
+		if err := verifyProvider(providerName, provCfg, p); err != nil {
+			p.Close()
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.myses) >= c.maxMyses {
+		if c.maxMyses >= c.config.Swarm.MaxMysesCeiling {
+			p.Close()
+			return nil, &MaxMysesError{Current: len(c.myses), Max: c.maxMyses}
+		}
+		c.maxMyses++
+		log.Warn().Int("max_myses", c.maxMyses).Int("ceiling", c.config.Swarm.MaxMysesCeiling).Msg("Raised max myses")
+	}
+
 	// Create in store
 	stored, err := c.store.CreateMysis(name, providerName, provCfg.Model, provCfg.Temperature)
 	if err != nil {
@@ -94,7 +389,10 @@ func (c *Commander) CreateMysis(name, providerName string) (*Mysis, error) {
 
 	// Create runtime mysis
 	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, p, c.store, c.bus, c.mcpEndpoint, c)
+	mysis.SetModel(stored.Model)
+	mysis.SetDriftCategories(c.driftCategories)
 	c.myses[stored.ID] = mysis
+	c.cacheMysisName(stored.ID, stored.Name)
 
 	// Emit event
 	c.bus.Publish(Event{
@@ -107,6 +405,42 @@ func (c *Commander) CreateMysis(name, providerName string) (*Mysis, error) {
 	return mysis, nil
 }
 
+// verifyProvider checks that a provider is reachable and serves the configured model.
+// Providers that can't list models are pinged instead; providers with neither are trusted.
+func verifyProvider(name string, cfg config.ProviderConfig, p provider.Provider) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.ProviderHealthCheckTimeout)
+	defer cancel()
+
+	switch checker := p.(type) {
+	case provider.ModelLister:
+		models, err := checker.ListModels(ctx)
+		if err != nil {
+			return fmt.Errorf("provider %s unreachable at %s - is it running? (%w)", name, cfg.Endpoint, err)
+		}
+		if len(models) > 0 && !servesModel(models, cfg.Model) {
+			sort.Strings(models)
+			return fmt.Errorf("provider %s does not serve model %q - set providers.%s.model to one of: %s",
+				name, cfg.Model, name, strings.Join(models, ", "))
+		}
+	case provider.Pinger:
+		if err := checker.Ping(ctx); err != nil {
+			return fmt.Errorf("provider %s unreachable at %s - is it running? (%w)", name, cfg.Endpoint, err)
+		}
+	}
+	return nil
+}
+
+// servesModel reports whether model is in the list. Ollama reports untagged models
+// with an explicit ":latest" tag.
+func servesModel(models []string, model string) bool {
+	for _, m := range models {
+		if m == model || m == model+":latest" {
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteMysis removes a mysis from the swarm.
 func (c *Commander) DeleteMysis(id string, purgeMemories bool) error {
 	c.mu.Lock()
@@ -118,12 +452,14 @@ func (c *Commander) DeleteMysis(id string, purgeMemories bool) error {
 
 	delete(c.myses, id)
 	c.mu.Unlock()
+	c.forgetMysisNames(id)
 
 	// Stop if running (outside of commander lock to avoid deadlock)
 	if mysis.State() == MysisStateRunning {
 		mysis.Stop()
 	}
 	mysis.releaseCurrentAccount()
+	mysis.DisableLogFile()
 
 	// Delete from store (memories cascade)
 	if err := c.store.DeleteMysis(id); err != nil {
@@ -141,105 +477,648 @@ func (c *Commander) DeleteMysis(id string, purgeMemories bool) error {
 	return nil
 }
 
-// GetMysis returns a mysis by ID.
-func (c *Commander) GetMysis(id string) (*Mysis, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// RenameMysis changes a mysis's name and persists it. Names must be non-empty and
+// unique (case-insensitively) among loaded myses. Sender names are resolved by ID, so
+// past broadcasts show the new name once the TUI refreshes.
+func (c *Commander) RenameMysis(id, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("mysis name cannot be empty")
+	}
+
+	// Hold the lock across the check and the update so two renames can't race to
+	// the same name
+	c.mu.Lock()
+	mysis, ok := c.myses[id]
+	if !ok {
+		c.mu.Unlock()
+		return fmt.Errorf("mysis not found: %s", id)
+	}
+	for otherID, other := range c.myses {
+		if otherID != id && strings.EqualFold(other.Name(), name) {
+			c.mu.Unlock()
+			return fmt.Errorf("mysis name already in use: %s", name)
+		}
+	}
+	oldName := mysis.Name()
+	if err := c.store.RenameMysis(id, name); err != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.setName(name)
+	c.mu.Unlock()
+	c.cacheMysisName(id, name)
+
+	log.Info().Str("mysis", oldName).Str("new_name", name).Msg("Mysis renamed")
+	c.bus.Publish(Event{
+		Type:      EventMysisRenamed,
+		MysisID:   id,
+		MysisName: name,
+		Rename:    &RenameData{OldName: oldName, NewName: name},
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+// GetMysis returns a mysis by ID.
+func (c *Commander) GetMysis(id string) (*Mysis, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	mysis, ok := c.myses[id]
+	if !ok {
+		return nil, fmt.Errorf("mysis not found: %s", id)
+	}
+	return mysis, nil
+}
+
+// ListMyses returns all myses.
+func (c *Commander) ListMyses() []*Mysis {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	myses := make([]*Mysis, 0, len(c.myses))
+	for _, m := range c.myses {
+		myses = append(myses, m)
+	}
+	return myses
+}
+
+// ForEachMysis calls fn for every mysis, oldest first, and is the safe way to act on
+// all of them. fn runs on a snapshot without the commander lock held, so it may
+// create, delete, start or stop myses. Myses created during the walk are not visited;
+// myses deleted before their turn are skipped.
+func (c *Commander) ForEachMysis(fn func(*Mysis)) {
+	myses := c.ListMyses()
+	sort.Slice(myses, func(i, j int) bool {
+		if !myses[i].CreatedAt().Equal(myses[j].CreatedAt()) {
+			return myses[i].CreatedAt().Before(myses[j].CreatedAt())
+		}
+		return myses[i].ID() < myses[j].ID()
+	})
+
+	for _, m := range myses {
+		c.mu.RLock()
+		current := c.myses[m.ID()] == m
+		c.mu.RUnlock()
+		if current {
+			fn(m)
+		}
+	}
+}
+
+// ActivitySnapshot is a mysis's in-game activity and when it is expected to be free.
+type ActivitySnapshot struct {
+	MysisID        string
+	MysisName      string
+	State          MysisState
+	Activity       ActivityState
+	Until          time.Time     // Expected end of the activity (zero if unknown)
+	Remaining      time.Duration // Time left until Until (zero if unknown or passed)
+	RemainingTicks int64         // Server ticks left (zero until the tick rate is known)
+	Available      bool          // Running or idle and not traveling, mining, fighting or cooling down
+}
+
+// MysisActivity returns activity snapshots for one mysis, or for all myses sorted by
+// name when mysisID is empty.
+func (c *Commander) MysisActivity(mysisID string) ([]ActivitySnapshot, error) {
+	var myses []*Mysis
+	if mysisID != "" {
+		m, err := c.GetMysis(mysisID)
+		if err != nil {
+			return nil, err
+		}
+		myses = []*Mysis{m}
+	} else {
+		myses = c.ListMyses()
+		sort.Slice(myses, func(i, j int) bool { return myses[i].Name() < myses[j].Name() })
+	}
+
+	now := time.Now()
+	snapshots := make([]ActivitySnapshot, len(myses))
+	for i, m := range myses {
+		snapshots[i] = m.activitySnapshot(now)
+	}
+	return snapshots, nil
+}
+
+// activitySnapshot captures the mysis activity at now.
+func (m *Mysis) activitySnapshot(now time.Time) ActivitySnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snap := ActivitySnapshot{
+		MysisID:   m.id,
+		MysisName: m.name,
+		State:     m.state,
+		Activity:  m.activityState,
+		Until:     m.activityUntil,
+	}
+	if !snap.Until.IsZero() && snap.Until.After(now) {
+		snap.Remaining = snap.Until.Sub(now)
+		if m.tickDuration > 0 {
+			snap.RemainingTicks = int64((snap.Remaining + m.tickDuration - 1) / m.tickDuration)
+		}
+	}
+
+	busy := false
+	switch snap.Activity {
+	case ActivityStateTraveling, ActivityStateMining, ActivityStateInCombat, ActivityStateCooldown:
+		// An activity without a known end counts as busy until it is cleared
+		busy = snap.Until.IsZero() || snap.Remaining > 0
+	}
+	snap.Available = validateCanAcceptMessage(snap.State) == nil && !busy
+	return snap
+}
+
+// StartMysis starts a mysis by ID.
+func (c *Commander) StartMysis(id string) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	// Don't increment WaitGroup here - mysis.Start() will do it
+	// This avoids double-counting when restarting errored myses
+	return mysis.Start()
+}
+
+// RecoverMysis clears the error on an errored mysis and resumes it in place.
+func (c *Commander) RecoverMysis(id string) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	return mysis.Recover()
+}
+
+// StopMysis stops a mysis by ID.
+func (c *Commander) StopMysis(id string) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	return mysis.Stop()
+}
+
+// SwitchProvider moves a mysis to another provider and model without touching its
+// memory. An empty model uses the provider's configured one. The switch waits for the
+// current turn to finish, so callers on the UI goroutine should run it in the
+// background; the next turn uses the new provider.
+func (c *Commander) SwitchProvider(id, providerName, model string) error {
+	mysis, provCfg, err := c.checkProviderSwitch(id, providerName)
+	if err != nil {
+		return err
+	}
+	if model == "" {
+		model = provCfg.Model
+	}
+	temperature := provCfg.Temperature
+
+	p, err := c.registry.Create(providerName, model, temperature)
+	if err != nil {
+		return fmt.Errorf("create provider %s: %w", providerName, err)
+	}
+
+	old := mysis.SetProvider(p)
+	if err := c.store.UpdateMysisConfig(id, providerName, model, temperature); err != nil {
+		mysis.SetProvider(old)
+		p.Close()
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetModel(model)
+	if old != nil {
+		if err := old.Close(); err != nil {
+			log.Warn().Err(err).Str("mysis", mysis.Name()).Msg("Failed to close previous provider")
+		}
+	}
+
+	log.Info().Str("mysis", mysis.Name()).Str("provider", providerName).Str("model", model).Msg("Switched provider")
+	c.bus.Publish(Event{
+		Type:      EventMysisConfigChanged,
+		MysisID:   id,
+		MysisName: mysis.Name(),
+		Config: &ConfigChangeData{
+			Provider: providerName,
+			Model:    model,
+		},
+		Timestamp: time.Now(),
+	})
+
+	return nil
+}
+
+// SwitchProviderAsync checks a provider switch and runs it in the background. A mysis
+// can use it to switch itself, which would deadlock waiting for its own turn.
+func (c *Commander) SwitchProviderAsync(id, providerName, model string) error {
+	if _, _, err := c.checkProviderSwitch(id, providerName); err != nil {
+		return err
+	}
+	go func() {
+		if err := c.SwitchProvider(id, providerName, model); err != nil {
+			log.Error().Err(err).Str("mysis_id", id).Str("provider", providerName).Msg("Provider switch failed")
+		}
+	}()
+	return nil
+}
+
+// checkProviderSwitch returns the mysis and provider config for a switch, refusing
+// providers that are unconfigured or have no registered factory.
+func (c *Commander) checkProviderSwitch(id, providerName string) (*Mysis, config.ProviderConfig, error) {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return nil, config.ProviderConfig{}, err
+	}
+	provCfg, ok := c.config.Providers[providerName]
+	if !ok {
+		return nil, config.ProviderConfig{}, fmt.Errorf("provider config not found: %s", providerName)
+	}
+	if !slices.Contains(c.registry.List(), providerName) {
+		return nil, config.ProviderConfig{}, fmt.Errorf("provider not registered: %s", providerName)
+	}
+	return mysis, provCfg, nil
+}
+
+// SetCompactSnapshots enables or disables snapshot compaction for a mysis and persists it.
+func (c *Commander) SetCompactSnapshots(id string, enabled bool) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.SetMysisCompactSnapshots(id, enabled); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetCompactSnapshots(enabled)
+
+	log.Info().Str("mysis", mysis.Name()).Bool("compact_snapshots", enabled).Msg("Snapshot compaction updated")
+	return nil
+}
+
+// SetSnapshotSummaries enables or disables summary markers for compacted snapshots of a
+// mysis and persists it.
+func (c *Commander) SetSnapshotSummaries(id string, enabled bool) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.SetMysisSnapshotSummaries(id, enabled); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetSnapshotSummaries(enabled)
+
+	log.Info().Str("mysis", mysis.Name()).Bool("snapshot_summaries", enabled).Msg("Snapshot summaries updated")
+	return nil
+}
+
+// SetContextWindow overrides how many recent memories a mysis scans for context and
+// persists it. Zero restores the default (constants.MaxContextMessages).
+func (c *Commander) SetContextWindow(id string, window int) error {
+	if err := ValidateContextWindow(window); err != nil {
+		return err
+	}
+
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.SetMysisContextWindow(id, window); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	if err := mysis.SetContextWindow(window); err != nil {
+		return err
+	}
+
+	log.Info().Str("mysis", mysis.Name()).Int("context_window", mysis.ContextWindow()).Msg("Context window updated")
+	return nil
+}
+
+// SetAutonomous enables or disables autonomous turns for a mysis and persists it. A
+// running mysis that is no longer autonomous goes idle after its current turn.
+func (c *Commander) SetAutonomous(id string, autonomous bool) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.SetMysisAutonomous(id, autonomous); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetAutonomous(autonomous)
+
+	log.Info().Str("mysis", mysis.Name()).Bool("autonomous", autonomous).Msg("Autonomous turns updated")
+	return nil
+}
+
+// SetSyntheticNudges enables or disables synthetic nudges for a mysis and persists it.
+// A running mysis without them goes idle once it has nothing left to answer.
+func (c *Commander) SetSyntheticNudges(id string, enabled bool) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.SetMysisSyntheticNudges(id, enabled); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetSyntheticNudges(enabled)
+
+	log.Info().Str("mysis", mysis.Name()).Bool("synthetic_nudges", enabled).Msg("Synthetic nudges updated")
+	return nil
+}
+
+// SetSeed sets the sampling seed sent with a mysis's provider calls and persists it
+// (nil clears it). With temperature 0 a seed makes runs near-deterministic, for A/B
+// testing prompts.
+func (c *Commander) SetSeed(id string, seed *int64) error {
+	if seed != nil && *seed < 0 {
+		return fmt.Errorf("seed %d must not be negative", *seed)
+	}
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.SetMysisSeed(id, seed); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetSeed(seed)
+
+	log.Info().Str("mysis", mysis.Name()).Interface("seed", seed).Msg("Seed updated")
+	return nil
+}
+
+// SetGoal sets a mysis's standing goal and persists it ("" clears it). A stored
+// system prompt is rebuilt so the goal applies from the next turn.
+func (c *Commander) SetGoal(id, goal string) error {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
+	}
+
+	goal = strings.TrimSpace(goal)
+	if err := c.store.SetMysisGoal(id, goal); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetGoal(goal)
+
+	// Before the first start there is no stored prompt; Start builds it with the goal
+	if system, err := c.store.GetSystemMemory(id); err == nil && system != nil {
+		if err := mysis.rebuildSystemMemory(); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Str("mysis", mysis.Name()).Str("goal", goal).Msg("Goal updated")
+	return nil
+}
+
+// SetToolPolicy restricts which tools a mysis may use and persists it. Empty lists
+// lift the restriction.
+func (c *Commander) SetToolPolicy(id string, policy ToolPolicy) error {
+	for _, name := range append(append([]string(nil), policy.Allow...), policy.Deny...) {
+		if name == "" || strings.Contains(name, ",") {
+			return fmt.Errorf("invalid tool name %q", name)
+		}
+	}
 
-	mysis, ok := c.myses[id]
-	if !ok {
-		return nil, fmt.Errorf("mysis not found: %s", id)
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return err
 	}
-	return mysis, nil
+
+	if err := c.store.SetMysisToolPolicy(id, policy.Allow, policy.Deny); err != nil {
+		return fmt.Errorf("update store: %w", err)
+	}
+	mysis.SetToolPolicy(policy)
+
+	log.Info().Str("mysis", mysis.Name()).Strs("allow", policy.Allow).Strs("deny", policy.Deny).Msg("Tool policy updated")
+	return nil
 }
 
-// ListMyses returns all myses.
-func (c *Commander) ListMyses() []*Mysis {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// GetRecentReasoning returns the latest memories with reasoning for a mysis,
+// in chronological order. Only the given mysis's memories are returned.
+func (c *Commander) GetRecentReasoning(id string, limit int) ([]*store.Memory, error) {
+	if _, err := c.GetMysis(id); err != nil {
+		return nil, err
+	}
 
-	myses := make([]*Mysis, 0, len(c.myses))
-	for _, m := range c.myses {
-		myses = append(myses, m)
+	memories, err := c.store.GetRecentReasoning(id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get recent reasoning: %w", err)
 	}
-	return myses
+	return memories, nil
 }
 
-// StartMysis starts a mysis by ID.
-func (c *Commander) StartMysis(id string) error {
+// SendMessage sends a message to a specific mysis (synchronous).
+func (c *Commander) SendMessage(id, content string) error {
 	mysis, err := c.GetMysis(id)
 	if err != nil {
 		return err
 	}
+	content, err = c.limitMessage(content)
+	if err != nil {
+		return err
+	}
+	return mysis.SendMessage(content, store.MemorySourceDirect)
+}
 
-	// Don't increment WaitGroup here - mysis.Start() will do it
-	// This avoids double-counting when restarting errored myses
-	return mysis.Start()
+// SystemPromptPreview returns the system prompt a mysis would be given now.
+func (c *Commander) SystemPromptPreview(id string) (*SystemPromptPreview, error) {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return nil, err
+	}
+	return mysis.SystemPromptPreview(), nil
 }
 
-// StopMysis stops a mysis by ID.
-func (c *Commander) StopMysis(id string) error {
+// RegenerateLast discards a mysis's last response and runs the turn again.
+func (c *Commander) RegenerateLast(id string) error {
 	mysis, err := c.GetMysis(id)
 	if err != nil {
 		return err
 	}
+	return mysis.RegenerateLast()
+}
 
-	return mysis.Stop()
+// LastFailedToolCall returns a mysis's most recent failed tool call.
+func (c *Commander) LastFailedToolCall(id string) (*FailedToolCall, error) {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return nil, err
+	}
+	return mysis.LastFailedToolCall()
 }
 
-// ConfigureMysis updates a mysis provider and model.
-func (c *Commander) ConfigureMysis(id, providerName, model string) error {
-	c.mu.Lock()
-	mysis, ok := c.myses[id]
-	if !ok {
-		c.mu.Unlock()
-		return fmt.Errorf("mysis not found: %s", id)
+// RetryToolCall re-issues a tool call as a mysis without storing anything. See
+// Mysis.RetryToolCall.
+func (c *Commander) RetryToolCall(ctx context.Context, id, name string, arguments json.RawMessage) (*mcp.ToolResult, error) {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return nil, err
 	}
-	c.mu.Unlock()
+	return mysis.RetryToolCall(ctx, name, arguments)
+}
 
-	// Get provider config
-	provCfg, ok := c.config.Providers[providerName]
-	if !ok {
-		return fmt.Errorf("provider config not found: %s", providerName)
+// ToggleMysisLogFile turns a mysis's log file on or off and returns its path, or ""
+// once it is off. It overrides [swarm] mysis_logs until restart.
+func (c *Commander) ToggleMysisLogFile(id string) (string, error) {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return "", err
 	}
-	temperature := provCfg.Temperature
+	if mysis.LogFilePath() != "" {
+		mysis.DisableLogFile()
+		return "", nil
+	}
+	dir, err := MysisLogDir()
+	if err != nil {
+		return "", err
+	}
+	return mysis.EnableLogFile(dir)
+}
 
-	// Get new provider
-	p, err := c.registry.Create(providerName, model, temperature)
+// mysisLogEnabled reports whether [swarm] mysis_logs names a mysis.
+func (c *Commander) mysisLogEnabled(name string) bool {
+	if c.config == nil {
+		return false
+	}
+	for _, logged := range c.config.Swarm.MysisLogs {
+		if strings.EqualFold(logged, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ask sends a direct message to a mysis and waits for its reply. Tool loops emit no
+// response event, so the reply is the final text response of the turn. A timeout of 0
+// uses constants.DefaultAskTimeout. Canceling ctx stops the wait; the turn keeps running.
+// senderID is the asking mysis ("" = the user); an ask that would close a cycle of
+// myses waiting on each other fails with ErrAskCycle.
+func (c *Commander) Ask(ctx context.Context, senderID, id, question string, timeout time.Duration) (string, error) {
+	mysis, err := c.GetMysis(id)
+	if err != nil {
+		return "", err
+	}
+	if senderID != "" {
+		ask, err := c.beginAsk(senderID, id)
+		if err != nil {
+			return "", fmt.Errorf("ask %s: %w", mysis.Name(), err)
+		}
+		defer c.endAsk(senderID, ask)
+	}
+	question, err = c.limitMessage(question)
 	if err != nil {
-		return fmt.Errorf("create provider: %w", err)
+		return "", err
+	}
+	if timeout <= 0 {
+		timeout = constants.DefaultAskTimeout
 	}
 
-	// Update store
-	if err := c.store.UpdateMysisConfig(id, providerName, model, temperature); err != nil {
-		return fmt.Errorf("update store: %w", err)
+	// Subscribe before sending so the response can't be missed
+	events := c.bus.Subscribe()
+	defer c.bus.Unsubscribe(events)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- mysis.SendMessage(question, store.MemorySourceDirect)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// Keep the latest response: a turn already in progress may answer first, and ours
+	// runs after it
+	var answer string
+	answered := false
+	take := func(e Event) {
+		if e.Type == EventMysisResponse && e.MysisID == id && e.Message != nil {
+			answer, answered = e.Message.Content, true
+		}
+	}
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return "", fmt.Errorf("event bus closed")
+			}
+			take(e)
+		case err := <-done:
+			if err != nil {
+				return "", err
+			}
+			// The response is published before SendMessage returns
+			for drained := false; !drained; {
+				select {
+				case e := <-events:
+					take(e)
+				default:
+					drained = true
+				}
+			}
+			if !answered {
+				return "", fmt.Errorf("%s finished its turn without a response", mysis.Name())
+			}
+			return answer, nil
+		case <-timer.C:
+			return "", fmt.Errorf("ask %s: %w after %s", mysis.Name(), ErrAskTimeout, timeout)
+		case <-ctx.Done():
+			return "", fmt.Errorf("ask %s: %w", mysis.Name(), ctx.Err())
+		}
 	}
+}
 
-	// Update runtime
-	mysis.SetProvider(p)
+// pendingAsk is a mysis Ask waiting on an answer.
+type pendingAsk struct {
+	target string // Asked mysis ID
+}
 
-	// Emit event
-	c.bus.Publish(Event{
-		Type:      EventMysisConfigChanged,
-		MysisID:   id,
-		MysisName: mysis.Name(),
-		Config: &ConfigChangeData{
-			Provider: providerName,
-			Model:    model,
-		},
-		Timestamp: time.Now(),
-	})
+// beginAsk records that senderID is waiting on an answer from id, unless id is already
+// waiting on senderID through a chain of pending asks.
+func (c *Commander) beginAsk(senderID, id string) (*pendingAsk, error) {
+	c.asksMu.Lock()
+	defer c.asksMu.Unlock()
+	for next := id; ; {
+		if next == senderID {
+			return nil, ErrAskCycle
+		}
+		ask, ok := c.pendingAsks[next]
+		if !ok {
+			break
+		}
+		next = ask.target
+	}
+	ask := &pendingAsk{target: id}
+	c.pendingAsks[senderID] = ask
+	return ask, nil
+}
 
-	return nil
+// endAsk clears the pending ask recorded by beginAsk, unless senderID has since
+// started another one.
+func (c *Commander) endAsk(senderID string, ask *pendingAsk) {
+	c.asksMu.Lock()
+	if c.pendingAsks[senderID] == ask {
+		delete(c.pendingAsks, senderID)
+	}
+	c.asksMu.Unlock()
 }
 
-// SendMessage sends a message to a specific mysis (synchronous).
-func (c *Commander) SendMessage(id, content string) error {
+// SendOneShotSystem queues a system reminder for the next turn of a specific mysis.
+func (c *Commander) SendOneShotSystem(id, content string) error {
 	mysis, err := c.GetMysis(id)
 	if err != nil {
 		return err
 	}
-	return mysis.SendMessage(content, store.MemorySourceDirect)
+	content, err = c.limitMessage(content)
+	if err != nil {
+		return err
+	}
+	return mysis.SendOneShotSystem(content)
 }
 
 // SendMessageAsync sends a message to a specific mysis without waiting for processing.
@@ -250,6 +1129,10 @@ func (c *Commander) SendMessageAsync(id, content string) error {
 	if err != nil {
 		return err
 	}
+	content, err = c.limitMessage(content)
+	if err != nil {
+		return err
+	}
 	// State validation is done inside mysis.SendMessage
 	go func() {
 		if err := mysis.SendMessage(content, store.MemorySourceDirect); err != nil {
@@ -262,7 +1145,14 @@ func (c *Commander) SendMessageAsync(id, content string) error {
 // Broadcast sends a message to all running myses.
 // Stores the message immediately and triggers async processing.
 // Returns quickly without waiting for LLM processing.
+// Returns ErrBroadcastDuplicate if identical content was broadcast within the dedup window,
+// or ErrMessageTooLong if content exceeds the maximum length under the reject policy.
 func (c *Commander) Broadcast(content string) error {
+	content, err := c.limitMessage(content)
+	if err != nil {
+		return err
+	}
+
 	c.mu.RLock()
 	myses := make([]*Mysis, 0)
 	for _, m := range c.myses {
@@ -278,6 +1168,12 @@ func (c *Commander) Broadcast(content string) error {
 		return fmt.Errorf("no myses available to receive broadcast (all stopped or errored)")
 	}
 
+	// Suppress double sends (repeated key press, retry) before fanning out
+	if c.isDuplicateBroadcast(content) {
+		log.Debug().Msg("Duplicate broadcast suppressed")
+		return ErrBroadcastDuplicate
+	}
+
 	// Emit broadcast event
 	c.bus.Publish(Event{
 		Type:      EventBroadcast,
@@ -285,10 +1181,11 @@ func (c *Commander) Broadcast(content string) error {
 		Timestamp: time.Now(),
 	})
 
-	// Queue broadcast to each mysis (non-blocking)
+	// Queue broadcast to each mysis (non-blocking), tracked so acks can be reported
+	broadcastID := uuid.New().String()
 	var errs []error
 	for _, m := range myses {
-		if err := m.QueueBroadcast(content, ""); err != nil {
+		if err := m.queueBroadcast(content, "", broadcastID); err != nil {
 			errs = append(errs, fmt.Errorf("mysis %s: %w", m.ID(), err))
 		}
 	}
@@ -299,8 +1196,48 @@ func (c *Commander) Broadcast(content string) error {
 	return nil
 }
 
+// SyncMysisToSwarm copies the most recent swarm broadcast into a mysis's own memories,
+// so a mysis created mid-mission prompts on it like any other broadcast rather than
+// only seeing it through the system prompt. Queuing the broadcast resets the
+// encouragement counter and wakes an idle mysis. It returns false without error when
+// there is no broadcast or the mysis already has it.
+func (c *Commander) SyncMysisToSwarm(mysisID string) (bool, error) {
+	m, err := c.GetMysis(mysisID)
+	if err != nil {
+		return false, err
+	}
+
+	broadcasts, err := c.store.GetRecentBroadcasts(1)
+	if err != nil {
+		return false, err
+	}
+	if len(broadcasts) == 0 {
+		return false, nil
+	}
+	latest := broadcasts[0]
+	if latest.SenderID == mysisID {
+		// Myses never receive their own broadcasts
+		return false, nil
+	}
+
+	present, err := c.store.HasBroadcast(mysisID, latest.Content)
+	if err != nil || present {
+		return false, err
+	}
+
+	if err := m.queueBroadcast(latest.Content, latest.SenderID, latest.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // BroadcastFrom sends a message to all running myses except the sender.
 func (c *Commander) BroadcastFrom(senderID, content string) error {
+	content, err := c.limitMessage(content)
+	if err != nil {
+		return err
+	}
+
 	c.mu.RLock()
 	myses := make([]*Mysis, 0)
 	for _, m := range c.myses {
@@ -341,29 +1278,88 @@ func (c *Commander) BroadcastAsync(content string) error {
 	return c.BroadcastFrom("", content)
 }
 
-// StopAll stops all running myses with a 10-second timeout.
+// StopAll stops interval callbacks and all running myses with a 10-second timeout,
+// then closes the [analytics] sinks. Quitting the app uses Shutdown instead.
 func (c *Commander) StopAll() {
-	c.mu.RLock()
-	myses := make([]*Mysis, 0)
-	for _, m := range c.myses {
-		myses = append(myses, m)
+	c.stopMyses(context.Background())
+
+	// Flush tool calls recorded by the turns that just ended
+	ctx, cancel := context.WithTimeout(context.Background(), constants.AnalyticsFlushTimeout)
+	defer cancel()
+	if err := c.stopAnalytics(ctx, false); err != nil {
+		log.Warn().Err(err).Msg("Failed to close tool call analytics")
 	}
-	c.mu.RUnlock()
+}
+
+// Shutdown stops every mysis, then flushes and closes the tool observers (the
+// [analytics] sinks and any observer set with SetToolObserver) so calls made by the
+// last turns aren't lost. An observer still flushing when ctx ends is abandoned.
+// Only the first call does anything; later calls return its result.
+func (c *Commander) Shutdown(ctx context.Context) error {
+	c.shutdownOnce.Do(func() {
+		c.stopMyses(ctx)
+		c.shutdownErr = c.stopAnalytics(ctx, true)
+	})
+	return c.shutdownErr
+}
+
+// Drain lets running myses finish their current turn without starting new ones,
+// then stops them all. Queued broadcasts are refused with ErrDraining from the start
+// of the drain. If ctx ends before every turn finishes, Drain returns ctx.Err()
+// without stopping anything, leaving the caller to fall back to StopAll or Shutdown.
+func (c *Commander) Drain(ctx context.Context) error {
+	c.drainOnce.Do(func() { close(c.drainCh) })
+	log.Info().Msg("Draining swarm - waiting for in-flight turns")
+
+	// Stop scheduled callbacks first so none broadcast during the drain
+	c.stopIntervals()
+
+	// Run loops exit after their current turn once drainCh is closed
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warn().Msg("Drain timeout - turns still in flight")
+		return ctx.Err()
+	}
+
+	c.stopMyses(ctx)
+	return nil
+}
+
+// Draining reports whether Drain was called.
+func (c *Commander) Draining() bool {
+	select {
+	case <-c.drainCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// stopMyses stops scheduled callbacks and every mysis, waiting up to 10s (or until
+// ctx ends) for their goroutines to finish.
+func (c *Commander) stopMyses(ctx context.Context) {
+	// Stop scheduled callbacks first so none broadcast during shutdown
+	c.stopIntervals()
 
 	// Stop with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	done := make(chan struct{})
+	count := 0
 	go func() {
 		// Stop all running myses
-		for _, m := range myses {
-			if m.State() == MysisStateRunning {
-				if err := m.Stop(); err != nil {
-					log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to stop mysis")
-				}
-			}
-		}
+		c.ForEachMysis(func(m *Mysis) {
+			count++
+			c.stopEach([]*Mysis{m})
+		})
 		// Wait for all mysis goroutines to complete
 		c.wg.Wait()
 		close(done)
@@ -371,12 +1367,145 @@ func (c *Commander) StopAll() {
 
 	select {
 	case <-done:
-		log.Info().Int("count", len(myses)).Msg("All myses stopped")
+		log.Info().Int("count", count).Msg("All myses stopped")
 	case <-ctx.Done():
 		log.Warn().Msg("StopAll timeout - some myses may still be running")
 	}
 }
 
+// BulkResult summarizes an operation applied to many myses. Individual failures
+// don't stop the batch; they are collected in Errors.
+type BulkResult struct {
+	Attempted int
+	Errors    []error
+	Names     []string // Myses the operation succeeded for
+}
+
+// Succeeded returns how many myses the operation succeeded for.
+func (r BulkResult) Succeeded() int {
+	return r.Attempted - len(r.Errors)
+}
+
+// StartAllIdle starts every idle mysis.
+func (c *Commander) StartAllIdle() BulkResult {
+	return c.startInState(MysisStateIdle, "Started idle myses")
+}
+
+// RelaunchErrored restarts every errored mysis.
+func (c *Commander) RelaunchErrored() BulkResult {
+	return c.startInState(MysisStateErrored, "Relaunched errored myses")
+}
+
+// RelaunchAndBroadcast restarts every errored mysis and queues content as a
+// broadcast to each one that came back running, so a swarm can be recovered and
+// re-tasked in one step. A mysis counts as succeeded only once it is relaunched and
+// has the broadcast; other myses don't receive it. Returns ErrMessageTooLong if
+// content exceeds the maximum length under the reject policy.
+func (c *Commander) RelaunchAndBroadcast(content string) (BulkResult, error) {
+	content, err := c.limitMessage(content)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	var result BulkResult
+	var relaunched []*Mysis
+	for _, m := range c.ListMyses() {
+		if m.State() != MysisStateErrored {
+			continue
+		}
+		result.Attempted++
+		// Start returns once the mysis is running, so it accepts the broadcast below
+		if err := m.Start(); err != nil {
+			log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to relaunch mysis")
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", m.Name(), err))
+			continue
+		}
+		relaunched = append(relaunched, m)
+	}
+
+	if len(relaunched) > 0 {
+		c.bus.Publish(Event{
+			Type:      EventBroadcast,
+			Message:   &MessageData{Role: "user", Content: content},
+			Timestamp: time.Now(),
+		})
+	}
+
+	broadcastID := uuid.New().String()
+	for _, m := range relaunched {
+		if err := m.queueBroadcast(content, "", broadcastID); err != nil {
+			log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to broadcast to relaunched mysis")
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", m.Name(), err))
+			continue
+		}
+		result.Names = append(result.Names, m.Name())
+	}
+
+	log.Info().Int("attempted", result.Attempted).Int("failed", len(result.Errors)).Msg("Relaunched errored myses with broadcast")
+	return result, nil
+}
+
+// StopRunning stops every running mysis without waiting for goroutines to exit.
+func (c *Commander) StopRunning() BulkResult {
+	result := c.stopEach(c.ListMyses())
+	log.Info().Int("attempted", result.Attempted).Int("failed", len(result.Errors)).Msg("Stopped running myses")
+	return result
+}
+
+// startInState starts every mysis currently in the given state.
+func (c *Commander) startInState(state MysisState, logMsg string) BulkResult {
+	var result BulkResult
+	for _, m := range c.ListMyses() {
+		if m.State() != state {
+			continue
+		}
+		result.Attempted++
+		if err := m.Start(); err != nil {
+			log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to start mysis")
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", m.Name(), err))
+			continue
+		}
+		result.Names = append(result.Names, m.Name())
+	}
+	log.Info().Int("attempted", result.Attempted).Int("failed", len(result.Errors)).Msg(logMsg)
+	return result
+}
+
+// stopEach stops the running myses in the list.
+func (c *Commander) stopEach(myses []*Mysis) BulkResult {
+	var result BulkResult
+	for _, m := range myses {
+		if m.State() != MysisStateRunning {
+			continue
+		}
+		result.Attempted++
+		if err := m.Stop(); err != nil {
+			log.Warn().Err(err).Str("mysis", m.Name()).Msg("Failed to stop mysis")
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", m.Name(), err))
+			continue
+		}
+		result.Names = append(result.Names, m.Name())
+	}
+	return result
+}
+
+// ProviderLatencies returns call latency percentiles per provider, sorted by name.
+func (c *Commander) ProviderLatencies() []provider.LatencySummary {
+	return c.registry.Latencies().Summaries()
+}
+
+// ProviderLatency returns a provider's call latency percentiles, or false if no
+// calls were observed since the last reset.
+func (c *Commander) ProviderLatency(name string) (provider.LatencySummary, bool) {
+	return c.registry.Latencies().Summary(name)
+}
+
+// ResetProviderLatencies drops the observed latencies, starting a fresh measurement window.
+func (c *Commander) ResetProviderLatencies() {
+	c.registry.Latencies().Reset()
+	log.Info().Msg("Provider latencies reset")
+}
+
 // MysisCount returns the current number of myses.
 func (c *Commander) MysisCount() int {
 	c.mu.RLock()
@@ -384,21 +1513,35 @@ func (c *Commander) MysisCount() int {
 	return len(c.myses)
 }
 
-// MaxMyses returns the maximum allowed myses.
+// MaxMyses returns the maximum allowed myses. It grows up to [swarm] max_myses_ceiling
+// as myses are created past it.
 func (c *Commander) MaxMyses() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.maxMyses
 }
 
+// CanCreateMysis reports whether another mysis can be created, and how many more can,
+// counting any room left under max_myses_ceiling. UIs use it to disable create actions.
+func (c *Commander) CanCreateMysis() (bool, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	limit := max(c.maxMyses, c.config.Swarm.MaxMysesCeiling)
+	remaining := max(limit-len(c.myses), 0)
+	return remaining > 0, remaining
+}
+
 // GetStateCounts returns the count of myses in each state.
 func (c *Commander) GetStateCounts() map[string]int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	counts := map[string]int{
-		"running": 0,
-		"idle":    0,
-		"stopped": 0,
-		"errored": 0,
+		"running":     0,
+		"idle":        0,
+		"stopped":     0,
+		"errored":     0,
+		"quarantined": 0,
 	}
 
 	for _, m := range c.myses {
@@ -409,6 +1552,60 @@ func (c *Commander) GetStateCounts() map[string]int {
 	return counts
 }
 
+// SwarmStats summarizes swarm-wide counters.
+type SwarmStats struct {
+	MysisCount  int
+	MaxMyses    int
+	StateCounts map[string]int
+	Usage       store.CostStats // Cumulative token usage and estimated cost across all myses
+
+	InFlightTurns      int // Provider calls currently in flight
+	MaxConcurrentTurns int // Cap on in-flight provider calls (0 = unlimited)
+}
+
+// Stats returns swarm-wide counters including the total estimated cost.
+func (c *Commander) Stats() SwarmStats {
+	stats := SwarmStats{
+		MysisCount:  c.MysisCount(),
+		MaxMyses:    c.MaxMyses(),
+		StateCounts: c.GetStateCounts(),
+	}
+	stats.InFlightTurns, stats.MaxConcurrentTurns = c.InFlightTurns()
+
+	if usage, err := c.store.GetTotalCostStats(); err == nil {
+		stats.Usage = *usage
+	} else {
+		log.Warn().Err(err).Msg("Failed to load swarm cost stats")
+	}
+
+	return stats
+}
+
+// Vacuum compacts the database file. VACUUM rewrites every table, so it refuses to
+// run while any mysis is running; stop them first.
+func (c *Commander) Vacuum() (store.VacuumResult, error) {
+	var running int
+	for _, m := range c.ListMyses() {
+		if m.State() == MysisStateRunning {
+			running++
+		}
+	}
+	if running > 0 {
+		return store.VacuumResult{}, fmt.Errorf("%w: stop %d running myses before vacuuming", ErrMysesRunning, running)
+	}
+
+	result, err := c.store.Vacuum()
+	if err != nil {
+		return result, err
+	}
+	log.Info().
+		Int64("before_bytes", result.BeforeBytes).
+		Int64("after_bytes", result.AfterBytes).
+		Int64("reclaimed_bytes", result.Reclaimed()).
+		Msg("Database vacuumed")
+	return result, nil
+}
+
 // Store returns the store for direct access (e.g., for testing).
 func (c *Commander) Store() *store.Store {
 	return c.store
@@ -422,11 +1619,7 @@ func (c *Commander) AggregateTick() int64 {
 
 	var maxTick int64
 	for _, m := range c.myses {
-		m.mu.RLock()
-		tick := m.lastServerTick
-		m.mu.RUnlock()
-
-		if tick > maxTick {
+		if tick, _ := m.LastServerTick(); tick > maxTick {
 			maxTick = tick
 		}
 	}
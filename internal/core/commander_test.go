@@ -1,12 +1,21 @@
 package core
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
 	"github.com/xonecas/zoea-nova/internal/provider"
 	"github.com/xonecas/zoea-nova/internal/store"
 )
@@ -133,7 +142,55 @@ func TestCommanderRestartErroredMysis(t *testing.T) {
 	cmd.StopMysis(id)
 }
 
-func TestCommanderConfigureMysis(t *testing.T) {
+func TestCommanderRecoverErroredMysis(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	mysis, _ := cmd.CreateMysis("recover-test", "mock")
+	id := mysis.ID()
+
+	if err := cmd.RecoverMysis(id); err == nil {
+		t.Error("expected error recovering an idle mysis")
+	}
+
+	if err := cmd.StartMysis(id); err != nil {
+		t.Fatalf("StartMysis() error: %v", err)
+	}
+	mysis.mu.RLock()
+	ctx := mysis.ctx
+	mysis.mu.RUnlock()
+
+	mysis.SetErrorState(fmt.Errorf("simulated error"))
+	if err := cmd.RecoverMysis(id); err != nil {
+		t.Fatalf("RecoverMysis() error: %v", err)
+	}
+	if mysis.State() != MysisStateRunning {
+		t.Errorf("expected state=running after recover, got %s", mysis.State())
+	}
+	if mysis.LastError() != nil {
+		t.Errorf("expected lastError to be cleared, got: %v", mysis.LastError())
+	}
+
+	// The live context is kept rather than replaced
+	mysis.mu.RLock()
+	sameCtx := mysis.ctx == ctx
+	loopCtx := mysis.loopCtx
+	mysis.mu.RUnlock()
+	if !sameCtx {
+		t.Error("expected recover to keep the live context")
+	}
+	if loopCtx != ctx {
+		t.Error("expected a run loop on the kept context")
+	}
+
+	if err := cmd.RecoverMysis(id); err == nil {
+		t.Error("expected error recovering a running mysis")
+	}
+
+	cmd.StopMysis(id)
+}
+
+func TestCommanderSwitchProvider(t *testing.T) {
 	cmd, bus, cleanup := setupCommanderTest(t)
 	defer cleanup()
 
@@ -150,8 +207,8 @@ func TestCommanderConfigureMysis(t *testing.T) {
 		t.Fatal("timeout waiting for created event")
 	}
 
-	if err := cmd.ConfigureMysis(id, "ollama", "llama3"); err != nil {
-		t.Fatalf("ConfigureMysis() error: %v", err)
+	if err := cmd.SwitchProvider(id, "ollama", "llama3"); err != nil {
+		t.Fatalf("SwitchProvider() error: %v", err)
 	}
 
 	// Should receive config changed event
@@ -185,14 +242,73 @@ func TestCommanderConfigureMysis(t *testing.T) {
 	}
 
 	// Configure with non-existent provider
-	if err := cmd.ConfigureMysis(id, "nonexistent", "model"); err == nil {
+	if err := cmd.SwitchProvider(id, "nonexistent", "model"); err == nil {
 		t.Error("expected error configuring with non-existent provider")
 	}
 
 	// Configure non-existent mysis
-	if err := cmd.ConfigureMysis("nonexistent", "mock", "mock-model"); err == nil {
+	if err := cmd.SwitchProvider("nonexistent", "mock", "mock-model"); err == nil {
 		t.Error("expected error configuring non-existent mysis")
 	}
+
+	// Configured providers still need a registered factory
+	cmd.config.Providers["unregistered"] = config.ProviderConfig{Model: "m"}
+	if err := cmd.SwitchProvider(id, "unregistered", ""); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Errorf("expected unregistered provider error, got %v", err)
+	}
+	if err := cmd.SwitchProviderAsync(id, "unregistered", ""); err == nil {
+		t.Error("expected SwitchProviderAsync to check the provider up front")
+	}
+}
+
+func TestCommanderSwitchProviderWaitsForTurn(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("switcher", "mock")
+
+	// A turn in progress keeps its provider until it finishes
+	m.turnMu.Lock()
+	done := make(chan error, 1)
+	go func() { done <- cmd.SwitchProvider(m.ID(), "ollama", "") }()
+	select {
+	case <-done:
+		t.Fatal("SwitchProvider returned mid-turn")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if m.ProviderName() != "mock" {
+		t.Errorf("provider switched mid-turn to %s", m.ProviderName())
+	}
+	m.turnMu.Unlock()
+	if err := <-done; err != nil {
+		t.Fatalf("SwitchProvider() error: %v", err)
+	}
+
+	stored, _ := cmd.Store().GetMysis(m.ID())
+	if stored.Provider != "ollama" || stored.Model != "llama3" {
+		t.Errorf("expected stored ollama/llama3, got %s/%s", stored.Provider, stored.Model)
+	}
+
+	// The next turn uses the new provider with the same memory
+	events := bus.Subscribe()
+	if err := m.SendMessage("Status?", store.MemorySourceDirect); err != nil {
+		t.Fatalf("SendMessage() error: %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if e.Type == EventMysisResponse && e.MysisID == m.ID() {
+				if e.Message.Content != "ollama response" {
+					t.Errorf("expected response from the new provider, got %q", e.Message.Content)
+				}
+				m.Stop()
+				return
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for response")
+		}
+	}
 }
 
 func TestCommanderListMyses(t *testing.T) {
@@ -291,6 +407,339 @@ func TestCommanderBroadcast(t *testing.T) {
 	}
 }
 
+func TestCommanderBroadcastDedup(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	cmd.config.Swarm.BroadcastDedupWindowMs = 500
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cmd.nowFunc = func() time.Time { return now }
+
+	mysis, _ := cmd.CreateMysis("dedup", "mock")
+	if err := cmd.StartMysis(mysis.ID()); err != nil {
+		t.Fatalf("StartMysis() error: %v", err)
+	}
+
+	if err := cmd.Broadcast("Regroup at base"); err != nil {
+		t.Fatalf("Broadcast() error: %v", err)
+	}
+	if err := cmd.Broadcast("Regroup at base"); !errors.Is(err, ErrBroadcastDuplicate) {
+		t.Fatalf("expected ErrBroadcastDuplicate, got %v", err)
+	}
+	if err := cmd.Broadcast("Mine iron"); err != nil {
+		t.Fatalf("expected different content to go through, got %v", err)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if err := cmd.Broadcast("Regroup at base"); err != nil {
+		t.Fatalf("expected repeat after window to go through, got %v", err)
+	}
+
+	memories, err := cmd.store.GetMemories(mysis.ID())
+	if err != nil {
+		t.Fatalf("GetMemories() error: %v", err)
+	}
+	stored := 0
+	for _, mem := range memories {
+		if mem.Source == store.MemorySourceBroadcast {
+			stored++
+		}
+	}
+	if stored != 3 {
+		t.Errorf("expected 3 stored broadcasts, got %d", stored)
+	}
+}
+
+func TestCommanderVacuumRequiresStoppedMyses(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	mysis, err := cmd.CreateMysis("vacuum-test", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	mysis.mu.Lock()
+	mysis.state = MysisStateRunning
+	mysis.mu.Unlock()
+	if _, err := cmd.Vacuum(); !errors.Is(err, ErrMysesRunning) {
+		t.Fatalf("expected ErrMysesRunning, got %v", err)
+	}
+
+	mysis.mu.Lock()
+	mysis.state = MysisStateStopped
+	mysis.mu.Unlock()
+	result, err := cmd.Vacuum()
+	if err != nil {
+		t.Fatalf("Vacuum() error: %v", err)
+	}
+	if result.BeforeBytes == 0 || result.AfterBytes == 0 {
+		t.Errorf("expected database sizes reported, got %+v", result)
+	}
+}
+
+func TestCommanderSyncMysisToSwarm(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	veteran, _ := cmd.CreateMysis("veteran", "mock")
+	rookie, _ := cmd.CreateMysis("rookie", "mock")
+
+	if synced, err := cmd.SyncMysisToSwarm(rookie.ID()); err != nil || synced {
+		t.Fatalf("expected no sync without broadcasts, got %v, %v", synced, err)
+	}
+
+	if err := cmd.store.AddMemory(veteran.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, "Mine the belt", "", ""); err != nil {
+		t.Fatalf("AddMemory() error: %v", err)
+	}
+
+	// Running without the run loop keeps the queued broadcast from auto-starting a turn
+	rookie.mu.Lock()
+	rookie.state = MysisStateRunning
+	rookie.encouragementCount = 3
+	rookie.mu.Unlock()
+
+	for i, want := range []bool{true, false} {
+		synced, err := cmd.SyncMysisToSwarm(rookie.ID())
+		if err != nil {
+			t.Fatalf("SyncMysisToSwarm() call %d error: %v", i+1, err)
+		}
+		if synced != want {
+			t.Errorf("SyncMysisToSwarm() call %d = %v, want %v", i+1, synced, want)
+		}
+	}
+
+	memories, err := cmd.store.GetMemories(rookie.ID())
+	if err != nil {
+		t.Fatalf("GetMemories() error: %v", err)
+	}
+	var broadcasts int
+	for _, mem := range memories {
+		if mem.Source == store.MemorySourceBroadcast && mem.Content == "Mine the belt" {
+			broadcasts++
+		}
+	}
+	if broadcasts != 1 {
+		t.Errorf("expected the broadcast stored once, got %d", broadcasts)
+	}
+	if count := rookie.EncouragementCount(); count != 0 {
+		t.Errorf("expected encouragement counter reset, got %d", count)
+	}
+
+	if _, err := cmd.SyncMysisToSwarm("missing"); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderMysisActivity(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	traveler, _ := cmd.CreateMysis("traveler", "mock")
+	cmd.CreateMysis("miner", "mock")
+
+	traveler.mu.Lock()
+	traveler.tickDuration = 10 * time.Second
+	traveler.mu.Unlock()
+	traveler.setActivity(ActivityStateTraveling, time.Now().Add(25*time.Second))
+
+	all, err := cmd.MysisActivity("")
+	if err != nil {
+		t.Fatalf("MysisActivity() error: %v", err)
+	}
+	if len(all) != 2 || all[0].MysisName != "miner" || all[1].MysisName != "traveler" {
+		t.Fatalf("expected both myses sorted by name, got %+v", all)
+	}
+	if !all[0].Available {
+		t.Errorf("expected idle mysis to be available, got %+v", all[0])
+	}
+
+	one, err := cmd.MysisActivity(traveler.ID())
+	if err != nil {
+		t.Fatalf("MysisActivity(id) error: %v", err)
+	}
+	snap := one[0]
+	if snap.Activity != ActivityStateTraveling || snap.Available || snap.RemainingTicks != 3 {
+		t.Errorf("expected traveling for 3 ticks, got %+v", snap)
+	}
+
+	// Once the expected end passes the mysis is available again
+	traveler.setActivity(ActivityStateTraveling, time.Now().Add(-time.Second))
+	if one, _ := cmd.MysisActivity(traveler.ID()); !one[0].Available || one[0].RemainingTicks != 0 {
+		t.Errorf("expected available after arrival, got %+v", one[0])
+	}
+
+	if _, err := cmd.MysisActivity("missing"); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderConfigureSwarm(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	// A slot held across a resize is released to the semaphore it came from
+	cmd.ConfigureSwarm("max_concurrent_turns", 1)
+	release, _ := cmd.acquireTurnSlot(context.Background())
+	if err := cmd.ConfigureSwarm("max_concurrent_turns", 2); err != nil {
+		t.Fatalf("ConfigureSwarm() error: %v", err)
+	}
+	if inFlight, limit := cmd.InFlightTurns(); inFlight != 1 || limit != 2 {
+		t.Errorf("expected 1/2 turns in flight, got %d/%d", inFlight, limit)
+	}
+	release()
+	if inFlight, _ := cmd.InFlightTurns(); inFlight != 0 {
+		t.Errorf("expected no turns in flight, got %d", inFlight)
+	}
+
+	if err := cmd.ConfigureSwarm("turn_interval_ms", 1500); err != nil {
+		t.Fatalf("ConfigureSwarm() error: %v", err)
+	}
+	if cmd.TurnInterval() != 1500*time.Millisecond {
+		t.Errorf("expected 1.5s turn interval, got %s", cmd.TurnInterval())
+	}
+	if err := cmd.ConfigureSwarm("max_myses", 4); err != nil {
+		t.Fatalf("ConfigureSwarm() error: %v", err)
+	}
+	if cmd.MaxMyses() != 4 {
+		t.Errorf("expected max myses 4, got %d", cmd.MaxMyses())
+	}
+
+	for _, tc := range []struct {
+		setting string
+		value   int
+	}{
+		{"max_concurrent_turns", -1},
+		{"max_concurrent_turns", 101},
+		{"turn_interval_ms", 100},
+		{"max_myses", 0},
+		{"nudge_everything", 1},
+	} {
+		if err := cmd.ConfigureSwarm(tc.setting, tc.value); err == nil {
+			t.Errorf("expected error for %s=%d", tc.setting, tc.value)
+		}
+	}
+	if cmd.TurnInterval() != 1500*time.Millisecond || cmd.MaxMyses() != 4 {
+		t.Error("rejected values should leave settings unchanged")
+	}
+}
+
+func TestCommanderTurnSlots(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.turnSlots = newTurnSlots(1)
+
+	release, err := cmd.acquireTurnSlot(context.Background())
+	if err != nil {
+		t.Fatalf("acquireTurnSlot() error: %v", err)
+	}
+	if stats := cmd.Stats(); stats.InFlightTurns != 1 || stats.MaxConcurrentTurns != 1 {
+		t.Errorf("expected 1/1 turns in flight, got %d/%d", stats.InFlightTurns, stats.MaxConcurrentTurns)
+	}
+
+	// A second caller blocks rather than erroring, and gives up with its context
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := cmd.acquireTurnSlot(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline while the slot is held, got %v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		next, err := cmd.acquireTurnSlot(context.Background())
+		if err != nil {
+			t.Errorf("acquireTurnSlot() error: %v", err)
+		}
+		acquired <- next
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired a slot while the cap was reached")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case next := <-acquired:
+		next()
+	case <-time.After(time.Second):
+		t.Fatal("waiting caller did not get the released slot")
+	}
+	if inFlight, _ := cmd.InFlightTurns(); inFlight != 0 {
+		t.Errorf("expected no turns in flight, got %d", inFlight)
+	}
+}
+
+func TestCommanderMessageLengthPolicy(t *testing.T) {
+	long := strings.Repeat("a", 30)
+
+	t.Run("truncate", func(t *testing.T) {
+		cmd, _, cleanup := setupCommanderTest(t)
+		defer cleanup()
+		cmd.config.Swarm.MaxMessageLength = 20
+
+		mysis, _ := cmd.CreateMysis("truncate", "mock")
+		if err := cmd.Broadcast(long); err != nil {
+			t.Fatalf("Broadcast() error: %v", err)
+		}
+
+		want := strings.Repeat("a", 17) + "..."
+		memories, err := cmd.store.GetMemories(mysis.ID())
+		if err != nil {
+			t.Fatalf("GetMemories() error: %v", err)
+		}
+		var stored []string
+		for _, mem := range memories {
+			if mem.Source == store.MemorySourceBroadcast {
+				stored = append(stored, mem.Content)
+			}
+		}
+		if len(stored) != 1 || stored[0] != want {
+			t.Errorf("expected stored broadcast %q, got %q", want, stored)
+		}
+
+		prompt := mysis.buildSystemPrompt()
+		if !strings.Contains(prompt, want) || strings.Contains(prompt, long) {
+			t.Errorf("expected truncated broadcast in system prompt, got:\n%s", prompt)
+		}
+
+		if err := cmd.SendMessage(mysis.ID(), long); err != nil {
+			t.Fatalf("SendMessage() error: %v", err)
+		}
+		memories, _ = cmd.store.GetMemories(mysis.ID())
+		for _, mem := range memories {
+			if mem.Source == store.MemorySourceDirect && mem.Content != want {
+				t.Errorf("expected truncated direct message %q, got %q", want, mem.Content)
+			}
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		cmd, _, cleanup := setupCommanderTest(t)
+		defer cleanup()
+		cmd.config.Swarm.MaxMessageLength = 20
+		cmd.config.Swarm.MessageLengthPolicy = constants.MessageLengthPolicyReject
+
+		mysis, _ := cmd.CreateMysis("reject", "mock")
+		if err := cmd.Broadcast(long); !errors.Is(err, ErrMessageTooLong) {
+			t.Fatalf("expected ErrMessageTooLong from Broadcast, got %v", err)
+		}
+		if err := cmd.SendMessageAsync(mysis.ID(), long); !errors.Is(err, ErrMessageTooLong) {
+			t.Fatalf("expected ErrMessageTooLong from SendMessageAsync, got %v", err)
+		}
+		if err := cmd.Broadcast(strings.Repeat("a", 20)); err != nil {
+			t.Fatalf("expected message at the limit to go through, got %v", err)
+		}
+
+		memories, _ := cmd.store.GetMemories(mysis.ID())
+		for _, mem := range memories {
+			if mem.Content == long {
+				t.Errorf("rejected message was stored: %+v", mem)
+			}
+		}
+	})
+}
+
 func TestCommanderBroadcastToIdleMyses(t *testing.T) {
 	cmd, bus, cleanup := setupCommanderTest(t)
 	defer cleanup()
@@ -472,6 +921,66 @@ func TestBroadcastExcludesSender(t *testing.T) {
 	}
 }
 
+func TestCommanderForEachMysis(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		m, err := cmd.CreateMysis(fmt.Sprintf("mysis-%d", i), "mock")
+		if err != nil {
+			t.Fatalf("CreateMysis() error: %v", err)
+		}
+		ids = append(ids, m.ID())
+	}
+
+	// Creating myses during the walk, from fn and from another goroutine, neither
+	// panics nor makes the walk skip or repeat a mysis
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			cmd.CreateMysis(fmt.Sprintf("racer-%d", i), "mock")
+		}
+	}()
+	var visited []string
+	cmd.ForEachMysis(func(m *Mysis) {
+		visited = append(visited, m.ID())
+		cmd.CreateMysis("spawn-"+m.ID(), "mock")
+	})
+	wg.Wait()
+
+	if len(visited) < len(ids) {
+		t.Fatalf("expected at least %d myses visited, got %d", len(ids), len(visited))
+	}
+	seen := make(map[string]bool)
+	for _, id := range visited {
+		if seen[id] {
+			t.Errorf("mysis %s visited twice", id)
+		}
+		seen[id] = true
+	}
+	for _, id := range ids {
+		if !seen[id] {
+			t.Errorf("mysis %s was skipped", id)
+		}
+	}
+
+	// A mysis deleted before its turn is skipped
+	visited = nil
+	last := ids[len(ids)-1]
+	cmd.ForEachMysis(func(m *Mysis) {
+		if m.ID() == ids[0] {
+			cmd.DeleteMysis(last, true)
+		}
+		visited = append(visited, m.ID())
+	})
+	if slices.Contains(visited, last) {
+		t.Error("expected the deleted mysis to be skipped")
+	}
+}
+
 func TestCommanderStopAll(t *testing.T) {
 	cmd, _, cleanup := setupCommanderTest(t)
 	defer cleanup()
@@ -494,45 +1003,134 @@ func TestCommanderStopAll(t *testing.T) {
 	}
 }
 
-func TestCommanderLoadMyses(t *testing.T) {
-	s, err := store.OpenMemory()
-	if err != nil {
-		t.Fatalf("OpenMemory() error: %v", err)
-	}
-	defer s.Close()
-
-	// Pre-populate store
-	s.CreateMysis("existing-1", "mock", "mock-model", 0.7)
-	s.CreateMysis("existing-2", "mock", "mock-model", 0.7)
-
-	bus := NewEventBus(100)
-	defer bus.Close()
+func TestCommanderBulkOperations(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
 
-	reg := provider.NewRegistry()
-	reg.RegisterFactory("mock", provider.NewMockFactory("mock", "response"))
+	idle1, _ := cmd.CreateMysis("bulk-idle-1", "mock")
+	idle2, _ := cmd.CreateMysis("bulk-idle-2", "mock")
+	errored, _ := cmd.CreateMysis("bulk-errored", "mock")
+	errored.SetErrorState(errors.New("provider down"))
 
-	cfg := &config.Config{
-		Swarm: config.SwarmConfig{MaxMyses: 16},
-		Providers: map[string]config.ProviderConfig{
-			"mock": {Endpoint: "http://mock", Model: "mock-model", Temperature: 0.7},
-		},
+	result := cmd.RelaunchErrored()
+	if result.Attempted != 1 || result.Succeeded() != 1 {
+		t.Errorf("RelaunchErrored() = %+v, want 1 attempted and succeeded", result)
+	}
+	if errored.State() != MysisStateRunning {
+		t.Errorf("expected relaunched mysis running, got %s", errored.State())
 	}
 
-	cmd := NewCommander(s, reg, bus, cfg, "")
+	result = cmd.StartAllIdle()
+	if result.Attempted != 2 || len(result.Errors) != 0 {
+		t.Errorf("StartAllIdle() = %+v, want 2 attempted without errors", result)
+	}
+	for _, m := range []*Mysis{idle1, idle2} {
+		if m.State() == MysisStateIdle {
+			t.Errorf("expected %s to be started", m.Name())
+		}
+	}
 
-	if err := cmd.LoadMyses(); err != nil {
-		t.Fatalf("LoadMyses() error: %v", err)
+	result = cmd.StopRunning()
+	if len(result.Errors) != 0 {
+		t.Errorf("StopRunning() errors: %v", result.Errors)
+	}
+	for _, m := range cmd.ListMyses() {
+		if m.State() == MysisStateRunning {
+			t.Errorf("expected %s to be stopped", m.Name())
+		}
 	}
 
-	if cmd.MysisCount() != 2 {
-		t.Errorf("expected 2 myses loaded, got %d", cmd.MysisCount())
+	// Nothing left to relaunch
+	if result := cmd.RelaunchErrored(); result.Attempted != 0 {
+		t.Errorf("expected no errored myses, got %+v", result)
 	}
 }
 
-// TestBroadcastDoesNotBlockOnBusyMysis verifies that broadcasting doesn't block
-// when one or more myses are busy processing a previous message.
-// This test ensures the parallel broadcast implementation works correctly.
-func TestBroadcastDoesNotBlockOnBusyMysis(t *testing.T) {
+func TestCommanderRelaunchAndBroadcast(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	idle, _ := cmd.CreateMysis("rb-idle", "mock")
+	errored1, _ := cmd.CreateMysis("rb-errored-1", "mock")
+	errored2, _ := cmd.CreateMysis("rb-errored-2", "mock")
+	errored1.SetErrorState(errors.New("provider down"))
+	errored2.SetErrorState(errors.New("provider down"))
+
+	result, err := cmd.RelaunchAndBroadcast("Regroup at the station")
+	if err != nil {
+		t.Fatalf("RelaunchAndBroadcast() error: %v", err)
+	}
+	if result.Attempted != 2 || result.Succeeded() != 2 {
+		t.Errorf("RelaunchAndBroadcast() = %+v, want 2 attempted and succeeded", result)
+	}
+	slices.Sort(result.Names)
+	if !slices.Equal(result.Names, []string{"rb-errored-1", "rb-errored-2"}) {
+		t.Errorf("expected both errored myses named, got %v", result.Names)
+	}
+
+	for _, m := range []*Mysis{errored1, errored2} {
+		if m.State() == MysisStateErrored || m.State() == MysisStateIdle {
+			t.Errorf("expected %s relaunched, got %s", m.Name(), m.State())
+		}
+		if has, err := cmd.Store().HasBroadcast(m.ID(), "Regroup at the station"); err != nil || !has {
+			t.Errorf("expected %s to receive the broadcast (err=%v)", m.Name(), err)
+		}
+	}
+	if has, _ := cmd.Store().HasBroadcast(idle.ID(), "Regroup at the station"); has {
+		t.Error("expected idle mysis to be left out of the broadcast")
+	}
+	if idle.State() != MysisStateIdle {
+		t.Errorf("expected idle mysis untouched, got %s", idle.State())
+	}
+
+	cmd.StopAll()
+
+	// Nothing errored: nothing attempted, nothing sent
+	result, err = cmd.RelaunchAndBroadcast("Anyone?")
+	if err != nil || result.Attempted != 0 {
+		t.Errorf("expected no attempts, got %+v (err=%v)", result, err)
+	}
+}
+
+func TestCommanderLoadMyses(t *testing.T) {
+	s, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory() error: %v", err)
+	}
+	defer s.Close()
+
+	// Pre-populate store
+	s.CreateMysis("existing-1", "mock", "mock-model", 0.7)
+	s.CreateMysis("existing-2", "mock", "mock-model", 0.7)
+
+	bus := NewEventBus(100)
+	defer bus.Close()
+
+	reg := provider.NewRegistry()
+	reg.RegisterFactory("mock", provider.NewMockFactory("mock", "response"))
+
+	cfg := &config.Config{
+		Swarm: config.SwarmConfig{MaxMyses: 16},
+		Providers: map[string]config.ProviderConfig{
+			"mock": {Endpoint: "http://mock", Model: "mock-model", Temperature: 0.7},
+		},
+	}
+
+	cmd := NewCommander(s, reg, bus, cfg, "")
+
+	if err := cmd.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+
+	if cmd.MysisCount() != 2 {
+		t.Errorf("expected 2 myses loaded, got %d", cmd.MysisCount())
+	}
+}
+
+// TestBroadcastDoesNotBlockOnBusyMysis verifies that broadcasting doesn't block
+// when one or more myses are busy processing a previous message.
+// This test ensures the parallel broadcast implementation works correctly.
+func TestBroadcastDoesNotBlockOnBusyMysis(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
 	s, err := store.Open(dbPath)
@@ -769,6 +1367,49 @@ func TestMaxMyses(t *testing.T) {
 	}
 }
 
+func TestCommanderMaxMysesReached(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Swarm.MaxMyses = 2
+	cmd.maxMyses = 2
+
+	if ok, remaining := cmd.CanCreateMysis(); !ok || remaining != 2 {
+		t.Errorf("CanCreateMysis() = %v, %d, want true, 2", ok, remaining)
+	}
+	cmd.CreateMysis("one", "mock")
+	cmd.CreateMysis("two", "mock")
+	if ok, remaining := cmd.CanCreateMysis(); ok || remaining != 0 {
+		t.Errorf("CanCreateMysis() at cap = %v, %d, want false, 0", ok, remaining)
+	}
+
+	_, err := cmd.CreateMysis("three", "mock")
+	var maxErr *MaxMysesError
+	if !errors.Is(err, ErrMaxMysesReached) || !errors.As(err, &maxErr) {
+		t.Fatalf("expected ErrMaxMysesReached, got %v", err)
+	}
+	if maxErr.Current != 2 || maxErr.Max != 2 {
+		t.Errorf("expected 2/2 in error, got %+v", maxErr)
+	}
+	if count := cmd.MysisCount(); count != 2 {
+		t.Errorf("expected no mysis created over the cap, got %d", count)
+	}
+
+	// A ceiling raises the cap one mysis at a time
+	cmd.config.Swarm.MaxMysesCeiling = 3
+	if ok, remaining := cmd.CanCreateMysis(); !ok || remaining != 1 {
+		t.Errorf("CanCreateMysis() under ceiling = %v, %d, want true, 1", ok, remaining)
+	}
+	if _, err := cmd.CreateMysis("three", "mock"); err != nil {
+		t.Fatalf("CreateMysis() under ceiling error: %v", err)
+	}
+	if got := cmd.MaxMyses(); got != 3 {
+		t.Errorf("expected cap raised to 3, got %d", got)
+	}
+	if _, err := cmd.CreateMysis("four", "mock"); !errors.As(err, &maxErr) || maxErr.Max != 3 {
+		t.Errorf("expected ErrMaxMysesReached at the ceiling, got %v", err)
+	}
+}
+
 // TestGetStateCounts verifies the GetStateCounts method returns accurate counts.
 func TestGetStateCounts(t *testing.T) {
 	cmd, _, cleanup := setupCommanderTest(t)
@@ -812,3 +1453,1380 @@ func TestGetStateCounts(t *testing.T) {
 		t.Errorf("total myses across states = %d, want 3", total)
 	}
 }
+
+func TestCommanderStatsCost(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	cmd.config.Pricing = map[string]config.PricingConfig{
+		"mock-model": {InputPer1K: 0.01, OutputPer1K: 0.02},
+	}
+
+	m, err := cmd.CreateMysis("cost-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	m.recordUsage(provider.Usage{PromptTokens: 1000, CompletionTokens: 500})
+	m.recordUsage(provider.Usage{PromptTokens: 1000, CompletionTokens: 500})
+
+	usage := m.TokenUsage()
+	if usage.PromptTokens != 2000 || usage.CompletionTokens != 1000 {
+		t.Errorf("unexpected in-memory usage: %+v", usage)
+	}
+
+	perMysis, err := cmd.Store().GetCostStats(m.ID())
+	if err != nil {
+		t.Fatalf("GetCostStats() error: %v", err)
+	}
+	if perMysis.Cost < 0.0399 || perMysis.Cost > 0.0401 {
+		t.Errorf("expected cost 0.04, got %f", perMysis.Cost)
+	}
+
+	stats := cmd.Stats()
+	if stats.MysisCount != 1 {
+		t.Errorf("expected 1 mysis, got %d", stats.MysisCount)
+	}
+	if stats.Usage.TotalTokens() != 3000 {
+		t.Errorf("expected 3000 total tokens, got %d", stats.Usage.TotalTokens())
+	}
+	if stats.Usage.Cost != perMysis.Cost {
+		t.Errorf("expected swarm cost %f, got %f", perMysis.Cost, stats.Usage.Cost)
+	}
+
+	// Cost follows the model after a switch and after a reload
+	cmd.config.Pricing["llama3"] = config.PricingConfig{InputPer1K: 1}
+	if err := cmd.SwitchProvider(m.ID(), "ollama", ""); err != nil {
+		t.Fatalf("SwitchProvider() error: %v", err)
+	}
+	m.recordUsage(provider.Usage{PromptTokens: 1000})
+	if err := cmd.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	reloaded, err := cmd.GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	reloaded.recordUsage(provider.Usage{PromptTokens: 1000})
+	perMysis, err = cmd.Store().GetCostStats(m.ID())
+	if err != nil {
+		t.Fatalf("GetCostStats() error: %v", err)
+	}
+	if perMysis.Cost < 2.0399 || perMysis.Cost > 2.0401 {
+		t.Errorf("expected cost 2.04 after the switch, got %f", perMysis.Cost)
+	}
+}
+
+func TestCommanderRenameMysis(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("old-name", "mock")
+	other, _ := cmd.CreateMysis("taken", "mock")
+	events := bus.Subscribe()
+
+	if err := cmd.RenameMysis(m.ID(), "  new-name "); err != nil {
+		t.Fatalf("RenameMysis() error: %v", err)
+	}
+	if m.Name() != "new-name" {
+		t.Errorf("expected runtime name new-name, got %q", m.Name())
+	}
+	stored, err := cmd.Store().GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if stored.Name != "new-name" {
+		t.Errorf("expected stored name new-name, got %q", stored.Name)
+	}
+
+	select {
+	case e := <-events:
+		if e.Type != EventMysisRenamed || e.MysisName != "new-name" {
+			t.Errorf("unexpected event: %+v", e)
+		}
+		if e.Rename == nil || e.Rename.OldName != "old-name" {
+			t.Errorf("expected rename data with old name, got %+v", e.Rename)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("timeout waiting for rename event")
+	}
+
+	// Renaming to its own name is allowed; another mysis's name is not
+	if err := cmd.RenameMysis(other.ID(), "TAKEN"); err != nil {
+		t.Errorf("expected renaming to own name to succeed, got %v", err)
+	}
+	for _, name := range []string{"", "   ", "New-Name"} {
+		if err := cmd.RenameMysis(other.ID(), name); err == nil {
+			t.Errorf("expected error renaming to %q", name)
+		}
+	}
+	if err := cmd.RenameMysis("missing", "anything"); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestMaxReasoningBytes(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	cmd.config.Swarm.MaxReasoningBytes = 10
+	m, err := cmd.CreateMysis("thinker", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	// Reasoning is only returned on the tool-enabled path
+	proxy := mcp.NewProxy(nil)
+	proxy.RegisterTool(mcp.Tool{Name: "noop", InputSchema: json.RawMessage(`{"type": "object"}`)},
+		func(ctx context.Context, args json.RawMessage) (*mcp.ToolResult, error) {
+			return &mcp.ToolResult{}, nil
+		})
+	m.mu.Lock()
+	m.provider = provider.NewMock("mock", "Done.").WithReasoning("Let me think about ore prices for a while")
+	m.mcpProxy = proxy
+	m.mu.Unlock()
+
+	events := bus.Subscribe()
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for response := false; !response; {
+		select {
+		case e := <-events:
+			response = e.Type == EventMysisResponse && e.MysisID == m.ID()
+		case <-timeout:
+			t.Fatal("timeout waiting for a response")
+		}
+	}
+	m.Stop()
+
+	memories, err := cmd.Store().GetRecentMemories(m.ID(), 10)
+	if err != nil {
+		t.Fatalf("GetRecentMemories() error: %v", err)
+	}
+	last := memories[len(memories)-1]
+	want := "Let me thi" + fmt.Sprintf(constants.ReasoningTruncatedFormat, 10, 41)
+	if last.Reasoning != want {
+		t.Errorf("expected stored reasoning %q, got %q", want, last.Reasoning)
+	}
+	if stats := m.computeMemoryStats(memories); stats.ReasoningBytes != len(want) {
+		t.Errorf("expected %d reasoning bytes, got %d", len(want), stats.ReasoningBytes)
+	}
+
+	// Multi-byte characters are never split
+	cmd.config.Swarm.MaxReasoningBytes = 5
+	if got := m.limitReasoning("ore→price→route"); got != "ore"+fmt.Sprintf(constants.ReasoningTruncatedFormat, 3, 19) {
+		t.Errorf("unexpected truncation %q", got)
+	}
+}
+
+func TestCommanderBroadcastAckStatus(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	scout, _ := cmd.CreateMysis("scout", "mock")
+	miner, _ := cmd.CreateMysis("miner", "mock")
+	if err := cmd.Broadcast("Hold at Sol"); err != nil {
+		t.Fatalf("Broadcast() error: %v", err)
+	}
+	defer cmd.StopAll()
+
+	broadcasts, _ := cmd.Store().GetRecentBroadcasts(1)
+	if len(broadcasts) != 1 || broadcasts[0].ID == "" {
+		t.Fatalf("expected a tracked broadcast, got %+v", broadcasts)
+	}
+
+	// Both idle myses wake up and answer the broadcast
+	var acks []BroadcastAck
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var err error
+		acks, err = cmd.BroadcastAckStatus(broadcasts[0].ID)
+		if err != nil {
+			t.Fatalf("BroadcastAckStatus() error: %v", err)
+		}
+		if len(acks) == 2 && acks[0].Acked && acks[1].Acked {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	names := map[string]string{scout.ID(): "scout", miner.ID(): "miner"}
+	if len(acks) != 2 {
+		t.Fatalf("expected 2 recipients, got %+v", acks)
+	}
+	for _, ack := range acks {
+		if !ack.Acked || ack.MysisName != names[ack.MysisID] {
+			t.Errorf("unexpected ack %+v", ack)
+		}
+	}
+
+	if _, err := cmd.BroadcastAckStatus(""); err == nil {
+		t.Error("expected an error for an untracked broadcast")
+	}
+}
+
+func TestCommanderBroadcastUsesCommanderName(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	cmd.config.Swarm.CommanderName = "Admiral Vex"
+	m, err := cmd.CreateMysis("scout", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	if got := cmd.ResolveMysisName(""); got != "Admiral Vex" {
+		t.Errorf("ResolveMysisName(\"\") = %q, want Admiral Vex", got)
+	}
+
+	cmd.Store().AddMemory(m.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, "Hold at Sol", "", "")
+	prompt := m.buildSystemPrompt()
+	if !strings.Contains(prompt, fmt.Sprintf(constants.BroadcastSectionTemplate, "Admiral Vex", "Hold at Sol")) {
+		t.Errorf("expected the broadcast to come from Admiral Vex, got:\n%s", prompt)
+	}
+}
+
+func TestCommanderResolveMysisName(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	if got := cmd.ResolveMysisName(""); got != CommanderName {
+		t.Errorf("ResolveMysisName(\"\") = %q, want %q", got, CommanderName)
+	}
+	if got := cmd.ResolveMysisName("missing"); got != "" {
+		t.Errorf("ResolveMysisName(missing) = %q, want empty", got)
+	}
+
+	m, _ := cmd.CreateMysis("scout", "mock")
+	if got := cmd.ResolveMysisName(m.ID()); got != "scout" {
+		t.Errorf("ResolveMysisName() = %q, want scout", got)
+	}
+
+	if err := cmd.RenameMysis(m.ID(), "ranger"); err != nil {
+		t.Fatalf("RenameMysis() error: %v", err)
+	}
+	if got := cmd.ResolveMysisName(m.ID()); got != "ranger" {
+		t.Errorf("after rename: ResolveMysisName() = %q, want ranger", got)
+	}
+
+	// Stored myses that aren't loaded still resolve
+	stored, err := cmd.Store().CreateMysis("offline", "unavailable", "model", 0.7)
+	if err != nil {
+		t.Fatalf("store CreateMysis() error: %v", err)
+	}
+	if got := cmd.ResolveMysisName(stored.ID); got != "offline" {
+		t.Errorf("ResolveMysisName(unloaded) = %q, want offline", got)
+	}
+
+	if err := cmd.DeleteMysis(m.ID(), true); err != nil {
+		t.Fatalf("DeleteMysis() error: %v", err)
+	}
+	if got := cmd.ResolveMysisName(m.ID()); got != "" {
+		t.Errorf("after delete: ResolveMysisName() = %q, want empty", got)
+	}
+}
+
+func TestCommanderAsk(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("oracle", "mock")
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{Response: "Checking first.", ToolCalls: []provider.ToolCall{{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)}}},
+		{Response: "I'm docked at Sol."},
+	})
+	m.SetProvider(mock)
+	m.mcpProxy = mcp.NewProxy(&batchingUpstream{})
+	m.state = MysisStateRunning
+
+	// Only the final text of the tool loop is returned
+	answer, err := cmd.Ask(context.Background(), "", m.ID(), "Where are you?", time.Second)
+	if err != nil {
+		t.Fatalf("Ask() error: %v", err)
+	}
+	if answer != "I'm docked at Sol." {
+		t.Errorf("Ask() = %q, want the final response", answer)
+	}
+
+	mock.SetDelay(time.Second)
+	m.SetProvider(mock)
+	if _, err := cmd.Ask(context.Background(), "", m.ID(), "Still there?", 50*time.Millisecond); !errors.Is(err, ErrAskTimeout) {
+		t.Errorf("expected ErrAskTimeout, got %v", err)
+	}
+
+	if _, err := cmd.Ask(context.Background(), "", "missing", "hello?", time.Second); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+// TestCommanderAskRejectsCycle checks that a mysis cannot ask one that is waiting on
+// its own answer, while the outstanding ask is left to finish.
+func TestCommanderAskRejectsCycle(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	alpha, _ := cmd.CreateMysis("alpha", "mock")
+	beta, _ := cmd.CreateMysis("beta", "mock")
+	for _, m := range []*Mysis{alpha, beta} {
+		m.SetProvider(provider.NewMock("mock", "Busy mining.").SetDelay(300 * time.Millisecond))
+		m.state = MysisStateRunning
+	}
+
+	asked := make(chan error, 1)
+	go func() {
+		_, err := cmd.Ask(context.Background(), alpha.ID(), beta.ID(), "Where are you?", time.Second)
+		asked <- err
+	}()
+	pending := func() bool {
+		cmd.asksMu.Lock()
+		defer cmd.asksMu.Unlock()
+		ask := cmd.pendingAsks[alpha.ID()]
+		return ask != nil && ask.target == beta.ID()
+	}
+	deadline := time.Now().Add(time.Second)
+	for !pending() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	start := time.Now()
+	if _, err := cmd.Ask(context.Background(), beta.ID(), alpha.ID(), "And you?", time.Second); !errors.Is(err, ErrAskCycle) {
+		t.Fatalf("expected ErrAskCycle, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("cycle rejected after %s, want immediately", elapsed)
+	}
+
+	if err := <-asked; err != nil {
+		t.Fatalf("outstanding Ask() error: %v", err)
+	}
+	if _, err := cmd.Ask(context.Background(), beta.ID(), alpha.ID(), "And you?", time.Second); err != nil {
+		t.Errorf("Ask() after the first finished: %v", err)
+	}
+}
+
+// TestCommanderAskCanceled checks that canceling the asker's context ends the wait and
+// clears its pending ask, and that a stale ask can't clear a newer one.
+func TestCommanderAskCanceled(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	alpha, _ := cmd.CreateMysis("alpha", "mock")
+	beta, _ := cmd.CreateMysis("beta", "mock")
+	beta.SetProvider(provider.NewMock("mock", "Busy mining.").SetDelay(time.Second))
+	beta.state = MysisStateRunning
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := cmd.Ask(ctx, alpha.ID(), beta.ID(), "Where are you?", time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("canceled Ask() returned after %s", elapsed)
+	}
+	cmd.asksMu.Lock()
+	left := len(cmd.pendingAsks)
+	cmd.asksMu.Unlock()
+	if left != 0 {
+		t.Errorf("expected no pending asks after cancel, got %d", left)
+	}
+
+	stale, _ := cmd.beginAsk(alpha.ID(), beta.ID())
+	fresh, _ := cmd.beginAsk(alpha.ID(), beta.ID())
+	cmd.endAsk(alpha.ID(), stale)
+	if cmd.pendingAsks[alpha.ID()] != fresh {
+		t.Error("stale ask cleared the newer pending ask")
+	}
+}
+
+func TestCommanderSetCompactSnapshots(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("compact-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	if !m.CompactSnapshots() {
+		t.Fatal("expected compaction enabled by default")
+	}
+
+	if err := cmd.SetCompactSnapshots(m.ID(), false); err != nil {
+		t.Fatalf("SetCompactSnapshots() error: %v", err)
+	}
+	if m.CompactSnapshots() {
+		t.Error("expected runtime compaction disabled")
+	}
+
+	stored, err := cmd.Store().GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if stored.CompactSnapshots {
+		t.Error("expected stored compaction disabled")
+	}
+
+	// Reloading from the store restores the setting
+	reloaded := NewCommander(cmd.Store(), cmd.registry, cmd.bus, cmd.config, "")
+	if err := reloaded.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	rm, err := reloaded.GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if rm.CompactSnapshots() {
+		t.Error("expected reloaded mysis to keep compaction disabled")
+	}
+
+	if err := cmd.SetCompactSnapshots("missing", true); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderSetSyntheticNudges(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("quiet-mysis", "mock")
+	if !m.SyntheticNudges() {
+		t.Fatal("expected synthetic nudges enabled by default")
+	}
+
+	if err := cmd.SetSyntheticNudges(m.ID(), false); err != nil {
+		t.Fatalf("SetSyntheticNudges() error: %v", err)
+	}
+	if m.SyntheticNudges() || m.nudgesEnabled() {
+		t.Error("expected runtime nudges disabled")
+	}
+	if stored, _ := cmd.Store().GetMysis(m.ID()); stored.SyntheticNudges {
+		t.Error("expected stored nudges disabled")
+	}
+
+	// Reloading from the store restores the setting
+	reloaded := NewCommander(cmd.Store(), cmd.registry, cmd.bus, cmd.config, "")
+	if err := reloaded.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	if rm, _ := reloaded.GetMysis(m.ID()); rm.SyntheticNudges() {
+		t.Error("expected reloaded mysis to keep nudges disabled")
+	}
+
+	if err := cmd.SetSyntheticNudges("missing", true); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderSetSeed(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("seeded", "mock")
+	if m.Seed() != nil {
+		t.Fatal("expected no seed by default")
+	}
+
+	seed := int64(1234)
+	if err := cmd.SetSeed(m.ID(), &seed); err != nil {
+		t.Fatalf("SetSeed() error: %v", err)
+	}
+	if got := m.Seed(); got == nil || *got != 1234 {
+		t.Errorf("expected runtime seed 1234, got %v", got)
+	}
+
+	// Reloading from the store restores the seed
+	reloaded := NewCommander(cmd.Store(), cmd.registry, cmd.bus, cmd.config, "")
+	if err := reloaded.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	if rm, _ := reloaded.GetMysis(m.ID()); rm.Seed() == nil || *rm.Seed() != 1234 {
+		t.Error("expected reloaded mysis to keep its seed")
+	}
+
+	// Provider calls carry the seed
+	mock := provider.NewMock("mock", "ok")
+	if _, err := m.chat(context.Background(), mock, []provider.Message{{Role: "user", Content: "hi"}}, nil); err != nil {
+		t.Fatalf("chat() error: %v", err)
+	}
+	if got := mock.LastSeed(); got == nil || *got != 1234 {
+		t.Errorf("expected the provider call to carry seed 1234, got %v", got)
+	}
+
+	negative := int64(-5)
+	if err := cmd.SetSeed(m.ID(), &negative); err == nil {
+		t.Error("expected a negative seed to be rejected")
+	}
+	if err := cmd.SetSeed(m.ID(), nil); err != nil || m.Seed() != nil {
+		t.Errorf("expected seed cleared, got %v (err=%v)", m.Seed(), err)
+	}
+	if _, err := m.chat(context.Background(), mock, []provider.Message{{Role: "user", Content: "hi"}}, nil); err != nil {
+		t.Fatalf("chat() error: %v", err)
+	}
+	if mock.LastSeed() != nil {
+		t.Error("expected no seed after clearing it")
+	}
+}
+
+func TestNonNudgingMysisIdlesUntilBroadcast(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	disabled := false
+	cmd.config.Swarm.SyntheticNudgeEnabled = &disabled
+
+	m, _ := cmd.CreateMysis("waiter", "mock")
+	events := bus.Subscribe()
+	timeout := time.After(5 * time.Second)
+	responses := 0
+	waitFor := func(match func(Event) bool, what string) {
+		t.Helper()
+		for {
+			select {
+			case e := <-events:
+				if e.MysisID != m.ID() {
+					continue
+				}
+				if e.Type == EventMysisResponse {
+					responses++
+				}
+				if match(e) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timeout waiting for %s", what)
+			}
+		}
+	}
+	idled := func(e Event) bool {
+		return e.Type == EventMysisStateChanged && e.State != nil && e.State.NewState == MysisStateIdle
+	}
+
+	// With nothing to answer, the first turn idles without calling the provider
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	waitFor(idled, "idle without nudging")
+	if responses != 0 || m.TurnCount() != 0 {
+		t.Fatalf("expected no turns while idle, got %d responses and %d turns", responses, m.TurnCount())
+	}
+
+	// A broadcast wakes it for a turn
+	if err := cmd.Broadcast("Regroup at Sol"); err != nil {
+		t.Fatalf("Broadcast() error: %v", err)
+	}
+	waitFor(func(e Event) bool { return e.Type == EventMysisResponse }, "turn on broadcast")
+	waitFor(idled, "idle after answering")
+	if responses != 1 {
+		t.Errorf("expected exactly one turn, got %d", responses)
+	}
+}
+
+func TestCommanderDrain(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	// Two scripted replies: the in-flight turn uses one, a nudged turn would use the other
+	slow := provider.NewScriptedMock([]provider.MockStep{
+		{Response: "first"},
+		{Response: "second"},
+	}).SetDelay(300 * time.Millisecond)
+	cmd.registry.RegisterFactory("slow", &customMockFactory{name: "slow", provider: slow})
+	cmd.config.Providers["slow"] = config.ProviderConfig{Endpoint: "http://slow", Model: "slow-model"}
+	if err := cmd.SetTurnInterval(constants.MinTurnInterval); err != nil {
+		t.Fatalf("SetTurnInterval() error: %v", err)
+	}
+
+	m, _ := cmd.CreateMysis("drainer", "slow")
+	bystander, _ := cmd.CreateMysis("bystander", "mock")
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+
+	// Drain once the first provider call is in flight
+	deadline := time.Now().Add(2 * time.Second)
+	for inFlight, _ := cmd.InFlightTurns(); inFlight == 0; inFlight, _ = cmd.InFlightTurns() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for the first turn")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := cmd.Drain(ctx); err != nil {
+		t.Fatalf("Drain() error: %v", err)
+	}
+
+	// The in-flight turn completed; no nudge started another
+	if slow.RemainingSteps() != 1 {
+		t.Errorf("expected exactly one provider call, %d steps left", slow.RemainingSteps())
+	}
+	memories, _ := cmd.Store().GetRecentMemories(m.ID(), 10)
+	var replied bool
+	for _, mem := range memories {
+		replied = replied || (mem.Role == store.MemoryRoleAssistant && mem.Content == "first")
+	}
+	if !replied {
+		t.Error("expected the in-flight turn's reply to be stored")
+	}
+	if m.State() != MysisStateStopped {
+		t.Errorf("expected the drained mysis stopped, got %s", m.State())
+	}
+
+	if !cmd.Draining() {
+		t.Error("expected Draining() after Drain")
+	}
+	if err := bystander.QueueBroadcast("Regroup at Sol", ""); !errors.Is(err, ErrDraining) {
+		t.Errorf("expected ErrDraining for a broadcast during drain, got %v", err)
+	}
+}
+
+func TestTurnCountPersists(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("turn-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	storedTurns := func() int {
+		t.Helper()
+		sm, err := cmd.Store().GetMysis(m.ID())
+		if err != nil {
+			t.Fatalf("GetMysis() error: %v", err)
+		}
+		return sm.TurnCount
+	}
+
+	for i := 0; i < constants.TurnCountPersistEvery-1; i++ {
+		m.completeTurn()
+	}
+	if got := storedTurns(); got != 0 {
+		t.Errorf("expected no write before %d turns, got %d stored", constants.TurnCountPersistEvery, got)
+	}
+	m.completeTurn()
+	if got := storedTurns(); got != constants.TurnCountPersistEvery {
+		t.Errorf("expected %d stored turns, got %d", constants.TurnCountPersistEvery, got)
+	}
+
+	// Stopping writes the remainder (a running mysis may take more turns first)
+	m.completeTurn()
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	if err := m.Stop(); err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+	want := m.TurnCount()
+	if want <= constants.TurnCountPersistEvery {
+		t.Fatalf("expected more than %d turns, got %d", constants.TurnCountPersistEvery, want)
+	}
+	if got := storedTurns(); got != want {
+		t.Errorf("expected %d stored turns after stop, got %d", want, got)
+	}
+
+	reloaded := NewCommander(cmd.Store(), cmd.registry, cmd.bus, cmd.config, "")
+	if err := reloaded.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	rm, err := reloaded.GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if rm.TurnCount() != want {
+		t.Errorf("expected reloaded turn count %d, got %d", want, rm.TurnCount())
+	}
+}
+
+func TestProviderLatencies(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("timed", "mock")
+	ctx := context.Background()
+	messages := []provider.Message{{Role: "user", Content: "Report in."}}
+
+	p := provider.NewMock("timed-provider", "ok").SetDelay(20 * time.Millisecond)
+	if _, err := m.chat(ctx, p, messages, nil); err != nil {
+		t.Fatalf("chat() error: %v", err)
+	}
+	// Failed calls aren't counted
+	failing := provider.NewMock("timed-provider", "").WithChatError(errors.New("boom"))
+	if _, err := m.chat(ctx, failing, messages, nil); err == nil {
+		t.Fatal("expected chat error")
+	}
+
+	latency, ok := cmd.ProviderLatency("timed-provider")
+	if !ok || latency.Count != 1 || latency.P50 < 20*time.Millisecond {
+		t.Errorf("unexpected latency: %+v (ok=%v)", latency, ok)
+	}
+	if all := cmd.ProviderLatencies(); len(all) != 1 || all[0].Provider != "timed-provider" {
+		t.Errorf("unexpected latencies: %+v", all)
+	}
+
+	cmd.ResetProviderLatencies()
+	if _, ok := cmd.ProviderLatency("timed-provider"); ok {
+		t.Error("expected reset to drop observed latencies")
+	}
+}
+
+// limitedMock is a mock provider whose requests wait on a max_concurrent limit, like
+// the real providers.
+type limitedMock struct {
+	*provider.MockProvider
+	limit *provider.ConcurrencyLimit
+}
+
+func (p limitedMock) ConcurrencyLimit() *provider.ConcurrencyLimit { return p.limit }
+
+func (p limitedMock) Chat(ctx context.Context, messages []provider.Message) (string, error) {
+	release, err := p.limit.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return p.MockProvider.Chat(ctx, messages)
+}
+
+func TestChatWaitsForProviderSlotBeforeTurnSlot(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.turnSlots = newTurnSlots(1)
+
+	m, _ := cmd.CreateMysis("queued", "mock")
+	messages := []provider.Message{{Role: "user", Content: "Report in."}}
+
+	// The busy provider's only slot is taken, so its call queues
+	limit := provider.NewConcurrencyLimit(1)
+	hold, _ := limit.Acquire(context.Background())
+	busy := limitedMock{provider.NewMock("busy", "ok"), limit}
+	done := make(chan error, 1)
+	go func() {
+		_, err := m.chat(context.Background(), busy, messages, nil)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// A call to another provider still gets the one swarm slot
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := m.chat(ctx, provider.NewMock("other", "ok"), messages, nil); err != nil {
+		t.Fatalf("expected the other provider to get a turn slot, got %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	hold()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("chat() error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued call did not finish after its provider slot freed up")
+	}
+
+	// The provider queue wait isn't counted as latency
+	if latency, ok := cmd.ProviderLatency("busy"); !ok || latency.P50 >= 100*time.Millisecond {
+		t.Errorf("expected latency without the queue wait, got %+v (ok=%v)", latency, ok)
+	}
+}
+
+func TestCommanderSetGoal(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("goal-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	// A stored prompt is rebuilt with the goal
+	if err := cmd.Store().AddMemory(m.ID(), store.MemoryRoleSystem, store.MemorySourceSystem, m.buildSystemPrompt(), "", ""); err != nil {
+		t.Fatalf("AddMemory() error: %v", err)
+	}
+
+	if err := cmd.SetGoal(m.ID(), "  Control sector 7 "); err != nil {
+		t.Fatalf("SetGoal() error: %v", err)
+	}
+	if m.Goal() != "Control sector 7" {
+		t.Errorf("expected trimmed runtime goal, got %q", m.Goal())
+	}
+	system, err := cmd.Store().GetSystemMemory(m.ID())
+	if err != nil || !strings.Contains(system.Content, "Control sector 7") {
+		t.Errorf("expected stored system prompt rebuilt with the goal, got %v", err)
+	}
+
+	// Reloading from the store restores the goal
+	reloaded := NewCommander(cmd.Store(), cmd.registry, cmd.bus, cmd.config, "")
+	if err := reloaded.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	rm, err := reloaded.GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if rm.Goal() != "Control sector 7" {
+		t.Errorf("expected reloaded mysis to keep its goal, got %q", rm.Goal())
+	}
+
+	if err := cmd.SetGoal(m.ID(), ""); err != nil {
+		t.Fatalf("SetGoal() error: %v", err)
+	}
+	if system, _ := cmd.Store().GetSystemMemory(m.ID()); strings.Contains(system.Content, "STANDING GOAL") {
+		t.Error("expected cleared goal removed from the stored prompt")
+	}
+	if err := cmd.SetGoal("missing", "x"); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderGetLatestSnapshot(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("scout", "mock")
+	addCall := func(id, name, result string) {
+		t.Helper()
+		call := constants.ToolCallStoragePrefix + id + constants.ToolCallStorageFieldDelimiter + name + constants.ToolCallStorageFieldDelimiter + "{}"
+		cmd.Store().AddMemory(m.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, call, "", "")
+		cmd.Store().AddMemory(m.ID(), store.MemoryRoleTool, store.MemorySourceTool, id+constants.ToolCallStorageFieldDelimiter+result, "", "")
+	}
+	addCall("call_1", "get_status", `{"credits": 100}`)
+	addCall("call_2", "get_status", `{"credits": 250}`)
+	addCall("call_3", "get_status", "Error calling get_status: not logged in")
+	addCall("call_4", "get_cargo", "hold empty")
+
+	// The newest successful result wins
+	snapshot, err := cmd.GetLatestSnapshot(m.ID(), "get_status")
+	if err != nil || snapshot == nil {
+		t.Fatalf("GetLatestSnapshot() = %v, %v", snapshot, err)
+	}
+	if string(snapshot.Payload) != `{"credits": 250}` || snapshot.MysisName != "scout" {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+
+	// Non-JSON results come back as a JSON string
+	if snapshot, _ := cmd.GetLatestSnapshot(m.ID(), "get_cargo"); snapshot == nil || string(snapshot.Payload) != `"hold empty"` {
+		t.Errorf("expected cargo text as a JSON string, got %+v", snapshot)
+	}
+
+	if snapshot, err := cmd.GetLatestSnapshot(m.ID(), "get_map"); snapshot != nil || err != nil {
+		t.Errorf("expected no map snapshot, got %+v, %v", snapshot, err)
+	}
+	if _, err := cmd.GetLatestSnapshot(m.ID(), "mine"); err == nil {
+		t.Error("expected error for a non-snapshot tool")
+	}
+	if _, err := cmd.GetLatestSnapshot("missing", "get_status"); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestMysisProviderWarmUp(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("warm-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	mock := provider.NewMock("mock", "ok")
+	m.SetProvider(mock)
+
+	// Disabled by default
+	m.warmUpProvider(context.Background())
+	if mock.PingCount() != 0 {
+		t.Fatalf("expected no ping without warm_up, got %d", mock.PingCount())
+	}
+
+	provCfg := cmd.config.Providers["mock"]
+	provCfg.WarmUp = true
+	cmd.config.Providers["mock"] = provCfg
+
+	m.warmUpProvider(context.Background())
+	if mock.PingCount() != 1 {
+		t.Errorf("expected 1 ping with warm_up, got %d", mock.PingCount())
+	}
+
+	// A failed ping must not error the mysis
+	mock.WithPingError(errors.New("model loading"))
+	m.warmUpProvider(context.Background())
+	if m.State() != MysisStateIdle {
+		t.Errorf("expected mysis to stay idle after failed warm-up, got %s", m.State())
+	}
+}
+
+// healthFactory creates mock providers with a configurable health check.
+type healthFactory struct {
+	name    string
+	pingErr error
+	models  []string // Non-nil: providers also list models
+	listErr error
+}
+
+func (f *healthFactory) Name() string { return f.name }
+
+func (f *healthFactory) Create(model string, temperature float64) provider.Provider {
+	mock := provider.NewMock(f.name, "ok").WithPingError(f.pingErr)
+	if f.models == nil && f.listErr == nil {
+		return mock
+	}
+	return &modelListingMock{MockProvider: mock, models: f.models, err: f.listErr}
+}
+
+type modelListingMock struct {
+	*provider.MockProvider
+	models []string
+	err    error
+}
+
+func (m *modelListingMock) ListModels(ctx context.Context) ([]string, error) {
+	return m.models, m.err
+}
+
+func TestCommanderVerifyProviderOnCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		verify  bool
+		factory *healthFactory
+		wantErr string
+	}{
+		{"disabled skips check", false, &healthFactory{pingErr: errors.New("connection refused")}, ""},
+		{"ping fails", true, &healthFactory{pingErr: errors.New("connection refused")}, "unreachable at http://ollama"},
+		{"ping ok", true, &healthFactory{}, ""},
+		{"list fails", true, &healthFactory{listErr: errors.New("connection refused")}, "unreachable at http://ollama"},
+		{"model served as latest", true, &healthFactory{models: []string{"llama3:latest"}}, ""},
+		{"model missing", true, &healthFactory{models: []string{"qwen3:8b", "mistral"}}, `does not serve model "llama3" - set providers.ollama.model to one of: mistral, qwen3:8b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, _, cleanup := setupCommanderTest(t)
+			defer cleanup()
+
+			tt.factory.name = "ollama"
+			cmd.registry.RegisterFactory("ollama", tt.factory)
+			cmd.config.Swarm.VerifyProviderOnCreate = tt.verify
+
+			m, err := cmd.CreateMysis("checked", "ollama")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("CreateMysis() error: %v", err)
+				}
+				if m == nil {
+					t.Fatal("expected mysis")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if cmd.MysisCount() != 0 {
+				t.Errorf("expected no mysis created, got %d", cmd.MysisCount())
+			}
+			if stored, _ := cmd.store.ListMyses(); len(stored) != 0 {
+				t.Errorf("expected nothing stored, got %d myses", len(stored))
+			}
+		})
+	}
+}
+
+func TestCommanderModelChoices(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	// ollama serves its configured model (tagged) and one more; mock can't list
+	cmd.registry.RegisterFactory("ollama", &healthFactory{name: "ollama", models: []string{"qwen3:8b", "llama3:latest"}})
+	cmd.registry.RegisterFactory("unconfigured", provider.NewMockFactory("unconfigured", "ok"))
+
+	got := cmd.ModelChoices(context.Background())
+	want := []ModelChoice{
+		{Provider: "mock", Model: "mock-model"},
+		{Provider: "ollama", Model: "llama3"},
+		{Provider: "ollama", Model: "qwen3:8b"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ModelChoices() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCommanderSystemPromptPreview(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("preview", "mock")
+
+	// No commander broadcast yet: the fallback section, nothing stored
+	preview, err := cmd.SystemPromptPreview(m.ID())
+	if err != nil {
+		t.Fatalf("SystemPromptPreview() error: %v", err)
+	}
+	if preview.Broadcast != "" || !strings.Contains(preview.Prompt, constants.BroadcastFallback) {
+		t.Errorf("expected fallback section, got broadcast %q", preview.Broadcast)
+	}
+	if preview.Stored != "" || preview.Stale() {
+		t.Errorf("expected no stored prompt, got %q", preview.Stored)
+	}
+
+	cmd.Store().ReplaceSystemMemory(m.ID(), preview.Prompt)
+	cmd.Store().AddMemory(m.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, "Hold at Sol", "", "")
+
+	preview, err = cmd.SystemPromptPreview(m.ID())
+	if err != nil {
+		t.Fatalf("SystemPromptPreview() error: %v", err)
+	}
+	if preview.Broadcast != "Hold at Sol" || !strings.Contains(preview.Prompt, fmt.Sprintf(constants.BroadcastSectionTemplate, CommanderName, "Hold at Sol")) {
+		t.Errorf("expected broadcast section, got broadcast %q", preview.Broadcast)
+	}
+	if !preview.Stale() {
+		t.Error("expected stored prompt to be stale after a new broadcast")
+	}
+
+	if _, err := cmd.SystemPromptPreview("missing"); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderCreateMysisFromTemplate(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	template := []*store.Memory{
+		{Role: store.MemoryRoleUser, Source: store.MemorySourceDirect, Content: "Check your status."},
+		{Role: store.MemoryRoleAssistant, Source: store.MemorySourceLLM, Content: constants.ToolCallStoragePrefix + `call_1:get_status:{}`},
+		{Role: store.MemoryRoleTool, Source: store.MemorySourceTool, Content: `call_1:{"credits":100}`},
+		{Role: store.MemoryRoleAssistant, Source: store.MemorySourceLLM, Content: "I have 100 credits.", Reasoning: "status first"},
+	}
+	if err := cmd.Store().SaveTemplate("onboarding", template); err != nil {
+		t.Fatalf("SaveTemplate() error: %v", err)
+	}
+
+	m, err := cmd.CreateMysisFromTemplate("seeded", "mock", "onboarding")
+	if err != nil {
+		t.Fatalf("CreateMysisFromTemplate() error: %v", err)
+	}
+	memories, _ := cmd.Store().GetMemories(m.ID())
+	if len(memories) != len(template) {
+		t.Fatalf("expected %d seeded memories, got %d", len(template), len(memories))
+	}
+	for i, mem := range memories {
+		if mem.Role != template[i].Role || mem.Content != template[i].Content || mem.Reasoning != template[i].Reasoning {
+			t.Errorf("memory %d = %+v, want %+v", i, mem, template[i])
+		}
+	}
+
+	// The tool call pair survives orphan removal
+	context, _, err := m.getContextMemories()
+	if err != nil {
+		t.Fatalf("getContextMemories() error: %v", err)
+	}
+	if len(context) != len(template) {
+		t.Errorf("expected the whole template in context, got %d memories", len(context))
+	}
+
+	// Unpaired tool calls are rejected before a mysis is created
+	cmd.Store().SaveTemplate("broken", template[:2])
+	if _, err := cmd.CreateMysisFromTemplate("broken", "mock", "broken"); err == nil || !strings.Contains(err.Error(), "call_1 has no result") {
+		t.Errorf("expected unpaired tool call error, got %v", err)
+	}
+	if _, err := cmd.CreateMysisFromTemplate("missing", "mock", "missing"); err == nil {
+		t.Error("expected error for unknown template")
+	}
+	if count := cmd.MysisCount(); count != 1 {
+		t.Errorf("expected only the seeded mysis, got %d", count)
+	}
+}
+
+func TestCommanderRegenerateLast(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("regen", "mock")
+	s := cmd.Store()
+	s.AddMemory(m.ID(), store.MemoryRoleSystem, store.MemorySourceSystem, "System prompt", "", "")
+	s.AddMemory(m.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "Check your status.", "", "")
+	s.AddMemory(m.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, constants.ToolCallStoragePrefix+`call_1:get_status:{}`, "", "")
+	s.AddMemory(m.ID(), store.MemoryRoleTool, store.MemorySourceTool, `call_1:{"credits":100}`, "", "")
+	m.AddNote("Credits matter")
+	s.AddMemory(m.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "old reply", "", "")
+
+	m.turnMu.Lock()
+	if err := cmd.RegenerateLast(m.ID()); !errors.Is(err, ErrTurnInProgress) {
+		t.Errorf("expected ErrTurnInProgress mid-turn, got %v", err)
+	}
+	m.turnMu.Unlock()
+
+	events := bus.Subscribe()
+	if err := cmd.RegenerateLast(m.ID()); err != nil {
+		t.Fatalf("RegenerateLast() error: %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for response := false; !response; {
+		select {
+		case e := <-events:
+			response = e.Type == EventMysisResponse && e.MysisID == m.ID()
+		case <-timeout:
+			t.Fatal("timeout waiting for the regenerated response")
+		}
+	}
+	m.Stop()
+
+	var contents []string
+	memories, _ := s.GetMemories(m.ID())
+	for _, mem := range memories {
+		if mem.Source != store.MemorySourceSystem {
+			contents = append(contents, mem.Content)
+		}
+	}
+	// The whole tool loop goes with the old reply; the prompt and note stay
+	want := "Check your status.|Credits matter|mock response"
+	if got := strings.Join(contents, "|"); got != want {
+		t.Errorf("memories after regenerate = %q, want %q", got, want)
+	}
+
+	if err := cmd.RegenerateLast(m.ID()); err == nil {
+		t.Error("expected error regenerating a stopped mysis")
+	}
+
+	fresh, _ := cmd.CreateMysis("fresh", "mock")
+	s.AddMemory(fresh.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "Hello?", "", "")
+	if err := cmd.RegenerateLast(fresh.ID()); err == nil || !strings.Contains(err.Error(), "no response") {
+		t.Errorf("expected no response error, got %v", err)
+	}
+}
+
+func TestGetContextMemories_PeerBroadcasts(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	alpha, _ := cmd.CreateMysis("alpha", "mock")
+	beta, _ := cmd.CreateMysis("beta", "mock")
+	s := cmd.Store()
+
+	s.AddMemory(alpha.ID(), store.MemoryRoleSystem, store.MemorySourceSystem, "System prompt", "", "")
+	for _, content := range []string{"ore at Sol", "pirates at Vega", "selling at Rigel"} {
+		s.AddMemory(alpha.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, content, "", beta.ID())
+	}
+	s.AddMemory(alpha.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, "my own news", "", alpha.ID())
+	s.AddMemory(alpha.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, "commander orders", "", "")
+	s.AddMemory(alpha.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "Go mine", "", "")
+
+	peerContents := func() []string {
+		t.Helper()
+		memories, addedSynthetic, err := alpha.getContextMemories()
+		if err != nil {
+			t.Fatalf("getContextMemories() error: %v", err)
+		}
+		if addedSynthetic || memories[len(memories)-1].Content != "Go mine" {
+			t.Errorf("expected the direct message to stay the turn's prompt")
+		}
+		var peers []string
+		for _, mem := range memories {
+			if mem.Role == store.MemoryRoleSystem && mem.Source == store.MemorySourceBroadcast {
+				peers = append(peers, mem.Content)
+			}
+		}
+		return peers
+	}
+
+	if peers := peerContents(); len(peers) != 0 {
+		t.Errorf("expected no peer broadcasts when disabled, got %q", peers)
+	}
+
+	cmd.config.Swarm.PeerBroadcastsInContext = 2
+	want := []string{
+		fmt.Sprintf(constants.PeerBroadcastContextFormat, "beta", "pirates at Vega"),
+		fmt.Sprintf(constants.PeerBroadcastContextFormat, "beta", "selling at Rigel"),
+	}
+	if peers := peerContents(); strings.Join(peers, "|") != strings.Join(want, "|") {
+		t.Errorf("peer broadcasts = %q, want %q", peers, want)
+	}
+
+	// A peer broadcast that is the turn's prompt is not repeated
+	s.AddMemory(alpha.ID(), store.MemoryRoleUser, store.MemorySourceBroadcast, "regroup", "", beta.ID())
+	memories, _, _ := alpha.getContextMemories()
+	count := 0
+	for _, mem := range memories {
+		if strings.Contains(mem.Content, "regroup") {
+			count++
+		}
+	}
+	if count != 1 || memories[len(memories)-1].Role != store.MemoryRoleUser {
+		t.Errorf("expected the peer prompt once, as the last user message, got %d", count)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	call := func(ids string) *store.Memory {
+		return &store.Memory{Role: store.MemoryRoleAssistant, Content: constants.ToolCallStoragePrefix + ids}
+	}
+	result := func(id string) *store.Memory {
+		return &store.Memory{Role: store.MemoryRoleTool, Content: id + ":ok"}
+	}
+	user := &store.Memory{Role: store.MemoryRoleUser, Content: "hi"}
+
+	tests := []struct {
+		name     string
+		memories []*store.Memory
+		wantErr  string
+	}{
+		{"paired calls", []*store.Memory{user, call("a:mine:{}|b:sell:{}"), result("b"), result("a")}, ""},
+		{"result after another message", []*store.Memory{call("a:mine:{}"), user, result("a")}, "has no result before the next message"},
+		{"result without call", []*store.Memory{user, result("a")}, "has no matching tool call"},
+		{"duplicate call id", []*store.Memory{call("a:mine:{}"), result("a"), call("a:mine:{}"), result("a")}, "duplicate tool call id"},
+		{"system prompt", []*store.Memory{{Role: store.MemoryRoleSystem, Source: store.MemorySourceSystem, Content: "prompt"}}, "system prompt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTemplate(tt.memories)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCommanderSetContextWindow(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("window-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	if err := cmd.SetContextWindow(m.ID(), 300); err == nil {
+		t.Error("expected error for out-of-range window")
+	}
+
+	if err := cmd.SetContextWindow(m.ID(), 50); err != nil {
+		t.Fatalf("SetContextWindow() error: %v", err)
+	}
+	if m.ContextWindow() != 50 {
+		t.Errorf("expected runtime window 50, got %d", m.ContextWindow())
+	}
+
+	reloaded := NewCommander(cmd.Store(), cmd.registry, cmd.bus, cmd.config, "")
+	if err := reloaded.LoadMyses(); err != nil {
+		t.Fatalf("LoadMyses() error: %v", err)
+	}
+	rm, err := reloaded.GetMysis(m.ID())
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if rm.ContextWindow() != 50 {
+		t.Errorf("expected reloaded window 50, got %d", rm.ContextWindow())
+	}
+}
+
+func TestCommanderGetRecentReasoning(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("reasoning-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	peer, err := cmd.CreateMysis("peer-mysis", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	cmd.Store().AddMemory(m.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "mine", "ore is cheap", "")
+	cmd.Store().AddMemory(peer.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "sell", "peer secret", "")
+
+	memories, err := cmd.GetRecentReasoning(m.ID(), 10)
+	if err != nil {
+		t.Fatalf("GetRecentReasoning() error: %v", err)
+	}
+	if len(memories) != 1 || memories[0].Reasoning != "ore is cheap" {
+		t.Errorf("unexpected reasoning: %+v", memories)
+	}
+
+	if _, err := cmd.GetRecentReasoning("missing", 10); err == nil {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestCommanderOnInterval(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	var calls atomic.Int32
+	cmd.OnInterval(10*time.Millisecond, func(*Commander) { calls.Add(1) })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if calls.Load() < 2 {
+		t.Fatalf("expected interval callback to run repeatedly, got %d calls", calls.Load())
+	}
+
+	cmd.StopAll()
+	stopped := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	if calls.Load() != stopped {
+		t.Errorf("expected no callbacks after StopAll, got %d more", calls.Load()-stopped)
+	}
+
+	// Intervals registered after StopAll never run
+	cmd.OnInterval(time.Millisecond, func(*Commander) { t.Error("callback ran after StopAll") })
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestCoordinatorBroadcastsSummary(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	cmd.config.Coordinator = config.CoordinatorConfig{
+		Enabled:  true,
+		Interval: time.Hour,
+		Mysis:    "coord",
+		Template: "{{.Running}} of {{.Total}} running",
+	}
+
+	coord, _ := cmd.CreateMysis("coord", "mock")
+	worker, _ := cmd.CreateMysis("worker", "mock")
+
+	if err := cmd.StartCoordinator(); err != nil {
+		t.Fatalf("StartCoordinator() error: %v", err)
+	}
+	if err := cmd.coordinate(); err == nil {
+		t.Error("expected no summary while no mysis is running")
+	}
+
+	cmd.StartMysis(coord.ID())
+	cmd.StartMysis(worker.ID())
+
+	if err := cmd.coordinate(); err != nil {
+		t.Fatalf("coordinate() error: %v", err)
+	}
+	if err := cmd.coordinate(); err == nil {
+		t.Error("expected unchanged summary to be skipped")
+	}
+
+	want := constants.CoordinatorSummaryPrefix + "2 of 2 running"
+	countSummaries := func(mysisID string) int {
+		memories, err := cmd.store.GetMemories(mysisID)
+		if err != nil {
+			t.Fatalf("GetMemories() error: %v", err)
+		}
+		n := 0
+		for _, mem := range memories {
+			if mem.Source == store.MemorySourceBroadcast && mem.Content == want {
+				n++
+				if mem.SenderID != coord.ID() {
+					t.Errorf("expected summary from coordinator mysis, got sender %q", mem.SenderID)
+				}
+			}
+		}
+		return n
+	}
+	if n := countSummaries(worker.ID()); n != 1 {
+		t.Errorf("expected worker to receive 1 summary, got %d", n)
+	}
+	if n := countSummaries(coord.ID()); n != 0 {
+		t.Errorf("expected coordinator mysis to be excluded, got %d summaries", n)
+	}
+}
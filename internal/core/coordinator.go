@@ -0,0 +1,152 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+// CoordinatorSummary is the data available to the coordinator summary template.
+type CoordinatorSummary struct {
+	Total       int
+	MaxMyses    int
+	Running     int
+	Idle        int
+	Stopped     int
+	Errored     int
+	Quarantined int
+	Tick        int64
+	TotalTokens int
+	Cost        float64
+}
+
+// coordinator periodically broadcasts a swarm summary.
+type coordinator struct {
+	tmpl      *template.Template
+	mysisName string // Sender mysis (excluded from recipients); empty = commander broadcast
+
+	mu          sync.Mutex
+	lastSummary string
+}
+
+// StartCoordinator schedules swarm summary broadcasts if [coordinator] is enabled.
+// Summaries are marked with constants.CoordinatorSummaryPrefix. They are skipped when no
+// mysis is running or nothing changed, so the coordinator never wakes an idle swarm and
+// its own broadcasts can't keep the swarm busy in a loop. A sender mysis never receives
+// its own summary.
+func (c *Commander) StartCoordinator() error {
+	if c.config == nil || !c.config.Coordinator.Enabled {
+		return nil
+	}
+	cfg := c.config.Coordinator
+
+	text := cfg.Template
+	if text == "" {
+		text = constants.DefaultCoordinatorTemplate
+	}
+	tmpl, err := template.New("coordinator").Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse coordinator template: %w", err)
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = constants.DefaultCoordinatorInterval
+	}
+
+	c.mu.Lock()
+	c.coordinator = &coordinator{tmpl: tmpl, mysisName: cfg.Mysis}
+	c.mu.Unlock()
+
+	c.OnInterval(interval, func(c *Commander) {
+		if err := c.coordinate(); err != nil {
+			log.Debug().Err(err).Msg("Coordinator summary not sent")
+		}
+	})
+	log.Info().Dur("interval", interval).Str("mysis", cfg.Mysis).Msg("Coordinator started")
+	return nil
+}
+
+// coordinate renders the swarm summary and broadcasts it if it changed.
+func (c *Commander) coordinate() error {
+	c.mu.RLock()
+	coord := c.coordinator
+	c.mu.RUnlock()
+	if coord == nil {
+		return fmt.Errorf("coordinator not started")
+	}
+
+	data := c.coordinatorSummary()
+	if data.Running == 0 {
+		return fmt.Errorf("no running myses")
+	}
+
+	summary, err := coord.render(data)
+	if err != nil {
+		return err
+	}
+
+	coord.mu.Lock()
+	defer coord.mu.Unlock()
+	if summary == coord.lastSummary {
+		return fmt.Errorf("swarm unchanged since last summary")
+	}
+
+	if coord.mysisName != "" {
+		sender := c.findMysisByName(coord.mysisName)
+		if sender == nil {
+			return fmt.Errorf("coordinator mysis %q not found", coord.mysisName)
+		}
+		err = c.BroadcastFrom(sender.ID(), summary)
+	} else {
+		err = c.Broadcast(summary)
+	}
+	if err != nil {
+		return err
+	}
+
+	coord.lastSummary = summary
+	return nil
+}
+
+// coordinatorSummary collects the current swarm counters for the summary template.
+func (c *Commander) coordinatorSummary() CoordinatorSummary {
+	stats := c.Stats()
+	return CoordinatorSummary{
+		Total:       stats.MysisCount,
+		MaxMyses:    stats.MaxMyses,
+		Running:     stats.StateCounts[string(MysisStateRunning)],
+		Idle:        stats.StateCounts[string(MysisStateIdle)],
+		Stopped:     stats.StateCounts[string(MysisStateStopped)],
+		Errored:     stats.StateCounts[string(MysisStateErrored)],
+		Quarantined: stats.StateCounts[string(MysisStateQuarantined)],
+		Tick:        c.AggregateTick(),
+		TotalTokens: stats.Usage.TotalTokens(),
+		Cost:        stats.Usage.Cost,
+	}
+}
+
+// render executes the summary template and marks the result as a coordinator summary.
+func (coord *coordinator) render(summary CoordinatorSummary) (string, error) {
+	var buf bytes.Buffer
+	if err := coord.tmpl.Execute(&buf, summary); err != nil {
+		return "", fmt.Errorf("render coordinator summary: %w", err)
+	}
+	return constants.CoordinatorSummaryPrefix + strings.TrimSpace(buf.String()), nil
+}
+
+func (c *Commander) findMysisByName(name string) *Mysis {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, m := range c.myses {
+		if m.Name() == name {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// DriftCategory is one kind of drift away from how the game works. When a mysis's
+// recent replies mention any keyword, its reminder is sent on the next autonomous turn.
+type DriftCategory struct {
+	Name     string
+	Keywords []string
+	Reminder string
+
+	pattern *regexp.Regexp // Keywords as whole-word, case-insensitive alternatives
+}
+
+// NewDriftCategory compiles a drift category. Keywords match whole words (or phrases),
+// case-insensitively, so "hour" does not match "hourglass".
+func NewDriftCategory(name string, keywords []string, reminder string) DriftCategory {
+	quoted := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			quoted = append(quoted, regexp.QuoteMeta(keyword))
+		}
+	}
+	category := DriftCategory{Name: name, Keywords: keywords, Reminder: reminder}
+	if len(quoted) > 0 {
+		category.pattern = regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return category
+}
+
+// Matches reports whether text mentions any of the category's keywords.
+func (c DriftCategory) Matches(text string) bool {
+	return c.pattern != nil && c.pattern.MatchString(text)
+}
+
+// DefaultDriftCategories returns the categories used when [drift] configures none:
+// real-world time references, which don't apply to a game that advances in ticks.
+func DefaultDriftCategories() []DriftCategory {
+	return []DriftCategory{
+		NewDriftCategory(constants.DriftCategoryRealTime, constants.DefaultRealTimeDriftKeywords, constants.DefaultRealTimeDriftReminder),
+	}
+}
+
+// DriftCategoriesFromConfig returns the [drift] categories, or the defaults if none are set.
+func DriftCategoriesFromConfig(cfg *config.Config) []DriftCategory {
+	if cfg == nil || len(cfg.Drift.Categories) == 0 {
+		return DefaultDriftCategories()
+	}
+	categories := make([]DriftCategory, len(cfg.Drift.Categories))
+	for i, c := range cfg.Drift.Categories {
+		categories[i] = NewDriftCategory(c.Name, c.Keywords, c.Reminder)
+	}
+	return categories
+}
+
+// SetDriftCategories replaces the drift categories checked before autonomous turns.
+// nil restores the defaults; an empty slice disables drift reminders.
+func (m *Mysis) SetDriftCategories(categories []DriftCategory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.driftCategories = categories
+}
+
+// DriftCategories returns the drift categories checked before autonomous turns.
+func (m *Mysis) DriftCategories() []DriftCategory {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.driftCategories == nil {
+		return DefaultDriftCategories()
+	}
+	return m.driftCategories
+}
+
+// detectDriftReminders returns the reminder of every category whose keywords appear in
+// the mysis's own replies among memories, in category order. Replies to synthetic
+// nudges count too, since they are most of an autonomous mysis's replies.
+func (m *Mysis) detectDriftReminders(memories []*store.Memory) []string {
+	var reminders []string
+	for _, category := range m.DriftCategories() {
+		for _, mem := range memories {
+			if mem.Role != store.MemoryRoleAssistant ||
+				(mem.Source != store.MemorySourceLLM && mem.Source != store.MemorySourceNudgeResponse) ||
+				strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix) {
+				continue
+			}
+			if category.Matches(mem.Content) {
+				reminders = append(reminders, category.Reminder)
+				break
+			}
+		}
+	}
+	return reminders
+}
+
+// recentDriftReminders checks the last constants.ContinuePromptDriftLookback memories
+// for drift.
+func (m *Mysis) recentDriftReminders() []string {
+	memories, err := m.store.GetRecentMemories(m.id, constants.ContinuePromptDriftLookback)
+	if err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to load memories for drift detection")
+		return nil
+	}
+	return m.detectDriftReminders(memories)
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+func reply(content string) *store.Memory {
+	return &store.Memory{Role: store.MemoryRoleAssistant, Source: store.MemorySourceLLM, Content: content}
+}
+
+func TestDetectDriftRemindersCustomCategories(t *testing.T) {
+	m := &Mysis{}
+	m.SetDriftCategories([]DriftCategory{
+		NewDriftCategory("docking", []string{"dock", "undock"}, "Check your docking status first."),
+		NewDriftCategory("fuel", []string{"out of fuel"}, "Refuel before travelling."),
+		NewDriftCategory("combat", []string{"attack"}, "Avoid combat."),
+	})
+
+	tests := []struct {
+		name     string
+		memories []*store.Memory
+		want     []string
+	}{
+		{
+			name:     "several categories in category order",
+			memories: []*store.Memory{reply("We are OUT OF FUEL."), reply("I'll dock at the station.")},
+			want:     []string{"Check your docking status first.", "Refuel before travelling."},
+		},
+		{
+			name:     "whole words only",
+			memories: []*store.Memory{reply("The docking bay is busy"), reply("counterattack!")},
+		},
+		{
+			name: "ignores tool calls and other roles",
+			memories: []*store.Memory{
+				reply(constants.ToolCallStoragePrefix + `call_1:attack:{}`),
+				{Role: store.MemoryRoleUser, Source: store.MemorySourceDirect, Content: "attack them"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.detectDriftReminders(tt.memories)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("detectDriftReminders() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftCategoriesDefaults(t *testing.T) {
+	m := &Mysis{}
+	got := m.detectDriftReminders([]*store.Memory{reply("I'll check back in 10 minutes.")})
+	if len(got) != 1 || got[0] != constants.DefaultRealTimeDriftReminder {
+		t.Errorf("expected the real_time reminder, got %q", got)
+	}
+
+	cfg := &config.Config{Drift: config.DriftConfig{Categories: []config.DriftCategoryConfig{
+		{Name: "fuel", Keywords: []string{"fuel"}, Reminder: "Refuel."},
+	}}}
+	categories := DriftCategoriesFromConfig(cfg)
+	if len(categories) != 1 || categories[0].Name != "fuel" {
+		t.Errorf("expected configured categories to replace the defaults, got %+v", categories)
+	}
+
+	// An empty list disables drift reminders
+	m.SetDriftCategories([]DriftCategory{})
+	if got := m.detectDriftReminders([]*store.Memory{reply("in 10 minutes")}); len(got) != 0 {
+		t.Errorf("expected no reminders, got %q", got)
+	}
+}
+
+func TestAutonomousTurnSendsDriftReminder(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("drifter", "mock", "test-model", 0.7)
+	if err := s.AddMemory(stored.ID, store.MemoryRoleAssistant, store.MemorySourceLLM, "Let's wait a few hours for prices to drop.", "", ""); err != nil {
+		t.Fatalf("AddMemory() error: %v", err)
+	}
+
+	mock := provider.NewScriptedMock([]provider.MockStep{{Response: "Selling now."}})
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	mysis.state = MysisStateRunning
+
+	// No user message, so the turn is autonomous
+	if err := mysis.SendMessageFrom("", store.MemorySourceSystem, ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 provider request, got %d", len(requests))
+	}
+	found := false
+	for _, msg := range requests[0] {
+		if msg.Role == "system" && strings.Contains(msg.Content, constants.DefaultRealTimeDriftReminder) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected drift reminder in context, got %+v", requests[0])
+	}
+}
+
+func TestNudgedTurnDriftDetected(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("drifter", "mock", "test-model", 0.7)
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{Response: "Let's wait a few hours for prices to drop."},
+		{Response: "Selling now."},
+	})
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	mysis.state = MysisStateRunning
+
+	// Both turns are autonomous; the first reply is stored as a nudge response
+	for i := 0; i < 2; i++ {
+		if err := mysis.SendMessageFrom("", store.MemorySourceSystem, ""); err != nil {
+			t.Fatalf("SendMessageFrom() error: %v", err)
+		}
+	}
+
+	memories, _ := s.GetRecentMemories(stored.ID, 10)
+	var nudged bool
+	for _, mem := range memories {
+		nudged = nudged || (mem.Source == store.MemorySourceNudgeResponse && strings.Contains(mem.Content, "few hours"))
+	}
+	if !nudged {
+		t.Fatal("expected the first reply stored as a nudge response")
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 provider requests, got %d", len(requests))
+	}
+	found := false
+	for _, msg := range requests[1] {
+		if msg.Role == "system" && strings.Contains(msg.Content, constants.DefaultRealTimeDriftReminder) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected drift reminder after a nudged reply, got %+v", requests[1])
+	}
+}
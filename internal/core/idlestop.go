@@ -0,0 +1,73 @@
+package core
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// idleStopAfter returns how long a running mysis may go without a direct message or
+// broadcast before it is stopped (0 = never).
+func (m *Mysis) idleStopAfter() time.Duration {
+	if m.commander != nil && m.commander.config != nil {
+		return m.commander.config.Swarm.IdleStopAfter
+	}
+	return 0
+}
+
+// idleStopDue reports whether [swarm] idle_stop_after has passed since the mysis last
+// got a direct message or broadcast (or was started), and for how long it has been quiet.
+// Unlike the encouragement circuit breaker, which idles a mysis after a few nudges, this
+// also catches myses that keep taking turns on an old message or broadcast.
+func (m *Mysis) idleStopDue() (time.Duration, bool) {
+	limit := m.idleStopAfter()
+	if limit <= 0 {
+		return 0, false
+	}
+	m.mu.RLock()
+	quiet := m.now().Sub(m.lastMessageAt)
+	m.mu.RUnlock()
+	return quiet, quiet >= limit
+}
+
+// scheduleIdleStop stops an idle mysis once idle_stop_after has passed since its last
+// message. The nudge breaker idles a mysis after a few nudges, usually well before
+// then, and an idle mysis takes no turns that would check it.
+func (m *Mysis) scheduleIdleStop() {
+	limit := m.idleStopAfter()
+	if limit <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wait := max(limit-m.now().Sub(m.lastMessageAt), 0)
+	if m.idleStopTimer != nil {
+		m.idleStopTimer.Stop()
+	}
+	m.idleStopTimer = time.AfterFunc(wait, func() {
+		if m.State() != MysisStateIdle {
+			return
+		}
+		if quiet, ok := m.idleStopDue(); ok {
+			m.stopIdle(quiet)
+		} else {
+			// A message came in since; wait out the new quiet period
+			m.scheduleIdleStop()
+		}
+	})
+}
+
+// stopIdle stops a mysis that has been quiet for too long, whether it is still taking
+// turns or was idled by the nudge breaker. Called between turns, so Stop does not wait
+// on a turn of its own.
+func (m *Mysis) stopIdle(quiet time.Duration) {
+	log.Info().
+		Str("mysis", m.name).
+		Dur("quiet", quiet).
+		Dur("idle_stop_after", m.idleStopAfter()).
+		Msg("No messages within idle_stop_after - stopping mysis")
+	m.fileLogger().Info().Dur("quiet", quiet).Msg("Stopped after idle_stop_after")
+	if err := m.stop(true); err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to stop idle mysis")
+	}
+}
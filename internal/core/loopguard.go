@@ -0,0 +1,56 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// maxRepeatedToolCalls returns how many identical tool calls in a row end a turn.
+func (m *Mysis) maxRepeatedToolCalls() int {
+	if m.commander != nil && m.commander.config != nil && m.commander.config.Swarm.MaxRepeatedToolCalls > 0 {
+		return m.commander.config.Swarm.MaxRepeatedToolCalls
+	}
+	return constants.DefaultMaxRepeatedToolCalls
+}
+
+// toolCallsKey identifies a set of tool calls by name and arguments, ignoring call IDs.
+func toolCallsKey(calls []provider.ToolCall) string {
+	var b strings.Builder
+	for _, tc := range calls {
+		b.WriteString(tc.Name)
+		b.WriteByte(0)
+		b.Write(tc.Arguments)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// storeRepeatedToolResults answers repeated tool calls without running them, telling
+// the model it is repeating itself. Every call still gets a result so the stored
+// tool loop stays complete.
+func (m *Mysis) storeRepeatedToolResults(calls []provider.ToolCall, repeats int) error {
+	for _, tc := range calls {
+		result := &mcp.ToolResult{
+			Content: []mcp.ContentBlock{{Type: "text", Text: fmt.Sprintf(constants.RepeatedToolCallResult, tc.Name, repeats)}},
+			IsError: true,
+		}
+		if err := m.store.AddMemory(m.id, store.MemoryRoleTool, store.MemorySourceTool, m.formatToolResult(tc.ID, tc.Name, result, nil), "", ""); err != nil {
+			return err
+		}
+		m.bus.Publish(Event{
+			Type:      EventMysisMessage,
+			MysisID:   m.id,
+			MysisName: m.name,
+			Message:   &MessageData{Role: "tool", Content: fmt.Sprintf("[%s] %s", tc.Name, m.formatToolResultDisplay(result, nil))},
+			Timestamp: time.Now(),
+		})
+	}
+	m.fileLogger().Warn().Str("tool", calls[0].Name).Int("repeats", repeats).Msg("Repeated tool call")
+	return nil
+}
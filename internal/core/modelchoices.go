@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/provider"
+)
+
+// ModelChoice is a provider and model a mysis can be switched to.
+type ModelChoice struct {
+	Provider string
+	Model    string
+}
+
+// ModelChoices lists the models each configured provider serves, for picking a
+// SwitchProvider target. Providers are queried in parallel; one that can't list its
+// models, or doesn't answer in time, offers only its configured model. Choices are
+// sorted by provider, with each provider's configured model first.
+func (c *Commander) ModelChoices(ctx context.Context) []ModelChoice {
+	ctx, cancel := context.WithTimeout(ctx, constants.ProviderHealthCheckTimeout)
+	defer cancel()
+
+	names := c.registry.List()
+	sort.Strings(names)
+
+	lists := make([][]ModelChoice, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		provCfg, ok := c.config.Providers[name]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lists[i] = []ModelChoice{{Provider: name, Model: provCfg.Model}}
+
+			p, err := c.registry.Create(name, provCfg.Model, provCfg.Temperature)
+			if err != nil {
+				return
+			}
+			defer p.Close()
+			lister, ok := p.(provider.ModelLister)
+			if !ok {
+				return
+			}
+			models, err := lister.ListModels(ctx)
+			if err != nil {
+				return
+			}
+			sort.Strings(models)
+			for _, model := range models {
+				if !servesModel([]string{model}, provCfg.Model) {
+					lists[i] = append(lists[i], ModelChoice{Provider: name, Model: model})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return slices.Concat(lists...)
+}
@@ -9,9 +9,13 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/config"
 	"github.com/xonecas/zoea-nova/internal/constants"
 	"github.com/xonecas/zoea-nova/internal/gamestate"
 	"github.com/xonecas/zoea-nova/internal/mcp"
@@ -34,9 +38,11 @@ type Mysis struct {
 	mcpProxy    *mcp.Proxy  // Per-mysis MCP proxy wrapping the client
 	commander   *Commander  // Reference to parent commander for WaitGroup
 
-	state  MysisState
-	ctx    context.Context
-	cancel context.CancelFunc
+	state   MysisState
+	ctx     context.Context
+	cancel  context.CancelFunc
+	loopCtx context.Context // Context of the live run loop (nil = none); see Recover
+	resumed bool            // Recover handed the mysis back to the live run loop
 
 	// turnMu ensures only one turn runs at a time.
 	turnMu sync.Mutex
@@ -50,17 +56,46 @@ type Mysis struct {
 	lastServerTick         int64
 	lastServerTickAt       time.Time
 	tickDuration           time.Duration
-	encouragementCount     int // Counter for consecutive synthetic encouragements (limit: 3 before idle)
-}
-
-type contextStats struct {
-	MemoryCount    int
-	MessageCount   int
-	ContentBytes   int
-	ReasoningBytes int
-	RoleCounts     map[string]int
-	SourceCounts   map[string]int
-	ToolCallCount  int
+	encouragementCount     int              // Counter for consecutive synthetic encouragements (limit: 3 before idle)
+	turnCount              int              // Turns completed over the mysis's lifetime
+	persistedTurnCount     int              // turnCount as last written to the store
+	lastMessageAt          time.Time        // Last direct message or broadcast, or start time; for idle_stop_after
+	idleStopTimer          *time.Timer      // Stops the mysis once it has been idle past idle_stop_after
+	tokenUsage             provider.Usage   // Cumulative token usage reported by the provider
+	contextTokens          int              // Tokens in the last turn's context, when checked against context_tokens
+	snapshotCompaction     bool             // Drop stale snapshot tool results from context (default true)
+	snapshotSummaries      bool             // Leave a one-line marker for each compacted snapshot tool
+	contextWindow          int              // Recent memories scanned for context (0 = MaxContextMessages)
+	autonomous             bool             // Nudge itself between turns; otherwise only turn when messaged (default true)
+	syntheticNudges        bool             // Nudge itself when there is nothing to answer; otherwise go idle (default true)
+	seed                   *int64           // Sampling seed sent with every provider call (nil = none)
+	goal                   string           // Standing goal injected into the system prompt ("" = none)
+	model                  string           // Stored model name, for [pricing] cost estimates; kept in step by the commander
+	nowFunc                func() time.Time // Clock for activity timing (nil = time.Now); tests inject a fixed clock
+	prompts                continuePrompts  // Encouragement texts, resolved from [prompts] at construction
+	orphansRemoved         int              // New orphaned tool calls stripped by the last getContextMemories
+	seenOrphans            map[int64]bool   // Orphan memory IDs in the last context window, already counted
+	poisonedTurns          int              // Consecutive turns that had orphaned tool calls stripped
+	oneShotSystem          []string         // Pending one-shot reminders for the next turn (never stored)
+	toolPolicy             ToolPolicy       // Per-mysis tool restrictions, layered on the proxy tool list
+	driftCategories        []DriftCategory  // Drift keyword categories (nil = DefaultDriftCategories)
+
+	// Per-mysis log file (see mysislog.go). fileLog is nil while disabled.
+	fileLog      atomic.Pointer[zerolog.Logger]
+	logMu        sync.Mutex
+	logFile      *mysisLogFile
+	logTruncated bool // The log file was truncated this run; later enables append
+}
+
+// ContextStats summarizes the size and composition of a mysis context.
+type ContextStats struct {
+	MemoryCount    int            `json:"memory_count"`
+	MessageCount   int            `json:"message_count"`
+	ContentBytes   int            `json:"content_bytes"`
+	ReasoningBytes int            `json:"reasoning_bytes"`
+	RoleCounts     map[string]int `json:"role_counts"`
+	SourceCounts   map[string]int `json:"source_counts"`
+	ToolCallCount  int            `json:"tool_call_count"`
 }
 
 // NewMysis creates a new mysis from stored data.
@@ -69,22 +104,47 @@ func NewMysis(id, name string, createdAt time.Time, p provider.Provider, s *stor
 	if len(cmd) > 0 {
 		commander = cmd[0]
 	}
-	return &Mysis{
-		id:            id,
-		name:          name,
-		createdAt:     createdAt,
-		provider:      p,
-		store:         s,
-		bus:           bus,
-		mcpEndpoint:   mcpEndpoint,
-		commander:     commander,
-		state:         MysisStateIdle,
-		activityState: ActivityStateIdle,
+	m := &Mysis{
+		id:                 id,
+		name:               name,
+		createdAt:          createdAt,
+		provider:           p,
+		store:              s,
+		bus:                bus,
+		mcpEndpoint:        mcpEndpoint,
+		commander:          commander,
+		state:              MysisStateIdle,
+		activityState:      ActivityStateIdle,
+		snapshotCompaction: true,
+		autonomous:         true,
+		syntheticNudges:    true,
+		nowFunc:            time.Now,
+	}
+	var prompts config.PromptsConfig
+	if commander != nil && commander.config != nil {
+		prompts = commander.config.Prompts
+	}
+	m.prompts = newContinuePrompts(prompts)
+	if commander != nil && commander.mysisLogEnabled(name) {
+		if dir, err := MysisLogDir(); err != nil {
+			log.Warn().Err(err).Str("mysis", name).Msg("Mysis log file disabled")
+		} else if _, err := m.EnableLogFile(dir); err != nil {
+			log.Warn().Err(err).Str("mysis", name).Msg("Mysis log file disabled")
+		}
+	}
+	return m
+}
+
+// now returns the current time from the mysis clock.
+func (m *Mysis) now() time.Time {
+	if m.nowFunc != nil {
+		return m.nowFunc()
 	}
+	return time.Now()
 }
 
-func (m *Mysis) computeMemoryStats(memories []*store.Memory) contextStats {
-	stats := contextStats{
+func (m *Mysis) computeMemoryStats(memories []*store.Memory) ContextStats {
+	stats := ContextStats{
 		MemoryCount:  len(memories),
 		RoleCounts:   make(map[string]int),
 		SourceCounts: make(map[string]int),
@@ -100,8 +160,8 @@ func (m *Mysis) computeMemoryStats(memories []*store.Memory) contextStats {
 	return stats
 }
 
-func (m *Mysis) computeMessageStats(messages []provider.Message) contextStats {
-	stats := contextStats{
+func (m *Mysis) computeMessageStats(messages []provider.Message) ContextStats {
+	stats := ContextStats{
 		MessageCount: len(messages),
 	}
 
@@ -127,6 +187,14 @@ func (m *Mysis) Name() string {
 	return a.name
 }
 
+// setName updates the in-memory name after a rename.
+func (m *Mysis) setName(name string) {
+	a := m
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.name = name
+}
+
 // CreatedAt returns when the mysis was created.
 func (m *Mysis) CreatedAt() time.Time {
 	a := m
@@ -159,6 +227,8 @@ func validateCanAcceptMessage(state MysisState) error {
 		return fmt.Errorf("mysis stopped - press 'r' to relaunch")
 	case MysisStateErrored:
 		return fmt.Errorf("mysis errored - press 'r' to relaunch")
+	case MysisStateQuarantined:
+		return fmt.Errorf("mysis quarantined - press 'r' to relaunch")
 	default:
 		return fmt.Errorf("unknown mysis state: %s", state)
 	}
@@ -206,12 +276,314 @@ func (m *Mysis) ActivityState() ActivityState {
 	return a.activityState
 }
 
-// SetProvider updates the mysis provider.
-func (m *Mysis) SetProvider(p provider.Provider) {
+// ActivityUntil returns when the current activity is expected to end (zero if unknown).
+func (m *Mysis) ActivityUntil() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.activityUntil
+}
+
+// TickDuration returns the observed duration of one server tick (zero until two ticks are seen).
+func (m *Mysis) TickDuration() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tickDuration
+}
+
+// LastServerTick returns the latest server tick the mysis observed and when it saw it
+// (0 and the zero time until a tool result carries a tick).
+func (m *Mysis) LastServerTick() (int64, time.Time) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lastServerTick, m.lastServerTickAt
+}
+
+// TickStale reports whether a running mysis has gone [swarm] tick_stale_after without
+// observing a new server tick, which suggests it lost its game connection. Myses that
+// never saw a tick aren't flagged.
+func (m *Mysis) TickStale(now time.Time) bool {
+	staleAfter := constants.DefaultTickStaleAfter
+	if m.commander != nil && m.commander.config != nil && m.commander.config.Swarm.TickStaleAfter > 0 {
+		staleAfter = m.commander.config.Swarm.TickStaleAfter
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.state != MysisStateRunning || m.lastServerTickAt.IsZero() {
+		return false
+	}
+	return now.Sub(m.lastServerTickAt) > staleAfter
+}
+
+// EncouragementCount returns the number of consecutive synthetic encouragements.
+func (m *Mysis) EncouragementCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.encouragementCount
+}
+
+// MemoryStats computes stats over the memories in the mysis context window.
+func (m *Mysis) MemoryStats() (ContextStats, error) {
+	memories, err := m.store.GetRecentMemories(m.ID(), m.ContextWindow())
+	if err != nil {
+		return ContextStats{}, fmt.Errorf("get recent memories: %w", err)
+	}
+	return m.computeMemoryStats(memories), nil
+}
+
+// TurnCount returns the number of turns the mysis has completed over its lifetime.
+func (m *Mysis) TurnCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.turnCount
+}
+
+// SetTurnCount restores the turn count loaded from the store.
+func (m *Mysis) SetTurnCount(count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.turnCount = count
+	m.persistedTurnCount = count
+}
+
+// TokenUsage returns cumulative token usage reported by the provider.
+func (m *Mysis) TokenUsage() provider.Usage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tokenUsage
+}
+
+// SetProvider replaces the mysis provider and returns the previous one. It waits for
+// the current turn to finish, so a turn never switches providers halfway through.
+func (m *Mysis) SetProvider(p provider.Provider) provider.Provider {
 	a := m
+	a.turnMu.Lock()
+	defer a.turnMu.Unlock()
+
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	old := a.provider
 	a.provider = p
+	return old
+}
+
+// CompactSnapshots reports whether stale snapshot tool results are compacted out of context.
+func (m *Mysis) CompactSnapshots() bool {
+	a := m
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.snapshotCompaction
+}
+
+// SetCompactSnapshots enables or disables snapshot compaction for this mysis.
+// Orphaned tool call removal still runs when compaction is disabled.
+func (m *Mysis) SetCompactSnapshots(enabled bool) {
+	a := m
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshotCompaction = enabled
+}
+
+// SnapshotSummaries reports whether compacted snapshot results leave a summary marker.
+func (m *Mysis) SnapshotSummaries() bool {
+	a := m
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.snapshotSummaries
+}
+
+// SetSnapshotSummaries enables or disables summary markers for compacted snapshots, so
+// the LLM still knows the state was queried before. Has no effect without compaction.
+func (m *Mysis) SetSnapshotSummaries(enabled bool) {
+	a := m
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshotSummaries = enabled
+}
+
+// ContextWindow returns the number of recent memories scanned when building context.
+func (m *Mysis) ContextWindow() int {
+	a := m
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.contextWindow == 0 {
+		return constants.MaxContextMessages
+	}
+	return a.contextWindow
+}
+
+// SetContextWindow overrides the context window for this mysis. Zero restores the default.
+func (m *Mysis) SetContextWindow(window int) error {
+	if err := ValidateContextWindow(window); err != nil {
+		return err
+	}
+	a := m
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.contextWindow = window
+	return nil
+}
+
+// Autonomous reports whether the mysis takes turns on its own between messages.
+func (m *Mysis) Autonomous() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.autonomous
+}
+
+// SetAutonomous enables or disables autonomous turns. A non-autonomous mysis answers
+// direct messages and broadcasts, then goes idle instead of nudging itself.
+func (m *Mysis) SetAutonomous(autonomous bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.autonomous = autonomous
+}
+
+// SyntheticNudges reports whether the mysis's own setting allows synthetic nudges.
+// [swarm] synthetic_nudge_enabled = false turns them off for every mysis regardless.
+func (m *Mysis) SyntheticNudges() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.syntheticNudges
+}
+
+// SetSyntheticNudges enables or disables synthetic nudges. Without them an autonomous
+// mysis with nothing to answer goes idle until a message or broadcast arrives.
+func (m *Mysis) SetSyntheticNudges(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.syntheticNudges = enabled
+}
+
+// Seed returns the sampling seed sent with the mysis's provider calls (nil = none).
+func (m *Mysis) Seed() *int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.seed
+}
+
+// SetSeed sets the sampling seed for later provider calls (nil clears it). Providers
+// that don't support seeds ignore it.
+func (m *Mysis) SetSeed(seed *int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seed = seed
+}
+
+// nudgesEnabled reports whether the mysis may prompt itself with a synthetic nudge:
+// it must be autonomous, and both its own setting and the swarm's must allow it.
+func (m *Mysis) nudgesEnabled() bool {
+	if !m.Autonomous() || !m.SyntheticNudges() {
+		return false
+	}
+	return m.commander == nil || m.commander.config == nil || m.commander.config.Swarm.SyntheticNudges()
+}
+
+// Goal returns the mysis's standing goal ("" = none).
+func (m *Mysis) Goal() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.goal
+}
+
+// SetGoal sets the standing goal built into the system prompt. The stored prompt is
+// not rebuilt; see Commander.SetGoal.
+func (m *Mysis) SetGoal(goal string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.goal = goal
+}
+
+// SetModel records the model the mysis's provider runs, for cost estimates. It does
+// not change the provider; see Commander.SwitchProvider.
+func (m *Mysis) SetModel(model string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.model = model
+}
+
+// awaitingReply reports whether the latest message to the mysis is still unanswered.
+// Notes and system memories don't count either way.
+func (m *Mysis) awaitingReply() (bool, error) {
+	memories, err := m.store.GetRecentMemories(m.id, m.ContextWindow())
+	if err != nil {
+		return false, err
+	}
+	for i := len(memories) - 1; i >= 0; i-- {
+		mem := memories[i]
+		if mem.Role == store.MemoryRoleSystem || mem.Source == store.MemorySourceNote {
+			continue
+		}
+		return mem.Role == store.MemoryRoleUser &&
+			(mem.Source == store.MemorySourceDirect || mem.Source == store.MemorySourceBroadcast), nil
+	}
+	return false, nil
+}
+
+// ValidateContextWindow checks a context window override. Zero (default) is allowed.
+func ValidateContextWindow(window int) error {
+	if window == 0 {
+		return nil
+	}
+	if window < constants.MinContextWindow || window > constants.MaxContextWindow {
+		return fmt.Errorf("context window %d out of range (%d-%d, or 0 for default)",
+			window, constants.MinContextWindow, constants.MaxContextWindow)
+	}
+	return nil
+}
+
+// ToolPolicy restricts which tools a mysis may use. An empty Allow permits every
+// tool not listed in Deny; Deny always wins.
+type ToolPolicy struct {
+	Allow []string
+	Deny  []string
+}
+
+// Permits reports whether the policy allows the named tool.
+func (p ToolPolicy) Permits(name string) bool {
+	for _, denied := range p.Deny {
+		if denied == name {
+			return false
+		}
+	}
+	if len(p.Allow) == 0 {
+		return true
+	}
+	for _, allowed := range p.Allow {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolPolicy returns the tool restrictions for this mysis.
+func (m *Mysis) ToolPolicy() ToolPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.toolPolicy
+}
+
+// SetToolPolicy replaces the tool restrictions for this mysis.
+func (m *Mysis) SetToolPolicy(policy ToolPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.toolPolicy = policy
+}
+
+// filterTools drops tools the mysis policy does not permit.
+func (m *Mysis) filterTools(tools []provider.Tool) []provider.Tool {
+	policy := m.ToolPolicy()
+	if len(policy.Allow) == 0 && len(policy.Deny) == 0 {
+		return tools
+	}
+	filtered := make([]provider.Tool, 0, len(tools))
+	for _, t := range tools {
+		if policy.Permits(t.Name) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
 }
 
 // SetErrorState sets the mysis to errored state with the given error.
@@ -231,8 +603,8 @@ func (m *Mysis) Start() error {
 
 	oldState := a.state
 
-	// If restarting from errored state, cleanup any existing context/goroutine
-	if oldState == MysisStateErrored && a.cancel != nil {
+	// If restarting from errored or quarantined state, cleanup any existing context/goroutine
+	if (oldState == MysisStateErrored || oldState == MysisStateQuarantined) && a.cancel != nil {
 		a.cancel() // Cancel old context
 		a.mu.Unlock()
 		// Wait for old goroutine to exit
@@ -265,13 +637,23 @@ func (m *Mysis) Start() error {
 
 	// Now that store update succeeded, update in-memory state
 	a.mu.Lock()
+	if a.state == MysisStateRunning {
+		// A concurrent Recover resumed the mysis while the old goroutine wound down
+		a.mu.Unlock()
+		cancel()
+		return fmt.Errorf("mysis already running")
+	}
 	a.state = MysisStateRunning
 	a.lastError = nil
 	a.activityState = ActivityStateIdle
 	a.activityUntil = time.Time{}
 	a.encouragementCount = 0 // Reset encouragement counter when starting/restarting
+	a.lastMessageAt = a.now()
+	a.poisonedTurns = 0
 	a.ctx = ctx
 	a.cancel = cancel
+	a.loopCtx = ctx
+	a.resumed = false
 	a.mu.Unlock()
 
 	// Add system prompt if it doesn't exist yet
@@ -299,6 +681,49 @@ func (m *Mysis) Start() error {
 	return nil
 }
 
+// toolRetryPolicy returns the proxy retry policy for the [mcp] retry settings.
+func toolRetryPolicy(cfg config.MCPConfig) mcp.RetryPolicy {
+	return mcp.RetryPolicy{
+		Retries: cfg.Retries(),
+		Backoff: cfg.ToolRetryBackoff,
+		PerTool: cfg.ToolRetryOverrides,
+	}
+}
+
+// mcpWatchdog builds the upstream reconnect watchdog from [mcp] settings.
+func mcpWatchdog(cfg config.MCPConfig, onReconnect func(done bool, err error)) mcp.Watchdog {
+	failures := cfg.ReconnectAfterFailures
+	if failures == 0 {
+		failures = constants.DefaultMCPReconnectFailures
+	}
+	cooldown := cfg.ReconnectCooldown
+	if cooldown == 0 {
+		cooldown = constants.DefaultMCPReconnectCooldown
+	}
+	return mcp.Watchdog{Failures: failures, Cooldown: cooldown, OnReconnect: onReconnect}
+}
+
+// publishMCPReconnect reports an upstream reconnect started by the watchdog of a mysis
+// without a commander.
+func (m *Mysis) publishMCPReconnect(done bool, err error) {
+	m.bus.Publish(Event{
+		Type:      EventMCPReconnect,
+		MysisID:   m.id,
+		MysisName: m.name,
+		Reconnect: newReconnectData(done, err),
+		Timestamp: time.Now(),
+	})
+}
+
+// newReconnectData builds the payload of an EventMCPReconnect.
+func newReconnectData(done bool, err error) *ReconnectData {
+	data := &ReconnectData{Done: done}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	return data
+}
+
 // initializeMCP creates and initializes a per-mysis MCP client for session isolation.
 // This runs in a goroutine to avoid blocking Start().
 func (m *Mysis) initializeMCP(ctx context.Context) {
@@ -321,6 +746,25 @@ func (m *Mysis) initializeMCP(ctx context.Context) {
 	// Register orchestrator tools if commander is available
 	if a.commander != nil {
 		mcp.RegisterOrchestratorTools(proxy, &commanderAdapter{a.commander})
+		proxy.SetCredentialGenerator(&credentialGeneratorAdapter{store: a.store, gen: a.commander.accountGen})
+	}
+
+	toolCacheTTL := constants.DefaultToolCacheTTL
+	var mcpConfig config.MCPConfig
+	if a.commander != nil && a.commander.config != nil {
+		proxy.SetUpstreamValidation(a.commander.config.MCP.ValidateArguments)
+		if ttl := a.commander.config.MCP.ToolCacheTTL; ttl > 0 {
+			toolCacheTTL = ttl
+		}
+		proxy.SetRetryPolicy(toolRetryPolicy(a.commander.config.MCP))
+		mcpConfig = a.commander.config.MCP
+	}
+	proxy.SetToolCacheTTL(toolCacheTTL)
+	// Failures count across the swarm, so one dead upstream triggers one reconnect
+	if a.commander != nil {
+		proxy.SetReconnectGroup(a.commander.mcpReconnects)
+	} else {
+		proxy.SetWatchdog(mcpWatchdog(mcpConfig, a.publishMCPReconnect))
 	}
 
 	// Initialize with timeout
@@ -357,6 +801,41 @@ func (m *Mysis) initializeMCP(ctx context.Context) {
 	a.mu.Unlock()
 }
 
+// warmUpProvider pings the provider before the first turn when warm_up is enabled for it
+// in config. Failures are logged and never error the mysis.
+func (m *Mysis) warmUpProvider(ctx context.Context) {
+	a := m
+	if a.commander == nil || a.commander.config == nil {
+		return
+	}
+
+	stored, err := a.store.GetMysis(a.id)
+	if err != nil {
+		return
+	}
+	if !a.commander.config.Providers[stored.Provider].WarmUp {
+		return
+	}
+
+	a.mu.RLock()
+	p := a.provider
+	a.mu.RUnlock()
+
+	pinger, ok := p.(provider.Pinger)
+	if !ok {
+		return
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, constants.ProviderWarmUpTimeout)
+	defer cancel()
+
+	if err := pinger.Ping(pingCtx); err != nil {
+		log.Warn().Err(err).Str("mysis", a.name).Str("provider", stored.Provider).Msg("Provider warm-up failed - continuing")
+		return
+	}
+	log.Debug().Str("mysis", a.name).Str("provider", stored.Provider).Msg("Provider warmed up")
+}
+
 // commanderAdapter adapts Commander to the mcp.Orchestrator interface.
 type commanderAdapter struct {
 	commander *Commander
@@ -401,23 +880,166 @@ func (a *commanderAdapter) SearchMessages(mysisID, query string, limit int) ([]m
 			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
 		}
 	}
-	return results, nil
+	return results, nil
+}
+
+func (a *commanderAdapter) SearchSwarm(query string, limit int) ([]mcp.SearchResult, error) {
+	memories, err := a.commander.Store().SearchAllMemories(query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.SearchResult, len(memories))
+	for i, m := range memories {
+		results[i] = mcp.SearchResult{
+			MysisName: m.MysisName,
+			Role:      string(m.Role),
+			Source:    string(m.Source),
+			Content:   m.Content,
+			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return results, nil
+}
+
+func (a *commanderAdapter) SearchReasoning(mysisID, query string, limit int) ([]mcp.ReasoningResult, error) {
+	memories, err := a.commander.Store().SearchReasoning(mysisID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.ReasoningResult, len(memories))
+	for i, m := range memories {
+		results[i] = mcp.ReasoningResult{
+			Role:      string(m.Role),
+			Source:    string(m.Source),
+			Content:   m.Content,
+			Reasoning: m.Reasoning,
+			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return results, nil
+}
+
+func (a *commanderAdapter) GetRecentReasoning(mysisID string, limit int) ([]mcp.ReasoningResult, error) {
+	memories, err := a.commander.GetRecentReasoning(mysisID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.ReasoningResult, len(memories))
+	for i, m := range memories {
+		results[i] = mcp.ReasoningResult{
+			Role:      string(m.Role),
+			Source:    string(m.Source),
+			Content:   m.Content,
+			Reasoning: m.Reasoning,
+			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return results, nil
+}
+
+func (a *commanderAdapter) SetCompactSnapshots(mysisID string, enabled bool) error {
+	return a.commander.SetCompactSnapshots(mysisID, enabled)
+}
+
+func (a *commanderAdapter) SetSnapshotSummaries(mysisID string, enabled bool) error {
+	return a.commander.SetSnapshotSummaries(mysisID, enabled)
+}
+
+func (a *commanderAdapter) SetContextWindow(mysisID string, window int) error {
+	return a.commander.SetContextWindow(mysisID, window)
+}
+
+func (a *commanderAdapter) SetAutonomous(mysisID string, autonomous bool) error {
+	return a.commander.SetAutonomous(mysisID, autonomous)
+}
+
+func (a *commanderAdapter) SetSyntheticNudges(mysisID string, enabled bool) error {
+	return a.commander.SetSyntheticNudges(mysisID, enabled)
+}
+
+func (a *commanderAdapter) SetSeed(mysisID string, seed *int64) error {
+	return a.commander.SetSeed(mysisID, seed)
+}
+
+func (a *commanderAdapter) SetGoal(mysisID, goal string) error {
+	return a.commander.SetGoal(mysisID, goal)
+}
+
+func (a *commanderAdapter) ConfigureSwarm(setting string, value int) error {
+	return a.commander.ConfigureSwarm(setting, value)
+}
+
+func (a *commanderAdapter) SwitchProviderAsync(mysisID, providerName, model string) error {
+	return a.commander.SwitchProviderAsync(mysisID, providerName, model)
+}
+
+func (a *commanderAdapter) AddNote(mysisID, content string) error {
+	mysis, err := a.commander.GetMysis(mysisID)
+	if err != nil {
+		return err
+	}
+	return mysis.AddNote(content)
+}
+
+func (a *commanderAdapter) SendOneShotSystem(mysisID, content string) error {
+	return a.commander.SendOneShotSystem(mysisID, content)
+}
+
+func (a *commanderAdapter) AskMysis(ctx context.Context, senderID, mysisID, question string, timeout time.Duration) (string, error) {
+	return a.commander.Ask(ctx, senderID, mysisID, question, timeout)
+}
+
+func (a *commanderAdapter) SetToolPolicy(mysisID string, allow, deny []string) error {
+	return a.commander.SetToolPolicy(mysisID, ToolPolicy{Allow: allow, Deny: deny})
+}
+
+func (a *commanderAdapter) GetPeerSnapshot(mysisID, toolName string) (*mcp.PeerSnapshot, error) {
+	snapshot, err := a.commander.GetLatestSnapshot(mysisID, toolName)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return &mcp.PeerSnapshot{
+		MysisID:   snapshot.MysisID,
+		MysisName: snapshot.MysisName,
+		Tool:      snapshot.ToolName,
+		CreatedAt: snapshot.CreatedAt.Format(time.RFC3339),
+		Payload:   snapshot.Payload,
+	}, nil
+}
+
+func (a *commanderAdapter) RelaunchAndBroadcast(message string) (*mcp.RelaunchReport, error) {
+	result, err := a.commander.RelaunchAndBroadcast(message)
+	if err != nil {
+		return nil, err
+	}
+	report := &mcp.RelaunchReport{Relaunched: result.Names}
+	for _, err := range result.Errors {
+		report.Failed = append(report.Failed, err.Error())
+	}
+	return report, nil
 }
 
-func (a *commanderAdapter) SearchReasoning(mysisID, query string, limit int) ([]mcp.ReasoningResult, error) {
-	memories, err := a.commander.Store().SearchReasoning(mysisID, query, limit)
+func (a *commanderAdapter) GetMysisActivity(mysisID string) ([]mcp.MysisActivity, error) {
+	snapshots, err := a.commander.MysisActivity(mysisID)
 	if err != nil {
 		return nil, err
 	}
 
-	results := make([]mcp.ReasoningResult, len(memories))
-	for i, m := range memories {
-		results[i] = mcp.ReasoningResult{
-			Role:      string(m.Role),
-			Source:    string(m.Source),
-			Content:   m.Content,
-			Reasoning: m.Reasoning,
-			CreatedAt: m.CreatedAt.Format("2006-01-02 15:04:05"),
+	results := make([]mcp.MysisActivity, len(snapshots))
+	for i, snap := range snapshots {
+		results[i] = mcp.MysisActivity{
+			MysisID:        snap.MysisID,
+			MysisName:      snap.MysisName,
+			State:          string(snap.State),
+			Activity:       string(snap.Activity),
+			RemainingTicks: snap.RemainingTicks,
+			Available:      snap.Available,
+		}
+		if !snap.Until.IsZero() {
+			results[i].ActivityUntil = snap.Until.Format(time.RFC3339)
 		}
 	}
 	return results, nil
@@ -468,11 +1090,27 @@ func (a *accountStoreAdapter) ClaimAccount(mysisID string) (*mcp.Account, error)
 	return &mcp.Account{Username: acc.Username, Password: acc.Password}, nil
 }
 
+// credentialGeneratorAdapter adapts a store.AccountGenerator to mcp.CredentialGenerator,
+// checking generated usernames against existing accounts.
+type credentialGeneratorAdapter struct {
+	store *store.Store
+	gen   store.AccountGenerator
+}
+
+func (a *credentialGeneratorAdapter) NewCredentials() (string, string, error) {
+	return a.store.NewAccountCredentials(a.gen)
+}
+
 // Stop halts the mysis processing loop.
 func (m *Mysis) Stop() error {
+	return m.stop(false)
+}
+
+// stop halts a running mysis, or an idle one too if fromIdle is set.
+func (m *Mysis) stop(fromIdle bool) error {
 	a := m
 	a.mu.Lock()
-	if a.state != MysisStateRunning {
+	if a.state != MysisStateRunning && (!fromIdle || a.state != MysisStateIdle) {
 		a.mu.Unlock()
 		return nil
 	}
@@ -521,6 +1159,8 @@ func (m *Mysis) Stop() error {
 	a.cancel = nil
 	a.mu.Unlock()
 
+	a.persistTurnCount()
+
 	// Close provider HTTP client
 	if a.provider != nil {
 		if err := a.provider.Close(); err != nil {
@@ -528,15 +1168,15 @@ func (m *Mysis) Stop() error {
 		}
 	}
 
-	// Close MCP client
+	// Close the MCP proxy, which cancels any watchdog reconnect and closes the client
 	a.mu.Lock()
-	mcpClient := a.mcpClient
+	mcpProxy := a.mcpProxy
 	a.mcpClient = nil
 	a.mcpProxy = nil
 	a.mu.Unlock()
 
-	if mcpClient != nil {
-		if err := mcpClient.Close(); err != nil {
+	if mcpProxy != nil {
+		if err := mcpProxy.Close(); err != nil {
 			log.Warn().Err(err).Str("mysis", a.name).Msg("Failed to close MCP client")
 		}
 	}
@@ -544,6 +1184,66 @@ func (m *Mysis) Stop() error {
 	return nil
 }
 
+// SendOneShotSystem queues a system reminder that is injected into the context of the
+// next turn only. The reminder is never stored, so it drops out of history once that
+// turn has reached the provider.
+func (m *Mysis) SendOneShotSystem(content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return fmt.Errorf("reminder cannot be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := validateCanAcceptMessage(m.state); err != nil {
+		return err
+	}
+	m.oneShotSystem = append(m.oneShotSystem, content)
+	log.Debug().Str("mysis", m.name).Int("pending", len(m.oneShotSystem)).Msg("One-shot system reminder queued")
+	return nil
+}
+
+// takeOneShotSystem removes and returns all pending one-shot reminders.
+func (m *Mysis) takeOneShotSystem() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reminders := m.oneShotSystem
+	m.oneShotSystem = nil
+	return reminders
+}
+
+// requeueOneShotSystem puts undelivered reminders back ahead of any queued since.
+func (m *Mysis) requeueOneShotSystem(reminders []string) {
+	if len(reminders) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.oneShotSystem = append(append([]string(nil), reminders...), m.oneShotSystem...)
+}
+
+// withOneShotSystem inserts reminders after the leading system prompt and notes.
+func withOneShotSystem(memories []*store.Memory, reminders []string, now time.Time) []*store.Memory {
+	if len(reminders) == 0 {
+		return memories
+	}
+	idx := 0
+	for idx < len(memories) && memories[idx].Role == store.MemoryRoleSystem {
+		idx++
+	}
+	result := make([]*store.Memory, 0, len(memories)+len(reminders))
+	result = append(result, memories[:idx]...)
+	for _, content := range reminders {
+		result = append(result, &store.Memory{
+			Role:      store.MemoryRoleSystem,
+			Source:    store.MemorySourceSystem,
+			Content:   content,
+			CreatedAt: now,
+		})
+	}
+	return append(result, memories[idx:]...)
+}
+
 // SendMessageFrom sends a message to the mysis for processing with sender tracking.
 // The source parameter indicates whether this is a direct or broadcast message.
 func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, senderID string) error {
@@ -574,7 +1274,9 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		// Reset encouragement counter - we have a real user message now
 		a.mu.Lock()
 		a.encouragementCount = 0
+		a.lastMessageAt = a.now()
 		a.mu.Unlock()
+		a.fileLogger().Info().Str("source", string(source)).Str("sender", senderID).Str("content", content).Msg("Message received")
 
 		// Emit message event - UI will refresh and show the message immediately
 		a.bus.Publish(Event{
@@ -621,6 +1323,25 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		return nil
 	}
 
+	// Non-autonomous myses, and those that may not nudge themselves, only turn to
+	// answer a message
+	if content == "" && !a.nudgesEnabled() {
+		pending, err := a.awaitingReply()
+		if err != nil {
+			a.setError(err)
+			return fmt.Errorf("get memories: %w", err)
+		}
+		if !pending {
+			if a.Autonomous() {
+				a.setIdle("Synthetic nudges disabled - waiting for a message")
+			} else {
+				a.setIdle("Not autonomous - waiting for a message")
+			}
+			return nil
+		}
+	}
+	a.fileLogger().Info().Str("trigger", string(source)).Msg("Turn started")
+
 	// Create context for the entire conversation turn
 	a.mu.RLock()
 	parentCtx := a.ctx
@@ -630,7 +1351,7 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		parentCtx = context.Background()
 	}
 
-	ctx, cancel := context.WithTimeout(parentCtx, constants.LLMRequestTimeout)
+	ctx, cancel := context.WithTimeout(parentCtx, turnTimeout(p))
 	defer cancel()
 
 	// Get available tools from MCP proxy
@@ -643,6 +1364,7 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 					Str("mysis", a.name).
 					Err(err).
 					Msg("MCP connection lost - releasing account")
+				mcpProxy.InvalidateToolCache()
 				if rebuildErr := a.rebuildSystemMemory(); rebuildErr != nil {
 					log.Error().Err(rebuildErr).Msg("failed to rebuild system memory after MCP session loss")
 				}
@@ -669,13 +1391,17 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 			})
 		} else {
 			tools = make([]provider.Tool, len(mcpTools))
-			toolNames := make([]string, len(mcpTools))
 			for i, t := range mcpTools {
 				tools[i] = provider.Tool{
 					Name:        t.Name,
 					Description: t.Description,
 					Parameters:  t.InputSchema,
 				}
+			}
+			// Per-mysis policy narrows whatever the proxy exposes
+			tools = a.filterTools(tools)
+			toolNames := make([]string, len(tools))
+			for i, t := range tools {
 				toolNames[i] = t.Name
 			}
 			// Log available tools (only on first message or if debugging)
@@ -699,13 +1425,36 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		})
 	}
 
+	// One-shot reminders belong to this turn; put them back if no provider call succeeds
+	reminders := a.takeOneShotSystem()
+	remindersDelivered := false
+	defer func() {
+		if !remindersDelivered {
+			a.requeueOneShotSystem(reminders)
+		}
+	}()
+
 	// Track if synthetic encouragement was added (for counter increment after turn completes)
 	var addedSyntheticEncouragement bool
+	var driftReminders []string
+
+	// Track if orphaned tool calls were stripped from context during this turn
+	var poisoned bool
+
+	// Track identical tool calls in a row; a stuck model ends the turn early
+	var lastToolCalls string
+	var repeatedCalls int
+	var repeatedTools string // Set when the turn ended on repeated calls
+
+	var prompt *store.Memory // The message this turn answers, nil for a nudge
+
+	// Decided once: the turn's own tool calls add memories
+	firstTurn := a.isFirstTurn()
 
 	// Loop: keep calling LLM until we get a final text response
 	for iteration := 0; iteration < constants.MaxToolIterations; iteration++ {
 		// Get recent conversation history (keeps context small for faster inference)
-		memories, addedSynthetic, err := a.getContextMemories()
+		memories, addedSynthetic, err := a.contextMemories(firstTurn)
 		if err != nil {
 			a.setError(err)
 			return fmt.Errorf("get memories: %w", err)
@@ -714,8 +1463,18 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		// Track if synthetic encouragement was added on first iteration
 		// (subsequent iterations reuse same context, so only first matters)
 		if iteration == 0 {
+			prompt = turnPrompt(memories)
 			addedSyntheticEncouragement = addedSynthetic
+			// Autonomous turns also get reminders for drift in recent replies
+			if addedSynthetic {
+				driftReminders = a.recentDriftReminders()
+			}
+		}
+		if a.lastOrphansRemoved() > 0 {
+			poisoned = true
 		}
+		memories = withOneShotSystem(memories, reminders, a.now())
+		memories = withOneShotSystem(memories, driftReminders, a.now())
 
 		// Check if encouragement limit reached (counter incremented after turn completes)
 		a.mu.RLock()
@@ -733,6 +1492,7 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 			Str("mysis_id", a.id).
 			Str("mysis_name", a.name).
 			Str("stage", "context_memories").
+			Int("context_window", a.ContextWindow()).
 			Int("memory_count", memoryStats.MemoryCount).
 			Int("message_count", 0).
 			Int("content_bytes", memoryStats.ContentBytes).
@@ -744,6 +1504,7 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 
 		// Convert to provider messages
 		messages := a.memoriesToMessages(memories)
+		messages = a.fitContextBudget(ctx, p, messages)
 		messageStats := a.computeMessageStats(messages)
 		log.Debug().
 			Str("mysis_id", a.id).
@@ -782,18 +1543,7 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		a.setActivity(ActivityStateLLMCall, time.Time{})
 
 		// Get response from provider
-		var response *provider.ChatResponse
-		if len(tools) > 0 {
-			response, err = p.ChatWithTools(ctx, messages, tools)
-		} else {
-			// No tools available, use simple chat
-			text, chatErr := p.Chat(ctx, messages)
-			if chatErr != nil {
-				err = chatErr
-			} else {
-				response = &provider.ChatResponse{Content: text}
-			}
-		}
+		response, err := a.chat(ctx, p, messages, tools)
 
 		// Clear LLM activity state after call completes (success or failure)
 		a.setActivity(ActivityStateIdle, time.Time{})
@@ -808,16 +1558,19 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 			a.setError(err)
 			return fmt.Errorf("provider chat: %w", err)
 		}
+		remindersDelivered = true
 
 		if response.Reasoning != "" {
 			log.Debug().Str("mysis", a.name).Int("reasoning_len", len(response.Reasoning)).Msg("LLM reasoning captured")
 		}
 
+		a.recordUsage(response.Usage)
+
 		// If we have tool calls, execute them
 		if len(response.ToolCalls) > 0 {
 			// Store the assistant's tool call request
 			toolCallJSON := a.formatToolCallsForStorage(response.ToolCalls)
-			if err := a.store.AddMemory(a.id, store.MemoryRoleAssistant, store.MemorySourceLLM, toolCallJSON, response.Reasoning, ""); err != nil {
+			if err := a.store.AddMemory(a.id, store.MemoryRoleAssistant, store.MemorySourceLLM, toolCallJSON, a.limitReasoning(response.Reasoning), ""); err != nil {
 				a.setError(err)
 				return fmt.Errorf("store tool call: %w", err)
 			}
@@ -835,26 +1588,63 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 				Timestamp: time.Now(),
 			})
 
-			// Execute each tool call
-			for _, tc := range response.ToolCalls {
-				// Signal MCP activity
+			if key := toolCallsKey(response.ToolCalls); key == lastToolCalls {
+				repeatedCalls++
+			} else {
+				lastToolCalls, repeatedCalls = key, 1
+			}
+			if repeatedCalls >= a.maxRepeatedToolCalls() {
+				if err := a.storeRepeatedToolResults(response.ToolCalls, repeatedCalls); err != nil {
+					a.setError(err)
+					return fmt.Errorf("store tool result: %w", err)
+				}
+				repeatedTools = strings.Join(toolNames, ", ")
+				break
+			}
+
+			// Read-only calls are independent, so send them upstream in one batch
+			var batched []mcp.ToolCallResult
+			if len(response.ToolCalls) > 1 && a.allSnapshotTools(response.ToolCalls) && a.allToolsPermitted(response.ToolCalls) {
 				a.bus.Publish(Event{
 					Type:      EventNetworkMCP,
 					MysisID:   a.id,
 					MysisName: a.name,
 					Timestamp: time.Now(),
 				})
-
-				// Set activity state to indicate MCP call in progress
 				a.setActivity(ActivityStateMCPCall, time.Time{})
 
-				result, execErr := a.executeToolCall(ctx, mcpProxy, tc)
+				batched = a.executeToolCallsBatch(ctx, mcpProxy, response.ToolCalls)
 
-				// Clear MCP activity state after call completes
 				a.setActivity(ActivityStateIdle, time.Time{})
-
-				// Signal MCP activity complete
 				a.bus.Publish(Event{Type: EventNetworkIdle, MysisID: a.id, Timestamp: time.Now()})
+			}
+
+			// Execute each tool call
+			for i, tc := range response.ToolCalls {
+				var result *mcp.ToolResult
+				var execErr error
+				if batched != nil {
+					result, execErr = batched[i].Result, batched[i].Err
+				} else {
+					// Signal MCP activity
+					a.bus.Publish(Event{
+						Type:      EventNetworkMCP,
+						MysisID:   a.id,
+						MysisName: a.name,
+						Timestamp: time.Now(),
+					})
+
+					// Set activity state to indicate MCP call in progress
+					a.setActivity(ActivityStateMCPCall, time.Time{})
+
+					result, execErr = a.executeToolCall(ctx, mcpProxy, tc)
+
+					// Clear MCP activity state after call completes
+					a.setActivity(ActivityStateIdle, time.Time{})
+
+					// Signal MCP activity complete
+					a.bus.Publish(Event{Type: EventNetworkIdle, MysisID: a.id, Timestamp: time.Now()})
+				}
 
 				a.updateActivityFromToolResult(result, execErr)
 
@@ -888,6 +1678,12 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 					a.setError(execErr)
 					return fmt.Errorf("tool call failed after retries: %w", execErr)
 				}
+
+				// Stopped mid-call: skip remaining tool calls so Stop returns promptly
+				if ctx.Err() != nil {
+					a.bus.Publish(Event{Type: EventNetworkIdle, MysisID: a.id, Timestamp: time.Now()})
+					return fmt.Errorf("tool call canceled: %w", ctx.Err())
+				}
 			}
 
 			// Continue loop to get next LLM response
@@ -900,11 +1696,17 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 			finalResponse = constants.FallbackLLMResponse
 		}
 
-		// Store the assistant response
-		if err := a.store.AddMemory(a.id, store.MemoryRoleAssistant, store.MemorySourceLLM, finalResponse, response.Reasoning, ""); err != nil {
+		// Store the assistant response, tagging replies to a synthetic nudge so
+		// autonomous filler can be told apart from commander-driven work
+		responseSource := store.MemorySourceLLM
+		if addedSyntheticEncouragement {
+			responseSource = store.MemorySourceNudgeResponse
+		}
+		if err := a.store.AddMemory(a.id, store.MemoryRoleAssistant, responseSource, finalResponse, a.limitReasoning(response.Reasoning), ""); err != nil {
 			a.setError(err)
 			return fmt.Errorf("store response: %w", err)
 		}
+		a.ackBroadcast(prompt)
 
 		// Signal network idle
 		a.bus.Publish(Event{Type: EventNetworkIdle, MysisID: a.id, Timestamp: time.Now()})
@@ -917,6 +1719,12 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 			Message:   &MessageData{Role: "assistant", Content: finalResponse},
 			Timestamp: time.Now(),
 		})
+		a.fileLogger().Info().Str("response", finalResponse).Msg("Turn completed")
+
+		a.completeTurn()
+		if a.trackPoisonedTurn(poisoned) {
+			return nil
+		}
 
 		// Increment encouragement counter if this was an autonomous turn (no user message)
 		// If synthetic encouragement was added, this means no real user message existed
@@ -941,16 +1749,53 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 		return nil
 	}
 
-	// Max tool iterations reached - end this turn gracefully and continue to next turn
-	// This is NOT an error - the mysis made progress and should continue autonomous operation
-	log.Warn().
-		Str("mysis", a.name).
-		Int("max_iterations", constants.MaxToolIterations).
-		Msg("Max tool iterations reached - ending turn, will continue next turn")
+	// Max tool iterations reached or the model is repeating itself - end this turn
+	// gracefully and continue to next turn. This is NOT an error (unless
+	// tool_iterations_policy says so) - the next turn starts with the repeat warning
+	// in context, or after the placeholder reply, which the model never sees.
+	if repeatedTools != "" {
+		log.Warn().
+			Str("mysis", a.name).
+			Str("tools", repeatedTools).
+			Int("repeats", repeatedCalls).
+			Msg("Repeated identical tool calls - ending turn, will continue next turn")
+	} else {
+		if a.toolIterationsPolicy() == constants.ToolIterationsPolicyError {
+			a.bus.Publish(Event{Type: EventNetworkIdle, MysisID: a.id, Timestamp: time.Now()})
+			err := fmt.Errorf("no reply after %d tool call rounds", constants.MaxToolIterations)
+			a.setError(err)
+			return err
+		}
+		log.Warn().
+			Str("mysis", a.name).
+			Int("max_iterations", constants.MaxToolIterations).
+			Msg("Max tool iterations reached - ending turn, will continue next turn")
+
+		// Close the turn with a placeholder reply so it ends like any other. It is
+		// system-sourced: the model never wrote it, so it stays out of its context.
+		finalResponse := fmt.Sprintf(constants.MaxToolIterationsResponse, constants.MaxToolIterations)
+		if err := a.store.AddMemory(a.id, store.MemoryRoleAssistant, store.MemorySourceSystem, finalResponse, "", ""); err != nil {
+			a.setError(err)
+			return fmt.Errorf("store response: %w", err)
+		}
+		a.bus.Publish(Event{
+			Type:      EventMysisResponse,
+			MysisID:   a.id,
+			MysisName: a.name,
+			Message:   &MessageData{Role: "assistant", Content: finalResponse},
+			Timestamp: time.Now(),
+		})
+	}
+	a.ackBroadcast(prompt)
 
 	// Signal network idle
 	a.bus.Publish(Event{Type: EventNetworkIdle, MysisID: a.id, Timestamp: time.Now()})
 
+	a.completeTurn()
+	if a.trackPoisonedTurn(poisoned) {
+		return nil
+	}
+
 	// Increment encouragement counter if this was an autonomous turn
 	// Same logic as successful turn completion (lines 697-713)
 	if addedSyntheticEncouragement {
@@ -975,6 +1820,125 @@ func (m *Mysis) SendMessageFrom(content string, source store.MemorySource, sende
 	return nil
 }
 
+// toolIterationsPolicy returns [swarm] tool_iterations_policy, defaulting to continue.
+func (m *Mysis) toolIterationsPolicy() string {
+	if m.commander != nil && m.commander.config != nil && m.commander.config.Swarm.ToolIterationsPolicy != "" {
+		return m.commander.config.Swarm.ToolIterationsPolicy
+	}
+	return constants.ToolIterationsPolicyContinue
+}
+
+// recordUsage accumulates provider token usage and persists it along with an
+// estimated cost from the [pricing] entry for this mysis's model.
+func (m *Mysis) recordUsage(usage provider.Usage) {
+	if usage.TotalTokens() == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	m.tokenUsage = m.tokenUsage.Add(usage)
+	model := m.model
+	m.mu.Unlock()
+
+	var cost float64
+	if m.commander != nil && m.commander.config != nil {
+		cost = m.commander.config.EstimateCost(model, usage.PromptTokens, usage.CompletionTokens)
+	}
+
+	if err := m.store.RecordUsage(m.id, usage.PromptTokens, usage.CompletionTokens, cost); err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to record token usage")
+	}
+}
+
+// completeTurn records that a turn finished without error. The count is written
+// to the store every TurnCountPersistEvery turns; Stop writes the remainder.
+func (m *Mysis) completeTurn() {
+	m.mu.Lock()
+	m.turnCount++
+	due := m.turnCount-m.persistedTurnCount >= constants.TurnCountPersistEvery
+	m.mu.Unlock()
+
+	if due {
+		m.persistTurnCount()
+	}
+}
+
+// persistTurnCount writes the turn count to the store if it changed since the last write.
+func (m *Mysis) persistTurnCount() {
+	m.mu.Lock()
+	count := m.turnCount
+	if count == m.persistedTurnCount {
+		m.mu.Unlock()
+		return
+	}
+	m.persistedTurnCount = count
+	m.mu.Unlock()
+
+	if err := m.store.SetMysisTurnCount(m.id, count); err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to persist turn count")
+	}
+}
+
+// recordOrphans counts memories stripped by orphan removal that weren't stripped before.
+// An orphan stays in the context window for several turns, so only new ones count.
+// Orphans that have left the window are forgotten.
+func (m *Mysis) recordOrphans(before, after []*store.Memory) {
+	kept := make(map[*store.Memory]bool, len(after))
+	for _, mem := range after {
+		kept[mem] = true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orphansRemoved = 0
+	seen := make(map[int64]bool)
+	for _, mem := range before {
+		if kept[mem] {
+			continue
+		}
+		seen[mem.ID] = true
+		if !m.seenOrphans[mem.ID] {
+			m.orphansRemoved++
+		}
+	}
+	m.seenOrphans = seen
+}
+
+// lastOrphansRemoved returns how many new orphaned tool calls the last context build stripped.
+func (m *Mysis) lastOrphansRemoved() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.orphansRemoved
+}
+
+// trackPoisonedTurn updates the consecutive poisoned turn counter after a turn completes.
+// Quarantines the mysis and returns true once MaxPoisonedTurns is reached. This is
+// separate from the nudge circuit breaker: the mysis is responding, but its tool calls
+// keep getting stripped as orphans.
+func (m *Mysis) trackPoisonedTurn(poisoned bool) bool {
+	m.mu.Lock()
+	if !poisoned {
+		m.poisonedTurns = 0
+		m.mu.Unlock()
+		return false
+	}
+	m.poisonedTurns++
+	count := m.poisonedTurns
+	m.mu.Unlock()
+
+	log.Debug().
+		Str("mysis", m.name).
+		Int("count", count).
+		Msg("Turn completed with orphaned tool calls stripped from context")
+
+	if count < constants.MaxPoisonedTurns {
+		return false
+	}
+
+	m.setQuarantined(fmt.Errorf("quarantined: orphaned tool calls stripped from context for %d consecutive turns", count))
+	return true
+}
+
 // SendMessage sends a message to the mysis for processing.
 func (m *Mysis) SendMessage(content string, source store.MemorySource) error {
 	a := m
@@ -985,6 +1949,11 @@ func (m *Mysis) SendMessage(content string, source store.MemorySource) error {
 // Unlike SendMessage, this does not block waiting for the mysis to process.
 // Returns immediately after storing the message in the database.
 func (m *Mysis) QueueBroadcast(content string, senderID string) error {
+	return m.queueBroadcast(content, senderID, "")
+}
+
+// queueBroadcast is QueueBroadcast for a broadcast tracked under broadcastID ("" = untracked).
+func (m *Mysis) queueBroadcast(content, senderID, broadcastID string) error {
 	a := m
 
 	a.mu.RLock()
@@ -994,15 +1963,19 @@ func (m *Mysis) QueueBroadcast(content string, senderID string) error {
 	if err := validateCanAcceptMessage(state); err != nil {
 		return err
 	}
+	if a.draining() {
+		return ErrDraining
+	}
 
 	// Store the message immediately (fast DB write, no LLM call)
-	if err := a.store.AddMemory(a.id, store.MemoryRoleUser, store.MemorySourceBroadcast, content, "", senderID); err != nil {
+	if err := a.store.AddBroadcastMemory(a.id, content, senderID, broadcastID); err != nil {
 		return fmt.Errorf("store broadcast: %w", err)
 	}
 
 	// Reset encouragement counter - we have a real user message now
 	a.mu.Lock()
 	a.encouragementCount = 0
+	a.lastMessageAt = a.now()
 	a.mu.Unlock()
 
 	// Emit message event
@@ -1030,6 +2003,8 @@ func (m *Mysis) QueueBroadcast(content string, senderID string) error {
 func (m *Mysis) memoriesToMessages(memories []*store.Memory) []provider.Message {
 	a := m
 	messages := make([]provider.Message, 0, len(memories))
+	ages := a.commander != nil && a.commander.config != nil && a.commander.config.Swarm.ToolResultAges
+	now := a.now()
 
 	for _, m := range memories {
 		msg := provider.Message{
@@ -1037,50 +2012,227 @@ func (m *Mysis) memoriesToMessages(memories []*store.Memory) []provider.Message
 			Content: m.Content,
 		}
 
-		// Handle tool role - needs ToolCallID
-		if m.Role == store.MemoryRoleTool {
-			// Extract tool call ID from stored format: "tool_call_id:content"
-			idx := strings.Index(m.Content, constants.ToolCallStorageFieldDelimiter)
-			if idx <= 0 {
-				log.Warn().
-					Str("content", m.Content).
-					Msg("Skipping malformed tool result - missing delimiter")
-				continue // Skip this message
-			}
-			toolCallID := m.Content[:idx]
-			if toolCallID == "" {
-				log.Warn().
-					Str("content", m.Content).
-					Msg("Skipping tool result with empty tool_call_id")
-				continue // Skip this message
+		// Handle tool role - needs ToolCallID
+		if m.Role == store.MemoryRoleTool {
+			// Extract tool call ID from stored format: "tool_call_id:content"
+			toolCallID, content, ok := ParseStoredToolResult(m.Content)
+			if !ok {
+				log.Warn().
+					Str("content", m.Content).
+					Msg("Skipping malformed tool result - missing tool_call_id")
+				continue // Skip this message
+			}
+			msg.ToolCallID = toolCallID
+			msg.Content = content
+			if ages {
+				msg.Content = a.toolResultAge(m.CreatedAt, now) + content
+			}
+		}
+
+		// Handle assistant messages with tool calls
+		if m.Role == store.MemoryRoleAssistant && strings.HasPrefix(m.Content, constants.ToolCallStoragePrefix) {
+			// Parse tool calls from stored format
+			msg.ToolCalls = a.parseStoredToolCalls(m.Content)
+			if len(msg.ToolCalls) > 0 {
+				msg.Content = "" // Only clear content if tool calls were parsed successfully
+			} else {
+				log.Warn().
+					Str("content", m.Content).
+					Msg("Failed to parse tool calls from assistant message - keeping original content")
+				// Keep original content as fallback
+			}
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages
+}
+
+// toolResultAge returns the marker for a tool result stored at createdAt: its age in
+// server ticks, or in seconds until the tick rate is known.
+func (m *Mysis) toolResultAge(createdAt, now time.Time) string {
+	age := max(now.Sub(createdAt), 0)
+	if tickDuration := m.TickDuration(); tickDuration > 0 {
+		return fmt.Sprintf(constants.ToolResultAgeFormat, int64(age/tickDuration))
+	}
+	return fmt.Sprintf(constants.ToolResultAgeSecondsFormat, int64(age/time.Second))
+}
+
+// chat gets a response from the provider once a turn slot is free. Myses wait
+// for a slot when the swarm is at [swarm] max_concurrent_turns. They queue for the
+// provider's own max_concurrent slot first, so a busy provider never holds swarm
+// slots that myses on other providers could use.
+func (m *Mysis) chat(ctx context.Context, p provider.Provider, messages []provider.Message, tools []provider.Tool) (resp *provider.ChatResponse, err error) {
+	ctx, releaseProvider, err := provider.AcquireSlot(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("wait for provider slot: %w", err)
+	}
+	defer releaseProvider()
+
+	if m.commander != nil {
+		release, slotErr := m.commander.acquireTurnSlot(ctx)
+		if slotErr != nil {
+			return nil, fmt.Errorf("wait for turn slot: %w", slotErr)
+		}
+		defer release()
+
+		// Latency excludes the wait for both slots; failed calls aren't counted
+		start := time.Now()
+		defer func() {
+			if err == nil {
+				m.commander.registry.Latencies().Observe(p.Name(), time.Since(start))
+			}
+		}()
+	}
+
+	if seed := m.Seed(); seed != nil {
+		ctx = provider.WithSeed(ctx, *seed)
+	}
+	if len(tools) > 0 && !p.SupportsTools() {
+		// Text-only model: describe tools in the prompt instead of dropping them
+		return provider.ChatWithToolShim(ctx, p, messages, tools)
+	}
+	if len(tools) > 0 {
+		return p.ChatWithTools(ctx, messages, tools)
+	}
+	// No tools available, use simple chat
+	text, err := p.Chat(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	return &provider.ChatResponse{Content: text}, nil
+}
+
+// turnTimeout returns the provider's request timeout, or constants.LLMRequestTimeout if unset.
+func turnTimeout(p provider.Provider) time.Duration {
+	if p != nil {
+		if timeout := p.RequestTimeout(); timeout > 0 {
+			return timeout
+		}
+	}
+	return constants.LLMRequestTimeout
+}
+
+// callToolCancelable runs a proxy tool call but returns as soon as ctx is done,
+// even if the tool handler or upstream transport ignores cancellation. An abandoned
+// call finishes in the background and its result is discarded. A call with an
+// already-done context is not started.
+func callToolCancelable(ctx context.Context, mcpProxy *mcp.Proxy, caller mcp.CallerContext, tc provider.ToolCall) (*mcp.ToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type callResult struct {
+		result *mcp.ToolResult
+		err    error
+	}
+
+	done := make(chan callResult, 1)
+	go func() {
+		result, err := mcpProxy.CallTool(ctx, caller, tc.Name, tc.Arguments)
+		done <- callResult{result: result, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.result, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// executeToolCallsBatch runs several read-only tool calls through the proxy's batch path.
+// Like callToolCancelable, it returns as soon as ctx is done.
+func (m *Mysis) executeToolCallsBatch(ctx context.Context, mcpProxy *mcp.Proxy, calls []provider.ToolCall) []mcp.ToolCallResult {
+	a := m
+	results := make([]mcp.ToolCallResult, len(calls))
+	if mcpProxy == nil {
+		for i := range results {
+			results[i].Result = &mcp.ToolResult{
+				Content: []mcp.ContentBlock{{Type: "text", Text: "MCP not configured"}},
+				IsError: true,
+			}
+		}
+		return results
+	}
+
+	if err := ctx.Err(); err != nil {
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	caller := mcp.CallerContext{
+		MysisID:   a.id,
+		MysisName: a.name,
+	}
+	mcpCalls := make([]mcp.ToolCall, len(calls))
+	for i, tc := range calls {
+		mcpCalls[i] = mcp.ToolCall{Name: tc.Name, Arguments: tc.Arguments}
+	}
+
+	// Batched calls are observed and logged individually, each with the duration of the
+	// whole batch
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		for i, tc := range calls {
+			if i < len(results) {
+				a.observeToolCall(tc, results[i].Result, results[i].Err, duration)
+				a.logToolCall(tc, results[i].Result, results[i].Err, duration)
 			}
-			msg.ToolCallID = toolCallID
-			msg.Content = m.Content[idx+1:]
 		}
+	}()
 
-		// Handle assistant messages with tool calls
-		if m.Role == store.MemoryRoleAssistant && strings.HasPrefix(m.Content, constants.ToolCallStoragePrefix) {
-			// Parse tool calls from stored format
-			msg.ToolCalls = a.parseStoredToolCalls(m.Content)
-			if len(msg.ToolCalls) > 0 {
-				msg.Content = "" // Only clear content if tool calls were parsed successfully
-			} else {
-				log.Warn().
-					Str("content", m.Content).
-					Msg("Failed to parse tool calls from assistant message - keeping original content")
-				// Keep original content as fallback
-			}
+	done := make(chan []mcp.ToolCallResult, 1)
+	go func() {
+		done <- mcpProxy.CallToolsBatch(ctx, caller, mcpCalls)
+	}()
+
+	select {
+	case results = <-done:
+	case <-ctx.Done():
+		for i := range results {
+			results[i].Err = ctx.Err()
 		}
+		return results
+	}
 
-		messages = append(messages, msg)
+	for _, res := range results {
+		if res.Err != nil && isMCPConnectionLost(res.Err) {
+			log.Warn().
+				Str("mysis", a.name).
+				Err(res.Err).
+				Msg("MCP connection lost during batched tool calls - releasing account")
+			if rebuildErr := a.rebuildSystemMemory(); rebuildErr != nil {
+				log.Error().Err(rebuildErr).Msg("failed to rebuild system memory after MCP session loss")
+			}
+			break
+		}
 	}
 
-	return messages
+	return results
 }
 
 // executeToolCall executes a single tool call via MCP proxy.
-func (m *Mysis) executeToolCall(ctx context.Context, mcpProxy *mcp.Proxy, tc provider.ToolCall) (*mcp.ToolResult, error) {
+func (m *Mysis) executeToolCall(ctx context.Context, mcpProxy *mcp.Proxy, tc provider.ToolCall) (result *mcp.ToolResult, err error) {
 	a := m
+	start := time.Now()
+	defer func() {
+		elapsed := time.Since(start)
+		a.observeToolCall(tc, result, err, elapsed)
+		a.logToolCall(tc, result, err, elapsed)
+	}()
+
+	// The provider only sees permitted tools, but models can still name others
+	if !a.ToolPolicy().Permits(tc.Name) {
+		return &mcp.ToolResult{
+			Content: []mcp.ContentBlock{{Type: "text", Text: fmt.Sprintf("tool %s is disabled for this mysis", tc.Name)}},
+			IsError: true,
+		}, nil
+	}
 	if mcpProxy == nil {
 		return &mcp.ToolResult{
 			Content: []mcp.ContentBlock{{Type: "text", Text: "MCP not configured"}},
@@ -1093,7 +2245,7 @@ func (m *Mysis) executeToolCall(ctx context.Context, mcpProxy *mcp.Proxy, tc pro
 		MysisName: a.name,
 	}
 
-	result, err := mcpProxy.CallTool(ctx, caller, tc.Name, tc.Arguments)
+	result, err = callToolCancelable(ctx, mcpProxy, caller, tc)
 	if err != nil && isMCPConnectionLost(err) {
 		log.Warn().
 			Str("mysis", a.name).
@@ -1217,6 +2369,27 @@ func extractUsername(result *mcp.ToolResult) string {
 	return ""
 }
 
+// limitReasoning cuts reasoning to [swarm] max_reasoning_bytes before it is stored,
+// keeping the head and noting the cut. Reasoning is never sent back as context, so
+// this only saves storage.
+func (m *Mysis) limitReasoning(reasoning string) string {
+	if m.commander == nil || m.commander.config == nil {
+		return reasoning
+	}
+	maxBytes := m.commander.config.Swarm.MaxReasoningBytes
+	if maxBytes <= 0 || len(reasoning) <= maxBytes {
+		return reasoning
+	}
+
+	// Don't split a multi-byte character
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(reasoning[cut]) {
+		cut--
+	}
+	log.Debug().Str("mysis", m.name).Int("reasoning_len", len(reasoning)).Int("max", maxBytes).Msg("Truncating stored reasoning")
+	return reasoning[:cut] + fmt.Sprintf(constants.ReasoningTruncatedFormat, cut, len(reasoning))
+}
+
 // formatToolCallsForStorage formats tool calls for storage in memory.
 func (m *Mysis) formatToolCallsForStorage(calls []provider.ToolCall) string {
 	var parts []string
@@ -1227,7 +2400,24 @@ func (m *Mysis) formatToolCallsForStorage(calls []provider.ToolCall) string {
 }
 
 // parseStoredToolCalls parses tool calls from stored format.
+// Invalid JSON arguments are replaced with an empty object so the provider accepts them.
 func (m *Mysis) parseStoredToolCalls(stored string) []provider.ToolCall {
+	calls := ParseStoredToolCalls(stored)
+	for i, call := range calls {
+		if !json.Valid(call.Arguments) {
+			log.Warn().
+				Str("tool_call_id", call.ID).
+				Str("tool_name", call.Name).
+				Msg("Invalid JSON in tool call arguments - using empty object")
+			calls[i].Arguments = json.RawMessage("{}")
+		}
+	}
+	return calls
+}
+
+// ParseStoredToolCalls decodes tool calls from the storage format
+// ("[TOOL_CALLS]id:name:args|..."). Arguments are returned as stored, even if invalid JSON.
+func ParseStoredToolCalls(stored string) []provider.ToolCall {
 	stored = strings.TrimPrefix(stored, constants.ToolCallStoragePrefix)
 	if stored == "" {
 		return nil
@@ -1238,44 +2428,43 @@ func (m *Mysis) parseStoredToolCalls(stored string) []provider.ToolCall {
 	for _, part := range parts {
 		fields := strings.SplitN(part, constants.ToolCallStorageFieldDelimiter, constants.ToolCallStorageFieldCount)
 		if len(fields) >= constants.ToolCallStorageFieldCount {
-			args := json.RawMessage(fields[2])
-			if !json.Valid(args) {
-				log.Warn().
-					Str("tool_call_id", fields[0]).
-					Str("tool_name", fields[1]).
-					Msg("Invalid JSON in tool call arguments - using empty object")
-				args = json.RawMessage("{}")
-			}
 			calls = append(calls, provider.ToolCall{
 				ID:        fields[0],
 				Name:      fields[1],
-				Arguments: args,
+				Arguments: json.RawMessage(fields[2]),
 			})
 		}
 	}
 	return calls
 }
 
+// ParseStoredToolResult splits a stored tool result ("tool_call_id:content") into its
+// tool call ID and content. Returns ok=false if the ID is missing.
+func ParseStoredToolResult(stored string) (toolCallID, content string, ok bool) {
+	idx := strings.Index(stored, constants.ToolCallStorageFieldDelimiter)
+	if idx <= 0 {
+		return "", "", false
+	}
+	return stored[:idx], stored[idx+len(constants.ToolCallStorageFieldDelimiter):], true
+}
+
 // formatToolResult formats a tool result for storage (includes ID for LLM context).
 func (m *Mysis) formatToolResult(toolCallID, toolName string, result *mcp.ToolResult, err error) string {
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			return fmt.Sprintf("%s%sError calling %s: tool call timed out: %v", toolCallID, constants.ToolCallStorageFieldDelimiter, toolName, err)
 		}
+		var retryErr *mcp.ToolRetryError
+		if errors.As(err, &retryErr) {
+			return fmt.Sprintf("%s%sError calling %s: MCP call failed after retries (%d retries, cause: %s): %v", toolCallID, constants.ToolCallStorageFieldDelimiter, toolName, retryErr.Retries, retryErr.Cause, retryErr.LastErr)
+		}
 		if errors.Is(err, mcp.ErrToolRetryExhausted) {
 			return fmt.Sprintf("%s%sError calling %s: MCP call failed after retries: %v", toolCallID, constants.ToolCallStorageFieldDelimiter, toolName, err)
 		}
 		return fmt.Sprintf("%s%sError calling %s: %v. Check the tool's required parameters and try again.", toolCallID, constants.ToolCallStorageFieldDelimiter, toolName, err)
 	}
 
-	var texts []string
-	for _, block := range result.Content {
-		if block.Type == "text" {
-			texts = append(texts, block.Text)
-		}
-	}
-
-	content := strings.Join(texts, "\n")
+	content := result.DisplayText()
 	if result.IsError {
 		if strings.Contains(content, "empty_entry") {
 			content = fmt.Sprintf("Error calling %s: %s. The entry field must contain non-empty text. Example: captains_log_add({\"entry\": \"Your message here\"})", toolName, content)
@@ -1284,7 +2473,35 @@ func (m *Mysis) formatToolResult(toolCallID, toolName string, result *mcp.ToolRe
 		}
 	}
 
-	return fmt.Sprintf("%s%s%s", toolCallID, constants.ToolCallStorageFieldDelimiter, content)
+	return fmt.Sprintf("%s%s%s", toolCallID, constants.ToolCallStorageFieldDelimiter, m.limitToolResult(toolName, content))
+}
+
+// limitToolResult cuts a tool result to [swarm] max_tool_result_bytes, or
+// max_snapshot_result_bytes for snapshot tools, before it is stored. The cut backs off
+// to a line break or comma in the last quarter of the kept bytes so JSON results end
+// on a field boundary, and a marker notes how much was dropped.
+func (m *Mysis) limitToolResult(toolName, content string) string {
+	if m.commander == nil || m.commander.config == nil {
+		return content
+	}
+	maxBytes := m.commander.config.Swarm.MaxToolResultBytes
+	if snapshot := m.commander.config.Swarm.MaxSnapshotResultBytes; snapshot > 0 && m.isSnapshotTool(toolName) {
+		maxBytes = snapshot
+	}
+	if maxBytes <= 0 || len(content) <= maxBytes {
+		return content
+	}
+
+	cut := maxBytes
+	if i := strings.LastIndexAny(content[:maxBytes], "\n,"); i >= maxBytes*3/4 {
+		cut = i + 1
+	}
+	// Don't split a multi-byte character
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
+	}
+	log.Debug().Str("mysis", m.name).Str("tool", toolName).Int("result_len", len(content)).Int("max", maxBytes).Msg("Truncating stored tool result")
+	return content[:cut] + fmt.Sprintf(constants.ToolResultTruncatedFormat, len(content)-cut)
 }
 
 func isToolTimeout(err error) bool {
@@ -1323,14 +2540,7 @@ func (m *Mysis) formatToolResultDisplay(result *mcp.ToolResult, err error) strin
 		return fmt.Sprintf("Error: %v", err)
 	}
 
-	var texts []string
-	for _, block := range result.Content {
-		if block.Type == "text" {
-			texts = append(texts, block.Text)
-		}
-	}
-
-	content := strings.Join(texts, "\n")
+	content := result.DisplayText()
 	if result.IsError {
 		content = "Error: " + content
 	}
@@ -1367,6 +2577,7 @@ func (m *Mysis) setError(err error) {
 		Str("old_state", string(oldState)).
 		Err(err).
 		Msg("Mysis transitioning to errored state")
+	a.fileLogger().Error().Err(err).Msg("Mysis errored")
 
 	a.lastError = err
 	a.state = MysisStateErrored
@@ -1385,7 +2596,68 @@ func (m *Mysis) setError(err error) {
 		Type:      EventMysisError,
 		MysisID:   a.id,
 		MysisName: a.name,
-		Error:     &ErrorData{Error: err.Error()},
+		Error:     newErrorData(err),
+		Timestamp: time.Now(),
+	})
+}
+
+// newErrorData builds error event data, tagging tool retry exhaustion with its metadata.
+func newErrorData(err error) *ErrorData {
+	data := &ErrorData{Error: err.Error()}
+	var retryErr *mcp.ToolRetryError
+	if errors.As(err, &retryErr) {
+		data.Kind = ErrorKindToolRetryExhausted
+		data.Tool = retryErr.Tool
+		data.Retries = retryErr.Retries
+		data.Cause = retryErr.Cause
+		if retryErr.LastErr != nil {
+			data.Reason = retryErr.LastErr.Error()
+		}
+	}
+	return data
+}
+
+// setQuarantined halts a mysis that keeps poisoning its context. Like setError it
+// records lastError and requires an explicit relaunch, but it uses its own state so
+// operators can tell a misbehaving model apart from provider or MCP failures.
+func (m *Mysis) setQuarantined(err error) {
+	a := m
+	a.mu.Lock()
+	oldState := a.state
+
+	if oldState == MysisStateStopped {
+		a.mu.Unlock()
+		log.Debug().Str("mysis", a.name).Err(err).Msg("Ignoring quarantine - mysis was intentionally stopped")
+		return
+	}
+
+	log.Warn().
+		Str("mysis", a.name).
+		Str("old_state", string(oldState)).
+		Err(err).
+		Msg("Mysis transitioning to quarantined state")
+	a.fileLogger().Warn().Err(err).Msg("Mysis quarantined")
+
+	// Cancel context to stop run loop goroutine (like setIdle does)
+	if a.cancel != nil {
+		a.cancel()
+	}
+
+	a.lastError = err
+	a.state = MysisStateQuarantined
+	a.mu.Unlock()
+
+	if updateErr := a.store.UpdateMysisState(a.id, store.MysisStateQuarantined); updateErr != nil {
+		log.Warn().Err(updateErr).Str("mysis", a.id).Msg("Failed to update state in store")
+	}
+
+	a.emitStateChange(oldState, MysisStateQuarantined)
+
+	a.publishCriticalEvent(Event{
+		Type:      EventMysisError,
+		MysisID:   a.id,
+		MysisName: a.name,
+		Error:     newErrorData(err),
 		Timestamp: time.Now(),
 	})
 }
@@ -1513,19 +2785,30 @@ func (m *Mysis) extractLatestToolLoop(memories []*store.Memory) []*store.Memory
 func (m *Mysis) findLastUserPromptIndex(memories []*store.Memory) int {
 	// Scan backwards to find most recent user prompt
 	for i := len(memories) - 1; i >= 0; i-- {
-		mem := memories[i]
-		if mem.Role == store.MemoryRoleUser {
-			// User messages from direct, broadcast, or system (nudges) all count as prompts
-			if mem.Source == store.MemorySourceDirect ||
-				mem.Source == store.MemorySourceBroadcast ||
-				mem.Source == store.MemorySourceSystem {
-				return i
-			}
+		if IsTurnPrompt(memories[i]) {
+			return i
 		}
 	}
 	return -1
 }
 
+// IsTurnPrompt reports whether a memory starts a turn: a user message from a direct
+// message, broadcast, or system nudge.
+func IsTurnPrompt(mem *store.Memory) bool {
+	if mem.Role != store.MemoryRoleUser {
+		return false
+	}
+	return mem.Source == store.MemorySourceDirect ||
+		mem.Source == store.MemorySourceBroadcast ||
+		mem.Source == store.MemorySourceSystem
+}
+
+// isTurnEndPlaceholder reports whether a memory is the reply stored for a turn that
+// ended without one (see constants.MaxToolIterationsResponse).
+func isTurnEndPlaceholder(mem *store.Memory) bool {
+	return mem.Role == store.MemoryRoleAssistant && mem.Source == store.MemorySourceSystem
+}
+
 // getContextMemories returns memories for LLM context with turn-aware composition.
 // Composes context as: [system prompt] + [historical context] + [current turn].
 //
@@ -1560,15 +2843,31 @@ func (m *Mysis) findLastUserPromptIndex(memories []*store.Memory) int {
 //	  {role: tool, content: "call_2:Ship health: 100%"}
 //	]
 func (m *Mysis) getContextMemories() ([]*store.Memory, bool, error) {
+	return m.contextMemories(m.isFirstTurn())
+}
+
+// contextMemories is getContextMemories with the first-turn decision made by the
+// caller, so it stays fixed across a turn's tool iterations.
+func (m *Mysis) contextMemories(firstTurn bool) ([]*store.Memory, bool, error) {
 	// Track if synthetic encouragement was added this call
 	addedSynthetic := false
 
 	// Get all recent memories
-	allMemories, err := m.store.GetRecentMemories(m.id, constants.MaxContextMessages)
+	allMemories, err := m.store.GetRecentMemories(m.id, m.ContextWindow())
 	if err != nil {
 		return nil, false, err
 	}
 
+	// Notes are injected separately below; keep them out of turn composition, along
+	// with the placeholder replies of turns that ended without one
+	filtered := allMemories[:0:0]
+	for _, mem := range allMemories {
+		if mem.Source != store.MemorySourceNote && !isTurnEndPlaceholder(mem) {
+			filtered = append(filtered, mem)
+		}
+	}
+	allMemories = filtered
+
 	// Find current turn boundary
 	turnBoundaryIdx := m.findLastUserPromptIndex(allMemories)
 
@@ -1580,6 +2879,10 @@ func (m *Mysis) getContextMemories() ([]*store.Memory, bool, error) {
 		result = append(result, system)
 	}
 
+	// Step 1b: Add the most recent notes (durable, never compacted)
+	result = append(result, m.contextNotes()...)
+	headerLen := len(result)
+
 	// Step 2: Add historical context (before current turn)
 	if turnBoundaryIdx > 0 {
 		historicalMemories := allMemories[:turnBoundaryIdx]
@@ -1631,8 +2934,9 @@ func (m *Mysis) getContextMemories() ([]*store.Memory, bool, error) {
 			if !alreadyInContext {
 				result = append(result, broadcast)
 			}
-		} else {
-			// No broadcast exists - add synthetic encouragement message
+		} else if m.nudgesEnabled() {
+			// No broadcast exists - add synthetic encouragement message. Myses without
+			// nudges get none; SendMessageFrom idles them before they get here.
 			// Include recent tool loop to maintain conversation continuity
 			historicalToolLoop := m.extractLatestToolLoop(allMemories)
 			if len(historicalToolLoop) > 0 {
@@ -1640,13 +2944,13 @@ func (m *Mysis) getContextMemories() ([]*store.Memory, bool, error) {
 			}
 
 			// Then add synthetic encouragement message
-			nudgeContent := "Continue your mission. Check notifications and coordinate with the swarm."
+			nudgeContent := m.buildNudge(m.EncouragementCount(), firstTurn)
 			nudgeMemory := &store.Memory{
 				Role:      store.MemoryRoleUser,
 				Source:    store.MemorySourceSystem,
 				Content:   nudgeContent,
 				SenderID:  "",
-				CreatedAt: time.Now(),
+				CreatedAt: m.now(),
 			}
 			result = append(result, nudgeMemory)
 
@@ -1656,16 +2960,107 @@ func (m *Mysis) getContextMemories() ([]*store.Memory, bool, error) {
 		}
 	}
 
-	// Apply compression to reduce context size and ensure API compliance
-	result = m.compactSnapshots(result)
+	// Step 4: Add recent peer broadcasts after the notes, when enabled. They are system
+	// messages, so they never become the turn's prompt or reset the encouragement count.
+	if peers := m.contextPeerBroadcasts(result); len(peers) > 0 {
+		result = append(result[:headerLen], append(peers, result[headerLen:]...)...)
+	}
+
+	// Apply compression to reduce context size and ensure API compliance.
+	// Orphan removal always runs; compaction can be disabled per mysis for debugging.
+	if m.CompactSnapshots() {
+		result = m.compactSnapshots(result)
+	}
+	beforeOrphans := result
 	result = m.removeOrphanedToolCalls(result)
+	m.recordOrphans(beforeOrphans, result)
 
 	return result, addedSynthetic, nil
 }
 
+// contextNotes returns the mysis's most recent notes, formatted for context injection.
+func (m *Mysis) contextNotes() []*store.Memory {
+	limit := constants.DefaultMaxNotesInContext
+	if m.commander != nil && m.commander.config != nil && m.commander.config.Swarm.MaxNotesInContext > 0 {
+		limit = m.commander.config.Swarm.MaxNotesInContext
+	}
+
+	notes, err := m.store.GetRecentNotes(m.id, limit)
+	if err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to load notes for context")
+		return nil
+	}
+
+	result := make([]*store.Memory, 0, len(notes))
+	for _, note := range notes {
+		injected := *note
+		injected.Content = "Note: " + note.Content
+		result = append(result, &injected)
+	}
+	return result
+}
+
+// contextPeerBroadcasts returns the last [swarm] peer_broadcasts_in_context broadcasts
+// from other myses, labeled as system messages. Broadcasts already in context are skipped.
+func (m *Mysis) contextPeerBroadcasts(context []*store.Memory) []*store.Memory {
+	if m.commander == nil || m.commander.config == nil || m.commander.config.Swarm.PeerBroadcastsInContext <= 0 {
+		return nil
+	}
+	limit := min(m.commander.config.Swarm.PeerBroadcastsInContext, constants.MaxPeerBroadcastsInContext)
+
+	broadcasts, err := m.store.GetRecentPeerBroadcasts(m.id, limit)
+	if err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to load peer broadcasts for context")
+		return nil
+	}
+
+	included := make(map[int64]bool, len(context))
+	for _, mem := range context {
+		if mem.ID != 0 {
+			included[mem.ID] = true
+		}
+	}
+
+	result := make([]*store.Memory, 0, len(broadcasts))
+	for _, b := range broadcasts {
+		if included[b.ID] {
+			continue
+		}
+		sender := m.commander.ResolveMysisName(b.SenderID)
+		if sender == "" {
+			sender = b.SenderID
+		}
+		injected := *b
+		injected.Role = store.MemoryRoleSystem
+		injected.Content = fmt.Sprintf(constants.PeerBroadcastContextFormat, sender, b.Content)
+		result = append(result, &injected)
+	}
+	return result
+}
+
+// AddNote stores a durable note for this mysis. Notes are never compacted and the most
+// recent ones are always included in context after the system prompt.
+func (m *Mysis) AddNote(content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return fmt.Errorf("note cannot be empty")
+	}
+	if len(content) > constants.MaxNoteLength {
+		return fmt.Errorf("note exceeds %d characters", constants.MaxNoteLength)
+	}
+
+	if err := m.store.AddNote(m.id, content); err != nil {
+		return fmt.Errorf("store note: %w", err)
+	}
+	log.Debug().Str("mysis", m.name).Int("bytes", len(content)).Msg("Note added")
+	return nil
+}
+
 // compactSnapshots removes redundant snapshot tool results, keeping only the most recent
 // result for each snapshot tool. This prevents state-heavy tools from crowding out
-// conversation history while ensuring the latest state is available.
+// conversation history while ensuring the latest state is available. With snapshot
+// summaries enabled, each compacted tool leaves one constants.SnapshotSummaryFormat
+// marker after the run of tool results holding its last dropped result.
 func (m *Mysis) compactSnapshots(memories []*store.Memory) []*store.Memory {
 	a := m
 	if len(memories) == 0 {
@@ -1695,14 +3090,41 @@ func (m *Mysis) compactSnapshots(memories []*store.Memory) []*store.Memory {
 		}
 	}
 
+	// Index of the last dropped result per tool, for summary markers
+	lastDropped := make(map[int]string)
+	if a.SnapshotSummaries() {
+		dropped := make(map[string]int)
+		for i, m := range memories {
+			if m.Role != store.MemoryRoleTool {
+				continue
+			}
+			toolName := a.extractToolNameFromResult(m.Content, toolCallNames)
+			if a.isSnapshotTool(toolName) && latestSnapshot[toolName] != i {
+				dropped[toolName] = i
+			}
+		}
+		for toolName, i := range dropped {
+			lastDropped[i] = toolName
+		}
+	}
+
 	// Second pass: build result, skipping older snapshot tool results
 	result := make([]*store.Memory, 0, len(memories))
+	var pending []string // Summary markers held until the current run of tool results ends
 	for i, m := range memories {
 		// Keep non-tool memories
 		if m.Role != store.MemoryRoleTool {
+			// Markers go between messages so tool results stay next to their calls
+			for _, toolName := range pending {
+				result = append(result, a.snapshotSummary(toolName, m.CreatedAt))
+			}
+			pending = nil
 			result = append(result, m)
 			continue
 		}
+		if toolName, ok := lastDropped[i]; ok {
+			pending = append(pending, toolName)
+		}
 
 		// Extract tool name
 		toolName := a.extractToolNameFromResult(m.Content, toolCallNames)
@@ -1723,10 +3145,23 @@ func (m *Mysis) compactSnapshots(memories []*store.Memory) []*store.Memory {
 		// Keep non-snapshot tool results
 		result = append(result, m)
 	}
+	for _, toolName := range pending {
+		result = append(result, a.snapshotSummary(toolName, memories[len(memories)-1].CreatedAt))
+	}
 
 	return result
 }
 
+// snapshotSummary returns the context-only marker for a compacted snapshot tool.
+func (m *Mysis) snapshotSummary(toolName string, createdAt time.Time) *store.Memory {
+	return &store.Memory{
+		Role:      store.MemoryRoleSystem,
+		Source:    store.MemorySourceSystem,
+		Content:   fmt.Sprintf(constants.SnapshotSummaryFormat, toolName),
+		CreatedAt: createdAt,
+	}
+}
+
 // collectValidToolResultIDs extracts all tool call IDs from tool result messages.
 // Used to validate that assistant tool calls have corresponding tool results.
 func (m *Mysis) collectValidToolResultIDs(memories []*store.Memory) map[string]bool {
@@ -1734,9 +3169,7 @@ func (m *Mysis) collectValidToolResultIDs(memories []*store.Memory) map[string]b
 
 	for _, mem := range memories {
 		if mem.Role == store.MemoryRoleTool {
-			idx := strings.Index(mem.Content, constants.ToolCallStorageFieldDelimiter)
-			if idx > 0 {
-				toolCallID := mem.Content[:idx]
+			if toolCallID, _, ok := ParseStoredToolResult(mem.Content); ok {
 				validToolResults[toolCallID] = true
 			}
 		}
@@ -1790,12 +3223,10 @@ func (m *Mysis) removeOrphanedToolCalls(memories []*store.Memory) []*store.Memor
 }
 
 func (m *Mysis) extractToolNameFromResult(content string, toolCallNames map[string]string) string {
-	idx := strings.Index(content, constants.ToolCallStorageFieldDelimiter)
-	if idx <= 0 {
+	callID, _, ok := ParseStoredToolResult(content)
+	if !ok {
 		return ""
 	}
-
-	callID := content[:idx]
 	return toolCallNames[callID]
 }
 
@@ -1820,6 +3251,27 @@ func (m *Mysis) toolCallNameIndex(memories []*store.Memory) map[string]string {
 	return index
 }
 
+// allToolsPermitted reports whether the mysis tool policy permits every call.
+func (m *Mysis) allToolsPermitted(calls []provider.ToolCall) bool {
+	policy := m.ToolPolicy()
+	for _, tc := range calls {
+		if !policy.Permits(tc.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// allSnapshotTools reports whether every call is a read-only snapshot tool.
+func (m *Mysis) allSnapshotTools(calls []provider.ToolCall) bool {
+	for _, tc := range calls {
+		if !m.isSnapshotTool(tc.Name) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Mysis) isSnapshotTool(toolName string) bool {
 	if toolName == "" {
 		return false
@@ -1844,8 +3296,34 @@ func (m *Mysis) run(ctx context.Context) {
 		defer a.commander.wg.Done()
 	}
 
+	a.warmUpProvider(ctx)
+
+	for {
+		a.runTurns(ctx)
+		if a.finishRun(ctx) {
+			return
+		}
+	}
+}
+
+// runTurns takes autonomous turns until the mysis leaves the running state or ctx is
+// canceled.
+func (m *Mysis) runTurns(ctx context.Context) {
+	a := m
 	// Autonomous turn loop - continues until idle, stopped, errored, or context canceled
 	for {
+		// A draining swarm finishes in-flight turns but starts no new ones
+		if a.draining() {
+			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - swarm draining")
+			return
+		}
+
+		// Stop instead of turning forever on old messages or nudges
+		if quiet, ok := a.idleStopDue(); ok {
+			a.stopIdle(quiet)
+			return
+		}
+
 		// Process one turn using existing SendMessageFrom infrastructure
 		// Use empty content - getContextMemories() will add synthetic encouragement if needed
 		// This reuses all the complex LLM loop logic, tool calling, error handling, etc.
@@ -1864,6 +3342,7 @@ func (m *Mysis) run(ctx context.Context) {
 		switch state {
 		case MysisStateIdle:
 			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - mysis idle")
+			a.scheduleIdleStop()
 			return
 		case MysisStateStopped:
 			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - mysis stopped")
@@ -1871,47 +3350,70 @@ func (m *Mysis) run(ctx context.Context) {
 		case MysisStateErrored:
 			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - mysis errored")
 			return
+		case MysisStateQuarantined:
+			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - mysis quarantined")
+			return
 		}
 
-		// Wait before next turn (2 seconds delay)
+		// Wait before next turn
 		select {
-		case <-time.After(2 * time.Second):
+		case <-time.After(a.turnInterval()):
 			// Continue to next turn
 		case <-ctx.Done():
 			// Context canceled (Stop() called)
 			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - context canceled")
 			return
+		case <-a.drainCh():
+			log.Debug().Str("mysis", a.name).Msg("Autonomous turn loop exiting - swarm draining")
+			return
+		}
+	}
+}
+
+// drainCh is closed when the commander starts draining (nil = never, without a
+// commander).
+func (m *Mysis) drainCh() <-chan struct{} {
+	if m.commander == nil {
+		return nil
+	}
+	return m.commander.drainCh
+}
+
+// draining reports whether the commander is draining the swarm for shutdown.
+func (m *Mysis) draining() bool {
+	return m.commander != nil && m.commander.Draining()
+}
+
+// latestCommanderBroadcast returns the most recent commander broadcast injected into
+// the system prompt, or nil if there is none (or it can't be loaded).
+func (m *Mysis) latestCommanderBroadcast() *store.BroadcastMessage {
+	// Get more than one to filter for commander broadcasts (empty sender_id)
+	broadcasts, err := m.store.GetRecentBroadcasts(10)
+	if err != nil {
+		return nil
+	}
+	for _, b := range broadcasts {
+		if b.SenderID == "" {
+			return b
 		}
 	}
+	return nil
 }
 
 // buildSystemPrompt creates the system prompt with the latest swarm broadcast injected.
 func (m *Mysis) buildSystemPrompt() string {
-	a := m
 	base := constants.SystemPrompt
 
-	// Get most recent commander broadcast (sender_id is empty for commander broadcasts)
-	broadcasts, err := a.store.GetRecentBroadcasts(10) // Get more to filter for commander broadcasts
-	if err != nil {
+	if commanderBroadcast := m.latestCommanderBroadcast(); commanderBroadcast == nil {
+		// No commander broadcasts yet - show fallback
 		base = strings.Replace(base, "{{LATEST_BROADCAST}}", constants.BroadcastFallback, 1)
 	} else {
-		// Find the most recent commander broadcast (empty sender_id)
-		var commanderBroadcast *store.BroadcastMessage
-		for _, b := range broadcasts {
-			if b.SenderID == "" {
-				commanderBroadcast = b
-				break
-			}
-		}
-
-		if commanderBroadcast == nil {
-			// No commander broadcasts yet - show fallback
-			base = strings.Replace(base, "{{LATEST_BROADCAST}}", constants.BroadcastFallback, 1)
-		} else {
-			// Format broadcast section (only content, no sender name)
-			broadcastSection := fmt.Sprintf(constants.BroadcastSectionTemplate, commanderBroadcast.Content)
-			base = strings.Replace(base, "{{LATEST_BROADCAST}}", broadcastSection, 1)
+		sender := CommanderName
+		if m.commander != nil {
+			sender = m.commander.commanderName()
 		}
+		broadcastSection := fmt.Sprintf(constants.BroadcastSectionTemplate, sender, commanderBroadcast.Content)
+		base = strings.Replace(base, "{{LATEST_BROADCAST}}", broadcastSection, 1)
 	}
 
 	prompt := base
@@ -1931,9 +3433,42 @@ func (m *Mysis) buildSystemPrompt() string {
 	gameStateSummary := m.buildGameStateSummary()
 	prompt = strings.Replace(prompt, "{{GAME_STATE_SUMMARY}}", gameStateSummary, 1)
 
+	// Replace {{GOAL}} with the standing goal, dropping the section when there is none
+	goalSection := ""
+	if goal := m.Goal(); goal != "" {
+		goalSection = fmt.Sprintf(constants.GoalSectionTemplate, goal)
+	}
+	prompt = strings.Replace(prompt, "{{GOAL}}", goalSection, 1)
+
 	return prompt
 }
 
+// SystemPromptPreview is the system prompt a mysis would be given now, next to the one
+// stored in its memory.
+type SystemPromptPreview struct {
+	Prompt    string // Rebuilt with the latest commander broadcast, account and game state
+	Stored    string // System memory sent on every turn ("" before the first start)
+	Broadcast string // Commander broadcast injected into Prompt ("" = fallback section)
+}
+
+// Stale reports whether the stored prompt differs from a rebuild. The stored prompt is
+// refreshed on login and account changes.
+func (p *SystemPromptPreview) Stale() bool {
+	return p.Stored != "" && p.Stored != p.Prompt
+}
+
+// SystemPromptPreview rebuilds the system prompt without storing it.
+func (m *Mysis) SystemPromptPreview() *SystemPromptPreview {
+	preview := &SystemPromptPreview{Prompt: m.buildSystemPrompt()}
+	if b := m.latestCommanderBroadcast(); b != nil {
+		preview.Broadcast = b.Content
+	}
+	if system, err := m.store.GetSystemMemory(m.id); err == nil && system != nil {
+		preview.Stored = system.Content
+	}
+	return preview
+}
+
 // buildGameStateSummary creates a compact summary of cached game state.
 func (m *Mysis) buildGameStateSummary() string {
 	username := m.CurrentAccountUsername()
@@ -1996,14 +3531,9 @@ func (m *Mysis) rebuildSystemMemory() error {
 	// Build new system prompt with current credentials and broadcasts
 	newPrompt := m.buildSystemPrompt()
 
-	// Delete old system memory
-	if err := m.store.DeleteSystemMemory(m.id); err != nil {
-		return fmt.Errorf("delete old system memory: %w", err)
-	}
-
-	// Store new system memory
-	if err := m.store.AddMemory(m.id, store.MemoryRoleSystem, store.MemorySourceSystem, newPrompt, "", ""); err != nil {
-		return fmt.Errorf("add new system memory: %w", err)
+	// Update in place so a stale duplicate can never be picked up later
+	if err := m.store.ReplaceSystemMemory(m.id, newPrompt); err != nil {
+		return fmt.Errorf("replace system memory: %w", err)
 	}
 
 	return nil
@@ -2015,7 +3545,7 @@ func (m *Mysis) updateActivityFromToolResult(result *mcp.ToolResult, err error)
 		return
 	}
 
-	now := time.Now()
+	now := a.now()
 	payload, ok := parseToolResultPayload(result)
 	var currentTick int64
 	var currentTickOK bool
@@ -2452,6 +3982,7 @@ func (m *Mysis) setErrorState(err error) {
 	a.state = MysisStateErrored
 	a.lastError = err
 	a.mu.Unlock()
+	a.fileLogger().Error().Err(err).Msg("Mysis errored")
 
 	if err := a.store.UpdateMysisState(a.id, store.MysisStateErrored); err != nil {
 		log.Warn().
@@ -2466,7 +3997,7 @@ func (m *Mysis) setErrorState(err error) {
 		Type:      EventMysisError,
 		MysisID:   a.id,
 		MysisName: a.name,
-		Error:     &ErrorData{Error: err.Error()},
+		Error:     newErrorData(err),
 		Timestamp: time.Now(),
 	})
 }
@@ -2515,9 +4046,7 @@ func (m *Mysis) removeOrphanedToolMessages(memories []*store.Memory) []*store.Me
 	for _, mem := range memories {
 		// Check if this is a tool result message
 		if mem.Role == store.MemoryRoleTool {
-			idx := strings.Index(mem.Content, constants.ToolCallStorageFieldDelimiter)
-			if idx > 0 {
-				toolCallID := mem.Content[:idx]
+			if toolCallID, _, ok := ParseStoredToolResult(mem.Content); ok {
 				if !validToolCalls[toolCallID] {
 					log.Debug().
 						Str("tool_call_id", toolCallID).
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -412,6 +413,95 @@ func TestFormatToolResult_Success(t *testing.T) {
 	}
 }
 
+func TestFormatToolResult_MixedContent(t *testing.T) {
+	m := &Mysis{}
+	result := &mcp.ToolResult{
+		Content: []mcp.ContentBlock{
+			{Type: "text", Text: "Sector map attached"},
+			{Type: "image", Data: "iVBORw0KGgo=", MimeType: "image/png"},
+			{Type: "resource", Resource: &mcp.ResourceContents{URI: "game://map.bin", Blob: "AAAA"}},
+		},
+	}
+
+	want := "Sector map attached\n[image: 8 bytes, image/png]\n[resource game://map.bin: 3 bytes]"
+	if got := m.formatToolResult("call_1", "get_map", result, nil); got != "call_1:"+want {
+		t.Errorf("formatToolResult() = %q, want non-text blocks kept as placeholders", got)
+	}
+	if got := m.formatToolResultDisplay(result, nil); got != want {
+		t.Errorf("formatToolResultDisplay() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatToolResult_Truncation(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("truncate", "mock")
+	result := func(text string) *mcp.ToolResult {
+		return &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: text}}}
+	}
+	mapDump := `{"systems":[{"id":"sol","x":0},{"id":"vega","x":12},{"id":"rigel","x":40}]}`
+
+	// Unlimited by default
+	if got := m.formatToolResult("call_1", "get_map", result(mapDump), nil); got != "call_1:"+mapDump {
+		t.Errorf("expected full result, got %q", got)
+	}
+
+	cmd.config.Swarm.MaxToolResultBytes = 40
+	got := m.formatToolResult("call_1", "mine", result(mapDump), nil)
+	id, content, ok := ParseStoredToolResult(got)
+	if !ok || id != "call_1" {
+		t.Fatalf("expected call_1 prefix intact, got %q", got)
+	}
+	kept := `{"systems":[{"id":"sol","x":0},`
+	want := kept + fmt.Sprintf(constants.ToolResultTruncatedFormat, len(mapDump)-len(kept))
+	if content != want {
+		t.Errorf("expected cut at a field boundary %q, got %q", want, content)
+	}
+
+	// Snapshot tools use their own limit
+	cmd.config.Swarm.MaxSnapshotResultBytes = 12
+	_, content, _ = ParseStoredToolResult(m.formatToolResult("call_2", "get_map", result(mapDump), nil))
+	if want := `{"systems":[` + fmt.Sprintf(constants.ToolResultTruncatedFormat, len(mapDump)-12); content != want {
+		t.Errorf("expected snapshot limit %q, got %q", want, content)
+	}
+	if got := m.formatToolResult("call_3", "mine", result("short"), nil); got != "call_3:short" {
+		t.Errorf("expected short result untouched, got %q", got)
+	}
+}
+
+func TestTurnTimeout(t *testing.T) {
+	if got := turnTimeout(provider.NewMock("mock", "")); got != constants.LLMRequestTimeout {
+		t.Errorf("expected default %s when unset, got %s", constants.LLMRequestTimeout, got)
+	}
+	if got := turnTimeout(provider.NewMock("mock", "").WithRequestTimeout(20 * time.Minute)); got != 20*time.Minute {
+		t.Errorf("expected provider timeout 20m, got %s", got)
+	}
+}
+
+func TestParseStoredToolResult(t *testing.T) {
+	tests := []struct {
+		stored      string
+		wantID      string
+		wantContent string
+		wantOK      bool
+	}{
+		{"call_1:tool result", "call_1", "tool result", true},
+		{"call_2:{\"pos\":\"sol:1\"}", "call_2", "{\"pos\":\"sol:1\"}", true},
+		{"call_3:", "call_3", "", true},
+		{":missing id", "", "", false},
+		{"no delimiter", "", "", false},
+	}
+
+	for _, tt := range tests {
+		id, content, ok := ParseStoredToolResult(tt.stored)
+		if id != tt.wantID || content != tt.wantContent || ok != tt.wantOK {
+			t.Errorf("ParseStoredToolResult(%q) = (%q, %q, %v), want (%q, %q, %v)",
+				tt.stored, id, content, ok, tt.wantID, tt.wantContent, tt.wantOK)
+		}
+	}
+}
+
 func TestSystemPromptContainsSearchGuidance(t *testing.T) {
 	// SystemPrompt was simplified - check for core game guidance
 	if !strings.Contains(constants.SystemPrompt, "game") {
@@ -837,9 +927,9 @@ func TestComputeMessageStats(t *testing.T) {
 }
 
 func TestMysisActivityTravelUntilFromTicks(t *testing.T) {
-	m := &Mysis{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Mysis{nowFunc: func() time.Time { return now }}
 
-	now := time.Now()
 	m.lastServerTick = 100
 	m.lastServerTickAt = now.Add(-20 * time.Second)
 
@@ -853,14 +943,21 @@ func TestMysisActivityTravelUntilFromTicks(t *testing.T) {
 		t.Fatalf("expected activity state traveling, got %s", m.activityState)
 	}
 
-	remaining := time.Until(m.activityUntil)
-	if remaining < 18*time.Second || remaining > 25*time.Second {
-		t.Fatalf("expected travel wait around 20s, got %s", remaining)
+	// 10 ticks in 20s = 2s/tick; 10 ticks to arrival = 20s
+	if want := now.Add(20 * time.Second); !m.activityUntil.Equal(want) {
+		t.Fatalf("expected activityUntil %s, got %s", want, m.activityUntil)
+	}
+	if m.tickDuration != 2*time.Second {
+		t.Fatalf("expected tick duration 2s, got %s", m.tickDuration)
+	}
+	if !m.lastServerTickAt.Equal(now) {
+		t.Fatalf("expected lastServerTickAt %s, got %s", now, m.lastServerTickAt)
 	}
 }
 
 func TestMysisActivityTravelFallbackWait(t *testing.T) {
-	m := &Mysis{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Mysis{nowFunc: func() time.Time { return now }}
 
 	result := &mcp.ToolResult{
 		Content: []mcp.ContentBlock{{Type: "text", Text: `{"arrival_tick":5000}`}},
@@ -872,9 +969,8 @@ func TestMysisActivityTravelFallbackWait(t *testing.T) {
 		t.Fatalf("expected activity state traveling, got %s", m.activityState)
 	}
 
-	remaining := time.Until(m.activityUntil)
-	if remaining < constants.WaitStateNudgeInterval-2*time.Second || remaining > constants.WaitStateNudgeInterval+2*time.Second {
-		t.Fatalf("expected travel fallback wait around %s, got %s", constants.WaitStateNudgeInterval, remaining)
+	if want := now.Add(constants.WaitStateNudgeInterval); !m.activityUntil.Equal(want) {
+		t.Fatalf("expected travel fallback until %s, got %s", want, m.activityUntil)
 	}
 }
 
@@ -909,6 +1005,24 @@ func TestMysisActivityTravelArrivalTickField(t *testing.T) {
 	}
 }
 
+func TestMysisActivityCooldownUntil(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &Mysis{nowFunc: func() time.Time { return now }, tickDuration: 3 * time.Second}
+
+	result := &mcp.ToolResult{
+		Content: []mcp.ContentBlock{{Type: "text", Text: `{"cooldown_ticks":4}`}},
+	}
+
+	m.updateActivityFromToolResult(result, nil)
+
+	if m.activityState != ActivityStateCooldown {
+		t.Fatalf("expected activity state cooldown, got %s", m.activityState)
+	}
+	if want := now.Add(12 * time.Second); !m.activityUntil.Equal(want) {
+		t.Fatalf("expected cooldown until %s, got %s", want, m.activityUntil)
+	}
+}
+
 func TestFindCurrentTick(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1221,6 +1335,136 @@ func TestEncouragementLimit(t *testing.T) {
 	// is tested in TestMysisCounterBehavior_* tests.
 }
 
+// TestNudgeResponseSource tests that replies to a synthetic nudge are stored with a
+// distinct source and reported separately from commander-driven replies.
+func TestNudgeResponseSource(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, err := s.CreateMysis("nudge-source-test", "mock", "test-model", 0.7)
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	mock := provider.NewMock("mock", "autonomous reply")
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	// Running without the run loop, so turns happen only when called below
+	mysis.state = MysisStateRunning
+
+	// Empty content runs an autonomous turn with a synthetic nudge
+	if err := mysis.SendMessageFrom("", store.MemorySourceDirect, ""); err != nil {
+		t.Fatalf("SendMessageFrom() autonomous error: %v", err)
+	}
+	mock.WithResponse("directed reply")
+	if err := mysis.SendMessageFrom("mine some ore", store.MemorySourceDirect, ""); err != nil {
+		t.Fatalf("SendMessageFrom() direct error: %v", err)
+	}
+
+	memories, err := s.GetMemories(stored.ID)
+	if err != nil {
+		t.Fatalf("GetMemories() error: %v", err)
+	}
+	sources := make(map[string]store.MemorySource)
+	for _, mem := range memories {
+		if mem.Role == store.MemoryRoleAssistant {
+			sources[mem.Content] = mem.Source
+		}
+	}
+	if sources["autonomous reply"] != store.MemorySourceNudgeResponse {
+		t.Errorf("expected nudge reply source %q, got %q", store.MemorySourceNudgeResponse, sources["autonomous reply"])
+	}
+	if sources["directed reply"] != store.MemorySourceLLM {
+		t.Errorf("expected directed reply source %q, got %q", store.MemorySourceLLM, sources["directed reply"])
+	}
+
+	stats := mysis.computeMemoryStats(memories)
+	if stats.SourceCounts[string(store.MemorySourceNudgeResponse)] != 1 || stats.SourceCounts[string(store.MemorySourceLLM)] != 1 {
+		t.Errorf("expected nudge and llm responses counted separately, got %v", stats.SourceCounts)
+	}
+}
+
+func TestSendOneShotSystem(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, err := s.CreateMysis("one-shot-test", "mock", "test-model", 0.7)
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{Err: errors.New("provider down")},
+		{Response: "defending"},
+		{Response: "mining"},
+	})
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	// Running without the run loop, so turns happen only when called below
+	mysis.state = MysisStateRunning
+
+	if err := mysis.SendOneShotSystem("   "); err == nil {
+		t.Error("expected error for empty reminder")
+	}
+	if err := mysis.SendOneShotSystem("prioritize defense now"); err != nil {
+		t.Fatalf("SendOneShotSystem() error: %v", err)
+	}
+
+	// A failed turn keeps the reminder for the next one
+	if err := mysis.SendMessageFrom("status?", store.MemorySourceDirect, ""); err == nil {
+		t.Fatal("expected provider error")
+	}
+	mysis.state = MysisStateRunning
+
+	// Two concurrent turns must deliver the reminder exactly once
+	var wg sync.WaitGroup
+	for _, content := range []string{"first", "second"} {
+		wg.Add(1)
+		go func(content string) {
+			defer wg.Done()
+			if err := mysis.SendMessageFrom(content, store.MemorySourceDirect, ""); err != nil {
+				t.Errorf("SendMessageFrom(%q) error: %v", content, err)
+			}
+		}(content)
+	}
+	wg.Wait()
+
+	requests := mock.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 provider requests, got %d", len(requests))
+	}
+	countReminders := func(messages []provider.Message) int {
+		n := 0
+		for i, msg := range messages {
+			if msg.Content == "prioritize defense now" {
+				if msg.Role != "system" || i+1 >= len(messages) || messages[i+1].Role != "user" {
+					t.Errorf("reminder should be a system message ahead of the prompt, got %+v", messages)
+				}
+				n++
+			}
+		}
+		return n
+	}
+	if countReminders(requests[0]) != 1 {
+		t.Error("expected reminder in the failed turn")
+	}
+	if got := countReminders(requests[1]) + countReminders(requests[2]); got != 1 {
+		t.Errorf("expected reminder delivered exactly once after retry, got %d", got)
+	}
+
+	memories, err := s.GetMemories(stored.ID)
+	if err != nil {
+		t.Fatalf("GetMemories() error: %v", err)
+	}
+	for _, mem := range memories {
+		if strings.Contains(mem.Content, "prioritize defense now") {
+			t.Errorf("reminder should never be stored, found %+v", mem)
+		}
+	}
+
+	// Stopped myses reject reminders like messages
+	mysis.state = MysisStateStopped
+	if err := mysis.SendOneShotSystem("too late"); err == nil {
+		t.Error("expected error for stopped mysis")
+	}
+}
+
 // TestEncouragementReset tests that encouragementCount resets to 0 when a real user message
 // (broadcast or direct) is received.
 func TestEncouragementReset(t *testing.T) {
@@ -1432,6 +1676,7 @@ func TestCanAcceptMessages(t *testing.T) {
 		{MysisStateRunning, true, ""},
 		{MysisStateStopped, false, "mysis stopped - press 'r' to relaunch"},
 		{MysisStateErrored, false, "mysis errored - press 'r' to relaunch"},
+		{MysisStateQuarantined, false, "mysis quarantined - press 'r' to relaunch"},
 	}
 
 	for _, tt := range tests {
@@ -1508,16 +1753,12 @@ func TestExecuteToolCall_ErrorPaths(t *testing.T) {
 
 		result, err := mysis.executeToolCall(ctx, proxy, tc)
 
-		// When tool is not found, MCP returns an error result (not an error)
-		// This is testing that executeToolCall handles missing tools gracefully
-		if err != nil {
-			t.Errorf("expected no error (tool not found returns error result), got: %v", err)
+		// An expired context aborts the call before it reaches the proxy
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
 		}
-		if result == nil {
-			t.Fatal("expected result, got nil")
-		}
-		if !result.IsError {
-			t.Error("expected IsError=true for tool not found")
+		if result != nil {
+			t.Errorf("expected no result, got %+v", result)
 		}
 	})
 
@@ -2170,9 +2411,32 @@ func TestBuildSystemPrompt_EdgeCases(t *testing.T) {
 		if !strings.Contains(prompt, "SWARM BROADCAST") {
 			t.Error("expected SWARM BROADCAST header")
 		}
-		// Should NOT include sender name (commander broadcasts don't show sender)
-		if strings.Contains(prompt, "From:") {
-			t.Error("commander broadcasts should not show 'From:' field")
+		// Should name the commander as the sender
+		if !strings.Contains(prompt, "From: "+CommanderName+"\n") {
+			t.Error("expected commander broadcasts to show 'From: Commander'")
+		}
+	})
+
+	t.Run("goal", func(t *testing.T) {
+		s, bus, cleanup := setupMysisTest(t)
+		defer cleanup()
+
+		stored, _ := s.CreateMysis("goal-mysis", "mock", "test-model", 0.7)
+		mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, provider.NewMock("mock", "response"), s, bus, "")
+
+		// No goal leaves the base prompt as it was
+		prompt := mysis.buildSystemPrompt()
+		if strings.Contains(prompt, "{{GOAL}}") || strings.Contains(prompt, "STANDING GOAL") {
+			t.Error("expected no goal section without a goal")
+		}
+		if !strings.Contains(prompt, "don't tell anyone.\n\n## Account Details") {
+			t.Error("expected the goal placeholder to leave no blank lines behind")
+		}
+
+		mysis.SetGoal("Control sector 7")
+		prompt = mysis.buildSystemPrompt()
+		if !strings.Contains(prompt, fmt.Sprintf(constants.GoalSectionTemplate, "Control sector 7")+"\n\n## Account Details") {
+			t.Errorf("expected goal section before account details, got %q", prompt)
 		}
 	})
 
@@ -2699,6 +2963,203 @@ func TestGetContextMemories_WithCompression(t *testing.T) {
 	}
 }
 
+func TestGetContextMemories_CompactionDisabled(t *testing.T) {
+	mysis, cleanup := setupTestMysis(t)
+	defer cleanup()
+
+	mysis.SetCompactSnapshots(false)
+
+	add := func(role store.MemoryRole, source store.MemorySource, content string) {
+		t.Helper()
+		if err := mysis.store.AddMemory(mysis.id, role, source, content, "", ""); err != nil {
+			t.Fatalf("AddMemory error: %v", err)
+		}
+	}
+	add(store.MemoryRoleUser, store.MemorySourceDirect, "Check game status")
+	add(store.MemoryRoleAssistant, store.MemorySourceLLM, "[TOOL_CALLS]call_status_1:get_status:{}")
+	add(store.MemoryRoleTool, store.MemorySourceTool, "call_status_1:old status data")
+	add(store.MemoryRoleAssistant, store.MemorySourceLLM, "[TOOL_CALLS]call_status_2:get_status:{}")
+	add(store.MemoryRoleTool, store.MemorySourceTool, "call_status_2:latest status data")
+	add(store.MemoryRoleAssistant, store.MemorySourceLLM, "[TOOL_CALLS]call_orphan:get_notifications:{}")
+
+	memories, _, err := mysis.getContextMemories()
+	if err != nil {
+		t.Fatalf("getContextMemories error: %v", err)
+	}
+
+	statusCount := 0
+	for _, mem := range memories {
+		if mem.Role == store.MemoryRoleTool && strings.Contains(mem.Content, "status data") {
+			statusCount++
+		}
+		if mem.Role == store.MemoryRoleAssistant && strings.Contains(mem.Content, "call_orphan") {
+			t.Error("Found orphaned tool call - orphan removal must run even with compaction disabled")
+		}
+	}
+	if statusCount != 2 {
+		t.Errorf("Expected both get_status results with compaction disabled, got %d", statusCount)
+	}
+}
+
+func TestGetContextMemories_SnapshotSummaries(t *testing.T) {
+	mysis, cleanup := setupTestMysis(t)
+	defer cleanup()
+
+	mysis.SetSnapshotSummaries(true)
+
+	add := func(role store.MemoryRole, source store.MemorySource, content string) {
+		t.Helper()
+		if err := mysis.store.AddMemory(mysis.id, role, source, content, "", ""); err != nil {
+			t.Fatalf("AddMemory error: %v", err)
+		}
+	}
+	add(store.MemoryRoleUser, store.MemorySourceDirect, "Check game status")
+	add(store.MemoryRoleAssistant, store.MemorySourceLLM, "[TOOL_CALLS]call_status_1:get_status:{}")
+	add(store.MemoryRoleTool, store.MemorySourceTool, "call_status_1:old status data")
+	add(store.MemoryRoleAssistant, store.MemorySourceLLM, "[TOOL_CALLS]call_status_2:get_status:{}")
+	add(store.MemoryRoleTool, store.MemorySourceTool, "call_status_2:latest status data")
+
+	memories, _, err := mysis.getContextMemories()
+	if err != nil {
+		t.Fatalf("getContextMemories error: %v", err)
+	}
+
+	marker := fmt.Sprintf(constants.SnapshotSummaryFormat, "get_status")
+	statusCount, markerCount := 0, 0
+	for i, mem := range memories {
+		if mem.Role == store.MemoryRoleTool && strings.Contains(mem.Content, "status data") {
+			statusCount++
+		}
+		if mem.Content == marker {
+			markerCount++
+			if _, _, ok := ParseStoredToolResult(mem.Content); ok || mem.Role == store.MemoryRoleTool {
+				t.Errorf("summary marker must not be a tool result: %+v", mem)
+			}
+			// The marker replaces the dropped call/result pair, before the latest call
+			if i+1 >= len(memories) || !strings.Contains(memories[i+1].Content, "call_status_2") {
+				t.Errorf("expected marker before the latest get_status call, got %+v", memories)
+			}
+		}
+	}
+	if statusCount != 1 {
+		t.Errorf("Expected exactly 1 get_status result, got %d", statusCount)
+	}
+	if markerCount != 1 {
+		t.Errorf("Expected 1 summary marker, got %d", markerCount)
+	}
+
+	// Without summaries, compaction drops the older result silently
+	mysis.SetSnapshotSummaries(false)
+	memories, _, _ = mysis.getContextMemories()
+	for _, mem := range memories {
+		if mem.Content == marker {
+			t.Error("unexpected summary marker with summaries disabled")
+		}
+	}
+}
+
+func TestGetContextMemories_ContextWindow(t *testing.T) {
+	mysis, cleanup := setupTestMysis(t)
+	defer cleanup()
+
+	// One prompt followed by 11 tool loops (23 memories) - the prompt falls outside
+	// the default 20-message window.
+	if err := mysis.store.AddMemory(mysis.id, store.MemoryRoleUser, store.MemorySourceDirect, "Investigate the belt", "", ""); err != nil {
+		t.Fatalf("AddMemory error: %v", err)
+	}
+	for i := 0; i < 11; i++ {
+		id := fmt.Sprintf("call_%d", i)
+		mysis.store.AddMemory(mysis.id, store.MemoryRoleAssistant, store.MemorySourceLLM, constants.ToolCallStoragePrefix+id+":scan:{}", "", "")
+		mysis.store.AddMemory(mysis.id, store.MemoryRoleTool, store.MemorySourceTool, id+":scan result", "", "")
+	}
+
+	hasPrompt := func() bool {
+		memories, _, err := mysis.getContextMemories()
+		if err != nil {
+			t.Fatalf("getContextMemories error: %v", err)
+		}
+		for _, mem := range memories {
+			if mem.Content == "Investigate the belt" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if mysis.ContextWindow() != constants.MaxContextMessages {
+		t.Fatalf("expected default window %d, got %d", constants.MaxContextMessages, mysis.ContextWindow())
+	}
+	if hasPrompt() {
+		t.Error("expected prompt to fall outside the default window")
+	}
+
+	if err := mysis.SetContextWindow(30); err != nil {
+		t.Fatalf("SetContextWindow error: %v", err)
+	}
+	if !hasPrompt() {
+		t.Error("expected prompt within a 30-message window")
+	}
+
+	for _, invalid := range []int{-1, 4, 201} {
+		if err := mysis.SetContextWindow(invalid); err == nil {
+			t.Errorf("expected error for window %d", invalid)
+		}
+	}
+	if mysis.ContextWindow() != 30 {
+		t.Errorf("invalid values must not change the window, got %d", mysis.ContextWindow())
+	}
+
+	if err := mysis.SetContextWindow(0); err != nil {
+		t.Fatalf("SetContextWindow(0) error: %v", err)
+	}
+	if mysis.ContextWindow() != constants.MaxContextMessages {
+		t.Errorf("expected default window after reset, got %d", mysis.ContextWindow())
+	}
+}
+
+func TestGetContextMemories_Notes(t *testing.T) {
+	mysis, cleanup := setupTestMysis(t)
+	defer cleanup()
+
+	mysis.store.AddMemory(mysis.id, store.MemoryRoleSystem, store.MemorySourceSystem, "System prompt", "", "")
+	for i := 0; i < constants.DefaultMaxNotesInContext+2; i++ {
+		if err := mysis.AddNote(fmt.Sprintf("note %d", i)); err != nil {
+			t.Fatalf("AddNote error: %v", err)
+		}
+	}
+	mysis.store.AddMemory(mysis.id, store.MemoryRoleUser, store.MemorySourceDirect, "Go mine", "", "")
+
+	memories, _, err := mysis.getContextMemories()
+	if err != nil {
+		t.Fatalf("getContextMemories error: %v", err)
+	}
+
+	if memories[0].Source != store.MemorySourceSystem {
+		t.Fatalf("expected system prompt first, got %s", memories[0].Source)
+	}
+
+	var notes []*store.Memory
+	for _, mem := range memories {
+		if mem.Source == store.MemorySourceNote {
+			notes = append(notes, mem)
+		}
+	}
+	if len(notes) != constants.DefaultMaxNotesInContext {
+		t.Fatalf("expected %d notes in context, got %d", constants.DefaultMaxNotesInContext, len(notes))
+	}
+	if memories[1] != notes[0] {
+		t.Error("expected notes immediately after the system prompt")
+	}
+	// Oldest notes are dropped first
+	if notes[0].Content != "Note: note 2" || notes[len(notes)-1].Content != fmt.Sprintf("Note: note %d", constants.DefaultMaxNotesInContext+1) {
+		t.Errorf("unexpected notes window: first=%q last=%q", notes[0].Content, notes[len(notes)-1].Content)
+	}
+
+	if err := mysis.AddNote("   "); err == nil {
+		t.Error("expected error for empty note")
+	}
+}
+
 // TestCompactSnapshots_MultipleSnapshots verifies that compactSnapshots()
 // correctly removes duplicate snapshot tool results, keeping only the latest
 // for each tool type while preserving order and non-snapshot tools.
@@ -2793,3 +3254,529 @@ func TestCompactSnapshots_MultipleSnapshots(t *testing.T) {
 		t.Error("Message order not preserved - user message should be first")
 	}
 }
+
+func TestMysisTurnCountAndTokenUsage(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("turn-mysis", "mock", "test-model", 0.7)
+	mock := provider.NewMock("mock", "Done.").WithUsage(provider.Usage{PromptTokens: 10, CompletionTokens: 5})
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+
+	// Usage is only reported on the tool-enabled path
+	proxy := mcp.NewProxy(nil)
+	proxy.RegisterTool(mcp.Tool{
+		Name:        "noop",
+		Description: "No-op tool",
+		InputSchema: json.RawMessage(`{"type": "object"}`),
+	}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolResult, error) {
+		return &mcp.ToolResult{}, nil
+	})
+	mysis.mcpProxy = proxy
+
+	if mysis.TurnCount() != 0 {
+		t.Fatalf("expected 0 turns before start, got %d", mysis.TurnCount())
+	}
+
+	events := bus.Subscribe()
+	if err := mysis.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer mysis.Stop()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if e.Type != EventMysisResponse {
+				continue
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for turn to complete")
+		}
+		break
+	}
+
+	// Response event is published before the counter update; allow it to land
+	deadline := time.Now().Add(time.Second)
+	for mysis.TurnCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if mysis.TurnCount() < 1 {
+		t.Errorf("expected at least 1 turn, got %d", mysis.TurnCount())
+	}
+	usage := mysis.TokenUsage()
+	if usage.TotalTokens() < 15 || usage.TotalTokens()%15 != 0 {
+		t.Errorf("expected usage in multiples of 15 tokens, got %+v", usage)
+	}
+}
+
+func TestMysisMemoryStats(t *testing.T) {
+	m, cleanup := setupTestMysis(t)
+	defer cleanup()
+
+	m.store.AddMemory(m.ID(), store.MemoryRoleSystem, store.MemorySourceSystem, "System prompt", "", "")
+	m.store.AddMemory(m.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "hello", "", "")
+	m.store.AddMemory(m.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "hi", "thinking", "")
+
+	stats, err := m.MemoryStats()
+	if err != nil {
+		t.Fatalf("MemoryStats() error: %v", err)
+	}
+	if stats.MemoryCount != 3 {
+		t.Errorf("MemoryCount = %d, want 3", stats.MemoryCount)
+	}
+	if stats.ReasoningBytes != len("thinking") {
+		t.Errorf("ReasoningBytes = %d, want %d", stats.ReasoningBytes, len("thinking"))
+	}
+	if stats.RoleCounts["user"] != 1 || stats.SourceCounts["llm"] != 1 {
+		t.Errorf("unexpected counts: roles=%v sources=%v", stats.RoleCounts, stats.SourceCounts)
+	}
+	if m.EncouragementCount() != 0 {
+		t.Errorf("EncouragementCount() = %d, want 0", m.EncouragementCount())
+	}
+	if !m.ActivityUntil().IsZero() {
+		t.Errorf("ActivityUntil() = %v, want zero", m.ActivityUntil())
+	}
+}
+
+func TestMysisQuarantineAfterPoisonedTurns(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("poison-test", "mock", "test-model", 0.7)
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, provider.NewMock("mock", "response"), s, bus, "")
+	mysis.state = MysisStateRunning
+
+	s.AddMemory(stored.ID, store.MemoryRoleSystem, store.MemorySourceSystem, "System prompt", "", "")
+	s.AddMemory(stored.ID, store.MemoryRoleUser, store.MemorySourceDirect, "go mine", "", "")
+
+	// Each turn the model emits a tool call that never gets a result
+	runOrphanTurn := func(i int) bool {
+		s.AddMemory(stored.ID, store.MemoryRoleAssistant, store.MemorySourceLLM,
+			fmt.Sprintf("%scall_%d:mine:{}", constants.ToolCallStoragePrefix, i), "", "")
+		if _, _, err := mysis.getContextMemories(); err != nil {
+			t.Fatalf("getContextMemories() error: %v", err)
+		}
+		return mysis.trackPoisonedTurn(mysis.lastOrphansRemoved() > 0)
+	}
+
+	for i := 1; i < constants.MaxPoisonedTurns; i++ {
+		if runOrphanTurn(i) {
+			t.Fatalf("quarantined early after %d turns", i)
+		}
+		if mysis.State() != MysisStateRunning {
+			t.Fatalf("expected running after %d poisoned turns, got %s", i, mysis.State())
+		}
+	}
+
+	// Stale orphans still in the window don't count as new poisoned turns
+	if _, _, err := mysis.getContextMemories(); err != nil {
+		t.Fatalf("getContextMemories() error: %v", err)
+	}
+	if mysis.lastOrphansRemoved() != 0 {
+		t.Errorf("expected stale orphans to be ignored, got %d", mysis.lastOrphansRemoved())
+	}
+
+	if !runOrphanTurn(constants.MaxPoisonedTurns) {
+		t.Fatal("expected quarantine after max poisoned turns")
+	}
+	if mysis.State() != MysisStateQuarantined {
+		t.Fatalf("expected quarantined, got %s", mysis.State())
+	}
+	if err := mysis.LastError(); err == nil || !strings.Contains(err.Error(), "orphaned tool calls") {
+		t.Errorf("expected descriptive last error, got %v", err)
+	}
+	storedMysis, _ := s.GetMysis(stored.ID)
+	if storedMysis.State != store.MysisStateQuarantined {
+		t.Errorf("expected stored state quarantined, got %s", storedMysis.State)
+	}
+
+	// Messages are rejected until relaunch
+	if err := mysis.SendMessage("hello", store.MemorySourceDirect); err == nil {
+		t.Error("expected quarantined mysis to reject messages")
+	}
+}
+
+func TestRecordOrphansForgetsOrphansOutsideWindow(t *testing.T) {
+	m := &Mysis{}
+	orphan := func(id int64) *store.Memory { return &store.Memory{ID: id} }
+	first, second, third := orphan(1), orphan(2), orphan(3)
+
+	m.recordOrphans([]*store.Memory{first, second}, nil)
+	if m.lastOrphansRemoved() != 2 {
+		t.Fatalf("expected 2 new orphans, got %d", m.lastOrphansRemoved())
+	}
+
+	// The first orphan scrolls out of the window as a third appears
+	m.recordOrphans([]*store.Memory{second, third}, nil)
+	if m.lastOrphansRemoved() != 1 {
+		t.Errorf("expected only the third orphan to count, got %d", m.lastOrphansRemoved())
+	}
+	if len(m.seenOrphans) != 2 || m.seenOrphans[first.ID] {
+		t.Errorf("expected only orphans in the window to be remembered, got %v", m.seenOrphans)
+	}
+}
+
+func TestMysisPoisonedTurnsResetOnCleanTurn(t *testing.T) {
+	m := &Mysis{}
+	for i := 0; i < constants.MaxPoisonedTurns-1; i++ {
+		m.trackPoisonedTurn(true)
+	}
+	m.trackPoisonedTurn(false)
+	if m.poisonedTurns != 0 {
+		t.Errorf("expected counter reset after clean turn, got %d", m.poisonedTurns)
+	}
+}
+
+func TestNonAutonomousMysisWaitsForMessages(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("listener", "mock", "test-model", 0.7)
+	mock := provider.NewScriptedMock([]provider.MockStep{{Response: "Heading to Sol."}})
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	mysis.SetAutonomous(false)
+
+	// With nothing to answer, the run loop's trigger idles the mysis without a turn
+	mysis.state = MysisStateRunning
+	if err := mysis.SendMessageFrom("", store.MemorySourceSystem, ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+	if len(mock.Requests()) != 0 {
+		t.Fatalf("expected no provider requests, got %d", len(mock.Requests()))
+	}
+	if mysis.State() != MysisStateIdle {
+		t.Errorf("expected idle, got %s", mysis.State())
+	}
+
+	// A message gets exactly one turn; the next trigger idles it again
+	s.AddMemory(stored.ID, store.MemoryRoleUser, store.MemorySourceBroadcast, "Regroup at Sol.", "", "")
+	for i := 0; i < 2; i++ {
+		mysis.state = MysisStateRunning
+		if err := mysis.SendMessageFrom("", store.MemorySourceSystem, ""); err != nil {
+			t.Fatalf("SendMessageFrom() error: %v", err)
+		}
+	}
+	if len(mock.Requests()) != 1 {
+		t.Errorf("expected 1 provider request, got %d", len(mock.Requests()))
+	}
+	if mysis.State() != MysisStateIdle {
+		t.Errorf("expected idle after answering, got %s", mysis.State())
+	}
+}
+
+func TestIdleStopAfterStopsQuietMysis(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Swarm.IdleStopAfter = 10 * time.Minute
+	cmd.SetTurnInterval(constants.MinTurnInterval)
+
+	var clockMu sync.Mutex
+	now := time.Now()
+	advance := func(d time.Duration) {
+		clockMu.Lock()
+		now = now.Add(d)
+		clockMu.Unlock()
+	}
+
+	m, _ := cmd.CreateMysis("drifter", "mock")
+	m.nowFunc = func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		return now
+	}
+
+	events := bus.Subscribe()
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	waitFor := func(match func(Event) bool, what string) {
+		t.Helper()
+		for {
+			select {
+			case e := <-events:
+				if e.MysisID == m.ID() && match(e) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timeout waiting for %s", what)
+			}
+		}
+	}
+
+	// A nudge turn within the limit keeps running
+	waitFor(func(e Event) bool { return e.Type == EventMysisResponse }, "first nudge turn")
+	if m.State() != MysisStateRunning || m.EncouragementCount() != 1 {
+		t.Fatalf("expected running after one nudge, got %s with %d nudges", m.State(), m.EncouragementCount())
+	}
+
+	// Past the limit the next turn stops instead, before the nudge breaker idles it
+	advance(10 * time.Minute)
+	waitFor(func(e Event) bool {
+		return e.Type == EventMysisStateChanged && e.State != nil && e.State.NewState == MysisStateStopped
+	}, "idle stop")
+	if m.State() != MysisStateStopped {
+		t.Errorf("expected stopped, got %s", m.State())
+	}
+	if m.EncouragementCount() >= 3 {
+		t.Errorf("expected the stop before the nudge breaker, got %d nudges", m.EncouragementCount())
+	}
+
+	// Restarting resets the quiet period
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Stop()
+	if _, due := m.idleStopDue(); due {
+		t.Error("expected restart to reset the idle clock")
+	}
+}
+
+func TestIdleStopAfterStopsNudgeBreakerIdledMysis(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Swarm.IdleStopAfter = 2 * time.Second
+	cmd.SetTurnInterval(constants.MinTurnInterval)
+
+	m, _ := cmd.CreateMysis("drifter", "mock")
+	events := bus.Subscribe()
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer m.Stop()
+
+	// Three nudges trip the breaker well before idle_stop_after, then the idle mysis
+	// is stopped once the quiet period runs out
+	var states []MysisState
+	timeout := time.After(5 * time.Second)
+	for len(states) == 0 || states[len(states)-1] != MysisStateStopped {
+		select {
+		case e := <-events:
+			if e.MysisID == m.ID() && e.Type == EventMysisStateChanged && e.State != nil {
+				states = append(states, e.State.NewState)
+			}
+		case <-timeout:
+			t.Fatalf("timeout waiting for idle stop, states so far: %v", states)
+		}
+	}
+	if len(states) < 2 || states[len(states)-2] != MysisStateIdle {
+		t.Errorf("expected idle before stopped, got %v", states)
+	}
+	if m.EncouragementCount() != 3 {
+		t.Errorf("expected the nudge breaker to idle the mysis first, got %d nudges", m.EncouragementCount())
+	}
+}
+
+// TestMemoriesToMessagesToolResultAges checks the opt-in age marker on tool results.
+func TestMemoriesToMessagesToolResultAges(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, err := cmd.CreateMysis("scout", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+	now := time.Now()
+	m.nowFunc = func() time.Time { return now }
+	memories := []*store.Memory{
+		{Role: store.MemoryRoleAssistant, Content: constants.ToolCallStoragePrefix + "call_1" + constants.ToolCallStorageFieldDelimiter + "get_status" + constants.ToolCallStorageFieldDelimiter + "{}", CreatedAt: now.Add(-31 * time.Second)},
+		{Role: store.MemoryRoleTool, Content: "call_1" + constants.ToolCallStorageFieldDelimiter + "Hull 100%", CreatedAt: now.Add(-30 * time.Second)},
+	}
+
+	toolContent := func() string {
+		t.Helper()
+		messages := m.memoriesToMessages(memories)
+		if len(messages) != 2 || messages[1].ToolCallID != "call_1" {
+			t.Fatalf("unexpected messages: %+v", messages)
+		}
+		return messages[1].Content
+	}
+
+	// Off by default: content is sent unchanged
+	if got := toolContent(); got != "Hull 100%" {
+		t.Errorf("expected unannotated result, got %q", got)
+	}
+
+	cmd.config.Swarm.ToolResultAges = true
+	if got := toolContent(); got != "[30s ago] Hull 100%" {
+		t.Errorf("expected seconds before the tick rate is known, got %q", got)
+	}
+
+	m.mu.Lock()
+	m.tickDuration = 10 * time.Second
+	m.mu.Unlock()
+	if got := toolContent(); got != "[3 ticks ago] Hull 100%" {
+		t.Errorf("expected age in ticks, got %q", got)
+	}
+}
+
+// TestFirstTurnPrompt checks that [prompts] first_turn replaces the nudge on a fresh
+// mysis's first turn only.
+func TestFirstTurnPrompt(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Prompts.FirstTurn = "You just joined. Log in and assess."
+
+	stored, _ := cmd.store.CreateMysis("rookie", "mock", "test-model", 0.7)
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{Response: "Logged in."},
+		{Response: "Mining."},
+		{Response: "Still mining."},
+	})
+	m := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, cmd.store, bus, "", cmd)
+	cmd.store.AddMemory(m.id, store.MemoryRoleSystem, store.MemorySourceSystem, "You are a mysis.", "", "")
+	m.state = MysisStateRunning
+
+	for i := 0; i < 3; i++ {
+		if err := m.SendMessageFrom("", store.MemorySourceDirect, ""); err != nil {
+			t.Fatalf("turn %d: SendMessageFrom() error: %v", i, err)
+		}
+	}
+
+	requests := mock.Requests()
+	want := []string{
+		"You just joined. Log in and assess.",
+		constants.NudgePrompt,
+		constants.NudgePrompt,
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(requests))
+	}
+	for i, request := range requests {
+		if got := request[len(request)-1].Content; got != want[i] {
+			t.Errorf("turn %d: expected prompt %q, got %q", i, want[i], got)
+		}
+	}
+}
+
+// TestFirstTurnPromptSurvivesToolCalls checks that a first turn keeps the first-turn
+// prompt after its tool calls add memories.
+func TestFirstTurnPromptSurvivesToolCalls(t *testing.T) {
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Prompts.FirstTurn = "You just joined. Log in and assess."
+
+	stored, _ := cmd.store.CreateMysis("rookie", "mock", "test-model", 0.7)
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{ToolCalls: []provider.ToolCall{{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)}}},
+		{Response: "Logged in."},
+	})
+	m := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, cmd.store, bus, "", cmd)
+	m.mcpProxy = mcp.NewProxy(&batchingUpstream{})
+	cmd.store.AddMemory(m.id, store.MemoryRoleSystem, store.MemorySourceSystem, "You are a mysis.", "", "")
+	m.state = MysisStateRunning
+
+	if err := m.SendMessageFrom("", store.MemorySourceDirect, ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	for i, request := range requests {
+		var nudges []string
+		for _, msg := range request {
+			if msg.Role == "user" {
+				nudges = append(nudges, msg.Content)
+			}
+		}
+		if len(nudges) != 1 || nudges[0] != "You just joined. Log in and assess." {
+			t.Errorf("iteration %d: expected only the first-turn prompt, got %q", i, nudges)
+		}
+	}
+}
+
+// TestContextNudgeUsesConfiguredPrompts checks that the synthetic nudge uses [prompts]
+// overrides and escalates with the encouragement count.
+func TestContextNudgeUsesConfiguredPrompts(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Prompts.Nudge = "Check your cargo."
+	cmd.config.Prompts.ContinueFirm = "Sell it now."
+
+	m, err := cmd.CreateMysis("trader", "mock")
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	for count, want := range []string{
+		"Check your cargo.\n\n" + constants.ContinuePrompt,
+		"Check your cargo.\n\nSell it now.",
+		"Check your cargo.\n\n" + constants.ContinuePromptUrgent,
+		"Check your cargo.\n\n" + constants.ContinuePromptUrgent,
+	} {
+		m.mu.Lock()
+		m.encouragementCount = count
+		m.mu.Unlock()
+
+		memories, addedSynthetic, err := m.getContextMemories()
+		if err != nil {
+			t.Fatalf("getContextMemories() error: %v", err)
+		}
+		if !addedSynthetic || len(memories) == 0 {
+			t.Fatalf("expected a synthetic nudge at count %d", count)
+		}
+		if got := memories[len(memories)-1].Content; got != want {
+			t.Errorf("count %d: expected nudge %q, got %q", count, want, got)
+		}
+	}
+}
+
+func TestLastServerTick(t *testing.T) {
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+
+	m, _ := cmd.CreateMysis("ticker", "mock")
+	if tick, at := m.LastServerTick(); tick != 0 || !at.IsZero() {
+		t.Fatalf("expected no tick yet, got %d at %v", tick, at)
+	}
+
+	start := time.Now()
+	m.updateServerTick(start, 100)
+	m.updateServerTick(start.Add(30*time.Second), 103)
+	tick, at := m.LastServerTick()
+	if tick != 103 || !at.Equal(start.Add(30*time.Second)) {
+		t.Errorf("LastServerTick() = %d at %v, want 103 at +30s", tick, at)
+	}
+	if m.TickDuration() != 10*time.Second {
+		t.Errorf("TickDuration() = %s, want 10s", m.TickDuration())
+	}
+
+	// Reads are safe alongside tick updates (run with -race)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := int64(0); i < 100; i++ {
+			m.updateServerTick(start.Add(time.Minute), 104+i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			m.LastServerTick()
+			m.TickStale(start)
+		}
+	}()
+	wg.Wait()
+	m.updateServerTick(start, 500)
+
+	// Only a running mysis goes stale, after [swarm] tick_stale_after
+	later := start.Add(constants.DefaultTickStaleAfter + time.Second)
+	if m.TickStale(later) {
+		t.Error("expected an idle mysis never to be flagged stale")
+	}
+	m.mu.Lock()
+	m.state = MysisStateRunning
+	m.mu.Unlock()
+	if !m.TickStale(later) {
+		t.Error("expected a running mysis past the default threshold to be stale")
+	}
+	cmd.config.Swarm.TickStaleAfter = time.Hour
+	if m.TickStale(later) {
+		t.Error("expected the configured threshold to apply")
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/xonecas/zoea-nova/internal/constants"
 	"github.com/xonecas/zoea-nova/internal/mcp"
 	"github.com/xonecas/zoea-nova/internal/provider"
 	"github.com/xonecas/zoea-nova/internal/store"
@@ -30,6 +33,45 @@ func (f *failingUpstream) CallTool(ctx context.Context, name string, arguments i
 	return nil, f.callErr
 }
 
+// batchingUpstream records single and batched upstream calls.
+type batchingUpstream struct {
+	mu      sync.Mutex
+	singles []string
+	batches [][]string
+}
+
+func (b *batchingUpstream) Initialize(ctx context.Context, clientInfo map[string]interface{}) (*mcp.Response, error) {
+	return nil, nil
+}
+
+func (b *batchingUpstream) ListTools(ctx context.Context) ([]mcp.Tool, error) {
+	var tools []mcp.Tool
+	for _, name := range []string{"get_status", "get_cargo", "get_system", "mine"} {
+		tools = append(tools, mcp.Tool{Name: name, InputSchema: json.RawMessage(`{"type": "object"}`)})
+	}
+	return tools, nil
+}
+
+func (b *batchingUpstream) CallTool(ctx context.Context, name string, arguments interface{}) (*mcp.ToolResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.singles = append(b.singles, name)
+	return &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: name + " ok"}}}, nil
+}
+
+func (b *batchingUpstream) CallToolsBatch(ctx context.Context, calls []mcp.ToolCall) ([]*mcp.ToolResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, len(calls))
+	results := make([]*mcp.ToolResult, len(calls))
+	for i, call := range calls {
+		names[i] = call.Name
+		results[i] = &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: call.Name + " batched"}}}
+	}
+	b.batches = append(b.batches, names)
+	return results, nil
+}
+
 func TestMysisToolExecution(t *testing.T) {
 	s, bus, cleanup := setupMysisTest(t)
 	defer cleanup()
@@ -357,4 +399,514 @@ func TestMysisToolRetryExhaustionSetsErrored(t *testing.T) {
 	if !foundRetry {
 		t.Error("tool retry exhaustion error not found in memories")
 	}
+
+	// Verify the error event carries retry metadata
+	var errData *ErrorData
+	for errData == nil {
+		select {
+		case e := <-events:
+			if e.Type == EventMysisError && e.Error != nil && e.Error.Kind == ErrorKindToolRetryExhausted {
+				errData = e.Error
+			}
+		case <-time.After(time.Second):
+			t.Fatal("tool retry exhausted error event not received")
+		}
+	}
+	if errData.Tool != "upstream_tool" || errData.Retries != 3 || errData.Reason != "upstream unavailable" {
+		t.Errorf("unexpected retry error data: %+v", errData)
+	}
+}
+
+func TestMysisScriptedToolLoop(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("scripted-mysis", "mock", "test-model", 0.7)
+
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{ToolCalls: []provider.ToolCall{{ID: "call_login", Name: "login", Arguments: json.RawMessage(`{"username": "zoea"}`)}}},
+		{ToolCalls: []provider.ToolCall{{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)}}},
+		{ToolCalls: []provider.ToolCall{{ID: "call_mine", Name: "mine", Arguments: json.RawMessage(`{}`)}}},
+		{Response: "Mined some ore."},
+	})
+
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+
+	var called []string
+	proxy := mcp.NewProxy(nil)
+	for _, name := range []string{"login", "get_status", "mine"} {
+		name := name
+		proxy.RegisterTool(mcp.Tool{
+			Name:        name,
+			InputSchema: json.RawMessage(`{"type": "object"}`),
+		}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolResult, error) {
+			called = append(called, name)
+			return &mcp.ToolResult{
+				Content: []mcp.ContentBlock{{Type: "text", Text: name + " ok"}},
+			}, nil
+		})
+	}
+	mysis.mcpProxy = proxy
+
+	events := bus.Subscribe()
+	mysis.Start()
+
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case e := <-events:
+			if e.Type == EventMysisResponse && e.Message != nil && e.Message.Content == "Mined some ore." {
+				done = true
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for scripted final response")
+		}
+	}
+	// Stop before the next autonomous turn runs past the end of the script
+	mysis.Stop()
+
+	if strings.Join(called, ",") != "login,get_status,mine" {
+		t.Errorf("expected tools called in script order, got %v", called)
+	}
+	if mock.RemainingSteps() != 0 {
+		t.Errorf("expected script consumed, %d steps left", mock.RemainingSteps())
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 provider requests, got %d", len(requests))
+	}
+
+	// Each request carries the system prompt and only the most recent tool loop
+	wantLoops := []struct{ callID, result string }{
+		{"", ""},
+		{"call_login", "login ok"},
+		{"call_status", "get_status ok"},
+		{"call_mine", "mine ok"},
+	}
+	for i, want := range wantLoops {
+		msgs := requests[i]
+		if len(msgs) == 0 || msgs[0].Role != "system" {
+			t.Errorf("request %d: expected system prompt first, got %+v", i, msgs)
+			continue
+		}
+		var callIDs []string
+		results := make(map[string]string)
+		for _, msg := range msgs {
+			for _, tc := range msg.ToolCalls {
+				callIDs = append(callIDs, tc.ID)
+			}
+			if msg.Role == "tool" {
+				results[msg.ToolCallID] = msg.Content
+			}
+		}
+		if want.callID == "" {
+			if len(callIDs) != 0 || len(results) != 0 {
+				t.Errorf("request %d: expected no tool loop, got calls %v results %v", i, callIDs, results)
+			}
+			continue
+		}
+		if len(callIDs) != 1 || callIDs[0] != want.callID {
+			t.Errorf("request %d: expected only tool call %s, got %v", i, want.callID, callIDs)
+		}
+		if len(results) != 1 || !strings.Contains(results[want.callID], want.result) {
+			t.Errorf("request %d: expected only result %q for %s, got %v", i, want.result, want.callID, results)
+		}
+	}
+}
+
+func TestMysisStopDuringBlockedToolCall(t *testing.T) {
+	tests := []struct {
+		name string
+		// block waits inside the tool handler until released
+		block func(ctx context.Context, release <-chan struct{})
+	}{
+		{"handler honors context", func(ctx context.Context, release <-chan struct{}) { <-ctx.Done() }},
+		{"handler ignores context", func(ctx context.Context, release <-chan struct{}) { <-release }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, bus, cleanup := setupMysisTest(t)
+			defer cleanup()
+
+			stored, _ := s.CreateMysis("blocked-tool-mysis", "mock", "test-model", 0.7)
+			mock := provider.NewMock("mock", "done").WithToolCalls([]provider.ToolCall{
+				{ID: "call_slow", Name: "slow_tool", Arguments: json.RawMessage(`{}`)},
+			})
+			mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+
+			entered := make(chan struct{}, 1)
+			release := make(chan struct{})
+			defer close(release)
+
+			proxy := mcp.NewProxy(nil)
+			proxy.RegisterTool(mcp.Tool{
+				Name:        "slow_tool",
+				InputSchema: json.RawMessage(`{"type": "object"}`),
+			}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolResult, error) {
+				select {
+				case entered <- struct{}{}:
+				default:
+				}
+				tt.block(ctx, release)
+				return &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "late"}}}, nil
+			})
+			mysis.mcpProxy = proxy
+
+			if err := mysis.Start(); err != nil {
+				t.Fatalf("Start() error: %v", err)
+			}
+
+			select {
+			case <-entered:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for tool call to start")
+			}
+
+			start := time.Now()
+			if err := mysis.Stop(); err != nil {
+				t.Fatalf("Stop() error: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Stop() took %v during blocked tool call, want well under 5s", elapsed)
+			}
+			if mysis.State() != MysisStateStopped {
+				t.Errorf("expected stopped state, got %s", mysis.State())
+			}
+		})
+	}
+}
+
+func TestMysisBatchesSnapshotToolCalls(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("batch-mysis", "mock", "test-model", 0.7)
+
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{ToolCalls: []provider.ToolCall{
+			{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)},
+			{ID: "call_cargo", Name: "get_cargo", Arguments: json.RawMessage(`{}`)},
+		}},
+		{ToolCalls: []provider.ToolCall{
+			{ID: "call_system", Name: "get_system", Arguments: json.RawMessage(`{}`)},
+			{ID: "call_mine", Name: "mine", Arguments: json.RawMessage(`{}`)},
+		}},
+		{Response: "Done."},
+	})
+
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	upstream := &batchingUpstream{}
+	mysis.mcpProxy = mcp.NewProxy(upstream)
+
+	events := bus.Subscribe()
+	mysis.Start()
+
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case e := <-events:
+			if e.Type == EventMysisResponse && e.Message != nil && e.Message.Content == "Done." {
+				done = true
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for final response")
+		}
+	}
+	mysis.Stop()
+
+	upstream.mu.Lock()
+	defer upstream.mu.Unlock()
+
+	// All read-only calls go in one batch; a mixed response runs sequentially
+	if len(upstream.batches) != 1 || strings.Join(upstream.batches[0], ",") != "get_status,get_cargo" {
+		t.Errorf("expected one batch of get_status,get_cargo, got %v", upstream.batches)
+	}
+	if strings.Join(upstream.singles, ",") != "get_system,mine" {
+		t.Errorf("expected sequential get_system,mine, got %v", upstream.singles)
+	}
+
+	// Batched results are stored per call, in call order
+	requests := mock.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 provider requests, got %d", len(requests))
+	}
+	results := make(map[string]string)
+	for _, msg := range requests[1] {
+		if msg.Role == "tool" {
+			results[msg.ToolCallID] = msg.Content
+		}
+	}
+	if !strings.Contains(results["call_status"], "get_status batched") || !strings.Contains(results["call_cargo"], "get_cargo batched") {
+		t.Errorf("expected batched results for both calls, got %v", results)
+	}
+}
+
+func TestMysisToolPolicy(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("scout", "mock", "test-model", 0.7)
+
+	mock := provider.NewScriptedMock([]provider.MockStep{
+		{ToolCalls: []provider.ToolCall{
+			{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)},
+			{ID: "call_mine", Name: "mine", Arguments: json.RawMessage(`{}`)},
+		}},
+		{Response: "Done."},
+	})
+
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	upstream := &batchingUpstream{}
+	mysis.mcpProxy = mcp.NewProxy(upstream)
+	mysis.SetToolPolicy(ToolPolicy{Deny: []string{"mine"}})
+	// Running without the run loop, so the turn happens only when called below
+	mysis.state = MysisStateRunning
+
+	if err := mysis.SendMessageFrom("scout the system", store.MemorySourceDirect, ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+
+	// The provider never sees the denied tool
+	for _, tool := range mock.LastTools() {
+		if tool.Name == "mine" {
+			t.Error("denied tool offered to the provider")
+		}
+	}
+
+	// A call to it anyway is rejected before reaching the upstream
+	upstream.mu.Lock()
+	singles := strings.Join(upstream.singles, ",")
+	upstream.mu.Unlock()
+	if singles != "get_status" {
+		t.Errorf("expected only get_status upstream, got %q", singles)
+	}
+	requests := mock.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 provider requests, got %d", len(requests))
+	}
+	for _, msg := range requests[1] {
+		if msg.ToolCallID == "call_mine" && !strings.Contains(msg.Content, "disabled for this mysis") {
+			t.Errorf("expected disabled tool error, got %q", msg.Content)
+		}
+	}
+}
+
+func TestToolPolicyPermits(t *testing.T) {
+	tests := []struct {
+		policy ToolPolicy
+		tool   string
+		want   bool
+	}{
+		{ToolPolicy{}, "attack", true},
+		{ToolPolicy{Deny: []string{"attack"}}, "attack", false},
+		{ToolPolicy{Allow: []string{"get_status"}}, "attack", false},
+		{ToolPolicy{Allow: []string{"get_status"}}, "get_status", true},
+		{ToolPolicy{Allow: []string{"attack"}, Deny: []string{"attack"}}, "attack", false},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Permits(tt.tool); got != tt.want {
+			t.Errorf("%+v.Permits(%q) = %v, want %v", tt.policy, tt.tool, got, tt.want)
+		}
+	}
+}
+
+func TestRepeatedToolCallsEndTurnEarly(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("stuck", "mock", "test-model", 0.7)
+	s.AddBroadcastMemory(stored.ID, "Mine some ore.", "", "b-1")
+
+	// A stuck model that would call the same tool for the whole iteration budget
+	var steps []provider.MockStep
+	for i := 0; i < constants.MaxToolIterations; i++ {
+		steps = append(steps, provider.MockStep{ToolCalls: []provider.ToolCall{
+			{ID: fmt.Sprintf("call_%d", i), Name: "mine", Arguments: json.RawMessage(`{"target":"ore"}`)},
+		}})
+	}
+	mock := provider.NewScriptedMock(steps)
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, s, bus, "")
+	mysis.state = MysisStateRunning
+
+	mined := 0
+	proxy := mcp.NewProxy(nil)
+	proxy.RegisterTool(mcp.Tool{
+		Name:        "mine",
+		InputSchema: json.RawMessage(`{"type": "object"}`),
+	}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolResult, error) {
+		mined++
+		return &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "cargo full"}}}, nil
+	})
+	mysis.mcpProxy = proxy
+
+	if err := mysis.SendMessageFrom("", store.MemorySourceSystem, ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+
+	// The third identical call ends the turn without running
+	if got := len(mock.Requests()); got != constants.DefaultMaxRepeatedToolCalls {
+		t.Errorf("expected %d provider requests, got %d", constants.DefaultMaxRepeatedToolCalls, got)
+	}
+	if mined != constants.DefaultMaxRepeatedToolCalls-1 {
+		t.Errorf("expected the tool to run %d times, ran %d", constants.DefaultMaxRepeatedToolCalls-1, mined)
+	}
+
+	memories, _ := s.GetRecentMemories(stored.ID, 1)
+	callID, content, ok := ParseStoredToolResult(memories[0].Content)
+	if !ok || callID != "call_2" || !strings.Contains(content, "same arguments 3 times in a row") {
+		t.Errorf("expected a repeat warning for call_2, got %q", memories[0].Content)
+	}
+
+	// The turn still finished, so the broadcast that prompted it is acknowledged
+	if acks, _ := s.BroadcastAcks("b-1"); len(acks) != 1 || !acks[0].Acked {
+		t.Errorf("expected the broadcast acknowledged, got %+v", acks)
+	}
+}
+
+func TestMaxToolIterationsEndsTurn(t *testing.T) {
+	for _, policy := range []string{"", constants.ToolIterationsPolicyError} {
+		t.Run("policy="+policy, func(t *testing.T) {
+			cmd, bus, cleanup := setupCommanderTest(t)
+			defer cleanup()
+			cmd.config.Swarm.ToolIterationsPolicy = policy
+
+			stored, _ := cmd.Store().CreateMysis("busy", "mock", "test-model", 0.7)
+			cmd.Store().AddBroadcastMemory(stored.ID, "Scan the belt.", "", "b-1")
+
+			// A model that only ever calls tools, each time with new arguments
+			var steps []provider.MockStep
+			for i := 0; i < constants.MaxToolIterations; i++ {
+				steps = append(steps, provider.MockStep{ToolCalls: []provider.ToolCall{
+					{ID: fmt.Sprintf("call_%d", i), Name: "scan", Arguments: json.RawMessage(fmt.Sprintf(`{"sector":%d}`, i))},
+				}})
+			}
+			mock := provider.NewScriptedMock(steps)
+			mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, mock, cmd.Store(), bus, "", cmd)
+			mysis.state = MysisStateRunning
+
+			proxy := mcp.NewProxy(nil)
+			proxy.RegisterTool(mcp.Tool{Name: "scan", InputSchema: json.RawMessage(`{"type": "object"}`)},
+				func(ctx context.Context, args json.RawMessage) (*mcp.ToolResult, error) {
+					return &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "nothing here"}}}, nil
+				})
+			mysis.mcpProxy = proxy
+
+			events := bus.Subscribe()
+			err := mysis.SendMessageFrom("", store.MemorySourceSystem, "")
+			if got := len(mock.Requests()); got != constants.MaxToolIterations {
+				t.Fatalf("expected %d provider requests, got %d", constants.MaxToolIterations, got)
+			}
+
+			memories, _ := cmd.Store().GetRecentMemories(stored.ID, 1)
+			acks, _ := cmd.Store().BroadcastAcks("b-1")
+			if policy == constants.ToolIterationsPolicyError {
+				if len(acks) != 1 || acks[0].Acked {
+					t.Errorf("expected a failed turn to leave the broadcast unacknowledged, got %+v", acks)
+				}
+				if err == nil || mysis.State() != MysisStateErrored {
+					t.Errorf("expected the mysis errored, got %v in state %s", err, mysis.State())
+				}
+				if memories[0].Role != store.MemoryRoleTool {
+					t.Errorf("expected no placeholder reply, got %+v", memories[0])
+				}
+				return
+			}
+
+			if err != nil || mysis.State() != MysisStateRunning {
+				t.Fatalf("expected the turn to end cleanly, got %v in state %s", err, mysis.State())
+			}
+			if len(acks) != 1 || !acks[0].Acked {
+				t.Errorf("expected the broadcast acknowledged, got %+v", acks)
+			}
+			want := fmt.Sprintf(constants.MaxToolIterationsResponse, constants.MaxToolIterations)
+			if memories[0].Role != store.MemoryRoleAssistant || memories[0].Source != store.MemorySourceSystem || memories[0].Content != want {
+				t.Errorf("expected system-sourced placeholder reply %q, got %+v", want, memories[0])
+			}
+
+			// The model never sees the placeholder as something it said
+			context, _, err := mysis.getContextMemories()
+			if err != nil {
+				t.Fatalf("getContextMemories() error: %v", err)
+			}
+			for _, mem := range context {
+				if mem.Content == want {
+					t.Error("expected the placeholder reply kept out of context")
+				}
+			}
+			for {
+				select {
+				case e := <-events:
+					if e.Type == EventMysisResponse && e.Message.Content == want {
+						return
+					}
+				default:
+					t.Fatal("expected a response event for the placeholder reply")
+				}
+			}
+		})
+	}
+}
+
+func TestMysisRetryFailedToolCall(t *testing.T) {
+	s, bus, cleanup := setupMysisTest(t)
+	defer cleanup()
+
+	stored, _ := s.CreateMysis("retry-mysis", "mock", "test-model", 0.7)
+	mysis := NewMysis(stored.ID, stored.Name, stored.CreatedAt, provider.NewMock("mock", "ok"), s, bus, "")
+
+	if _, err := mysis.LastFailedToolCall(); err == nil {
+		t.Fatal("expected an error without failed tool calls")
+	}
+
+	calls := []provider.ToolCall{
+		{ID: "call_1", Name: "travel", Arguments: json.RawMessage(`{"poi":"belt"}`)},
+		{ID: "call_2", Name: "mine", Arguments: json.RawMessage(`{}`)},
+	}
+	s.AddMemory(stored.ID, store.MemoryRoleAssistant, store.MemorySourceLLM, mysis.formatToolCallsForStorage(calls), "", "")
+	s.AddMemory(stored.ID, store.MemoryRoleTool, store.MemorySourceTool, "call_1"+constants.ToolCallStorageFieldDelimiter+"Error calling travel: unknown poi", "", "")
+	s.AddMemory(stored.ID, store.MemoryRoleTool, store.MemorySourceTool, "call_2"+constants.ToolCallStorageFieldDelimiter+"mined 5 ore", "", "")
+	before, _ := s.CountMemories(stored.ID)
+
+	failed, err := mysis.LastFailedToolCall()
+	if err != nil {
+		t.Fatalf("LastFailedToolCall() error: %v", err)
+	}
+	if failed.Call.Name != "travel" || string(failed.Call.Arguments) != `{"poi":"belt"}` || failed.Error != "Error calling travel: unknown poi" {
+		t.Errorf("unexpected failed call: %+v", failed)
+	}
+
+	if _, err := mysis.RetryToolCall(context.Background(), "travel", json.RawMessage(`{"poi":"sol"}`)); err == nil {
+		t.Error("expected an error without an MCP session")
+	}
+
+	var gotCaller mcp.CallerContext
+	var gotArgs string
+	proxy := mcp.NewProxy(nil)
+	proxy.RegisterToolWithContext(mcp.Tool{Name: "travel", InputSchema: json.RawMessage(`{"type": "object"}`)},
+		func(ctx context.Context, caller mcp.CallerContext, args json.RawMessage) (*mcp.ToolResult, error) {
+			gotCaller, gotArgs = caller, string(args)
+			return &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "arrived"}}}, nil
+		})
+	mysis.mcpProxy = proxy
+
+	if _, err := mysis.RetryToolCall(context.Background(), "travel", json.RawMessage(`{"poi":`)); err == nil {
+		t.Error("expected an error for invalid JSON arguments")
+	}
+	result, err := mysis.RetryToolCall(context.Background(), "travel", json.RawMessage(`{"poi":"sol"}`))
+	if err != nil {
+		t.Fatalf("RetryToolCall() error: %v", err)
+	}
+	if result.IsError || result.Content[0].Text != "arrived" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if gotCaller.MysisID != stored.ID || gotCaller.MysisName != "retry-mysis" || gotArgs != `{"poi":"sol"}` {
+		t.Errorf("unexpected call: caller %+v, args %s", gotCaller, gotArgs)
+	}
+
+	// A diagnostic re-run leaves memory alone
+	if after, _ := s.CountMemories(stored.ID); after != before {
+		t.Errorf("expected %d memories after retry, got %d", before, after)
+	}
 }
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+)
+
+// nopLogger discards everything; fileLogger returns it while a mysis has no log file.
+var nopLogger = zerolog.Nop()
+
+// mysisLogFile is a log file that can be closed while loggers still hold it. Writes
+// after Close are dropped, so zerolog never reports a write error on the terminal.
+type mysisLogFile struct {
+	mu   sync.Mutex
+	file *os.File
+	path string
+}
+
+func (f *mysisLogFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return len(p), nil
+	}
+	return f.file.Write(p)
+}
+
+func (f *mysisLogFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// MysisLogDir returns the directory holding per-mysis log files.
+func MysisLogDir() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logs"), nil
+}
+
+// mysisLogName turns a mysis name into a safe file name.
+func mysisLogName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if strings.Trim(safe, ".") == "" {
+		safe = "mysis"
+	}
+	return safe + ".log"
+}
+
+// EnableLogFile writes the mysis's turns, tool calls and errors to <dir>/<name>.log,
+// in addition to the shared log. Like the shared log, the file is truncated the first
+// time it is opened in a run; enabling it again appends.
+func (m *Mysis) EnableLogFile(dir string) (string, error) {
+	m.logMu.Lock()
+	defer m.logMu.Unlock()
+
+	if m.logFile != nil {
+		return m.logFile.path, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create log dir: %w", err)
+	}
+	path := filepath.Join(dir, mysisLogName(m.Name()))
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !m.logTruncated {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return "", fmt.Errorf("open log file: %w", err)
+	}
+	m.logTruncated = true
+
+	m.logFile = &mysisLogFile{file: file, path: path}
+	logger := zerolog.New(m.logFile).With().Timestamp().Str("mysis_id", m.id).Logger()
+	m.fileLog.Store(&logger)
+	return path, nil
+}
+
+// DisableLogFile stops writing the mysis log file and closes it.
+func (m *Mysis) DisableLogFile() {
+	m.logMu.Lock()
+	defer m.logMu.Unlock()
+
+	m.fileLog.Store(nil)
+	if m.logFile != nil {
+		m.logFile.Close()
+		m.logFile = nil
+	}
+}
+
+// LogFilePath returns the path of the mysis log file, or "" when it is disabled.
+func (m *Mysis) LogFilePath() string {
+	m.logMu.Lock()
+	defer m.logMu.Unlock()
+	if m.logFile == nil {
+		return ""
+	}
+	return m.logFile.path
+}
+
+// logToolCall writes a tool call to the mysis log file.
+func (m *Mysis) logToolCall(tc provider.ToolCall, result *mcp.ToolResult, err error, elapsed time.Duration) {
+	logger := m.fileLog.Load()
+	if logger == nil {
+		return
+	}
+	// Models sometimes send invalid JSON arguments, which would corrupt the log line
+	args := tc.Arguments
+	if len(args) == 0 || !json.Valid(args) {
+		args, _ = json.Marshal(string(tc.Arguments))
+	}
+	logger.Info().
+		Str("tool", tc.Name).
+		RawJSON("args", args).
+		Dur("duration", elapsed).
+		Bool("is_error", result != nil && result.IsError).
+		Err(err).
+		Msg("Tool call")
+}
+
+// fileLogger returns the logger for the mysis log file. It discards everything while
+// the file is disabled, so call sites cost one atomic load.
+func (m *Mysis) fileLogger() *zerolog.Logger {
+	if logger := m.fileLog.Load(); logger != nil {
+		return logger
+	}
+	return &nopLogger
+}
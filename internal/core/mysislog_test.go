@@ -0,0 +1,119 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+)
+
+func TestMysisLogFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	logPath := filepath.Join(home, ".zoea-nova", "logs", "scout_1.log")
+	os.MkdirAll(filepath.Dir(logPath), 0755)
+	os.WriteFile(logPath, []byte("previous run\n"), 0644)
+
+	cmd, bus, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Swarm.MysisLogs = []string{"Scout/1"}
+
+	m, _ := cmd.CreateMysis("scout/1", "mock")
+	if m.LogFilePath() != logPath {
+		t.Fatalf("LogFilePath() = %q, want %q", m.LogFilePath(), logPath)
+	}
+
+	events := bus.Subscribe()
+	if err := m.SendMessage("Report in.", "direct"); err != nil {
+		t.Fatalf("SendMessage() error: %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for response := false; !response; {
+		select {
+		case e := <-events:
+			response = e.Type == EventMysisResponse && e.MysisID == m.ID()
+		case <-timeout:
+			t.Fatal("timeout waiting for response")
+		}
+	}
+	m.setError(errors.New("provider unreachable"))
+
+	// Turning the log off and on again appends instead of truncating
+	if path, err := cmd.ToggleMysisLogFile(m.ID()); err != nil || path != "" {
+		t.Fatalf("ToggleMysisLogFile() = %q, %v; want disabled", path, err)
+	}
+	m.setErrorState(errors.New("dropped while disabled"))
+	if path, err := cmd.ToggleMysisLogFile(m.ID()); err != nil || path != logPath {
+		t.Fatalf("ToggleMysisLogFile() = %q, %v; want %q", path, err, logPath)
+	}
+	m.setErrorState(errors.New("mcp session lost"))
+	m.Stop()
+	cmd.DeleteMysis(m.ID(), true)
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	log := string(data)
+	for _, want := range []string{"Report in.", "Turn started", "Turn completed", "mock response", "provider unreachable", "mcp session lost"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log file missing %q:\n%s", want, log)
+		}
+	}
+	for _, unwanted := range []string{"previous run", "dropped while disabled"} {
+		if strings.Contains(log, unwanted) {
+			t.Errorf("log file should not contain %q:\n%s", unwanted, log)
+		}
+	}
+
+	// Myses not named in mysis_logs don't get a file
+	other, _ := cmd.CreateMysis("miner", "mock")
+	if other.LogFilePath() != "" {
+		t.Errorf("expected no log file for miner, got %q", other.LogFilePath())
+	}
+}
+
+func TestMysisLogFileBatchedToolCalls(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cmd, _, cleanup := setupCommanderTest(t)
+	defer cleanup()
+	cmd.config.Swarm.MysisLogs = []string{"scout"}
+
+	m, _ := cmd.CreateMysis("scout", "mock")
+	m.SetProvider(provider.NewScriptedMock([]provider.MockStep{
+		{ToolCalls: []provider.ToolCall{
+			{ID: "call_status", Name: "get_status", Arguments: json.RawMessage(`{}`)},
+			{ID: "call_cargo", Name: "get_cargo", Arguments: json.RawMessage(`{}`)},
+		}},
+		{Response: "Done."},
+	}))
+	upstream := &batchingUpstream{}
+	m.mcpProxy = mcp.NewProxy(upstream)
+	m.state = MysisStateRunning
+
+	if err := m.SendMessageFrom("Check in.", "direct", ""); err != nil {
+		t.Fatalf("SendMessageFrom() error: %v", err)
+	}
+	if len(upstream.batches) != 1 {
+		t.Fatalf("expected the calls to be batched, got %v", upstream.batches)
+	}
+
+	data, err := os.ReadFile(m.LogFilePath())
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	log := string(data)
+	for _, want := range []string{`"tool":"get_status"`, `"tool":"get_cargo"`} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log file missing batched call %s:\n%s", want, log)
+		}
+	}
+}
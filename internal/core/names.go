@@ -0,0 +1,71 @@
+package core
+
+import "strings"
+
+// CommanderName is the sender name shown for broadcasts with no sender ID, unless
+// [swarm] commander_name overrides it.
+const CommanderName = "Commander"
+
+// commanderName returns the configured commander identity.
+func (c *Commander) commanderName() string {
+	if c.config != nil {
+		if name := strings.TrimSpace(c.config.Swarm.CommanderName); name != "" {
+			return name
+		}
+	}
+	return CommanderName
+}
+
+// ResolveMysisName returns the name of the mysis with the given ID, the commander's
+// name for an empty ID, or "" if no such mysis exists. Names are cached, including misses;
+// creating, renaming, deleting and loading myses keeps the cache current.
+func (c *Commander) ResolveMysisName(id string) string {
+	if id == "" {
+		return c.commanderName()
+	}
+
+	c.namesMu.RLock()
+	name, ok := c.names[id]
+	c.namesMu.RUnlock()
+	if ok {
+		return name
+	}
+
+	if mysis, err := c.GetMysis(id); err == nil {
+		name = mysis.Name()
+	} else if stored, err := c.store.GetMysis(id); err == nil {
+		// Stored but not loaded, e.g. its provider is unavailable
+		name = stored.Name
+	}
+
+	c.namesMu.Lock()
+	if c.names == nil {
+		c.names = make(map[string]string)
+	}
+	c.names[id] = name
+	c.namesMu.Unlock()
+	return name
+}
+
+// cacheMysisName records a mysis name after it is created or renamed.
+func (c *Commander) cacheMysisName(id, name string) {
+	c.namesMu.Lock()
+	defer c.namesMu.Unlock()
+	if c.names == nil {
+		c.names = make(map[string]string)
+	}
+	c.names[id] = name
+}
+
+// forgetMysisNames drops cached names; with no IDs it clears the whole cache.
+func (c *Commander) forgetMysisNames(ids ...string) {
+	c.namesMu.Lock()
+	defer c.namesMu.Unlock()
+	if len(ids) == 0 {
+		c.names = nil
+		return
+	}
+	for _, id := range ids {
+		delete(c.names, id)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// PeerSnapshot is the latest result of a snapshot tool (get_status, get_cargo...) in a
+// mysis's memory, for sharing observations without asking the mysis.
+type PeerSnapshot struct {
+	MysisID   string
+	MysisName string
+	ToolName  string
+	Payload   json.RawMessage // Parsed result; a JSON string when the result isn't JSON
+	CreatedAt time.Time
+}
+
+// GetLatestSnapshot returns the most recent successful result of a snapshot tool in a
+// mysis's memory. It returns nil, nil when the mysis has no such result among its
+// last constants.PeerSnapshotScanMemories memories.
+func (c *Commander) GetLatestSnapshot(mysisID, toolName string) (*PeerSnapshot, error) {
+	m, err := c.GetMysis(mysisID)
+	if err != nil {
+		return nil, err
+	}
+	if !m.isSnapshotTool(toolName) {
+		return nil, fmt.Errorf("%q is not a snapshot tool (get_*)", toolName)
+	}
+
+	memories, err := c.store.GetRecentMemories(mysisID, constants.PeerSnapshotScanMemories)
+	if err != nil {
+		return nil, fmt.Errorf("get memories: %w", err)
+	}
+	toolCallNames := m.toolCallNameIndex(memories)
+	for i := len(memories) - 1; i >= 0; i-- {
+		mem := memories[i]
+		if mem.Role != store.MemoryRoleTool || m.extractToolNameFromResult(mem.Content, toolCallNames) != toolName {
+			continue
+		}
+		_, content, _ := ParseStoredToolResult(mem.Content)
+		if strings.HasPrefix(content, "Error calling ") {
+			continue
+		}
+
+		payload := json.RawMessage(content)
+		if !json.Valid(payload) {
+			payload, _ = json.Marshal(content)
+		}
+		return &PeerSnapshot{
+			MysisID:   mysisID,
+			MysisName: m.Name(),
+			ToolName:  toolName,
+			Payload:   payload,
+			CreatedAt: mem.CreatedAt,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+// continuePrompts holds the encouragement texts a mysis sends itself, with any
+// [prompts] overrides from the config applied.
+type continuePrompts struct {
+	nudge     string
+	firstTurn string    // Orientation for a fresh mysis (empty = nudge as usual)
+	levels    [3]string // gentle, firm, urgent (empty = nudge alone)
+}
+
+// newContinuePrompts resolves cfg against the built-in prompts. The escalating continue
+// prompts only follow the nudge once at least one of them is configured; unset levels
+// then use the built-in text.
+func newContinuePrompts(cfg config.PromptsConfig) continuePrompts {
+	pick := func(override, fallback string) string {
+		if override != "" {
+			return override
+		}
+		return fallback
+	}
+	prompts := continuePrompts{
+		nudge:     pick(cfg.Nudge, constants.NudgePrompt),
+		firstTurn: cfg.FirstTurn,
+	}
+	if cfg.Continue != "" || cfg.ContinueFirm != "" || cfg.ContinueUrgent != "" {
+		prompts.levels = [3]string{
+			pick(cfg.Continue, constants.ContinuePrompt),
+			pick(cfg.ContinueFirm, constants.ContinuePromptFirm),
+			pick(cfg.ContinueUrgent, constants.ContinuePromptUrgent),
+		}
+	}
+	return prompts
+}
+
+// buildContinuePrompt returns the synthetic encouragement for a mysis that has already
+// been nudged count times in a row: the nudge, then, when continue prompts are
+// configured, one that gets firmer with each nudge.
+func (m *Mysis) buildContinuePrompt(count int) string {
+	level := min(max(count, 0), len(m.prompts.levels)-1)
+	if m.prompts.levels[level] == "" {
+		return m.prompts.nudge
+	}
+	return m.prompts.nudge + "\n\n" + m.prompts.levels[level]
+}
+
+// buildNudge returns the synthetic message for a turn with nothing to answer: the
+// first-turn prompt on a first turn (see isFirstTurn), otherwise the continue prompt
+// for count nudges in a row.
+func (m *Mysis) buildNudge(count int, firstTurn bool) string {
+	if firstTurn {
+		return m.prompts.firstTurn
+	}
+	return m.buildContinuePrompt(count)
+}
+
+// isFirstTurn reports whether a first-turn prompt is configured and the mysis has no
+// history beyond its system prompt. Turns decide this once before their first provider
+// call.
+func (m *Mysis) isFirstTurn() bool {
+	if m.prompts.firstTurn == "" {
+		return false
+	}
+	stored, err := m.store.CountMemories(m.id)
+	if err != nil {
+		log.Warn().Err(err).Str("mysis", m.name).Msg("Failed to count memories for first-turn prompt")
+		return false
+	}
+	return stored <= 1
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// Recover clears the error on an errored mysis and resumes it in place. Unlike Start,
+// which cancels the old context and waits for its goroutine before starting over,
+// Recover keeps the mysis context (and with it the MCP session) when it is still live,
+// and keeps the run loop if it hasn't exited yet. Only a canceled context is replaced,
+// along with a fresh run loop. The next turn is a nudge, as after Start.
+//
+// Recover is safe to call while Start is cleaning up the same mysis: whichever one
+// resumes it first wins and the other returns an error.
+func (m *Mysis) Recover() error {
+	a := m
+	a.mu.Lock()
+	if a.state != MysisStateErrored {
+		state := a.state
+		a.mu.Unlock()
+		return fmt.Errorf("mysis is %s, not errored", state)
+	}
+
+	// Claim the transition under the lock so a concurrent Start or Recover backs off
+	oldState := a.state
+	a.state = MysisStateRunning
+	a.lastError = nil
+	a.encouragementCount = 0
+	a.lastMessageAt = a.now()
+	a.poisonedTurns = 0
+
+	ctx := a.ctx
+	freshCtx := ctx == nil || ctx.Err() != nil
+	if freshCtx {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		a.ctx = ctx
+		a.cancel = cancel
+	}
+	spawn := a.loopCtx != ctx
+	a.loopCtx = ctx
+	a.resumed = !spawn
+	a.mu.Unlock()
+
+	// In-memory state is authoritative, as in Stop
+	if err := a.store.UpdateMysisState(a.id, store.MysisStateRunning); err != nil {
+		log.Warn().Err(err).Str("mysis", a.name).Msg("Failed to persist Running state")
+	}
+
+	log.Info().Str("mysis", a.name).Bool("new_loop", spawn).Msg("Mysis recovered in place")
+	a.fileLogger().Info().Msg("Mysis recovered")
+	a.emitStateChange(oldState, MysisStateRunning)
+
+	if spawn {
+		if a.commander != nil {
+			a.commander.wg.Add(1)
+		}
+		go a.run(ctx)
+	}
+	if freshCtx {
+		go a.initializeMCP(ctx)
+	}
+	return nil
+}
+
+// finishRun reports whether the run loop for ctx should exit. It keeps the loop going
+// when Recover handed the mysis back to it while it was winding down; otherwise it
+// releases the loop so Recover knows to start a new one.
+func (m *Mysis) finishRun(ctx context.Context) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.resumed && m.loopCtx == ctx {
+		m.resumed = false
+		if m.state == MysisStateRunning && ctx.Err() == nil {
+			return false
+		}
+	}
+	if m.loopCtx == ctx {
+		m.loopCtx = nil
+	}
+	return true
+}
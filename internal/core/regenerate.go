@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// regenerateScanLimit is how many recent memories RegenerateLast searches for the last
+// turn. A tool loop longer than this cannot be regenerated.
+const regenerateScanLimit = 200
+
+// RegenerateLast deletes the mysis's last response, together with the tool loop that led
+// to it, and runs the turn again so the model answers the same prompt afresh. An idle
+// mysis is started to take the turn. Returns once the response is removed; the new turn
+// runs in the background. Returns ErrTurnInProgress if a turn is running.
+func (m *Mysis) RegenerateLast() error {
+	a := m
+
+	a.mu.RLock()
+	state := a.state
+	a.mu.RUnlock()
+	if err := validateCanAcceptMessage(state); err != nil {
+		return err
+	}
+
+	// Never delete memories under a running turn - it would store results for calls
+	// that no longer exist
+	if !a.turnMu.TryLock() {
+		return ErrTurnInProgress
+	}
+	removed, err := a.removeLastResponse()
+	a.turnMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("mysis", a.name).Int("removed", removed).Msg("Regenerating last response")
+
+	// Refresh views so the removed response disappears before the new one arrives
+	a.bus.Publish(Event{
+		Type:      EventMysisMessage,
+		MysisID:   a.id,
+		MysisName: a.name,
+		Timestamp: time.Now(),
+	})
+
+	if state == MysisStateIdle {
+		// The run loop takes a turn as soon as it starts
+		return a.Start()
+	}
+	// Take the turn now rather than on the next autonomous tick
+	go a.SendMessageFrom("", store.MemorySourceSystem, "")
+	return nil
+}
+
+// removeLastResponse deletes the memories of the last turn: its final text response
+// and every tool call and result before it, back to the prompt. Notes are kept. Whole
+// tool loops are removed, so no call or result is left orphaned.
+func (m *Mysis) removeLastResponse() (int, error) {
+	a := m
+
+	memories, err := a.store.GetRecentMemories(a.id, regenerateScanLimit)
+	if err != nil {
+		return 0, fmt.Errorf("load memories: %w", err)
+	}
+
+	var ids []int64
+	var first *store.Memory // Earliest memory of the turn
+	for i := len(memories) - 1; i >= 0; i-- {
+		mem := memories[i]
+		if mem.Source == store.MemorySourceNote {
+			continue
+		}
+		isToolCall := mem.Role == store.MemoryRoleAssistant && strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix)
+		isReply := mem.Role == store.MemoryRoleAssistant && !isToolCall && len(ids) == 0
+		if mem.Role != store.MemoryRoleTool && !isToolCall && !isReply {
+			break
+		}
+		ids = append(ids, mem.ID)
+		first = mem
+	}
+
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no response to regenerate")
+	}
+	// A turn that starts with a tool result was cut off by the scan limit; its calls
+	// would be left orphaned
+	if first.Role != store.MemoryRoleAssistant {
+		return 0, fmt.Errorf("last turn is longer than %d memories", regenerateScanLimit)
+	}
+
+	if err := a.store.DeleteMemoriesByID(a.id, ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// replayToolStubText is returned to the provider in place of real tool output during replay.
+const replayToolStubText = "Replay mode: tool call recorded but not executed."
+
+// ReplayTurn captures the provider output for one replayed prompt.
+type ReplayTurn struct {
+	Prompt     *store.Memory       // The user or broadcast prompt that started the turn
+	Original   string              // The response originally stored for this prompt (empty if none)
+	Response   string              // Final text response from the replay provider
+	Reasoning  string              // Reasoning from the final response (if any)
+	ToolCalls  []provider.ToolCall // Tool calls requested during the turn (stubbed, never executed)
+	Iterations int                 // Number of provider calls made for the turn
+	Err        error               // Provider error that ended the turn early
+}
+
+// ReplaySession re-runs the user and broadcast prompts in memories through p without
+// touching the live mysis. Context for each prompt is rebuilt the same way
+// getContextMemories does: system prompt + compressed history + current turn, limited to
+// the MaxContextMessages window that existed when the prompt was received.
+//
+// Tool calls returned by the provider are recorded on the turn and answered with a stub
+// result so the provider can continue; nothing is sent to the game server.
+//
+// The store is only used to look up the system prompt when memories do not contain one,
+// and may be nil. Provider errors are recorded per turn; only context cancellation
+// aborts the replay.
+func ReplaySession(ctx context.Context, s *store.Store, memories []*store.Memory, p provider.Provider) ([]ReplayTurn, error) {
+	// Helper methods used here are stateless; a bare Mysis lets us share them.
+	r := &Mysis{}
+
+	var system *store.Memory
+	history := make([]*store.Memory, 0, len(memories))
+	for _, mem := range memories {
+		if mem.Role == store.MemoryRoleSystem && mem.Source == store.MemorySourceSystem {
+			if system == nil {
+				system = mem
+			}
+			continue
+		}
+		history = append(history, mem)
+	}
+	if system == nil && s != nil && len(memories) > 0 {
+		if sys, err := s.GetSystemMemory(memories[0].MysisID); err == nil {
+			system = sys
+		}
+	}
+
+	tools := replayTools(r, history)
+
+	var turns []ReplayTurn
+	for i, mem := range history {
+		if !isReplayPrompt(mem) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return turns, err
+		}
+
+		// Reconstruct the sliding window as it looked when the prompt arrived
+		start := i + 1 - constants.MaxContextMessages
+		if start < 0 {
+			start = 0
+		}
+		window := history[start : i+1]
+
+		contextMemories := make([]*store.Memory, 0, len(window)+1)
+		if system != nil {
+			contextMemories = append(contextMemories, system)
+		}
+		contextMemories = append(contextMemories, r.extractLatestToolLoop(window[:len(window)-1])...)
+		contextMemories = append(contextMemories, mem)
+
+		turn := ReplayTurn{
+			Prompt:   mem,
+			Original: originalResponse(history[i+1:]),
+		}
+		replayTurn(ctx, r, p, tools, contextMemories, &turn)
+		turns = append(turns, turn)
+
+		log.Debug().
+			Int64("prompt_id", mem.ID).
+			Int("iterations", turn.Iterations).
+			Int("tool_calls", len(turn.ToolCalls)).
+			Err(turn.Err).
+			Msg("Replayed turn")
+	}
+
+	return turns, nil
+}
+
+// replayTurn runs the tool loop for a single prompt, stubbing every tool call.
+func replayTurn(ctx context.Context, r *Mysis, p provider.Provider, tools []provider.Tool, contextMemories []*store.Memory, turn *ReplayTurn) {
+	for iteration := 0; iteration < constants.MaxToolIterations; iteration++ {
+		contextMemories = r.compactSnapshots(contextMemories)
+		contextMemories = r.removeOrphanedToolCalls(contextMemories)
+		messages := r.memoriesToMessages(contextMemories)
+
+		var response *provider.ChatResponse
+		var err error
+		if len(tools) > 0 && !p.SupportsTools() {
+			response, err = provider.ChatWithToolShim(ctx, p, messages, tools)
+		} else if len(tools) > 0 {
+			response, err = p.ChatWithTools(ctx, messages, tools)
+		} else {
+			var text string
+			text, err = p.Chat(ctx, messages)
+			response = &provider.ChatResponse{Content: text}
+		}
+		turn.Iterations++
+		if err != nil {
+			turn.Err = fmt.Errorf("provider chat: %w", err)
+			return
+		}
+
+		if len(response.ToolCalls) == 0 {
+			turn.Response = response.Content
+			turn.Reasoning = response.Reasoning
+			if turn.Response == "" && turn.Reasoning == "" {
+				turn.Response = constants.FallbackLLMResponse
+			}
+			return
+		}
+
+		turn.ToolCalls = append(turn.ToolCalls, response.ToolCalls...)
+		contextMemories = append(contextMemories, &store.Memory{
+			Role:    store.MemoryRoleAssistant,
+			Source:  store.MemorySourceLLM,
+			Content: r.formatToolCallsForStorage(response.ToolCalls),
+		})
+		stub := &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: replayToolStubText}}}
+		for _, tc := range response.ToolCalls {
+			contextMemories = append(contextMemories, &store.Memory{
+				Role:    store.MemoryRoleTool,
+				Source:  store.MemorySourceTool,
+				Content: r.formatToolResult(tc.ID, tc.Name, stub, nil),
+			})
+		}
+	}
+}
+
+// replayTools builds tool definitions from the tool names seen in history so the provider
+// can request the same tools it had during the original session. Schemas are not stored,
+// so each tool accepts an arbitrary object.
+func replayTools(r *Mysis, history []*store.Memory) []provider.Tool {
+	seen := make(map[string]bool)
+	var tools []provider.Tool
+	for _, mem := range history {
+		if mem.Role != store.MemoryRoleAssistant || !strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix) {
+			continue
+		}
+		for _, call := range r.parseStoredToolCalls(mem.Content) {
+			if call.Name == "" || seen[call.Name] {
+				continue
+			}
+			seen[call.Name] = true
+			tools = append(tools, provider.Tool{
+				Name:        call.Name,
+				Description: "Replayed tool (stubbed)",
+				Parameters:  json.RawMessage(`{"type":"object"}`),
+			})
+		}
+	}
+	return tools
+}
+
+// isReplayPrompt reports whether a memory starts a turn that should be replayed.
+func isReplayPrompt(mem *store.Memory) bool {
+	return mem.Role == store.MemoryRoleUser &&
+		(mem.Source == store.MemorySourceDirect || mem.Source == store.MemorySourceBroadcast)
+}
+
+// originalResponse returns the first final assistant text after a prompt, stopping at the
+// next prompt.
+func originalResponse(after []*store.Memory) string {
+	for _, mem := range after {
+		if isReplayPrompt(mem) {
+			return ""
+		}
+		if mem.Role == store.MemoryRoleAssistant && !strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix) {
+			return mem.Content
+		}
+	}
+	return ""
+}
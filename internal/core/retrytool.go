@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/mcp"
+	"github.com/xonecas/zoea-nova/internal/provider"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// FailedToolCall is a stored tool call whose result was an error.
+type FailedToolCall struct {
+	Call  provider.ToolCall
+	Error string // The stored error result, without the tool call ID
+}
+
+// LastFailedToolCall returns the mysis's most recent tool call that failed, searching
+// the last regenerateScanLimit memories.
+func (m *Mysis) LastFailedToolCall() (*FailedToolCall, error) {
+	memories, err := m.store.GetRecentMemories(m.id, regenerateScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("load memories: %w", err)
+	}
+
+	// Results follow their calls, so walk back to the failed result first
+	var failedID, failedContent string
+	for i := len(memories) - 1; i >= 0; i-- {
+		mem := memories[i]
+		if failedID == "" {
+			if mem.Role != store.MemoryRoleTool {
+				continue
+			}
+			id, content, ok := ParseStoredToolResult(mem.Content)
+			if ok && strings.HasPrefix(content, "Error calling ") {
+				failedID, failedContent = id, content
+			}
+			continue
+		}
+		if mem.Role != store.MemoryRoleAssistant || !strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix) {
+			continue
+		}
+		for _, call := range ParseStoredToolCalls(mem.Content) {
+			if call.ID == failedID {
+				return &FailedToolCall{Call: call, Error: failedContent}, nil
+			}
+		}
+	}
+	if failedID != "" {
+		return nil, fmt.Errorf("failed tool call %s is no longer in the last %d memories", failedID, regenerateScanLimit)
+	}
+	return nil, fmt.Errorf("no failed tool call")
+}
+
+// RetryToolCall re-issues a tool call through the mysis's MCP session, as the mysis, so
+// account and session state match the original call. It is a diagnostic re-run: nothing
+// is stored in memory and the tool call audit trail is left alone. The mysis must be
+// running to have a session.
+func (m *Mysis) RetryToolCall(ctx context.Context, name string, arguments json.RawMessage) (*mcp.ToolResult, error) {
+	if len(arguments) == 0 {
+		arguments = json.RawMessage("{}")
+	}
+	if !json.Valid(arguments) {
+		return nil, fmt.Errorf("arguments for %s are not valid JSON", name)
+	}
+	if !m.ToolPolicy().Permits(name) {
+		return nil, fmt.Errorf("tool %s is disabled for this mysis", name)
+	}
+
+	m.mu.RLock()
+	mcpProxy := m.mcpProxy
+	m.mu.RUnlock()
+	if mcpProxy == nil {
+		return nil, fmt.Errorf("mysis has no MCP session; start it first")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.ToolRetryTimeout)
+	defer cancel()
+
+	log.Info().Str("mysis", m.name).Str("tool", name).RawJSON("arguments", arguments).Msg("Retrying tool call")
+	caller := mcp.CallerContext{
+		MysisID:   m.id,
+		MysisName: m.name,
+	}
+	return callToolCancelable(ctx, mcpProxy, caller, provider.ToolCall{Name: name, Arguments: arguments})
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+// SwarmSettings lists the swarm settings ConfigureSwarm accepts.
+var SwarmSettings = []string{"max_concurrent_turns", "max_myses", "turn_interval_ms"}
+
+// ConfigureSwarm changes a swarm-wide setting by name, effective immediately. Changes
+// last until restart; config.toml is not rewritten.
+func (c *Commander) ConfigureSwarm(setting string, value int) error {
+	switch setting {
+	case "max_concurrent_turns":
+		return c.SetMaxConcurrentTurns(value)
+	case "max_myses":
+		return c.SetMaxMyses(value)
+	case "turn_interval_ms":
+		return c.SetTurnInterval(time.Duration(value) * time.Millisecond)
+	default:
+		return fmt.Errorf("unknown swarm setting %q (valid: %s)", setting, strings.Join(SwarmSettings, ", "))
+	}
+}
+
+// SetMaxConcurrentTurns changes how many provider calls may be in flight at once
+// (0 = unlimited). Calls already holding or waiting on a slot finish under the old cap.
+func (c *Commander) SetMaxConcurrentTurns(limit int) error {
+	if limit < 0 || limit > constants.MaxConcurrentTurnsLimit {
+		return fmt.Errorf("max_concurrent_turns=%d must be between 0 and %d", limit, constants.MaxConcurrentTurnsLimit)
+	}
+	c.slotsMu.Lock()
+	c.turnSlots = newTurnSlots(limit)
+	c.slotsMu.Unlock()
+
+	log.Info().Int("max_concurrent_turns", limit).Msg("Max concurrent turns updated")
+	return nil
+}
+
+// SetMaxMyses changes the swarm size cap. Lowering it below the current count only
+// blocks new myses; the ceiling, when set, still bounds how far it may be raised.
+func (c *Commander) SetMaxMyses(limit int) error {
+	ceiling := 100 // Same bound as [swarm] max_myses
+	if c.config.Swarm.MaxMysesCeiling > 0 {
+		ceiling = c.config.Swarm.MaxMysesCeiling
+	}
+	if limit < 1 || limit > ceiling {
+		return fmt.Errorf("max_myses=%d must be between 1 and %d", limit, ceiling)
+	}
+	c.mu.Lock()
+	c.maxMyses = limit
+	c.mu.Unlock()
+
+	log.Info().Int("max_myses", limit).Msg("Max myses updated")
+	return nil
+}
+
+// SetTurnInterval changes the pause between autonomous turns, starting with each
+// mysis's next pause.
+func (c *Commander) SetTurnInterval(d time.Duration) error {
+	if d < constants.MinTurnInterval || d > constants.MaxTurnInterval {
+		return fmt.Errorf("turn interval %s must be between %s and %s", d, constants.MinTurnInterval, constants.MaxTurnInterval)
+	}
+	c.turnInterval.Store(int64(d))
+
+	log.Info().Dur("turn_interval", d).Msg("Turn interval updated")
+	return nil
+}
+
+// TurnInterval returns the pause between autonomous turns.
+func (c *Commander) TurnInterval() time.Duration {
+	if d := time.Duration(c.turnInterval.Load()); d > 0 {
+		return d
+	}
+	return constants.DefaultTurnInterval
+}
+
+// turnInterval returns the pause before the mysis's next autonomous turn.
+func (m *Mysis) turnInterval() time.Duration {
+	if m.commander != nil {
+		return m.commander.TurnInterval()
+	}
+	return constants.DefaultTurnInterval
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/store"
+)
+
+// CreateMysisFromTemplate creates a mysis whose memory starts with a copy of a stored
+// template conversation (see store.SaveTemplate), e.g. a few priming exchanges. The
+// system prompt is still added on first start.
+func (c *Commander) CreateMysisFromTemplate(name, providerName, templateID string) (*Mysis, error) {
+	memories, err := c.store.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTemplate(memories); err != nil {
+		return nil, fmt.Errorf("template %s: %w", templateID, err)
+	}
+
+	mysis, err := c.CreateMysis(name, providerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store.SeedMemories(mysis.ID(), memories); err != nil {
+		// Don't leave a half-seeded mysis behind
+		_ = c.DeleteMysis(mysis.ID(), true)
+		return nil, fmt.Errorf("seed memories from template %s: %w", templateID, err)
+	}
+	return mysis, nil
+}
+
+// validateTemplate checks that a template can be replayed as conversation history.
+// Every assistant tool call must be answered by tool results directly after it, and
+// every result must answer such a call; otherwise orphan removal would strip them
+// from the context. System prompts are rejected because the template would replace
+// the one built on first start.
+func validateTemplate(memories []*store.Memory) error {
+	seen := make(map[string]bool)
+	pending := make(map[string]bool) // Tool calls still waiting for a result
+
+	for i, mem := range memories {
+		if len(pending) > 0 && mem.Role != store.MemoryRoleTool {
+			return fmt.Errorf("memory %d: tool call %s has no result before the next message", i, firstKey(pending))
+		}
+
+		switch {
+		case mem.Role == store.MemoryRoleSystem && mem.Source == store.MemorySourceSystem:
+			return fmt.Errorf("memory %d: templates cannot contain a system prompt", i)
+
+		case mem.Role == store.MemoryRoleAssistant && strings.HasPrefix(mem.Content, constants.ToolCallStoragePrefix):
+			calls := ParseStoredToolCalls(mem.Content)
+			if len(calls) == 0 {
+				return fmt.Errorf("memory %d: malformed tool calls", i)
+			}
+			for _, call := range calls {
+				if seen[call.ID] {
+					return fmt.Errorf("memory %d: duplicate tool call id %s", i, call.ID)
+				}
+				seen[call.ID] = true
+				pending[call.ID] = true
+			}
+
+		case mem.Role == store.MemoryRoleTool:
+			callID, _, ok := ParseStoredToolResult(mem.Content)
+			if !ok {
+				return fmt.Errorf("memory %d: malformed tool result", i)
+			}
+			if !pending[callID] {
+				return fmt.Errorf("memory %d: tool result %s has no matching tool call", i, callID)
+			}
+			delete(pending, callID)
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("tool call %s has no result", firstKey(pending))
+	}
+	return nil
+}
+
+// firstKey returns the smallest key, for deterministic error messages.
+func firstKey(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
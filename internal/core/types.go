@@ -13,6 +13,9 @@ const (
 	MysisStateRunning MysisState = "running"
 	MysisStateStopped MysisState = "stopped"
 	MysisStateErrored MysisState = "errored"
+	// MysisStateQuarantined means the mysis kept poisoning its context with orphaned
+	// tool calls and was halted; it requires an explicit relaunch.
+	MysisStateQuarantined MysisState = "quarantined"
 )
 
 // ActivityState represents what the mysis is currently doing in-game.
@@ -36,6 +39,7 @@ const (
 	EventMysisDeleted       EventType = "mysis_deleted"
 	EventMysisStateChanged  EventType = "mysis_state_changed"
 	EventMysisConfigChanged EventType = "mysis_config_changed"
+	EventMysisRenamed       EventType = "mysis_renamed"
 	EventMysisMessage       EventType = "mysis_message"
 	EventMysisResponse      EventType = "mysis_response"
 	EventMysisError         EventType = "mysis_error"
@@ -44,6 +48,7 @@ const (
 	EventNetworkMCP         EventType = "network_mcp"  // MCP request started/finished
 	EventNetworkIdle        EventType = "network_idle" // Network activity finished
 	EventRateLimit          EventType = "rate_limit"
+	EventMCPReconnect       EventType = "mcp_reconnect" // Upstream reconnect started/finished
 )
 
 // Event represents something that happened in the swarm.
@@ -55,7 +60,9 @@ type Event struct {
 	Error     *ErrorData
 	State     *StateChangeData
 	Config    *ConfigChangeData
+	Rename    *RenameData
 	RateLimit *RateLimitData
+	Reconnect *ReconnectData
 	Timestamp time.Time
 }
 
@@ -65,9 +72,24 @@ type MessageData struct {
 	Content string
 }
 
+// ErrorKind distinguishes error event subtypes.
+type ErrorKind string
+
+const (
+	ErrorKindGeneric            ErrorKind = ""
+	ErrorKindToolRetryExhausted ErrorKind = "tool_retry_exhausted"
+)
+
 // ErrorData contains data for error events.
 type ErrorData struct {
 	Error string
+	Kind  ErrorKind
+
+	// Populated for ErrorKindToolRetryExhausted
+	Tool    string
+	Retries int
+	Cause   string // mcp.RetryCause* classification
+	Reason  string // Last upstream error
 }
 
 // StateChangeData contains data for state change events.
@@ -82,7 +104,19 @@ type ConfigChangeData struct {
 	Model    string
 }
 
+// RenameData contains data for rename events.
+type RenameData struct {
+	OldName string
+	NewName string
+}
+
 type RateLimitData struct {
 	Provider string
 	Model    string
 }
+
+// ReconnectData contains data for MCP reconnect events.
+type ReconnectData struct {
+	Done  bool   // False when the reconnect starts, true when it finishes
+	Error string // Why a finished reconnect failed (empty on success)
+}
@@ -5,34 +5,71 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
 // Client is an MCP client that communicates with an upstream server.
 type Client struct {
-	endpoint        string
-	httpClient      *http.Client
-	requestID       atomic.Int64
+	endpoint   string
+	httpClient *http.Client
+	requestID  atomic.Int64
+
+	// Guards the session, which a watchdog reconnect resets while calls are in flight
+	sessionMu       sync.RWMutex
 	sessionID       string // Session ID from server, included in subsequent requests
 	protocolVersion string // Negotiated protocol version
 }
 
 // NewClient creates a new MCP client.
+// Each client owns its transport so Close only drops this client's connections.
+// Requests are bound to the caller's context, so canceling it aborts dials,
+// in-flight requests, and SSE body reads.
 func NewClient(endpoint string) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+
 	return &Client{
 		endpoint: endpoint,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second, // 30s timeout to prevent hanging requests
+			Transport: transport,
+			Timeout:   30 * time.Second, // 30s timeout to prevent hanging requests
 		},
 		protocolVersion: "2024-11-05", // Default, may be updated during initialization
 	}
 }
 
+// setSessionHeaders adds the session ID and protocol version, when known, to req.
+func (c *Client) setSessionHeaders(req *http.Request) {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+	if c.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", c.sessionID)
+	}
+	if c.protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", c.protocolVersion)
+	}
+}
+
+// captureSession keeps the session ID the server returned in resp, if any.
+func (c *Client) captureSession(resp *http.Response) {
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		c.sessionMu.Lock()
+		c.sessionID = sessionID
+		c.sessionMu.Unlock()
+	}
+}
+
 // nextID returns the next request ID.
 func (c *Client) nextID() int64 {
 	return c.requestID.Add(1)
@@ -48,10 +85,27 @@ func (c *Client) Call(ctx context.Context, method string, params interface{}) (*
 	return c.send(ctx, req)
 }
 
-// send sends a request and receives a response.
-// Supports both JSON and SSE (Streamable HTTP) responses per MCP spec.
-func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
-	body, err := json.Marshal(req)
+// ErrBatchUnsupported is returned by CallToolsBatch when the server rejects JSON-RPC batches.
+var ErrBatchUnsupported = errors.New("mcp server does not support batch requests")
+
+// httpStatusError is a non-200 response from the server.
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.RetryAfter != "" {
+		return fmt.Sprintf("http error %d: %s (Retry-After: %s)", e.StatusCode, e.Body, e.RetryAfter)
+	}
+	return fmt.Sprintf("http error %d: %s", e.StatusCode, e.Body)
+}
+
+// post sends a JSON-RPC payload and returns the successful HTTP response.
+// The caller must close the response body.
+func (c *Client) post(ctx context.Context, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
@@ -65,38 +119,40 @@ func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
 	httpReq.Header.Set("Accept", "application/json, text/event-stream")
 
 	// Include session ID if we have one (required after initialization)
-	if c.sessionID != "" {
-		httpReq.Header.Set("Mcp-Session-Id", c.sessionID)
-	}
-
-	// Include protocol version header
-	if c.protocolVersion != "" {
-		httpReq.Header.Set("MCP-Protocol-Version", c.protocolVersion)
-	}
+	c.setSessionHeaders(httpReq)
 
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("http request: %w", err)
 	}
-	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
 		respBody, _ := io.ReadAll(httpResp.Body)
+		statusErr := &httpStatusError{StatusCode: httpResp.StatusCode, Body: string(respBody)}
 
 		// For 429 rate limits, include Retry-After header if present
 		if httpResp.StatusCode == http.StatusTooManyRequests {
-			if retryAfter := httpResp.Header.Get("Retry-After"); retryAfter != "" {
-				return nil, fmt.Errorf("http error %d: %s (Retry-After: %s)", httpResp.StatusCode, string(respBody), retryAfter)
-			}
+			statusErr.RetryAfter = httpResp.Header.Get("Retry-After")
 		}
 
-		return nil, fmt.Errorf("http error %d: %s", httpResp.StatusCode, string(respBody))
+		return nil, statusErr
 	}
 
 	// Capture session ID from response if present
-	if sessionID := httpResp.Header.Get("Mcp-Session-Id"); sessionID != "" {
-		c.sessionID = sessionID
+	c.captureSession(httpResp)
+
+	return httpResp, nil
+}
+
+// send sends a request and receives a response.
+// Supports both JSON and SSE (Streamable HTTP) responses per MCP spec.
+func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
+	httpResp, err := c.post(ctx, req)
+	if err != nil {
+		return nil, err
 	}
+	defer httpResp.Body.Close()
 
 	contentType := httpResp.Header.Get("Content-Type")
 
@@ -119,6 +175,89 @@ func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
 	return &resp, nil
 }
 
+// sendBatch sends a JSON-RPC batch and returns the responses in any order.
+// Returns ErrBatchUnsupported if the server rejects the batch as a whole.
+func (c *Client) sendBatch(ctx context.Context, reqs []*Request) ([]*Response, error) {
+	httpResp, err := c.post(ctx, reqs)
+	if err != nil {
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 &&
+			statusErr.StatusCode != http.StatusTooManyRequests {
+			return nil, fmt.Errorf("%w: %v", ErrBatchUnsupported, err)
+		}
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	var payloads [][]byte
+	if strings.HasPrefix(httpResp.Header.Get("Content-Type"), "text/event-stream") {
+		payloads, err = readSSEData(httpResp.Body)
+	} else {
+		var data []byte
+		data, err = io.ReadAll(httpResp.Body)
+		payloads = [][]byte{data}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	// Servers may answer with one array or, over SSE, one event per response
+	var responses []*Response
+	for _, data := range payloads {
+		data = bytes.TrimSpace(data)
+		if len(data) > 0 && data[0] == '[' {
+			var batch []*Response
+			if err := json.Unmarshal(data, &batch); err != nil {
+				return nil, fmt.Errorf("unmarshal batch response: %w", err)
+			}
+			responses = append(responses, batch...)
+			continue
+		}
+
+		var resp Response
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response: %w", err)
+		}
+		// A single error without an ID is the server refusing the batch itself
+		if resp.ID == nil && resp.Error != nil {
+			return nil, fmt.Errorf("%w: mcp error %d: %s", ErrBatchUnsupported, resp.Error.Code, resp.Error.Message)
+		}
+		responses = append(responses, &resp)
+	}
+
+	return responses, nil
+}
+
+// readSSEData returns the data payload of every event in an SSE stream.
+func readSSEData(body io.Reader) ([][]byte, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 2*1024*1024)
+
+	var payloads [][]byte
+	var dataLines []string
+	flush := func() {
+		if len(dataLines) > 0 {
+			payloads = append(payloads, []byte(strings.Join(dataLines, "")))
+			dataLines = nil
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "data: ") {
+			dataLines = append(dataLines, strings.TrimPrefix(line, "data: "))
+		} else if line == "" {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read SSE stream: %w", err)
+	}
+	flush()
+
+	return payloads, nil
+}
+
 // parseSSEResponse parses a Server-Sent Events stream for MCP responses.
 func (c *Client) parseSSEResponse(body io.Reader) (*Response, error) {
 	scanner := bufio.NewScanner(body)
@@ -206,6 +345,57 @@ func (c *Client) CallTool(ctx context.Context, name string, arguments interface{
 		return nil, err
 	}
 
+	return toolResultFromResponse(resp)
+}
+
+// CallToolsBatch invokes several tools in a single JSON-RPC batch request.
+// Results are returned in call order; a tool that fails on the server gets an
+// error ToolResult like CallTool. Returns ErrBatchUnsupported if the server
+// rejects batches, in which case the caller should fall back to CallTool.
+func (c *Client) CallToolsBatch(ctx context.Context, calls []ToolCall) ([]*ToolResult, error) {
+	reqs := make([]*Request, len(calls))
+	index := make(map[int64]int, len(calls))
+	for i, call := range calls {
+		id := c.nextID()
+		req, err := NewRequest(id, "tools/call", CallToolParams{Name: call.Name, Arguments: call.Arguments})
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		reqs[i] = req
+		index[id] = i
+	}
+
+	responses, err := c.sendBatch(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ToolResult, len(calls))
+	for _, resp := range responses {
+		id, ok := responseID(resp.ID)
+		if !ok {
+			continue
+		}
+		i, ok := index[id]
+		if !ok {
+			continue
+		}
+		if results[i], err = toolResultFromResponse(resp); err != nil {
+			return nil, fmt.Errorf("%s: %w", calls[i].Name, err)
+		}
+	}
+
+	for i, result := range results {
+		if result == nil {
+			return nil, fmt.Errorf("batch response missing result for %s", calls[i].Name)
+		}
+	}
+
+	return results, nil
+}
+
+// toolResultFromResponse converts a tools/call response, mapping JSON-RPC errors to error results.
+func toolResultFromResponse(resp *Response) (*ToolResult, error) {
 	if resp.Error != nil {
 		return &ToolResult{
 			Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("Error: %s", resp.Error.Message)}},
@@ -221,6 +411,20 @@ func (c *Client) CallTool(ctx context.Context, name string, arguments interface{
 	return &result, nil
 }
 
+// responseID converts a decoded JSON-RPC ID back to the int64 the client sent.
+func responseID(id interface{}) (int64, bool) {
+	switch v := id.(type) {
+	case float64:
+		return int64(v), true
+	case int64:
+		return v, true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
 // Initialize sends the initialize request to the server and completes the handshake.
 func (c *Client) Initialize(ctx context.Context, clientInfo map[string]interface{}) (*Response, error) {
 	params := map[string]interface{}{
@@ -299,14 +503,7 @@ func (c *Client) Notify(ctx context.Context, method string, params interface{})
 	httpReq.Header.Set("Accept", "application/json, text/event-stream")
 
 	// Include session ID if we have one
-	if c.sessionID != "" {
-		httpReq.Header.Set("Mcp-Session-Id", c.sessionID)
-	}
-
-	// Include protocol version header
-	if c.protocolVersion != "" {
-		httpReq.Header.Set("MCP-Protocol-Version", c.protocolVersion)
-	}
+	c.setSessionHeaders(httpReq)
 
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -315,9 +512,7 @@ func (c *Client) Notify(ctx context.Context, method string, params interface{})
 	defer httpResp.Body.Close()
 
 	// Capture session ID from response if present
-	if sessionID := httpResp.Header.Get("Mcp-Session-Id"); sessionID != "" {
-		c.sessionID = sessionID
-	}
+	c.captureSession(httpResp)
 
 	// Notifications may return 200/202/204, we just check for success
 	if httpResp.StatusCode >= 400 {
@@ -328,6 +523,16 @@ func (c *Client) Notify(ctx context.Context, method string, params interface{})
 	return nil
 }
 
+// Reset drops the session and idle connections, so the next Initialize starts a new
+// session.
+func (c *Client) Reset() {
+	c.sessionMu.Lock()
+	c.sessionID = ""
+	c.protocolVersion = ""
+	c.sessionMu.Unlock()
+	c.httpClient.CloseIdleConnections()
+}
+
 // Close closes idle HTTP connections
 func (c *Client) Close() error {
 	if c.httpClient != nil {
@@ -3,10 +3,12 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestClientInitialize(t *testing.T) {
@@ -131,6 +133,110 @@ func TestClientCallToolErrorReturnsToolResult(t *testing.T) {
 	}
 }
 
+func TestClientCallToolsBatch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var batch []Request
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("expected batch request: %v", err)
+			return
+		}
+
+		// Answer out of order, with one per-call error
+		var responses []*Response
+		for i := len(batch) - 1; i >= 0; i-- {
+			var params CallToolParams
+			json.Unmarshal(batch[i].Params, &params)
+			if params.Name == "get_cargo" {
+				responses = append(responses, NewErrorResponse(batch[i].ID, 42, "not docked"))
+				continue
+			}
+			resp, _ := NewResponse(batch[i].ID, ToolResult{Content: []ContentBlock{{Type: "text", Text: params.Name}}})
+			responses = append(responses, resp)
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	results, err := client.CallToolsBatch(context.Background(), []ToolCall{
+		{Name: "get_status"},
+		{Name: "get_cargo"},
+		{Name: "get_system", Arguments: json.RawMessage(`{"id":"sol"}`)},
+	})
+	if err != nil {
+		t.Fatalf("CallToolsBatch() error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 HTTP request, got %d", requests)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].IsError || results[0].Content[0].Text != "get_status" {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if !results[1].IsError || !strings.Contains(results[1].Content[0].Text, "not docked") {
+		t.Errorf("expected per-call error result, got %+v", results[1])
+	}
+	if results[2].IsError || results[2].Content[0].Text != "get_system" {
+		t.Errorf("unexpected third result: %+v", results[2])
+	}
+}
+
+func TestClientCallToolsBatchUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"http 400", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "batch requests not supported", http.StatusBadRequest)
+		}},
+		{"invalid request error", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(NewErrorResponse(nil, ErrorCodeInvalidRequest, "Invalid Request"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			_, err := client.CallToolsBatch(context.Background(), []ToolCall{{Name: "get_status"}, {Name: "get_cargo"}})
+			if !errors.Is(err, ErrBatchUnsupported) {
+				t.Fatalf("expected ErrBatchUnsupported, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClientCallToolCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.CallTool(ctx, "mine", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CallTool() took %v after cancel", elapsed)
+	}
+}
+
 // TestClientClose verifies Close() is idempotent and returns nil.
 func TestClientClose(t *testing.T) {
 	client := NewClient("http://localhost:3000")
@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
 )
 
 func TestNewRequest(t *testing.T) {
@@ -49,6 +54,41 @@ func TestNewErrorResponse(t *testing.T) {
 	}
 }
 
+func TestToolResultDisplayText(t *testing.T) {
+	// "iVBORw0KGgo=" is the 8-byte PNG signature
+	raw := `{"content": [
+		{"type": "text", "text": "Scanned sector 7"},
+		{"type": "image", "data": "iVBORw0KGgo=", "mimeType": "image/png"},
+		{"type": "resource", "resource": {"uri": "game://sector/7", "mimeType": "text/plain", "text": "3 asteroids"}},
+		{"type": "resource", "resource": {"uri": "game://sector/7.png", "mimeType": "image/png", "blob": "iVBORw=="}},
+		{"type": "hologram"}
+	]}`
+	var result ToolResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"Scanned sector 7",
+		"[image: 8 bytes, image/png]",
+		"[resource: game://sector/7]\n3 asteroids",
+		"[resource game://sector/7.png: 4 bytes, image/png]",
+		"[hologram content]",
+	}, "\n")
+	if got := result.DisplayText(); got != want {
+		t.Errorf("DisplayText() = %q, want %q", got, want)
+	}
+
+	// Non-text content survives a round trip through the proxy
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"data":"iVBORw0KGgo="`) || !strings.Contains(string(data), `"blob":"iVBORw=="`) {
+		t.Errorf("expected image data and blob preserved, got %s", data)
+	}
+}
+
 func TestProxyLocalTools(t *testing.T) {
 	proxy := NewProxy(nil) // No upstream
 
@@ -174,7 +214,17 @@ func TestClientWithMockServer(t *testing.T) {
 }
 
 // mockOrchestrator is a test implementation of the Orchestrator interface.
-type mockOrchestrator struct{}
+type mockOrchestrator struct {
+	lastSwarmLimit int
+	lastReminder   string
+	lastAskSender  string
+	lastAskTimeout time.Duration
+	lastAllow      []string
+	lastDeny       []string
+	lastProvider   string
+	lastSetting    string
+	relaunchReport *RelaunchReport
+}
 
 func (m *mockOrchestrator) MysisCount() int {
 	return 2
@@ -212,10 +262,174 @@ func (m *mockOrchestrator) SearchMessages(mysisID, query string, limit int) ([]S
 	return []SearchResult{}, nil
 }
 
+func (m *mockOrchestrator) SearchSwarm(query string, limit int) ([]SearchResult, error) {
+	m.lastSwarmLimit = limit
+	if query != "iron" {
+		return nil, nil
+	}
+	return []SearchResult{
+		{MysisName: "beta", Role: "tool", Source: "tool", Content: "found iron at Sol", CreatedAt: "2026-01-01 10:05:00"},
+		{MysisName: "alpha", Role: "assistant", Source: "llm", Content: "mining iron", CreatedAt: "2026-01-01 10:00:00"},
+	}, nil
+}
+
 func (m *mockOrchestrator) SearchReasoning(mysisID, query string, limit int) ([]ReasoningResult, error) {
 	return []ReasoningResult{}, nil
 }
 
+func (m *mockOrchestrator) GetRecentReasoning(mysisID string, limit int) ([]ReasoningResult, error) {
+	if mysisID != "mysis-1" {
+		return nil, errors.New("mysis not found")
+	}
+	results := []ReasoningResult{
+		{Role: "assistant", Source: "llm", Content: "mining", Reasoning: "ore prices are up", CreatedAt: "2026-01-01 10:00:00"},
+		{Role: "assistant", Source: "llm", Content: "selling", Reasoning: "cargo is full", CreatedAt: "2026-01-01 10:05:00"},
+	}
+	if limit < len(results) {
+		results = results[len(results)-limit:]
+	}
+	return results, nil
+}
+
+func (m *mockOrchestrator) SetCompactSnapshots(mysisID string, enabled bool) error {
+	if mysisID == "mysis-1" || mysisID == "mysis-2" {
+		return nil
+	}
+	return errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) SetSnapshotSummaries(mysisID string, enabled bool) error {
+	if mysisID == "mysis-1" || mysisID == "mysis-2" {
+		return nil
+	}
+	return errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) SetContextWindow(mysisID string, window int) error {
+	if mysisID != "mysis-1" && mysisID != "mysis-2" {
+		return errors.New("mysis not found")
+	}
+	if window != 0 && (window < 5 || window > 200) {
+		return errors.New("context window out of range")
+	}
+	return nil
+}
+
+func (m *mockOrchestrator) SetAutonomous(mysisID string, autonomous bool) error {
+	if mysisID == "mysis-1" || mysisID == "mysis-2" {
+		return nil
+	}
+	return errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) SetSyntheticNudges(mysisID string, enabled bool) error {
+	if mysisID == "mysis-1" || mysisID == "mysis-2" {
+		return nil
+	}
+	return errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) SetSeed(mysisID string, seed *int64) error {
+	if seed != nil && *seed < 0 {
+		return fmt.Errorf("seed %d must not be negative", *seed)
+	}
+	if mysisID == "mysis-1" || mysisID == "mysis-2" {
+		return nil
+	}
+	return errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) ConfigureSwarm(setting string, value int) error {
+	if setting != "max_concurrent_turns" || value < 0 {
+		return errors.New("invalid setting")
+	}
+	m.lastSetting = fmt.Sprintf("%s=%d", setting, value)
+	return nil
+}
+
+func (m *mockOrchestrator) SetGoal(mysisID, goal string) error {
+	if mysisID == "mysis-1" || mysisID == "mysis-2" {
+		return nil
+	}
+	return errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) SwitchProviderAsync(mysisID, provider, model string) error {
+	if mysisID != "mysis-1" && mysisID != "mysis-2" {
+		return errors.New("mysis not found")
+	}
+	if provider != "ollama" {
+		return errors.New("provider config not found: " + provider)
+	}
+	m.lastProvider = provider + "/" + model
+	return nil
+}
+
+func (m *mockOrchestrator) AddNote(mysisID, content string) error {
+	if content == "" {
+		return errors.New("note cannot be empty")
+	}
+	return nil
+}
+
+func (m *mockOrchestrator) SendOneShotSystem(mysisID, content string) error {
+	if mysisID != "mysis-1" && mysisID != "mysis-2" {
+		return errors.New("mysis not found")
+	}
+	m.lastReminder = content
+	return nil
+}
+
+func (m *mockOrchestrator) AskMysis(ctx context.Context, senderID, mysisID, question string, timeout time.Duration) (string, error) {
+	if mysisID != "mysis-1" && mysisID != "mysis-2" {
+		return "", errors.New("mysis not found")
+	}
+	m.lastAskSender = senderID
+	m.lastAskTimeout = timeout
+	return "answer to: " + question, nil
+}
+
+func (m *mockOrchestrator) SetToolPolicy(mysisID string, allow, deny []string) error {
+	if mysisID != "mysis-1" && mysisID != "mysis-2" {
+		return errors.New("mysis not found")
+	}
+	m.lastAllow, m.lastDeny = allow, deny
+	return nil
+}
+
+func (m *mockOrchestrator) GetMysisActivity(mysisID string) ([]MysisActivity, error) {
+	all := []MysisActivity{
+		{MysisID: "mysis-1", MysisName: "alpha", State: "running", Activity: "traveling", ActivityUntil: "2026-01-01T00:05:00Z", RemainingTicks: 3},
+		{MysisID: "mysis-2", MysisName: "beta", State: "running", Activity: "idle", Available: true},
+	}
+	if mysisID == "" {
+		return all, nil
+	}
+	for _, activity := range all {
+		if activity.MysisID == mysisID {
+			return []MysisActivity{activity}, nil
+		}
+	}
+	return nil, errors.New("mysis not found")
+}
+
+func (m *mockOrchestrator) GetPeerSnapshot(mysisID, toolName string) (*PeerSnapshot, error) {
+	switch {
+	case mysisID != "mysis-1" && mysisID != "mysis-2":
+		return nil, errors.New("mysis not found")
+	case mysisID == "mysis-2":
+		return nil, nil
+	}
+	return &PeerSnapshot{MysisID: mysisID, MysisName: "alpha", Tool: toolName, CreatedAt: "2026-01-01T00:00:00Z", Payload: json.RawMessage(`{"credits":120}`)}, nil
+}
+
+func (m *mockOrchestrator) RelaunchAndBroadcast(message string) (*RelaunchReport, error) {
+	if m.relaunchReport == nil {
+		return &RelaunchReport{}, nil
+	}
+	return m.relaunchReport, nil
+}
+
 func TestOrchestratorTools(t *testing.T) {
 	// Create mock orchestrator
 	orchestrator := &mockOrchestrator{}
@@ -238,6 +452,387 @@ func TestOrchestratorTools(t *testing.T) {
 
 }
 
+func TestZoeaConfigureMysis(t *testing.T) {
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, &mockOrchestrator{})
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "compact_snapshots": false}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_configure_mysis) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if result.Content[0].Text != "updated compact_snapshots=false" {
+		t.Errorf("unexpected result: %q", result.Content[0].Text)
+	}
+
+	// No settings is an error
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1"}`))
+	if !result.IsError {
+		t.Error("expected error when no settings are provided")
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "context_window": 40}`))
+	if result.IsError || result.Content[0].Text != "updated context_window=40" {
+		t.Errorf("unexpected context_window result: %+v", result)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "autonomous": false}`))
+	if result.IsError || result.Content[0].Text != "updated autonomous=false" {
+		t.Errorf("unexpected autonomous result: %+v", result)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "synthetic_nudges": false}`))
+	if result.IsError || result.Content[0].Text != "updated synthetic_nudges=false" {
+		t.Errorf("unexpected synthetic_nudges result: %+v", result)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "seed": 42}`))
+	if result.IsError || result.Content[0].Text != "updated seed=42" {
+		t.Errorf("unexpected seed result: %+v", result)
+	}
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "seed": -1}`))
+	if result.IsError || result.Content[0].Text != "updated seed cleared" {
+		t.Errorf("unexpected seed clear result: %+v", result)
+	}
+	for _, bad := range []string{`1.5`, `"abc"`, `-7`} {
+		result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "seed": `+bad+`}`))
+		if !result.IsError {
+			t.Errorf("expected seed %s to be rejected, got %+v", bad, result)
+		}
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "goal": "Control sector 7"}`))
+	if result.IsError || result.Content[0].Text != `updated goal="Control sector 7"` {
+		t.Errorf("unexpected goal result: %+v", result)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "context_window": 1000}`))
+	if !result.IsError {
+		t.Error("expected error for out-of-range context_window")
+	}
+
+	orchestrator := &mockOrchestrator{}
+	policyProxy := NewProxy(nil)
+	RegisterOrchestratorTools(policyProxy, orchestrator)
+	result, _ = policyProxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "deny_tools": ["attack"]}`))
+	if result.IsError || result.Content[0].Text != "updated allow_tools=[] deny_tools=[attack]" {
+		t.Errorf("unexpected tool policy result: %+v", result)
+	}
+	if len(orchestrator.lastAllow) != 0 || len(orchestrator.lastDeny) != 1 || orchestrator.lastDeny[0] != "attack" {
+		t.Errorf("unexpected policy passed: allow=%v deny=%v", orchestrator.lastAllow, orchestrator.lastDeny)
+	}
+
+	result, _ = policyProxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "provider": "ollama", "model": "qwen3:8b"}`))
+	if result.IsError || orchestrator.lastProvider != "ollama/qwen3:8b" {
+		t.Errorf("unexpected provider switch: %+v, passed %q", result, orchestrator.lastProvider)
+	}
+	result, _ = policyProxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "model": "qwen3:8b"}`))
+	if !result.IsError {
+		t.Error("expected error for model without provider")
+	}
+	result, _ = policyProxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "provider": "missing"}`))
+	if !result.IsError {
+		t.Error("expected error for unknown provider")
+	}
+
+	// Unknown mysis is reported
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_mysis", json.RawMessage(`{"mysis_id": "nope", "compact_snapshots": true}`))
+	if !result.IsError {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestZoeaConfigureSwarm(t *testing.T) {
+	orchestrator := &mockOrchestrator{}
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, orchestrator)
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_configure_swarm", json.RawMessage(`{"setting": "max_concurrent_turns", "value": 4}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_configure_swarm) error: %v", err)
+	}
+	if result.IsError || result.Content[0].Text != "updated max_concurrent_turns=4" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if orchestrator.lastSetting != "max_concurrent_turns=4" {
+		t.Errorf("unexpected setting passed: %q", orchestrator.lastSetting)
+	}
+
+	// Rejected settings are reported as error results
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_swarm", json.RawMessage(`{"setting": "max_concurrent_turns", "value": -1}`))
+	if !result.IsError {
+		t.Error("expected error for an invalid value")
+	}
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_configure_swarm", json.RawMessage(`{"setting": "max_concurrent_turns"}`))
+	if !result.IsError {
+		t.Error("expected error for a missing value")
+	}
+}
+
+func TestZoeaNoteAdd(t *testing.T) {
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, &mockOrchestrator{})
+	ctx := context.Background()
+
+	caller := CallerContext{MysisID: "mysis-1", MysisName: "alpha"}
+	result, err := proxy.CallTool(ctx, caller, "zoea_note_add", json.RawMessage(`{"content": "Iron is cheap at Sol"}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_note_add) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+
+	// Requires a calling mysis
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_note_add", json.RawMessage(`{"content": "orphan"}`))
+	if !result.IsError {
+		t.Error("expected error without caller mysis")
+	}
+
+	// Schema validation rejects missing content
+	result, _ = proxy.CallTool(ctx, caller, "zoea_note_add", json.RawMessage(`{}`))
+	if !result.IsError {
+		t.Error("expected error for missing content")
+	}
+}
+
+func TestZoeaAskMysis(t *testing.T) {
+	orchestrator := &mockOrchestrator{}
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, orchestrator)
+	ctx := context.Background()
+	caller := CallerContext{MysisID: "mysis-2", MysisName: "beta"}
+
+	result, err := proxy.CallTool(ctx, caller, "zoea_ask_mysis", json.RawMessage(`{"mysis_id": "mysis-1", "question": "where are you?", "timeout_seconds": 3600}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_ask_mysis) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if result.Content[0].Text != "answer to: where are you?" {
+		t.Errorf("unexpected answer: %q", result.Content[0].Text)
+	}
+	if orchestrator.lastAskSender != "mysis-2" {
+		t.Errorf("expected the caller as sender, got %q", orchestrator.lastAskSender)
+	}
+	if orchestrator.lastAskTimeout != constants.MaxAskTimeout {
+		t.Errorf("expected timeout capped at %s, got %s", constants.MaxAskTimeout, orchestrator.lastAskTimeout)
+	}
+
+	for _, args := range []string{
+		`{"mysis_id": "mysis-2", "question": "hello?"}`, // asking itself
+		`{"mysis_id": "nope", "question": "hello?"}`,    // unknown mysis
+		`{"mysis_id": "mysis-1", "question": " "}`,      // empty question
+		`{"mysis_id": "mysis-1", "question": "hi", "timeout_seconds": -1}`,
+	} {
+		result, _ = proxy.CallTool(ctx, caller, "zoea_ask_mysis", json.RawMessage(args))
+		if !result.IsError {
+			t.Errorf("expected error for %s", args)
+		}
+	}
+}
+
+func TestZoeaSystemReminder(t *testing.T) {
+	orchestrator := &mockOrchestrator{}
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, orchestrator)
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_system_reminder", json.RawMessage(`{"mysis_id": "mysis-1", "content": "prioritize defense now"}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_system_reminder) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if orchestrator.lastReminder != "prioritize defense now" {
+		t.Errorf("reminder = %q, want %q", orchestrator.lastReminder, "prioritize defense now")
+	}
+
+	// Unknown mysis is reported
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_system_reminder", json.RawMessage(`{"mysis_id": "nope", "content": "hi"}`))
+	if !result.IsError {
+		t.Error("expected error for unknown mysis")
+	}
+
+	// Schema validation rejects missing content
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_system_reminder", json.RawMessage(`{"mysis_id": "mysis-1"}`))
+	if !result.IsError {
+		t.Error("expected error for missing content")
+	}
+}
+
+func TestZoeaMysisActivity(t *testing.T) {
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, &mockOrchestrator{})
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_mysis_activity", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_mysis_activity) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	var all []MysisActivity
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &all); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected every mysis, got %+v", all)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_mysis_activity", json.RawMessage(`{"mysis_id": "mysis-1"}`))
+	var one []MysisActivity
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &one); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(one) != 1 || one[0].Activity != "traveling" || one[0].RemainingTicks != 3 || one[0].Available {
+		t.Errorf("unexpected activity: %+v", one)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_mysis_activity", json.RawMessage(`{"mysis_id": "nope"}`))
+	if !result.IsError {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestZoeaPeerSnapshot(t *testing.T) {
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, &mockOrchestrator{})
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_peer_snapshot", json.RawMessage(`{"mysis_id": "mysis-1"}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_peer_snapshot) error: %v", err)
+	}
+	var snapshot PeerSnapshot
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &snapshot); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	var payload struct{ Credits int }
+	json.Unmarshal(snapshot.Payload, &payload)
+	if snapshot.Tool != "get_status" || payload.Credits != 120 {
+		t.Errorf("expected get_status by default, got %+v", snapshot)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_peer_snapshot", json.RawMessage(`{"mysis_id": "mysis-2", "tool": "get_cargo"}`))
+	if result.IsError || result.Content[0].Text != "no get_cargo snapshot recorded for mysis mysis-2" {
+		t.Errorf("unexpected empty result: %+v", result)
+	}
+
+	for _, args := range []string{`{"mysis_id": "nope"}`, `{}`} {
+		result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_peer_snapshot", json.RawMessage(args))
+		if !result.IsError {
+			t.Errorf("expected error for %s", args)
+		}
+	}
+}
+
+func TestZoeaRelaunchBroadcast(t *testing.T) {
+	orchestrator := &mockOrchestrator{}
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, orchestrator)
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_relaunch_broadcast", json.RawMessage(`{"message": "Regroup"}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_relaunch_broadcast) error: %v", err)
+	}
+	if result.IsError || result.Content[0].Text != "no errored myses to relaunch" {
+		t.Errorf("unexpected result with nothing errored: %+v", result)
+	}
+
+	// Partial failure is reported, not an error
+	orchestrator.relaunchReport = &RelaunchReport{Relaunched: []string{"alpha"}, Failed: []string{"beta: provider down"}}
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_relaunch_broadcast", json.RawMessage(`{"message": "Regroup"}`))
+	var report RelaunchReport
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &report); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.IsError || len(report.Relaunched) != 1 || len(report.Failed) != 1 {
+		t.Errorf("unexpected partial result: %+v", result)
+	}
+
+	orchestrator.relaunchReport = &RelaunchReport{Failed: []string{"beta: provider down"}}
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_relaunch_broadcast", json.RawMessage(`{"message": "Regroup"}`))
+	if !result.IsError {
+		t.Error("expected error when every relaunch failed")
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_relaunch_broadcast", json.RawMessage(`{"message": "  "}`))
+	if !result.IsError {
+		t.Error("expected error for empty message")
+	}
+}
+
+func TestZoeaMysisReasoning(t *testing.T) {
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, &mockOrchestrator{})
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_mysis_reasoning", json.RawMessage(`{"mysis_id": "mysis-1", "limit": 1}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_mysis_reasoning) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+
+	var results []ReasoningResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &results); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(results) != 1 || results[0].Reasoning != "cargo is full" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	// Unknown mysis is reported
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_mysis_reasoning", json.RawMessage(`{"mysis_id": "nope"}`))
+	if !result.IsError {
+		t.Error("expected error for unknown mysis")
+	}
+}
+
+func TestZoeaSearchSwarm(t *testing.T) {
+	orchestrator := &mockOrchestrator{}
+	proxy := NewProxy(nil)
+	RegisterOrchestratorTools(proxy, orchestrator)
+	ctx := context.Background()
+
+	result, err := proxy.CallTool(ctx, CallerContext{}, "zoea_search_swarm", json.RawMessage(`{"query": "iron", "limit": 500}`))
+	if err != nil {
+		t.Fatalf("CallTool(zoea_search_swarm) error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if orchestrator.lastSwarmLimit != 50 {
+		t.Errorf("expected limit capped at 50, got %d", orchestrator.lastSwarmLimit)
+	}
+
+	var results []SearchResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &results); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(results) != 2 || results[0].MysisName != "beta" || results[1].MysisName != "alpha" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	result, _ = proxy.CallTool(ctx, CallerContext{}, "zoea_search_swarm", json.RawMessage(`{"query": "gold"}`))
+	if result.IsError || !strings.Contains(result.Content[0].Text, "no swarm messages found") {
+		t.Errorf("unexpected empty result: %+v", result)
+	}
+	if orchestrator.lastSwarmLimit != 20 {
+		t.Errorf("expected default limit 20, got %d", orchestrator.lastSwarmLimit)
+	}
+}
+
 func TestZoeaSearchMessagesPayload(t *testing.T) {
 	// Create mock orchestrator
 	orchestrator := &mockOrchestrator{}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/constants"
 )
 
 // ToolHandler is a function that handles a tool call.
@@ -35,6 +38,11 @@ type AccountStore interface {
 	ReleaseAllAccounts() error
 }
 
+// CredentialGenerator mints unused credentials for register calls that omit them.
+type CredentialGenerator interface {
+	NewCredentials() (username, password string, err error)
+}
+
 type Account struct {
 	Username string
 	Password string
@@ -54,15 +62,171 @@ type Proxy struct {
 	contextHandlers map[string]ToolHandlerWithContext
 	accountStore    AccountStore
 	gameStateStore  GameStateStore
+	credentialGen   CredentialGenerator
+
+	// validateUpstream enables argument validation for upstream tools.
+	// Local tools are always validated against their registered schema.
+	validateUpstream bool
+	upstreamSchemas  map[string]json.RawMessage // Cached from the last successful ListTools
+
+	batchUnsupported bool // Upstream rejected a batch request; stop trying
+
+	retryPolicy RetryPolicy
+
+	// Upstream tool list cache. toolsMu serializes refreshes so concurrent
+	// callers share a single upstream request.
+	toolsMu        sync.Mutex
+	toolCacheTTL   time.Duration // 0 disables caching
+	cachedTools    []Tool
+	cachedToolsAt  time.Time
+	toolCacheValid bool
+	nowFunc        func() time.Time // Clock for cache expiry (nil = time.Now)
+
+	// Upstream reconnect watchdog, shared with the other proxies in its group
+	watchdogMu sync.Mutex
+	reconnects *ReconnectGroup // nil = no watchdog
+
+	// Lifecycle of the proxy; Close cancels it, which stops any running reconnect
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Watchdog reconnects the upstream when calls keep failing, for breakage a single
+// call's retries can't fix (e.g. the server restarted and dropped every session).
+type Watchdog struct {
+	Failures int           // Consecutive failed upstream calls that trigger a reconnect (0 = off)
+	Cooldown time.Duration // Minimum time between reconnects
+	// OnReconnect, if set, is told when a reconnect starts (done false) and when it
+	// finishes (done true, with its error). It runs on the reconnect goroutine.
+	OnReconnect func(done bool, err error)
+}
+
+// ReconnectGroup runs one Watchdog for several proxies, e.g. every mysis in a swarm.
+// Failed upstream calls from any member count toward the same threshold, and reaching
+// it reconnects every member at once, at most once per cooldown.
+type ReconnectGroup struct {
+	mu            sync.Mutex
+	watchdog      Watchdog
+	members       map[*Proxy]struct{}
+	failures      int       // Consecutive failed upstream calls across members
+	lastReconnect time.Time // When the group last started a reconnect
+	reconnecting  bool
+}
+
+// NewReconnectGroup creates a reconnect group that applies w.
+func NewReconnectGroup(w Watchdog) *ReconnectGroup {
+	return &ReconnectGroup{watchdog: w, members: make(map[*Proxy]struct{})}
+}
+
+// ToolCallResult is the outcome of one call made through Proxy.CallToolsBatch.
+type ToolCallResult struct {
+	Result *ToolResult
+	Err    error
 }
 
 var (
 	ErrToolRetryExhausted = errors.New("mcp tool call failed after retries")
 )
 
-// Retry delays increased to respect SpaceMolt's "Try again in 5 seconds" rate limit
+// Upstream failure causes reported by ToolRetryError.
+const (
+	RetryCauseTimeout           = "timeout"
+	RetryCauseRateLimited       = "rate_limited"
+	RetryCauseServerError       = "server_error"
+	RetryCauseConnectionRefused = "connection_refused"
+	RetryCauseOther             = "other"
+)
+
+// ToolRetryError reports an upstream tool call that failed on every attempt.
+// It matches ErrToolRetryExhausted with errors.Is.
+type ToolRetryError struct {
+	Tool    string
+	Retries int    // Retries attempted after the initial call
+	Cause   string // One of the RetryCause* constants, classified from LastErr
+	LastErr error
+}
+
+func (e *ToolRetryError) Error() string {
+	return fmt.Sprintf("tool %s failed after %d retries (%s): %v", e.Tool, e.Retries, e.Cause, e.LastErr)
+}
+
+func (e *ToolRetryError) Unwrap() error { return e.LastErr }
+
+func (e *ToolRetryError) Is(target error) bool { return target == ErrToolRetryExhausted }
+
+// classifyRetryCause maps an upstream error to a RetryCause* constant.
+func classifyRetryCause(err error) string {
+	if err == nil {
+		return RetryCauseOther
+	}
+	msg := strings.ToLower(err.Error())
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout(), strings.Contains(msg, "timeout"):
+		return RetryCauseTimeout
+	case strings.Contains(msg, "429"), strings.Contains(msg, "rate limited"):
+		return RetryCauseRateLimited
+	case strings.Contains(msg, "connection refused"):
+		return RetryCauseConnectionRefused
+	case strings.Contains(msg, "http error 5"):
+		return RetryCauseServerError
+	default:
+		return RetryCauseOther
+	}
+}
+
+// Retry delays increased to respect SpaceMolt's "Try again in 5 seconds" rate limit.
+// Retries past the end of the schedule wait for the last delay.
 var toolRetryDelays = []time.Duration{2 * time.Second, 5 * time.Second, 10 * time.Second}
 
+// maxToolRetryDelay caps exponential backoff and server-requested Retry-After delays.
+const maxToolRetryDelay = 30 * time.Second
+
+// RetryPolicy controls how upstream tool calls are retried after transport, rate limit
+// and 5xx errors. Tool results with IsError set are never retried.
+type RetryPolicy struct {
+	Retries int           // Retries after the first attempt
+	Backoff time.Duration // Delay before the first retry, doubled after each (0 = toolRetryDelays)
+	// PerTool overrides Retries for specific tools; 0 disables retries for that tool.
+	PerTool map[string]int
+}
+
+// retriesFor returns the number of retries for a tool.
+func (rp RetryPolicy) retriesFor(tool string) int {
+	if n, ok := rp.PerTool[tool]; ok {
+		return n
+	}
+	return rp.Retries
+}
+
+// delay returns the wait before the given retry (1-based).
+func (rp RetryPolicy) delay(retry int) time.Duration {
+	if rp.Backoff <= 0 {
+		if retry > len(toolRetryDelays) {
+			retry = len(toolRetryDelays)
+		}
+		return toolRetryDelays[retry-1]
+	}
+	delay := rp.Backoff
+	for i := 1; i < retry && delay < maxToolRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxToolRetryDelay {
+		delay = maxToolRetryDelay
+	}
+	return delay
+}
+
+// isRetryableToolError reports whether an upstream error may succeed on retry:
+// transport failures, rate limits and 5xx responses. Other HTTP errors will not.
+func isRetryableToolError(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
 // retryAfterRegex matches "Retry-After: N" in error messages
 var retryAfterRegex = regexp.MustCompile(`Retry-After:\s*(\d+)`)
 
@@ -96,11 +260,15 @@ func parseRetryAfter(err error) (time.Duration, bool) {
 
 // NewProxy creates a new MCP proxy.
 func NewProxy(upstream UpstreamClient) *Proxy {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Proxy{
+		ctx:             ctx,
+		cancel:          cancel,
 		upstream:        upstream,
 		localTools:      make(map[string]Tool),
 		localHandlers:   make(map[string]ToolHandler),
 		contextHandlers: make(map[string]ToolHandlerWithContext),
+		retryPolicy:     RetryPolicy{Retries: constants.DefaultToolRetries},
 	}
 }
 
@@ -116,6 +284,73 @@ func (p *Proxy) SetGameStateStore(store GameStateStore) {
 	p.gameStateStore = store
 }
 
+// SetCredentialGenerator fills in credentials for register calls that leave them out.
+func (p *Proxy) SetCredentialGenerator(gen CredentialGenerator) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.credentialGen = gen
+}
+
+// SetUpstreamValidation enables or disables argument validation for upstream tools.
+// Schemas come from the most recent ListTools call; tools not yet listed are not validated.
+func (p *Proxy) SetUpstreamValidation(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.validateUpstream = enabled
+}
+
+// SetRetryPolicy sets how failed upstream tool calls are retried.
+func (p *Proxy) SetRetryPolicy(policy RetryPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retryPolicy = policy
+}
+
+// SetToolCacheTTL sets how long the upstream tool list is reused before ListTools
+// fetches it again. Zero disables caching.
+func (p *Proxy) SetToolCacheTTL(ttl time.Duration) {
+	p.toolsMu.Lock()
+	defer p.toolsMu.Unlock()
+	p.toolCacheTTL = ttl
+	p.toolCacheValid = false
+}
+
+// SetWatchdog sets when failing upstream calls trigger a reconnect, counting this
+// proxy's calls only. Use SetReconnectGroup to share the count with other proxies.
+func (p *Proxy) SetWatchdog(w Watchdog) {
+	p.SetReconnectGroup(NewReconnectGroup(w))
+}
+
+// SetReconnectGroup moves the proxy into g, leaving any previous group (nil = none).
+func (p *Proxy) SetReconnectGroup(g *ReconnectGroup) {
+	p.watchdogMu.Lock()
+	defer p.watchdogMu.Unlock()
+	if p.reconnects != nil {
+		p.reconnects.leave(p)
+	}
+	p.reconnects = g
+	if g != nil {
+		g.join(p)
+	}
+}
+
+// InvalidateToolCache drops the cached upstream tool list so the next ListTools
+// refetches it. Call it after a reconnect or anything else that changes what the
+// upstream exposes.
+func (p *Proxy) InvalidateToolCache() {
+	p.toolsMu.Lock()
+	defer p.toolsMu.Unlock()
+	p.toolCacheValid = false
+	p.cachedTools = nil
+}
+
+func (p *Proxy) now() time.Time {
+	if p.nowFunc != nil {
+		return p.nowFunc()
+	}
+	return time.Now()
+}
+
 // RegisterTool registers a local tool with the proxy.
 func (p *Proxy) RegisterTool(tool Tool, handler ToolHandler) {
 	p.mu.Lock()
@@ -135,19 +370,20 @@ func (p *Proxy) RegisterToolWithContext(tool Tool, handler ToolHandlerWithContex
 }
 
 // ListTools returns all available tools (local + upstream).
+// Local tools are always read fresh; the upstream list is cached for the tool cache TTL.
 func (p *Proxy) ListTools(ctx context.Context) ([]Tool, error) {
 	p.mu.RLock()
-	defer p.mu.RUnlock()
-
 	// Start with local tools
 	tools := make([]Tool, 0, len(p.localTools))
 	for _, t := range p.localTools {
 		tools = append(tools, t)
 	}
+	upstream := p.upstream
+	p.mu.RUnlock()
 
 	// Add upstream tools if available
-	if p.upstream != nil {
-		upstreamTools, err := p.upstream.ListTools(ctx)
+	if upstream != nil {
+		upstreamTools, err := p.upstreamTools(ctx, upstream)
 		if err != nil {
 			log.Warn().
 				Err(err).
@@ -160,14 +396,52 @@ func (p *Proxy) ListTools(ctx context.Context) ([]Tool, error) {
 	return tools, nil
 }
 
+// upstreamTools returns the upstream tool list, from cache while it is fresh.
+func (p *Proxy) upstreamTools(ctx context.Context, upstream UpstreamClient) ([]Tool, error) {
+	p.toolsMu.Lock()
+	defer p.toolsMu.Unlock()
+
+	if p.toolCacheValid && p.toolCacheTTL > 0 && p.now().Sub(p.cachedToolsAt) < p.toolCacheTTL {
+		return p.cachedTools, nil
+	}
+
+	upstreamTools, err := upstream.ListTools(ctx)
+	if err != nil {
+		p.toolCacheValid = false
+		return nil, err
+	}
+
+	schemas := make(map[string]json.RawMessage, len(upstreamTools))
+	for _, t := range upstreamTools {
+		schemas[t.Name] = t.InputSchema
+	}
+	p.mu.Lock()
+	p.upstreamSchemas = schemas
+	p.mu.Unlock()
+
+	p.cachedTools = upstreamTools
+	p.cachedToolsAt = p.now()
+	p.toolCacheValid = true
+	return upstreamTools, nil
+}
+
 // CallTool invokes a tool, checking local handlers first then upstream.
 func (p *Proxy) CallTool(ctx context.Context, caller CallerContext, name string, arguments json.RawMessage) (*ToolResult, error) {
 	p.mu.RLock()
 	handler, isLocal := p.localHandlers[name]
 	contextHandler, hasContext := p.contextHandlers[name]
+	localSchema := p.localTools[name].InputSchema
+	upstreamSchema, hasUpstreamSchema := p.upstreamSchemas[name]
+	validateUpstream := p.validateUpstream
 	accountStore := p.accountStore
 	p.mu.RUnlock()
 
+	if hasContext || isLocal {
+		if errs := ValidateArguments(localSchema, arguments); len(errs) > 0 {
+			return invalidArgumentsResult(name, localSchema, errs), nil
+		}
+	}
+
 	if hasContext {
 		return contextHandler(ctx, caller, arguments)
 	}
@@ -206,6 +480,9 @@ func (p *Proxy) CallTool(ctx context.Context, caller CallerContext, name string,
 				}
 				return result, err
 			}
+
+			// No pool account - register a new one, generating any missing credentials
+			arguments = p.fillRegisterCredentials(arguments, upstreamSchema)
 		}
 
 		// Intercept login - substitute credentials with assigned account if mysis has one
@@ -221,6 +498,12 @@ func (p *Proxy) CallTool(ctx context.Context, caller CallerContext, name string,
 			}
 		}
 
+		if validateUpstream && hasUpstreamSchema {
+			if errs := ValidateArguments(upstreamSchema, arguments); len(errs) > 0 {
+				return invalidArgumentsResult(name, upstreamSchema, errs), nil
+			}
+		}
+
 		var args interface{}
 		if len(arguments) > 0 {
 			if err := json.Unmarshal(arguments, &args); err != nil {
@@ -244,20 +527,126 @@ func (p *Proxy) CallTool(ctx context.Context, caller CallerContext, name string,
 	}, nil
 }
 
+// CallToolsBatch invokes several tools, sending plain upstream calls to the upstream in
+// one batch request when it supports batching. Local tools, account tools and calls with
+// invalid arguments go through CallTool individually. If the upstream rejects or fails
+// the batch, those calls fall back to sequential CallTool (with retries).
+// Results are returned in call order, each with its own error.
+func (p *Proxy) CallToolsBatch(ctx context.Context, caller CallerContext, calls []ToolCall) []ToolCallResult {
+	results := make([]ToolCallResult, len(calls))
+	done := make([]bool, len(calls))
+
+	p.mu.RLock()
+	batcher, canBatch := p.upstream.(BatchUpstreamClient)
+	canBatch = canBatch && !p.batchUnsupported
+	var batchIdx []int
+	var batch []ToolCall
+	for i, call := range calls {
+		if canBatch && p.isPlainUpstreamCallLocked(call) {
+			batchIdx = append(batchIdx, i)
+			batch = append(batch, call)
+		}
+	}
+	p.mu.RUnlock()
+
+	if len(batch) > 1 {
+		batchResults, err := batcher.CallToolsBatch(ctx, batch)
+		switch {
+		case err == nil:
+			for j, i := range batchIdx {
+				results[i] = ToolCallResult{Result: batchResults[j]}
+				done[i] = true
+			}
+		case errors.Is(err, ErrBatchUnsupported):
+			log.Info().Err(err).Msg("Upstream rejected batch request - using sequential tool calls")
+			p.mu.Lock()
+			p.batchUnsupported = true
+			p.mu.Unlock()
+		case ctx.Err() == nil:
+			log.Warn().Err(err).Int("calls", len(batch)).Msg("Batch tool call failed - retrying sequentially")
+		}
+	}
+
+	for i, call := range calls {
+		if done[i] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Result, results[i].Err = p.CallTool(ctx, caller, call.Name, call.Arguments)
+	}
+
+	return results
+}
+
+// isPlainUpstreamCallLocked reports whether a call goes straight to the upstream
+// with no local handling, so it can be batched. Caller must hold p.mu.
+func (p *Proxy) isPlainUpstreamCallLocked(call ToolCall) bool {
+	if _, ok := p.localTools[call.Name]; ok {
+		return false
+	}
+	switch call.Name {
+	case "register", "login", "logout":
+		return false
+	}
+	if len(call.Arguments) > 0 && !json.Valid(call.Arguments) {
+		return false
+	}
+	if schema, ok := p.upstreamSchemas[call.Name]; ok && p.validateUpstream {
+		if errs := ValidateArguments(schema, call.Arguments); len(errs) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidArgumentsResult builds an error result listing every argument problem and the
+// expected parameters, so the LLM can correct the call without a round-trip upstream.
+func invalidArgumentsResult(name string, schema json.RawMessage, errs []ArgumentError) *ToolResult {
+	problems := make([]string, len(errs))
+	for i, e := range errs {
+		problems[i] = e.String()
+	}
+	text := fmt.Sprintf("invalid arguments for %s: %s", name, strings.Join(problems, "; "))
+	if params := DescribeParameters(schema); params != "" {
+		text += fmt.Sprintf(". Expected parameters: %s", params)
+	}
+
+	log.Debug().
+		Str("tool", name).
+		Strs("problems", problems).
+		Msg("Rejected tool call with invalid arguments")
+
+	return &ToolResult{
+		Content: []ContentBlock{{Type: "text", Text: text}},
+		IsError: true,
+	}
+}
+
+// callUpstreamWithRetry calls an upstream tool, retrying transport, rate limit and 5xx
+// errors under the retry policy. Every attempt and delay shares ctx, so the call's
+// deadline bounds the retries too.
 func (p *Proxy) callUpstreamWithRetry(ctx context.Context, name string, args interface{}) (*ToolResult, error) {
+	p.mu.RLock()
+	policy := p.retryPolicy
+	p.mu.RUnlock()
+	retries := policy.retriesFor(name)
+
 	var lastErr error
-	for attempt := 0; attempt <= len(toolRetryDelays); attempt++ {
+	for attempt := 0; attempt <= retries; attempt++ {
 		if attempt > 0 {
-			delay := toolRetryDelays[attempt-1]
+			delay := policy.delay(attempt)
 
 			// Check if error is a 429 rate limit
 			is429 := lastErr != nil && (strings.Contains(lastErr.Error(), "429") || strings.Contains(lastErr.Error(), "Rate limited"))
 
 			// Try to parse Retry-After from error message
 			if retryAfter, ok := parseRetryAfter(lastErr); ok {
-				// Use server-specified delay, but cap at 30 seconds for safety
-				if retryAfter > 30*time.Second {
-					retryAfter = 30 * time.Second
+				// Use server-specified delay, but cap it for safety
+				if retryAfter > maxToolRetryDelay {
+					retryAfter = maxToolRetryDelay
 				}
 				delay = retryAfter
 
@@ -299,12 +688,17 @@ func (p *Proxy) callUpstreamWithRetry(ctx context.Context, name string, args int
 					Int("attempt", attempt+1).
 					Msg("MCP tool call succeeded after retry")
 			}
+			p.recordUpstreamCall(nil)
 			return result, nil
 		}
 
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return nil, err
 		}
+		if !isRetryableToolError(err) {
+			p.recordUpstreamCall(err)
+			return nil, err
+		}
 
 		lastErr = err
 	}
@@ -312,11 +706,120 @@ func (p *Proxy) callUpstreamWithRetry(ctx context.Context, name string, args int
 	// Log final failure with more context
 	log.Error().
 		Str("tool", name).
-		Int("total_attempts", len(toolRetryDelays)+1).
+		Int("total_attempts", retries+1).
+		Str("cause", classifyRetryCause(lastErr)).
 		Err(lastErr).
 		Msg("MCP tool call failed after all retries")
+	p.recordUpstreamCall(lastErr)
+
+	return nil, &ToolRetryError{
+		Tool:    name,
+		Retries: retries,
+		Cause:   classifyRetryCause(lastErr),
+		LastErr: lastErr,
+	}
+}
+
+// recordUpstreamCall feeds the proxy's reconnect group the outcome of an upstream call.
+// A closed proxy reports nothing.
+func (p *Proxy) recordUpstreamCall(err error) {
+	p.watchdogMu.Lock()
+	g := p.reconnects
+	p.watchdogMu.Unlock()
+	if g == nil || p.ctx.Err() != nil {
+		return
+	}
+	g.record(err, p.now())
+}
 
-	return nil, fmt.Errorf("%w: %v", ErrToolRetryExhausted, lastErr)
+func (g *ReconnectGroup) join(p *Proxy) {
+	g.mu.Lock()
+	g.members[p] = struct{}{}
+	g.mu.Unlock()
+}
+
+func (g *ReconnectGroup) leave(p *Proxy) {
+	g.mu.Lock()
+	delete(g.members, p)
+	g.mu.Unlock()
+}
+
+// record counts an upstream call outcome. Once Watchdog.Failures calls in a row have
+// failed it reconnects every member in the background, unless a reconnect is running
+// or started within Watchdog.Cooldown.
+func (g *ReconnectGroup) record(err error, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err == nil {
+		g.failures = 0
+		return
+	}
+	g.failures++
+
+	w := g.watchdog
+	if w.Failures <= 0 || g.failures < w.Failures || g.reconnecting {
+		return
+	}
+	if !g.lastReconnect.IsZero() && now.Sub(g.lastReconnect) < w.Cooldown {
+		return
+	}
+	members := make([]*Proxy, 0, len(g.members))
+	for p := range g.members {
+		if p.ctx.Err() == nil {
+			members = append(members, p)
+		}
+	}
+	if len(members) == 0 {
+		return
+	}
+	g.failures = 0
+	g.reconnecting = true
+	g.lastReconnect = now
+	go g.reconnect(w, members)
+}
+
+// reconnect reconnects every member concurrently and reports the combined outcome once.
+func (g *ReconnectGroup) reconnect(w Watchdog, members []*Proxy) {
+	log.Warn().Int("failures", w.Failures).Int("sessions", len(members)).Msg("MCP upstream keeps failing - reconnecting")
+	if w.OnReconnect != nil {
+		w.OnReconnect(false, nil)
+	}
+
+	errs := make([]error, len(members))
+	var wg sync.WaitGroup
+	for i, p := range members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = p.reconnect()
+		}()
+	}
+	wg.Wait()
+	err := errors.Join(errs...)
+	if err != nil {
+		log.Error().Err(err).Msg("MCP upstream reconnect failed")
+	} else {
+		log.Info().Msg("MCP upstream reconnected")
+	}
+
+	g.mu.Lock()
+	g.reconnecting = false
+	g.mu.Unlock()
+	if w.OnReconnect != nil {
+		w.OnReconnect(true, err)
+	}
+}
+
+// reconnect drops the upstream session, if the client keeps one, and initializes a
+// new one. Closing the proxy cancels it.
+func (p *Proxy) reconnect() error {
+	if resetter, ok := p.upstream.(interface{ Reset() }); ok {
+		resetter.Reset()
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, constants.MCPReconnectTimeout)
+	defer cancel()
+	return p.Initialize(ctx)
 }
 
 func (p *Proxy) interceptAuthTools(toolName string, arguments json.RawMessage, result *ToolResult, mysisID string) {
@@ -330,9 +833,65 @@ func (p *Proxy) interceptAuthTools(toolName string, arguments json.RawMessage, r
 	}
 }
 
+// fillRegisterCredentials adds a generated username when register omits one, and a
+// generated password when the upstream register schema accepts one but none was given.
+func (p *Proxy) fillRegisterCredentials(arguments json.RawMessage, schema json.RawMessage) json.RawMessage {
+	p.mu.RLock()
+	gen := p.credentialGen
+	p.mu.RUnlock()
+	if gen == nil {
+		return arguments
+	}
+
+	args := map[string]interface{}{}
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return arguments
+		}
+	}
+	username, _ := args["username"].(string)
+	password, _ := args["password"].(string)
+	wantsPassword := password == "" && schemaHasProperty(schema, "password")
+	if username != "" && !wantsPassword {
+		return arguments
+	}
+
+	genUsername, genPassword, err := gen.NewCredentials()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to generate account credentials")
+		return arguments
+	}
+	if username == "" {
+		args["username"] = genUsername
+	}
+	if wantsPassword {
+		args["password"] = genPassword
+	}
+
+	filled, err := json.Marshal(args)
+	if err != nil {
+		return arguments
+	}
+	log.Debug().Interface("username", args["username"]).Bool("password", wantsPassword).Msg("Generated register credentials")
+	return filled
+}
+
+// schemaHasProperty reports whether an object schema declares the named property.
+func schemaHasProperty(schema json.RawMessage, name string) bool {
+	var parsed struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if len(schema) == 0 || json.Unmarshal(schema, &parsed) != nil {
+		return false
+	}
+	_, ok := parsed.Properties[name]
+	return ok
+}
+
 func (p *Proxy) handleRegisterResponse(arguments json.RawMessage, result *ToolResult, mysisID string) {
 	var args struct {
 		Username string `json:"username"`
+		Password string `json:"password"`
 	}
 	if err := json.Unmarshal(arguments, &args); err != nil {
 		return
@@ -344,6 +903,10 @@ func (p *Proxy) handleRegisterResponse(arguments json.RawMessage, result *ToolRe
 	}
 
 	password, ok := findStringField(payload, "password", "token")
+	if !ok || password == "" {
+		// Servers that take the password as an argument may not echo it back
+		password, ok = args.Password, args.Password != ""
+	}
 	if args.Username != "" && ok && password != "" {
 		_, _ = p.accountStore.CreateAccount(args.Username, password, mysisID)
 	}
@@ -533,6 +1096,9 @@ func (p *Proxy) Initialize(ctx context.Context) error {
 		"version": "0.1.0",
 	}
 
+	// A new session may expose a different tool set
+	p.InvalidateToolCache()
+
 	resp, err := p.upstream.Initialize(ctx, clientInfo)
 	if err != nil {
 		return fmt.Errorf("initialize upstream: %w", err)
@@ -559,6 +1125,9 @@ func (p *Proxy) LocalToolCount() int {
 
 // Close closes the upstream client connection if available.
 func (p *Proxy) Close() error {
+	p.cancel()
+	p.SetReconnectGroup(nil)
+
 	p.mu.RLock()
 	upstream := p.upstream
 	p.mu.RUnlock()
@@ -3,7 +3,12 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type mockUpstream struct {
@@ -13,6 +18,8 @@ type mockUpstream struct {
 	lastArgs  interface{}
 	result    *ToolResult
 	err       error
+	errs      []error // Returned by the first calls, before err
+	listCount atomic.Int32
 }
 
 func (m *mockUpstream) Initialize(ctx context.Context, clientInfo map[string]interface{}) (*Response, error) {
@@ -20,6 +27,7 @@ func (m *mockUpstream) Initialize(ctx context.Context, clientInfo map[string]int
 }
 
 func (m *mockUpstream) ListTools(ctx context.Context) ([]Tool, error) {
+	m.listCount.Add(1)
 	return m.tools, nil
 }
 
@@ -27,9 +35,31 @@ func (m *mockUpstream) CallTool(ctx context.Context, name string, arguments inte
 	m.callCount++
 	m.lastName = name
 	m.lastArgs = arguments
+	if m.callCount <= len(m.errs) {
+		return nil, m.errs[m.callCount-1]
+	}
 	return m.result, m.err
 }
 
+// mockBatchUpstream adds batch support to mockUpstream.
+type mockBatchUpstream struct {
+	mockUpstream
+	batches  [][]ToolCall
+	batchErr error
+}
+
+func (m *mockBatchUpstream) CallToolsBatch(ctx context.Context, calls []ToolCall) ([]*ToolResult, error) {
+	m.batches = append(m.batches, calls)
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+	results := make([]*ToolResult, len(calls))
+	for i, call := range calls {
+		results[i] = &ToolResult{Content: []ContentBlock{{Type: "text", Text: "batched " + call.Name}}}
+	}
+	return results, nil
+}
+
 type mockAccountStore struct {
 	created  []Account
 	marked   []string
@@ -134,3 +164,524 @@ func TestProxyAuthInterceptionRegister(t *testing.T) {
 		t.Fatalf("unexpected created account: %+v", accounts.created[0])
 	}
 }
+
+type fixedCredentials struct{ username, password string }
+
+func (f fixedCredentials) NewCredentials() (string, string, error) {
+	return f.username, f.password, nil
+}
+
+func TestProxyRegisterGeneratesCredentials(t *testing.T) {
+	upstream := &mockUpstream{
+		tools: []Tool{{Name: "register", InputSchema: json.RawMessage(`{"type":"object","properties":{"username":{"type":"string"},"password":{"type":"string"}}}`)}},
+		// Server does not echo the password back
+		result: &ToolResult{Content: []ContentBlock{{Type: "text", Text: `{"player":{"username":"zoea_001"}}`}}},
+	}
+	proxy := NewProxy(upstream)
+	accounts := &mockAccountStore{}
+	proxy.SetAccountStore(accounts)
+	proxy.SetCredentialGenerator(fixedCredentials{username: "zoea_001", password: "Gen3ratedPass"})
+	ctx := context.Background()
+	if _, err := proxy.ListTools(ctx); err != nil {
+		t.Fatalf("ListTools() error: %v", err)
+	}
+
+	if _, err := proxy.CallTool(ctx, CallerContext{MysisID: "m1"}, "register", json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	args, _ := upstream.lastArgs.(map[string]interface{})
+	if args["username"] != "zoea_001" || args["password"] != "Gen3ratedPass" {
+		t.Errorf("expected generated credentials sent upstream, got %v", upstream.lastArgs)
+	}
+	if len(accounts.created) != 1 || accounts.created[0] != (Account{Username: "zoea_001", Password: "Gen3ratedPass"}) {
+		t.Errorf("expected generated account stored, got %+v", accounts.created)
+	}
+
+	// Explicit credentials are left alone
+	if _, err := proxy.CallTool(ctx, CallerContext{MysisID: "m2"}, "register", json.RawMessage(`{"username":"pilot","password":"mine"}`)); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	args, _ = upstream.lastArgs.(map[string]interface{})
+	if args["username"] != "pilot" || args["password"] != "mine" {
+		t.Errorf("expected caller credentials kept, got %v", upstream.lastArgs)
+	}
+}
+
+func TestProxyValidatesLocalToolArguments(t *testing.T) {
+	proxy := NewProxy(nil)
+	called := false
+	proxy.RegisterTool(Tool{
+		Name:        "zoea_local",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}`),
+	}, func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+		called = true
+		return &ToolResult{Content: []ContentBlock{{Type: "text", Text: "ok"}}}, nil
+	})
+
+	result, err := proxy.CallTool(context.Background(), CallerContext{}, "zoea_local", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if called {
+		t.Fatal("expected handler not to be called with invalid arguments")
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, `"query" is required`) {
+		t.Fatalf("expected missing field error, got %+v", result)
+	}
+	if !strings.Contains(result.Content[0].Text, "Expected parameters: query (string, required)") {
+		t.Errorf("expected parameter guidance, got %q", result.Content[0].Text)
+	}
+}
+
+func TestProxyUpstreamValidationOptIn(t *testing.T) {
+	upstream := &mockUpstream{
+		tools: []Tool{{
+			Name:        "travel",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"target":{"type":"string"}},"required":["target"]}`),
+		}},
+		result: &ToolResult{Content: []ContentBlock{{Type: "text", Text: "ok"}}},
+	}
+	proxy := NewProxy(upstream)
+	if _, err := proxy.ListTools(context.Background()); err != nil {
+		t.Fatalf("ListTools() error: %v", err)
+	}
+
+	// Disabled by default: invalid arguments still reach upstream
+	if _, err := proxy.CallTool(context.Background(), CallerContext{}, "travel", json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if upstream.callCount != 1 {
+		t.Fatalf("expected upstream call without validation, got %d calls", upstream.callCount)
+	}
+
+	proxy.SetUpstreamValidation(true)
+	result, err := proxy.CallTool(context.Background(), CallerContext{}, "travel", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if upstream.callCount != 1 {
+		t.Fatalf("expected invalid call to be blocked, got %d upstream calls", upstream.callCount)
+	}
+	if !result.IsError {
+		t.Fatal("expected validation error result")
+	}
+
+	if _, err := proxy.CallTool(context.Background(), CallerContext{}, "travel", json.RawMessage(`{"target":"sol"}`)); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if upstream.callCount != 2 {
+		t.Fatalf("expected valid call to reach upstream, got %d calls", upstream.callCount)
+	}
+}
+
+func TestProxyRetryExhaustedReportsMetadata(t *testing.T) {
+	saved := toolRetryDelays
+	toolRetryDelays = []time.Duration{0, 0, 0}
+	defer func() { toolRetryDelays = saved }()
+
+	upstream := &mockUpstream{err: errors.New("http error 502: bad gateway")}
+	proxy := NewProxy(upstream)
+
+	_, err := proxy.CallTool(context.Background(), CallerContext{}, "get_status", json.RawMessage(`{}`))
+	if !errors.Is(err, ErrToolRetryExhausted) {
+		t.Fatalf("expected ErrToolRetryExhausted, got %v", err)
+	}
+	var retryErr *ToolRetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected *ToolRetryError, got %T", err)
+	}
+	if retryErr.Tool != "get_status" || retryErr.Retries != 3 || retryErr.Cause != RetryCauseServerError {
+		t.Errorf("unexpected retry metadata: %+v", retryErr)
+	}
+	if upstream.callCount != 4 {
+		t.Errorf("expected 4 upstream calls, got %d", upstream.callCount)
+	}
+	if !strings.Contains(err.Error(), "failed after 3 retries") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestProxyRetryPolicy(t *testing.T) {
+	serverErr := &httpStatusError{StatusCode: 502, Body: "bad gateway"}
+	ok := &ToolResult{Content: []ContentBlock{{Type: "text", Text: "docked"}}}
+	call := func(proxy *Proxy, ctx context.Context) (*ToolResult, error) {
+		return proxy.CallTool(ctx, CallerContext{}, "get_status", json.RawMessage(`{}`))
+	}
+
+	t.Run("recovers after transient errors", func(t *testing.T) {
+		upstream := &mockUpstream{errs: []error{serverErr, errors.New("http request: connection reset by peer")}, result: ok}
+		proxy := NewProxy(upstream)
+		proxy.SetRetryPolicy(RetryPolicy{Retries: 2, Backoff: time.Millisecond})
+
+		result, err := call(proxy, context.Background())
+		if err != nil || result != ok {
+			t.Fatalf("CallTool() = %+v, %v", result, err)
+		}
+		if upstream.callCount != 3 {
+			t.Errorf("expected 3 upstream calls, got %d", upstream.callCount)
+		}
+	})
+
+	t.Run("per-tool limit exhausted", func(t *testing.T) {
+		upstream := &mockUpstream{errs: []error{serverErr, serverErr}, result: ok}
+		proxy := NewProxy(upstream)
+		proxy.SetRetryPolicy(RetryPolicy{Retries: 5, Backoff: time.Millisecond, PerTool: map[string]int{"get_status": 1}})
+
+		_, err := call(proxy, context.Background())
+		var retryErr *ToolRetryError
+		if !errors.Is(err, ErrToolRetryExhausted) || !errors.As(err, &retryErr) || retryErr.Retries != 1 {
+			t.Fatalf("expected ErrToolRetryExhausted after 1 retry, got %v", err)
+		}
+		if upstream.callCount != 2 {
+			t.Errorf("expected 2 upstream calls, got %d", upstream.callCount)
+		}
+	})
+
+	t.Run("tool errors and client errors are not retried", func(t *testing.T) {
+		toolErr := &ToolResult{Content: []ContentBlock{{Type: "text", Text: "not docked"}}, IsError: true}
+		upstream := &mockUpstream{result: toolErr}
+		proxy := NewProxy(upstream)
+		proxy.SetRetryPolicy(RetryPolicy{Retries: 3, Backoff: time.Millisecond})
+
+		if result, err := call(proxy, context.Background()); err != nil || result != toolErr || upstream.callCount != 1 {
+			t.Errorf("expected the IsError result from 1 call, got %+v, %v after %d calls", result, err, upstream.callCount)
+		}
+
+		upstream.callCount = 0
+		upstream.result, upstream.err = nil, &httpStatusError{StatusCode: 404, Body: "not found"}
+		if _, err := call(proxy, context.Background()); errors.Is(err, ErrToolRetryExhausted) || upstream.callCount != 1 {
+			t.Errorf("expected the 404 from 1 call, got %v after %d calls", err, upstream.callCount)
+		}
+	})
+
+	t.Run("retries share the call deadline", func(t *testing.T) {
+		upstream := &mockUpstream{err: serverErr}
+		proxy := NewProxy(upstream)
+		proxy.SetRetryPolicy(RetryPolicy{Retries: 3, Backoff: time.Hour})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := call(proxy, ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected DeadlineExceeded, got %v", err)
+		}
+		if upstream.callCount != 1 {
+			t.Errorf("expected 1 upstream call before the deadline, got %d", upstream.callCount)
+		}
+	})
+
+	t.Run("backoff doubles up to the cap", func(t *testing.T) {
+		policy := RetryPolicy{Backoff: 10 * time.Second}
+		for retry, want := range map[int]time.Duration{1: 10 * time.Second, 2: 20 * time.Second, 3: maxToolRetryDelay, 9: maxToolRetryDelay} {
+			if got := policy.delay(retry); got != want {
+				t.Errorf("delay(%d) = %s, want %s", retry, got, want)
+			}
+		}
+		if got := (RetryPolicy{}).delay(5); got != toolRetryDelays[len(toolRetryDelays)-1] {
+			t.Errorf("expected default schedule to repeat its last delay, got %s", got)
+		}
+	})
+}
+
+func TestProxyCallToolsBatch(t *testing.T) {
+	upstream := &mockBatchUpstream{mockUpstream: mockUpstream{result: &ToolResult{Content: []ContentBlock{{Type: "text", Text: "single"}}}}}
+	proxy := NewProxy(upstream)
+	proxy.RegisterTool(Tool{Name: "get_notes"}, func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+		return &ToolResult{Content: []ContentBlock{{Type: "text", Text: "local"}}}, nil
+	})
+
+	calls := []ToolCall{{Name: "get_status"}, {Name: "get_notes"}, {Name: "get_cargo", Arguments: json.RawMessage(`{}`)}}
+	results := proxy.CallToolsBatch(context.Background(), CallerContext{}, calls)
+
+	if len(upstream.batches) != 1 || len(upstream.batches[0]) != 2 {
+		t.Fatalf("expected one batch of the 2 upstream calls, got %+v", upstream.batches)
+	}
+	if upstream.callCount != 0 {
+		t.Errorf("expected no single upstream calls, got %d", upstream.callCount)
+	}
+	want := []string{"batched get_status", "local", "batched get_cargo"}
+	for i, res := range results {
+		if res.Err != nil || res.Result.Content[0].Text != want[i] {
+			t.Errorf("result %d = %+v, want %q", i, res, want[i])
+		}
+	}
+
+	// A rejected batch falls back to sequential calls and is not tried again
+	upstream.batchErr = ErrBatchUnsupported
+	upstream.batches = nil
+	results = proxy.CallToolsBatch(context.Background(), CallerContext{}, calls)
+	if upstream.callCount != 2 {
+		t.Errorf("expected 2 sequential upstream calls, got %d", upstream.callCount)
+	}
+	if results[0].Result.Content[0].Text != "single" || results[1].Result.Content[0].Text != "local" {
+		t.Errorf("unexpected fallback results: %+v", results)
+	}
+	proxy.CallToolsBatch(context.Background(), CallerContext{}, calls)
+	if len(upstream.batches) != 1 {
+		t.Errorf("expected batching disabled after rejection, got %d batch attempts", len(upstream.batches))
+	}
+}
+
+func TestProxyToolListCache(t *testing.T) {
+	upstream := &mockUpstream{tools: []Tool{{Name: "get_status"}}}
+	proxy := NewProxy(upstream)
+	proxy.SetToolCacheTTL(time.Minute)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	proxy.nowFunc = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Concurrent callers within the TTL share one upstream fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := proxy.ListTools(ctx); err != nil {
+				t.Errorf("ListTools() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := upstream.listCount.Load(); got != 1 {
+		t.Fatalf("expected 1 upstream ListTools within TTL, got %d", got)
+	}
+
+	// Local tools are always fresh
+	proxy.RegisterTool(Tool{Name: "zoea_local"}, func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+		return &ToolResult{}, nil
+	})
+	tools, _ := proxy.ListTools(ctx)
+	if len(tools) != 2 || upstream.listCount.Load() != 1 {
+		t.Errorf("expected new local tool without refetch, got %d tools and %d fetches", len(tools), upstream.listCount.Load())
+	}
+
+	// Expiry and reinitialization both refetch
+	now = now.Add(time.Minute)
+	proxy.ListTools(ctx)
+	if got := upstream.listCount.Load(); got != 2 {
+		t.Errorf("expected refetch after TTL, got %d fetches", got)
+	}
+	if err := proxy.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	proxy.ListTools(ctx)
+	if got := upstream.listCount.Load(); got != 3 {
+		t.Errorf("expected refetch after reinitialize, got %d fetches", got)
+	}
+
+	// Zero TTL disables caching
+	proxy.SetToolCacheTTL(0)
+	proxy.ListTools(ctx)
+	proxy.ListTools(ctx)
+	if got := upstream.listCount.Load(); got != 5 {
+		t.Errorf("expected every call to hit upstream without a TTL, got %d fetches", got)
+	}
+}
+
+// resettableUpstream counts the session resets and re-initializations a reconnect makes.
+type resettableUpstream struct {
+	mockUpstream
+	resets atomic.Int32
+	inits  atomic.Int32
+}
+
+func (m *resettableUpstream) Reset() { m.resets.Add(1) }
+
+func (m *resettableUpstream) Initialize(ctx context.Context, clientInfo map[string]interface{}) (*Response, error) {
+	m.inits.Add(1)
+	return &Response{JSONRPC: "2.0"}, nil
+}
+
+func TestProxyWatchdogReconnects(t *testing.T) {
+	upstream := &resettableUpstream{}
+	upstream.err = &httpStatusError{StatusCode: 404, Body: "unknown session"}
+	proxy := NewProxy(upstream)
+	proxy.SetRetryPolicy(RetryPolicy{Retries: 0})
+	now := time.Now()
+	proxy.nowFunc = func() time.Time { return now }
+
+	events := make(chan bool, 10)
+	proxy.SetWatchdog(Watchdog{
+		Failures: 3,
+		Cooldown: time.Minute,
+		OnReconnect: func(done bool, err error) {
+			if err != nil {
+				t.Errorf("reconnect error: %v", err)
+			}
+			events <- done
+		},
+	})
+	call := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			proxy.CallTool(context.Background(), CallerContext{}, "get_status", json.RawMessage(`{}`))
+		}
+	}
+	waitDone := func() {
+		t.Helper()
+		for {
+			select {
+			case done := <-events:
+				if done {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the reconnect")
+			}
+		}
+	}
+
+	// A success in between resets the count
+	call(2)
+	upstream.err, upstream.result = nil, &ToolResult{}
+	call(1)
+	upstream.err, upstream.result = &httpStatusError{StatusCode: 404, Body: "unknown session"}, nil
+	call(2)
+	if len(events) != 0 {
+		t.Fatal("expected no reconnect before 3 failures in a row")
+	}
+
+	// Sustained failures reconnect once within the cooldown
+	call(10)
+	waitDone()
+	call(10)
+	select {
+	case <-events:
+		t.Fatal("expected no second reconnect within the cooldown")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if upstream.resets.Load() != 1 || upstream.inits.Load() != 1 {
+		t.Errorf("expected 1 reset and 1 initialize, got %d and %d", upstream.resets.Load(), upstream.inits.Load())
+	}
+
+	// Once the cooldown has passed, the next failure reconnects again
+	now = now.Add(time.Minute)
+	call(1)
+	waitDone()
+	if upstream.inits.Load() != 2 {
+		t.Errorf("expected a second reconnect after the cooldown, got %d", upstream.inits.Load())
+	}
+}
+
+func TestReconnectGroupSharesFailures(t *testing.T) {
+	events := make(chan bool, 10)
+	group := NewReconnectGroup(Watchdog{
+		Failures: 3,
+		Cooldown: time.Minute,
+		OnReconnect: func(done bool, err error) {
+			if err != nil {
+				t.Errorf("reconnect error: %v", err)
+			}
+			events <- done
+		},
+	})
+
+	upstreams := []*resettableUpstream{{}, {}}
+	proxies := make([]*Proxy, len(upstreams))
+	for i, upstream := range upstreams {
+		upstream.err = &httpStatusError{StatusCode: 404, Body: "unknown session"}
+		proxies[i] = NewProxy(upstream)
+		proxies[i].SetRetryPolicy(RetryPolicy{Retries: 0})
+		proxies[i].SetReconnectGroup(group)
+	}
+
+	// Neither proxy fails 3 times on its own, but together they do
+	for _, i := range []int{0, 1, 0} {
+		proxies[i].CallTool(context.Background(), CallerContext{}, "get_status", json.RawMessage(`{}`))
+	}
+	var started, finished int
+	for finished == 0 {
+		select {
+		case done := <-events:
+			if done {
+				finished++
+			} else {
+				started++
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the shared reconnect")
+		}
+	}
+	if started != 1 {
+		t.Errorf("expected one reconnect report for the group, got %d", started)
+	}
+	for i, upstream := range upstreams {
+		if upstream.resets.Load() != 1 || upstream.inits.Load() != 1 {
+			t.Errorf("proxy %d: expected 1 reset and 1 initialize, got %d and %d", i, upstream.resets.Load(), upstream.inits.Load())
+		}
+	}
+
+	// A closed proxy leaves the group and is not reconnected again
+	proxies[1].Close()
+	group.mu.Lock()
+	members := len(group.members)
+	group.mu.Unlock()
+	if members != 1 {
+		t.Errorf("expected 1 member after Close, got %d", members)
+	}
+}
+
+// hangingUpstream blocks Initialize until its context is done, like a server that
+// accepts the connection but never answers.
+type hangingUpstream struct {
+	mockUpstream
+}
+
+func (m *hangingUpstream) Initialize(ctx context.Context, clientInfo map[string]interface{}) (*Response, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestProxyCloseCancelsReconnect(t *testing.T) {
+	upstream := &hangingUpstream{}
+	upstream.err = &httpStatusError{StatusCode: 404, Body: "unknown session"}
+	proxy := NewProxy(upstream)
+	proxy.SetRetryPolicy(RetryPolicy{Retries: 0})
+
+	events := make(chan error, 10)
+	proxy.SetWatchdog(Watchdog{
+		Failures: 1,
+		Cooldown: time.Minute,
+		OnReconnect: func(done bool, err error) {
+			if done {
+				events <- err
+			}
+		},
+	})
+	proxy.CallTool(context.Background(), CallerContext{}, "get_status", json.RawMessage(`{}`))
+	proxy.Close()
+
+	select {
+	case err := <-events:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the reconnect to be canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reconnect kept running after Close")
+	}
+
+	// A closed proxy starts no new reconnects
+	proxy.CallTool(context.Background(), CallerContext{}, "get_status", json.RawMessage(`{}`))
+	select {
+	case <-events:
+		t.Fatal("expected no reconnect after Close")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestClassifyRetryCause(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errors.New("http request: dial tcp 127.0.0.1:80: connect: connection refused"), RetryCauseConnectionRefused},
+		{errors.New("http error 500: internal"), RetryCauseServerError},
+		{errors.New("http error 429: slow down (Retry-After: 5)"), RetryCauseRateLimited},
+		{errors.New("http request: i/o timeout"), RetryCauseTimeout},
+		{errors.New("mcp error -32000: boom"), RetryCauseOther},
+	}
+	for _, tt := range tests {
+		if got := classifyRetryCause(tt.err); got != tt.want {
+			t.Errorf("classifyRetryCause(%q) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ArgumentError describes a single tool argument that failed schema validation.
+type ArgumentError struct {
+	Field   string // Dotted path to the argument ("" for the root object)
+	Problem string // Human-readable description of the problem
+}
+
+func (e ArgumentError) String() string {
+	if e.Field == "" {
+		return e.Problem
+	}
+	return fmt.Sprintf("%q %s", e.Field, e.Problem)
+}
+
+// ValidateArguments checks tool arguments against a JSON Schema.
+//
+// Only the subset of JSON Schema used by tool definitions is supported: type,
+// properties, required, enum, items, and additionalProperties=false. Unknown
+// keywords are ignored, and an empty or unparseable schema accepts anything.
+func ValidateArguments(schema json.RawMessage, arguments json.RawMessage) []ArgumentError {
+	if len(schema) == 0 {
+		return nil
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil
+	}
+
+	var value interface{}
+	if len(arguments) == 0 || string(arguments) == "null" {
+		value = map[string]interface{}{}
+	} else if err := json.Unmarshal(arguments, &value); err != nil {
+		return []ArgumentError{{Problem: fmt.Sprintf("arguments are not valid JSON: %v", err)}}
+	}
+
+	var errs []ArgumentError
+	validateValue(value, root, "", &errs)
+	return errs
+}
+
+func validateValue(value interface{}, schema map[string]interface{}, path string, errs *[]ArgumentError) {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := jsonType(value)
+		if !typeMatches(actual, value, types) {
+			*errs = append(*errs, ArgumentError{
+				Field:   path,
+				Problem: fmt.Sprintf("must be %s (got %s)", strings.Join(types, " or "), actual),
+			})
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			options := make([]string, len(enum))
+			for i, allowed := range enum {
+				data, _ := json.Marshal(allowed)
+				options[i] = string(data)
+			}
+			*errs = append(*errs, ArgumentError{
+				Field:   path,
+				Problem: fmt.Sprintf("must be one of %s", strings.Join(options, ", ")),
+			})
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		validateObject(v, schema, path, errs)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateValue(item, items, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	}
+}
+
+func validateObject(obj map[string]interface{}, schema map[string]interface{}, path string, errs *[]ArgumentError) {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, present := obj[name]; !present {
+				*errs = append(*errs, ArgumentError{Field: joinPath(path, name), Problem: "is required"})
+			}
+		}
+	}
+
+	// Iterate in sorted order so error messages are deterministic
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	additional, hasAdditional := schema["additionalProperties"].(bool)
+	for _, k := range keys {
+		propSchema, known := properties[k].(map[string]interface{})
+		if !known {
+			if hasAdditional && !additional {
+				*errs = append(*errs, ArgumentError{Field: joinPath(path, k), Problem: "is not a recognized parameter"})
+			}
+			continue
+		}
+		validateValue(obj[k], propSchema, joinPath(path, k), errs)
+	}
+}
+
+// DescribeParameters returns a short "name (type, required)" list for a schema's
+// top-level properties, used to guide the LLM after a validation failure.
+func DescribeParameters(schema json.RawMessage) string {
+	var root map[string]interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return ""
+	}
+	properties, _ := root["properties"].(map[string]interface{})
+	if len(properties) == 0 {
+		return ""
+	}
+
+	required := make(map[string]bool)
+	if list, ok := root["required"].([]interface{}); ok {
+		for _, r := range list {
+			if name, ok := r.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		desc := name
+		var attrs []string
+		if prop, ok := properties[name].(map[string]interface{}); ok {
+			attrs = append(attrs, schemaTypes(prop["type"])...)
+		}
+		if required[name] {
+			attrs = append(attrs, "required")
+		}
+		if len(attrs) > 0 {
+			desc += " (" + strings.Join(attrs, ", ") + ")"
+		}
+		parts = append(parts, desc)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func schemaTypes(raw interface{}) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+func typeMatches(actual string, value interface{}, allowed []string) bool {
+	for _, t := range allowed {
+		if t == actual {
+			return true
+		}
+		if t == "integer" && actual == "number" {
+			if f := value.(float64); f == math.Trunc(f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
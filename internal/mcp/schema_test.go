@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateArguments(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"target": {"type": "string"},
+			"quantity": {"type": "integer"},
+			"mode": {"type": "string", "enum": ["fast", "safe"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["target"],
+		"additionalProperties": false
+	}`)
+
+	tests := []struct {
+		name     string
+		args     string
+		expected []string
+	}{
+		{"valid", `{"target":"sol","quantity":3,"mode":"safe","tags":["a"]}`, nil},
+		{"empty args missing required", ``, []string{`"target" is required`}},
+		{"wrong type", `{"target":5}`, []string{`"target" must be string (got number)`}},
+		{"fractional integer", `{"target":"sol","quantity":1.5}`, []string{`"quantity" must be integer (got number)`}},
+		{"enum mismatch", `{"target":"sol","mode":"yolo"}`, []string{`"mode" must be one of "fast", "safe"`}},
+		{"array item", `{"target":"sol","tags":["a",1]}`, []string{`"tags[1]" must be string (got number)`}},
+		{"unknown field", `{"target":"sol","extra":true}`, []string{`"extra" is not a recognized parameter`}},
+		{"not an object", `[1,2]`, []string{`must be object (got array)`}},
+		{"malformed json", `{"target":`, []string{"arguments are not valid JSON"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateArguments(schema, json.RawMessage(tt.args))
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tt.expected), len(errs), errs)
+			}
+			for i, want := range tt.expected {
+				if !strings.Contains(errs[i].String(), want) {
+					t.Errorf("error %d: expected %q, got %q", i, want, errs[i].String())
+				}
+			}
+		})
+	}
+}
+
+func TestValidateArguments_EmptySchemaAcceptsAnything(t *testing.T) {
+	if errs := ValidateArguments(nil, json.RawMessage(`{"any":"thing"}`)); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestDescribeParameters(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","properties":{"b":{"type":"integer"},"a":{"type":"string"}},"required":["a"]}`)
+	got := DescribeParameters(schema)
+	want := "a (string, required), b (integer)"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
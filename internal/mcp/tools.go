@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
 )
 
 // SearchResult represents a search result from memory.
 type SearchResult struct {
+	MysisName string `json:",omitempty"` // Set for swarm-wide search
 	Role      string
 	Source    string
 	Content   string
@@ -22,6 +27,32 @@ type ReasoningResult struct {
 	CreatedAt string
 }
 
+// MysisActivity is a mysis's in-game activity and expected availability.
+type MysisActivity struct {
+	MysisID        string
+	MysisName      string
+	State          string
+	Activity       string
+	ActivityUntil  string `json:",omitempty"` // RFC 3339; empty if unknown
+	RemainingTicks int64  `json:",omitempty"` // Server ticks until free; 0 if unknown
+	Available      bool   // Free to take a new task now
+}
+
+// PeerSnapshot is the latest result of a snapshot tool in a mysis's memory.
+type PeerSnapshot struct {
+	MysisID   string
+	MysisName string
+	Tool      string
+	CreatedAt string          // RFC 3339
+	Payload   json.RawMessage // The tool result; a JSON string when it isn't JSON
+}
+
+// RelaunchReport is the outcome of relaunching errored myses with a broadcast.
+type RelaunchReport struct {
+	Relaunched []string // Myses relaunched that received the broadcast
+	Failed     []string `json:",omitempty"` // One "name: error" entry per mysis that didn't
+}
+
 // Orchestrator defines the interface for swarm orchestration.
 // This interface breaks the import cycle between mcp and core packages.
 type Orchestrator interface {
@@ -32,7 +63,25 @@ type Orchestrator interface {
 	BroadcastAsync(message string) error
 	BroadcastFrom(senderID, message string) error
 	SearchMessages(mysisID, query string, limit int) ([]SearchResult, error)
+	SearchSwarm(query string, limit int) ([]SearchResult, error)
 	SearchReasoning(mysisID, query string, limit int) ([]ReasoningResult, error)
+	GetRecentReasoning(mysisID string, limit int) ([]ReasoningResult, error)
+	SetCompactSnapshots(mysisID string, enabled bool) error
+	SetSnapshotSummaries(mysisID string, enabled bool) error
+	SetContextWindow(mysisID string, window int) error
+	SetAutonomous(mysisID string, autonomous bool) error
+	SetSyntheticNudges(mysisID string, enabled bool) error
+	SetSeed(mysisID string, seed *int64) error
+	SetGoal(mysisID, goal string) error
+	SwitchProviderAsync(mysisID, provider, model string) error
+	ConfigureSwarm(setting string, value int) error
+	AddNote(mysisID, content string) error
+	SendOneShotSystem(mysisID, content string) error
+	AskMysis(ctx context.Context, senderID, mysisID, question string, timeout time.Duration) (string, error)
+	SetToolPolicy(mysisID string, allow, deny []string) error
+	GetMysisActivity(mysisID string) ([]MysisActivity, error)
+	GetPeerSnapshot(mysisID, toolName string) (*PeerSnapshot, error)
+	RelaunchAndBroadcast(message string) (*RelaunchReport, error)
 }
 
 // RegisterOrchestratorTools registers the internal orchestration tools with the proxy.
@@ -100,6 +149,67 @@ func RegisterOrchestratorTools(proxy *Proxy, orchestrator Orchestrator) {
 		},
 	)
 
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_search_swarm",
+			Description: "Search messages across every mysis in the swarm by text content (e.g. who found iron). Newest results first, each tagged with the owning mysis name",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"query": {"type": "string", "description": "Text to search for in message content"},
+					"limit": {"type": "integer", "description": "Maximum results to return (default 20, max 50)"}
+				},
+				"required": ["query"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				Query string `json:"query"`
+				Limit int    `json:"limit"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if params.Query == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "query cannot be empty"}},
+					IsError: true,
+				}, nil
+			}
+
+			// Lower cap than per-mysis search: results span every mysis
+			limit := params.Limit
+			if limit <= 0 {
+				limit = 20
+			} else if limit > 50 {
+				limit = 50
+			}
+
+			results, err := orchestrator.SearchSwarm(params.Query, limit)
+			if err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("search failed: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if len(results) == 0 {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("no swarm messages found matching '%s'", params.Query)}},
+				}, nil
+			}
+
+			data, _ := json.MarshalIndent(results, "", "  ")
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: string(data)}},
+			}, nil
+		},
+	)
+
 	proxy.RegisterTool(
 		Tool{
 			Name:        "zoea_search_reasoning",
@@ -161,4 +271,564 @@ func RegisterOrchestratorTools(proxy *Proxy, orchestrator Orchestrator) {
 			}, nil
 		},
 	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_mysis_reasoning",
+			Description: "Get a mysis's most recent reasoning in chronological order, to understand why it made its decisions",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"mysis_id": {"type": "string", "description": "The ID of the mysis whose reasoning to fetch"},
+					"limit": {"type": "integer", "description": "Maximum entries to return (default 10, max 50)"}
+				},
+				"required": ["mysis_id"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				MysisID string `json:"mysis_id"`
+				Limit   int    `json:"limit"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if params.MysisID == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "mysis_id cannot be empty"}},
+					IsError: true,
+				}, nil
+			}
+
+			limit := params.Limit
+			if limit <= 0 {
+				limit = 10
+			} else if limit > 50 {
+				limit = 50
+			}
+
+			results, err := orchestrator.GetRecentReasoning(params.MysisID, limit)
+			if err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("failed to get reasoning: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if len(results) == 0 {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("no reasoning recorded for mysis %s", params.MysisID)}},
+				}, nil
+			}
+
+			data, _ := json.MarshalIndent(results, "", "  ")
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: string(data)}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_mysis_activity",
+			Description: "Get what myses are doing in-game (traveling, mining, cooldown...) and when they will be free, to assign tasks to available myses. Omit mysis_id for every mysis",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"mysis_id": {"type": "string", "description": "The ID of a single mysis (optional)"}
+				}
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				MysisID string `json:"mysis_id"`
+			}
+			if len(args) > 0 {
+				if err := json.Unmarshal(args, &params); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+			}
+
+			results, err := orchestrator.GetMysisActivity(params.MysisID)
+			if err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("failed to get activity: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			data, _ := json.MarshalIndent(results, "", "  ")
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: string(data)}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_peer_snapshot",
+			Description: "Get the latest result of a snapshot tool (get_status, get_cargo, get_system...) that another mysis called, with when it was taken, to share observations without asking the mysis",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"mysis_id": {"type": "string", "description": "The ID of the mysis whose snapshot to read"},
+					"tool": {"type": "string", "description": "Snapshot tool name (default get_status)"}
+				},
+				"required": ["mysis_id"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				MysisID string `json:"mysis_id"`
+				Tool    string `json:"tool"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if params.MysisID == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "mysis_id cannot be empty"}},
+					IsError: true,
+				}, nil
+			}
+			if params.Tool == "" {
+				params.Tool = "get_status"
+			}
+
+			snapshot, err := orchestrator.GetPeerSnapshot(params.MysisID, params.Tool)
+			if err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("failed to get snapshot: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+			if snapshot == nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("no %s snapshot recorded for mysis %s", params.Tool, params.MysisID)}},
+				}, nil
+			}
+
+			data, _ := json.MarshalIndent(snapshot, "", "  ")
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: string(data)}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_configure_mysis",
+			Description: "Adjust per-mysis runtime settings. compact_snapshots=false keeps every snapshot tool result in context (useful for debugging); snapshot_summaries=true leaves a one-line marker for each compacted snapshot tool; context_window sets how many recent messages are scanned for context; allow_tools/deny_tools replace the mysis tool policy (empty lists lift it); autonomous=false makes the mysis turn only when messaged or broadcast to; synthetic_nudges=false lets an autonomous mysis go idle instead of nudging itself when it has nothing to answer; goal sets a standing goal kept in the mysis's system prompt (empty clears it); seed sends a fixed sampling seed with every provider call for near-deterministic runs at temperature 0 (-1 clears it); provider/model switch the LLM once the current turn finishes, keeping memory",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"mysis_id": {"type": "string", "description": "The ID of the mysis to configure"},
+					"compact_snapshots": {"type": "boolean", "description": "Keep only the latest result of each snapshot tool in context (default true)"},
+					"snapshot_summaries": {"type": "boolean", "description": "Replace compacted snapshot results with a one-line marker such as \"[older get_status omitted]\" (default false)"},
+					"context_window": {"type": "integer", "description": "Recent messages scanned for context (5-200, 0 restores the default)"},
+					"autonomous": {"type": "boolean", "description": "Keep taking turns between messages (default true); false idles the mysis until it is messaged"},
+					"synthetic_nudges": {"type": "boolean", "description": "Nudge the mysis to keep playing when it has no messages (default true); false lets it go idle until messaged"},
+					"allow_tools": {"type": "array", "items": {"type": "string"}, "description": "Only these tools may be used (empty = all tools)"},
+					"deny_tools": {"type": "array", "items": {"type": "string"}, "description": "These tools may never be used"},
+					"seed": {"type": "integer", "description": "Sampling seed for providers that support one (Ollama, OpenAI-compatible chat completions); -1 clears it"},
+					"goal": {"type": "string", "description": "Standing goal added to every system prompt, e.g. \"Control sector 7\" (empty clears it)"},
+					"provider": {"type": "string", "description": "Provider to switch to, as named in the config"},
+					"model": {"type": "string", "description": "Model to switch to (requires provider; empty uses the provider's configured model)"}
+				},
+				"required": ["mysis_id"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				MysisID           string   `json:"mysis_id"`
+				CompactSnapshots  *bool    `json:"compact_snapshots"`
+				SnapshotSummaries *bool    `json:"snapshot_summaries"`
+				ContextWindow     *int     `json:"context_window"`
+				Autonomous        *bool    `json:"autonomous"`
+				SyntheticNudges   *bool    `json:"synthetic_nudges"`
+				AllowTools        []string `json:"allow_tools"`
+				DenyTools         []string `json:"deny_tools"`
+				Seed              *int64   `json:"seed"`
+				Goal              *string  `json:"goal"`
+				Provider          string   `json:"provider"`
+				Model             string   `json:"model"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if params.MysisID == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "mysis_id cannot be empty"}},
+					IsError: true,
+				}, nil
+			}
+
+			var changed []string
+			if params.CompactSnapshots != nil {
+				if err := orchestrator.SetCompactSnapshots(params.MysisID, *params.CompactSnapshots); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("compact_snapshots=%t", *params.CompactSnapshots))
+			}
+			if params.SnapshotSummaries != nil {
+				if err := orchestrator.SetSnapshotSummaries(params.MysisID, *params.SnapshotSummaries); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("snapshot_summaries=%t", *params.SnapshotSummaries))
+			}
+			if params.ContextWindow != nil {
+				if err := orchestrator.SetContextWindow(params.MysisID, *params.ContextWindow); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("context_window=%d", *params.ContextWindow))
+			}
+			if params.Autonomous != nil {
+				if err := orchestrator.SetAutonomous(params.MysisID, *params.Autonomous); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("autonomous=%t", *params.Autonomous))
+			}
+			if params.SyntheticNudges != nil {
+				if err := orchestrator.SetSyntheticNudges(params.MysisID, *params.SyntheticNudges); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("synthetic_nudges=%t", *params.SyntheticNudges))
+			}
+			if params.AllowTools != nil || params.DenyTools != nil {
+				if err := orchestrator.SetToolPolicy(params.MysisID, params.AllowTools, params.DenyTools); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("allow_tools=[%s] deny_tools=[%s]",
+					strings.Join(params.AllowTools, ","), strings.Join(params.DenyTools, ",")))
+			}
+			if params.Seed != nil {
+				seed := params.Seed
+				if *seed == -1 {
+					seed = nil
+				}
+				if err := orchestrator.SetSeed(params.MysisID, seed); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				if seed == nil {
+					changed = append(changed, "seed cleared")
+				} else {
+					changed = append(changed, fmt.Sprintf("seed=%d", *seed))
+				}
+			}
+			if params.Goal != nil {
+				if err := orchestrator.SetGoal(params.MysisID, *params.Goal); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("goal=%q", *params.Goal))
+			}
+			if params.Model != "" && params.Provider == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "model requires provider"}},
+					IsError: true,
+				}, nil
+			}
+			if params.Provider != "" {
+				if err := orchestrator.SwitchProviderAsync(params.MysisID, params.Provider, params.Model); err != nil {
+					return &ToolResult{
+						Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+						IsError: true,
+					}, nil
+				}
+				changed = append(changed, fmt.Sprintf("provider=%s model=%s (after the current turn)", params.Provider, params.Model))
+			}
+
+			if len(changed) == 0 {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "no settings provided"}},
+					IsError: true,
+				}, nil
+			}
+
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: "updated " + strings.Join(changed, ", ")}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_configure_swarm",
+			Description: "Change a swarm-wide setting until restart: max_concurrent_turns (provider calls in flight at once, 0 = unlimited), max_myses (swarm size cap) or turn_interval_ms (pause between autonomous turns, 500-600000)",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"setting": {"type": "string", "enum": ["max_concurrent_turns", "max_myses", "turn_interval_ms"], "description": "The setting to change"},
+					"value": {"type": "integer", "description": "The new value"}
+				},
+				"required": ["setting", "value"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				Setting string `json:"setting"`
+				Value   int    `json:"value"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if err := orchestrator.ConfigureSwarm(params.Setting, params.Value); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("configure failed: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("updated %s=%d", params.Setting, params.Value)}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_relaunch_broadcast",
+			Description: "Relaunch every errored mysis and send it a broadcast (e.g. a fresh directive after an outage). Only the relaunched myses receive it; reports which came back and which failed",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"message": {"type": "string", "description": "The broadcast for the relaunched myses"}
+				},
+				"required": ["message"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if strings.TrimSpace(params.Message) == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "message cannot be empty"}},
+					IsError: true,
+				}, nil
+			}
+
+			report, err := orchestrator.RelaunchAndBroadcast(params.Message)
+			if err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("relaunch failed: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+			if len(report.Relaunched) == 0 && len(report.Failed) == 0 {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "no errored myses to relaunch"}},
+				}, nil
+			}
+
+			data, _ := json.MarshalIndent(report, "", "  ")
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: string(data)}},
+				// Only an outright failure is an error; partial results are reported
+				IsError: len(report.Relaunched) == 0,
+			}, nil
+		},
+	)
+
+	proxy.RegisterTool(
+		Tool{
+			Name:        "zoea_system_reminder",
+			Description: "Inject a one-shot system reminder into the next turn of a mysis (e.g. 'prioritize defense now'). The reminder is not stored and is dropped after that turn",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"mysis_id": {"type": "string", "description": "The ID of the mysis to remind"},
+					"content": {"type": "string", "description": "The reminder text"}
+				},
+				"required": ["mysis_id", "content"]
+			}`),
+		},
+		func(ctx context.Context, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				MysisID string `json:"mysis_id"`
+				Content string `json:"content"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if params.MysisID == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "mysis_id cannot be empty"}},
+					IsError: true,
+				}, nil
+			}
+
+			if err := orchestrator.SendOneShotSystem(params.MysisID, params.Content); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("failed to queue reminder: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: "reminder queued for the next turn"}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterToolWithContext(
+		Tool{
+			Name:        "zoea_ask_mysis",
+			Description: "Ask a mysis a question and wait for its answer. Returns the final text reply of its turn (after any tool calls), or an error on timeout",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"mysis_id": {"type": "string", "description": "The ID of the mysis to ask"},
+					"question": {"type": "string", "description": "The question to send"},
+					"timeout_seconds": {"type": "integer", "description": "How long to wait for the answer (default 120, max 600)"}
+				},
+				"required": ["mysis_id", "question"]
+			}`),
+		},
+		func(ctx context.Context, caller CallerContext, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				MysisID        string `json:"mysis_id"`
+				Question       string `json:"question"`
+				TimeoutSeconds int    `json:"timeout_seconds"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if params.MysisID == "" || strings.TrimSpace(params.Question) == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "mysis_id and question are required"}},
+					IsError: true,
+				}, nil
+			}
+			// The caller's own turn is waiting on this call, so it could never answer
+			if params.MysisID == caller.MysisID {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "a mysis cannot ask itself"}},
+					IsError: true,
+				}, nil
+			}
+			if params.TimeoutSeconds < 0 {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "timeout_seconds must not be negative"}},
+					IsError: true,
+				}, nil
+			}
+			timeout := time.Duration(params.TimeoutSeconds) * time.Second
+			if timeout > constants.MaxAskTimeout {
+				timeout = constants.MaxAskTimeout
+			}
+
+			answer, err := orchestrator.AskMysis(ctx, caller.MysisID, params.MysisID, params.Question, timeout)
+			if err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("ask failed: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: answer}},
+			}, nil
+		},
+	)
+
+	proxy.RegisterToolWithContext(
+		Tool{
+			Name:        "zoea_note_add",
+			Description: "Save a durable note for yourself (plans, coordinates, lessons learned). Your most recent notes are always shown at the top of your context",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"content": {"type": "string", "description": "The note to remember"}
+				},
+				"required": ["content"]
+			}`),
+		},
+		func(ctx context.Context, caller CallerContext, args json.RawMessage) (*ToolResult, error) {
+			var params struct {
+				Content string `json:"content"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			if caller.MysisID == "" {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: "notes are only available to myses"}},
+					IsError: true,
+				}, nil
+			}
+
+			if err := orchestrator.AddNote(caller.MysisID, params.Content); err != nil {
+				return &ToolResult{
+					Content: []ContentBlock{{Type: "text", Text: fmt.Sprintf("failed to add note: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+
+			return &ToolResult{
+				Content: []ContentBlock{{Type: "text", Text: "note saved"}},
+			}, nil
+		},
+	)
 }
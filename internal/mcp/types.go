@@ -3,7 +3,10 @@ package mcp
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"strings"
 )
 
 // Request represents an MCP request.
@@ -47,10 +50,64 @@ type ToolResult struct {
 	IsError bool           `json:"isError,omitempty"`
 }
 
-// ContentBlock represents a content block in tool results.
+// DisplayText joins the result's content blocks as text, with placeholders for
+// non-text content (see ContentBlock.DisplayText).
+func (r *ToolResult) DisplayText() string {
+	texts := make([]string, 0, len(r.Content))
+	for _, block := range r.Content {
+		texts = append(texts, block.DisplayText())
+	}
+	return strings.Join(texts, "\n")
+}
+
+// ContentBlock represents a content block in tool results: "text", "image" or
+// "audio" (base64 Data of MimeType), or "resource" (an embedded Resource).
 type ContentBlock struct {
-	Type string `json:"type"`
-	Text string `json:"text,omitempty"`
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	Data     string            `json:"data,omitempty"`
+	MimeType string            `json:"mimeType,omitempty"`
+	Resource *ResourceContents `json:"resource,omitempty"`
+}
+
+// ResourceContents is a resource embedded in a tool result, holding either Text or a
+// base64 Blob.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// DisplayText returns a text block's text. Other content becomes a placeholder with
+// its size and a reference, e.g. "[image: 2048 bytes, image/png]", so it isn't lost
+// when results are stored or shown; text resources keep their text under the URI.
+func (b ContentBlock) DisplayText() string {
+	switch b.Type {
+	case "text":
+		return b.Text
+	case "image", "audio":
+		return contentPlaceholder(b.Type, b.Data, b.MimeType)
+	case "resource":
+		if b.Resource == nil {
+			return "[resource]"
+		}
+		if b.Resource.Blob == "" {
+			return "[resource: " + b.Resource.URI + "]\n" + b.Resource.Text
+		}
+		return contentPlaceholder("resource "+b.Resource.URI, b.Resource.Blob, b.Resource.MimeType)
+	default:
+		return "[" + b.Type + " content]"
+	}
+}
+
+// contentPlaceholder describes base64 content by its decoded size and MIME type.
+func contentPlaceholder(kind, data, mimeType string) string {
+	size := base64.StdEncoding.DecodedLen(len(data)) - strings.Count(data[max(len(data)-2, 0):], "=")
+	if mimeType == "" {
+		return fmt.Sprintf("[%s: %d bytes]", kind, size)
+	}
+	return fmt.Sprintf("[%s: %d bytes, %s]", kind, size, mimeType)
 }
 
 // ListToolsResult is the result of tools/list.
@@ -128,3 +185,9 @@ type UpstreamClient interface {
 	ListTools(ctx context.Context) ([]Tool, error)
 	CallTool(ctx context.Context, name string, arguments interface{}) (*ToolResult, error)
 }
+
+// BatchUpstreamClient is an upstream that can send several tool calls in one request.
+// Proxy uses it when present; see Client.CallToolsBatch.
+type BatchUpstreamClient interface {
+	CallToolsBatch(ctx context.Context, calls []ToolCall) ([]*ToolResult, error)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ModelSettings is implemented by providers that report the model and temperature
+// their requests use.
+type ModelSettings interface {
+	Model() string
+	Temperature() float64
+}
+
+// CachingProvider wraps a provider with an on-disk response cache keyed by a hash of
+// the messages, tools, model, temperature and seed. It is meant for replaying the same
+// context during prompt iteration: a hit skips the backend entirely, so responses
+// (including tool calls) are only reproducible if the replayed context is identical.
+// Do not use it for live play, where the same context should not get a stale reply.
+//
+// Chat and ChatWithTools are cached; Stream and CountTokens pass through. Optional
+// interfaces such as Pinger are not forwarded.
+type CachingProvider struct {
+	Provider
+	dir string
+}
+
+// NewCachingProvider returns inner wrapped with a response cache stored in dir.
+func NewCachingProvider(inner Provider, dir string) *CachingProvider {
+	return &CachingProvider{Provider: inner, dir: dir}
+}
+
+// cacheKey identifies one request. Text-only providers are keyed separately from
+// tool-calling ones so a shimmed Chat never answers a ChatWithTools request.
+type cacheKey struct {
+	Provider    string    `json:"provider"`
+	Model       string    `json:"model,omitempty"`
+	Temperature float64   `json:"temperature,omitempty"`
+	Seed        *int64    `json:"seed,omitempty"`
+	Method      string    `json:"method"`
+	Messages    []Message `json:"messages"`
+	Tools       []Tool    `json:"tools,omitempty"`
+}
+
+func (p *CachingProvider) path(ctx context.Context, method string, messages []Message, tools []Tool) (string, error) {
+	key := cacheKey{Provider: p.Name(), Seed: SeedFromContext(ctx), Method: method, Messages: messages, Tools: tools}
+	if settings, ok := p.Provider.(ModelSettings); ok {
+		key.Model, key.Temperature = settings.Model(), settings.Temperature()
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("hash request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return filepath.Join(p.dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// load returns the cached response at path, or nil on a miss.
+func (p *CachingProvider) load(path string) *ChatResponse {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn().Err(err).Str("path", path).Msg("Failed to read cached response")
+		}
+		return nil
+	}
+	var resp ChatResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		log.Warn().Err(err).Str("path", path).Msg("Ignoring corrupt cached response")
+		return nil
+	}
+	// Nothing was sent, so nothing was spent
+	resp.Usage = Usage{}
+	return &resp
+}
+
+// save stores resp at path. Failures are logged; the response is still returned.
+func (p *CachingProvider) save(path string, resp *ChatResponse) {
+	data, err := json.Marshal(resp)
+	if err == nil {
+		err = os.MkdirAll(p.dir, 0755)
+	}
+	if err == nil {
+		// Write then rename so a concurrent reader never sees a partial file
+		tmp := path + ".tmp"
+		if err = os.WriteFile(tmp, data, 0644); err == nil {
+			err = os.Rename(tmp, path)
+		}
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("path", path).Msg("Failed to cache response")
+	}
+}
+
+// Chat returns the cached reply for messages, or asks the wrapped provider and caches it.
+func (p *CachingProvider) Chat(ctx context.Context, messages []Message) (string, error) {
+	path, err := p.path(ctx, "chat", messages, nil)
+	if err != nil {
+		return "", err
+	}
+	if resp := p.load(path); resp != nil {
+		return resp.Content, nil
+	}
+	text, err := p.Provider.Chat(ctx, messages)
+	if err != nil {
+		return "", err
+	}
+	p.save(path, &ChatResponse{Content: text})
+	return text, nil
+}
+
+// ChatWithTools returns the cached response for messages and tools, or asks the
+// wrapped provider and caches it. Errors are never cached.
+func (p *CachingProvider) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*ChatResponse, error) {
+	path, err := p.path(ctx, "chat_with_tools", messages, tools)
+	if err != nil {
+		return nil, err
+	}
+	if resp := p.load(path); resp != nil {
+		return resp, nil
+	}
+	resp, err := p.Provider.ChatWithTools(ctx, messages, tools)
+	if err != nil {
+		return nil, err
+	}
+	p.save(path, resp)
+	return resp, nil
+}
+
+// CachingFactory wraps the providers another factory creates with a response cache.
+type CachingFactory struct {
+	inner ProviderFactory
+	dir   string
+}
+
+// NewCachingFactory returns a factory whose providers cache responses in dir.
+func NewCachingFactory(inner ProviderFactory, dir string) *CachingFactory {
+	return &CachingFactory{inner: inner, dir: dir}
+}
+
+func (f *CachingFactory) Name() string { return f.inner.Name() }
+
+func (f *CachingFactory) Create(model string, temperature float64) Provider {
+	return NewCachingProvider(f.inner.Create(model, temperature), f.dir)
+}
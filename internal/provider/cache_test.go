@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCachingProvider(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	mock := NewScriptedMock([]MockStep{
+		{Response: "Undocking.", ToolCalls: []ToolCall{{ID: "call_1", Name: "undock", Arguments: json.RawMessage(`{}`)}}},
+		{Response: "Docked."},
+		{Err: errors.New("backend down")},
+		{Response: "Recovered."},
+	})
+	mock.WithUsage(Usage{PromptTokens: 100, CompletionTokens: 10})
+	p := NewCachingProvider(mock, dir)
+
+	messages := []Message{{Role: "user", Content: "Leave the station."}}
+	tools := []Tool{{Name: "undock", Parameters: json.RawMessage(`{"type":"object"}`)}}
+
+	first, err := p.ChatWithTools(ctx, messages, tools)
+	if err != nil {
+		t.Fatalf("ChatWithTools() error: %v", err)
+	}
+	second, err := p.ChatWithTools(ctx, messages, tools)
+	if err != nil {
+		t.Fatalf("cached ChatWithTools() error: %v", err)
+	}
+	if len(mock.Requests()) != 1 {
+		t.Fatalf("expected 1 backend call, got %d", len(mock.Requests()))
+	}
+	if second.Content != first.Content || len(second.ToolCalls) != 1 || second.ToolCalls[0].Name != "undock" {
+		t.Errorf("cached response = %+v, want %+v", second, first)
+	}
+	if second.Usage.TotalTokens() != 0 {
+		t.Errorf("expected cache hits to report no usage, got %+v", second.Usage)
+	}
+
+	// A different context or tool set misses
+	if resp, err := p.ChatWithTools(ctx, messages, nil); err != nil || resp.Content != "Docked." {
+		t.Errorf("ChatWithTools() without tools = %+v, %v; want a fresh reply", resp, err)
+	}
+
+	// Errors are not cached
+	other := []Message{{Role: "user", Content: "Status?"}}
+	if _, err := p.Chat(ctx, other); err == nil {
+		t.Fatal("expected backend error")
+	}
+	if text, err := p.Chat(ctx, other); err != nil || text != "Recovered." {
+		t.Errorf("Chat() after error = %q, %v; want Recovered.", text, err)
+	}
+	if text, err := p.Chat(ctx, other); err != nil || text != "Recovered." {
+		t.Errorf("cached Chat() = %q, %v; want Recovered.", text, err)
+	}
+	if mock.RemainingSteps() != 0 || len(mock.Requests()) != 4 {
+		t.Errorf("expected 4 backend calls, got %d", len(mock.Requests()))
+	}
+
+	// Entries survive a new provider, as long as model and temperature match
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Errorf("expected 3 cache files, got %d", len(files))
+	}
+	reopened := NewCachingProvider(NewScriptedMock(nil), dir)
+	if resp, err := reopened.ChatWithTools(ctx, messages, tools); err != nil || resp.Content != "Undocking." {
+		t.Errorf("reopened ChatWithTools() = %+v, %v; want cached reply", resp, err)
+	}
+	otherModel := NewCachingProvider(modelMock{NewScriptedMock(nil)}, dir)
+	if _, err := otherModel.ChatWithTools(ctx, messages, tools); !errors.Is(err, ErrMockScriptExhausted) {
+		t.Errorf("expected a different model to miss the cache, got %v", err)
+	}
+
+	// Corrupt entries are treated as misses
+	for _, f := range files {
+		os.WriteFile(f, []byte("{"), 0644)
+	}
+	if _, err := reopened.Chat(ctx, other); !errors.Is(err, ErrMockScriptExhausted) {
+		t.Errorf("expected corrupt entry to reach the backend, got %v", err)
+	}
+}
+
+// modelMock is a mock that reports model settings.
+type modelMock struct{ *MockProvider }
+
+func (modelMock) Model() string        { return "qwen" }
+func (modelMock) Temperature() float64 { return 0.7 }
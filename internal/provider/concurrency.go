@@ -0,0 +1,59 @@
+package provider
+
+import "context"
+
+// ConcurrencyLimit caps how many requests run against one backend at a time. Providers
+// created by the same factory share it, so every mysis on that provider queues on the
+// same slots. A nil limit never blocks.
+type ConcurrencyLimit struct {
+	slots chan struct{}
+}
+
+// NewConcurrencyLimit returns a limit of n simultaneous requests, or nil for no limit.
+func NewConcurrencyLimit(n int) *ConcurrencyLimit {
+	if n <= 0 {
+		return nil
+	}
+	return &ConcurrencyLimit{slots: make(chan struct{}, n)}
+}
+
+// ConcurrencyLimited is implemented by providers that cap their concurrent requests.
+type ConcurrencyLimited interface {
+	ConcurrencyLimit() *ConcurrencyLimit
+}
+
+type heldSlotKey struct{}
+
+// AcquireSlot waits for a slot in p's concurrency limit, if it has one, so callers can
+// queue for the backend before taking any other shared slot. Requests p makes with the
+// returned context use the held slot instead of waiting for another.
+func AcquireSlot(ctx context.Context, p Provider) (context.Context, func(), error) {
+	limited, ok := p.(ConcurrencyLimited)
+	if !ok || limited.ConcurrencyLimit() == nil {
+		return ctx, func() {}, nil
+	}
+	limit := limited.ConcurrencyLimit()
+	release, err := limit.Acquire(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return context.WithValue(ctx, heldSlotKey{}, limit), release, nil
+}
+
+// Acquire blocks until a slot is free or ctx is done. The returned release must be
+// called once the request finishes. It returns at once if ctx already holds a slot
+// of l from AcquireSlot.
+func (l *ConcurrencyLimit) Acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if held, _ := ctx.Value(heldSlotKey{}).(*ConcurrencyLimit); held == l {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
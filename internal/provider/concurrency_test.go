@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentSharedAcrossProviders(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"role": "assistant", "content": "ok"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	// Each mysis gets its own provider from the factory; the limit spans them all
+	factory := NewOllamaFactory("ollama", server.URL).WithMaxConcurrent(2)
+	providers := []Provider{factory.Create("qwen3:8b", 0.7), factory.Create("qwen3:8b", 0.7), factory.Create("qwen3:4b", 0.7)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 9; i++ {
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+			if _, err := p.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}); err != nil {
+				t.Errorf("Chat() error: %v", err)
+			}
+		}(providers[i%len(providers)])
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak concurrent requests = %d, want at most 2", got)
+	}
+}
+
+func TestConcurrencyLimitAcquire(t *testing.T) {
+	var unlimited *ConcurrencyLimit
+	if NewConcurrencyLimit(0) != nil {
+		t.Error("expected no limit for 0")
+	}
+	release, err := unlimited.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() on no limit error: %v", err)
+	}
+	release()
+
+	limit := NewConcurrencyLimit(1)
+	release, err = limit.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+
+	// A full limit waits until ctx ends
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limit.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded while the slot is taken, got %v", err)
+	}
+
+	release()
+	release, err = limit.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() after release error: %v", err)
+	}
+	release()
+}
+
+func TestAcquireSlotHeldByContext(t *testing.T) {
+	limit := NewConcurrencyLimit(1)
+	p := NewOllama("http://localhost", "qwen3:8b").WithConcurrencyLimit(limit)
+
+	ctx, release, err := AcquireSlot(context.Background(), p)
+	if err != nil {
+		t.Fatalf("AcquireSlot() error: %v", err)
+	}
+
+	// Requests made under the held slot don't wait for another
+	inner, err := limit.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire() with a held slot error: %v", err)
+	}
+	inner()
+
+	// Everyone else still waits for it
+	waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limit.Acquire(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded while the slot is held, got %v", err)
+	}
+	release()
+
+	// Providers without a limit hand back the context as is
+	plain := NewMock("plain", "ok")
+	if got, release, err := AcquireSlot(ctx, plain); err != nil || got != ctx {
+		t.Errorf("expected AcquireSlot() on an unlimited provider to be a no-op, got err=%v", err)
+	} else {
+		release()
+	}
+}
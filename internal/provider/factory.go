@@ -1,8 +1,18 @@
 package provider
 
+import (
+	"net/http"
+	"time"
+)
+
 type OllamaFactory struct {
-	name     string
-	endpoint string
+	name      string
+	endpoint  string
+	textOnly  bool
+	timeout   time.Duration
+	autoPull  bool
+	transport http.RoundTripper
+	limit     *ConcurrencyLimit // Shared by every created provider
 }
 
 func NewOllamaFactory(name string, endpoint string) *OllamaFactory {
@@ -12,16 +22,55 @@ func NewOllamaFactory(name string, endpoint string) *OllamaFactory {
 	}
 }
 
+// WithTextOnly makes created providers use the prompt-based tool shim.
+func (f *OllamaFactory) WithTextOnly(textOnly bool) *OllamaFactory {
+	f.textOnly = textOnly
+	return f
+}
+
+// WithRequestTimeout sets the per-turn timeout for created providers.
+func (f *OllamaFactory) WithRequestTimeout(timeout time.Duration) *OllamaFactory {
+	f.timeout = timeout
+	return f
+}
+
+// WithAutoPull makes created providers pull a missing model and retry.
+func (f *OllamaFactory) WithAutoPull(autoPull bool) *OllamaFactory {
+	f.autoPull = autoPull
+	return f
+}
+
+// WithMaxConcurrent caps simultaneous requests across all created providers (0 = unlimited).
+func (f *OllamaFactory) WithMaxConcurrent(n int) *OllamaFactory {
+	f.limit = NewConcurrencyLimit(n)
+	return f
+}
+
+// WithHTTPTransport sends created providers' requests through rt (nil = default).
+func (f *OllamaFactory) WithHTTPTransport(rt http.RoundTripper) *OllamaFactory {
+	f.transport = rt
+	return f
+}
+
 func (f *OllamaFactory) Name() string { return f.name }
 
 func (f *OllamaFactory) Create(model string, temperature float64) Provider {
-	return NewOllamaWithTemp(f.name, f.endpoint, model, temperature)
+	return NewOllamaWithTemp(f.name, f.endpoint, model, temperature).
+		WithTextOnly(f.textOnly).
+		WithRequestTimeout(f.timeout).
+		WithAutoPull(f.autoPull).
+		WithHTTPTransport(f.transport).
+		WithConcurrencyLimit(f.limit)
 }
 
 type OpenCodeFactory struct {
-	name     string
-	endpoint string
-	apiKey   string
+	name      string
+	endpoint  string
+	apiKey    string
+	textOnly  bool
+	timeout   time.Duration
+	transport http.RoundTripper
+	limit     *ConcurrencyLimit // Shared by every created provider
 }
 
 func NewOpenCodeFactory(name string, endpoint, apiKey string) *OpenCodeFactory {
@@ -32,8 +81,36 @@ func NewOpenCodeFactory(name string, endpoint, apiKey string) *OpenCodeFactory {
 	}
 }
 
+// WithTextOnly makes created providers use the prompt-based tool shim.
+func (f *OpenCodeFactory) WithTextOnly(textOnly bool) *OpenCodeFactory {
+	f.textOnly = textOnly
+	return f
+}
+
+// WithRequestTimeout sets the per-turn timeout for created providers.
+func (f *OpenCodeFactory) WithRequestTimeout(timeout time.Duration) *OpenCodeFactory {
+	f.timeout = timeout
+	return f
+}
+
+// WithMaxConcurrent caps simultaneous requests across all created providers (0 = unlimited).
+func (f *OpenCodeFactory) WithMaxConcurrent(n int) *OpenCodeFactory {
+	f.limit = NewConcurrencyLimit(n)
+	return f
+}
+
+// WithHTTPTransport sends created providers' requests through rt (nil = default).
+func (f *OpenCodeFactory) WithHTTPTransport(rt http.RoundTripper) *OpenCodeFactory {
+	f.transport = rt
+	return f
+}
+
 func (f *OpenCodeFactory) Name() string { return f.name }
 
 func (f *OpenCodeFactory) Create(model string, temperature float64) Provider {
-	return NewOpenCodeWithTemp(f.name, f.endpoint, model, f.apiKey, temperature)
+	return NewOpenCodeWithTemp(f.name, f.endpoint, model, f.apiKey, temperature).
+		WithTextOnly(f.textOnly).
+		WithRequestTimeout(f.timeout).
+		WithHTTPTransport(f.transport).
+		WithConcurrencyLimit(f.limit)
 }
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -32,6 +34,94 @@ func TestOllamaChatReturnsStatusError(t *testing.T) {
 	}
 }
 
+func TestOllamaModelNotFound(t *testing.T) {
+	const notFound = `{"error":{"message":"model \"qwen3:8b\" not found, try pulling it first","type":"api_error"}}`
+
+	t.Run("reports bad request without auto_pull", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/pull" {
+				t.Error("pulled without auto_pull")
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(notFound))
+		}))
+		defer server.Close()
+
+		provider := NewOllama("http://unused", "qwen3:8b")
+		provider.baseURL = server.URL + "/v1"
+		provider.httpClient = server.Client()
+
+		_, err := provider.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}})
+		if !errors.Is(err, ErrBadRequest) {
+			t.Fatalf("expected ErrBadRequest, got %v", err)
+		}
+		if !strings.Contains(err.Error(), `ollama pull qwen3:8b`) {
+			t.Errorf("expected pull instructions, got %v", err)
+		}
+	})
+
+	t.Run("pulls and retries with auto_pull", func(t *testing.T) {
+		var pulled atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/pull":
+				pulled.Store(true)
+				for _, line := range []string{
+					`{"status":"pulling manifest"}`,
+					`{"status":"pulling abc","total":100,"completed":50}`,
+					`{"status":"pulling abc","total":100,"completed":100}`,
+					`{"status":"success"}`,
+				} {
+					_, _ = w.Write([]byte(line + "\n"))
+				}
+			case "/v1/chat/completions":
+				if !pulled.Load() {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(notFound))
+					return
+				}
+				_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ready"}}]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		provider := NewOllama("http://unused", "qwen3:8b").WithAutoPull(true)
+		provider.baseURL = server.URL + "/v1"
+		provider.httpClient = server.Client()
+
+		content, err := provider.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}})
+		if err != nil {
+			t.Fatalf("Chat() error: %v", err)
+		}
+		if content != "ready" {
+			t.Errorf("expected retried response, got %q", content)
+		}
+	})
+
+	t.Run("reports a failed pull", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/pull" {
+				_, _ = w.Write([]byte(`{"error":"pull model manifest: file does not exist"}` + "\n"))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(notFound))
+		}))
+		defer server.Close()
+
+		provider := NewOllama("http://unused", "qwen3:8b").WithAutoPull(true)
+		provider.baseURL = server.URL + "/v1"
+		provider.httpClient = server.Client()
+
+		_, err := provider.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}})
+		if err == nil || !strings.Contains(err.Error(), "file does not exist") {
+			t.Fatalf("expected pull error, got %v", err)
+		}
+	})
+}
+
 func TestOllamaChatReturnsServerError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusServiceUnavailable)
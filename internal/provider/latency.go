@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBucketsPerDoubling sets the histogram resolution: bucket bounds grow by
+// 2^(1/4), so a reported percentile is within about 19% of the true latency.
+const latencyBucketsPerDoubling = 4
+
+// latencyBuckets covers 1ms to about 4 hours; slower calls land in the last bucket.
+const latencyBuckets = 96
+
+// LatencyHistogram counts call latencies in log-scale buckets. Observe takes no
+// lock and doesn't allocate, so it is cheap on the chat path.
+type LatencyHistogram struct {
+	counts [latencyBuckets]atomic.Uint64
+	total  atomic.Uint64
+}
+
+// Observe records one call latency.
+func (h *LatencyHistogram) Observe(d time.Duration) {
+	h.counts[latencyBucket(d)].Add(1)
+	h.total.Add(1)
+}
+
+// Count returns how many latencies were observed.
+func (h *LatencyHistogram) Count() uint64 {
+	return h.total.Load()
+}
+
+// Percentile returns the upper bound of the bucket holding quantile q (0 < q <= 1),
+// or 0 when nothing was observed.
+func (h *LatencyHistogram) Percentile(q float64) time.Duration {
+	total := h.total.Load()
+	if total == 0 {
+		return 0
+	}
+	rank := max(uint64(math.Ceil(q*float64(total))), 1)
+	var seen uint64
+	for i := range h.counts {
+		seen += h.counts[i].Load()
+		if seen >= rank {
+			return latencyBucketBound(i)
+		}
+	}
+	return latencyBucketBound(latencyBuckets - 1)
+}
+
+// latencyBucket returns the index of the bucket d falls in.
+func latencyBucket(d time.Duration) int {
+	ms := float64(d) / float64(time.Millisecond)
+	if ms <= 1 {
+		return 0
+	}
+	return min(int(math.Ceil(math.Log2(ms)*latencyBucketsPerDoubling)), latencyBuckets-1)
+}
+
+// latencyBucketBound returns the upper bound of bucket i.
+func latencyBucketBound(i int) time.Duration {
+	return time.Duration(math.Exp2(float64(i)/latencyBucketsPerDoubling) * float64(time.Millisecond))
+}
+
+// LatencySummary reports a provider's call latency percentiles.
+type LatencySummary struct {
+	Provider string
+	Count    uint64
+	P50      time.Duration
+	P95      time.Duration
+	P99      time.Duration
+}
+
+// LatencyTracker keeps a latency histogram per provider name.
+type LatencyTracker struct {
+	mu         sync.RWMutex
+	histograms map[string]*LatencyHistogram
+}
+
+// NewLatencyTracker creates an empty tracker.
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{histograms: make(map[string]*LatencyHistogram)}
+}
+
+// Observe records a call latency for the named provider.
+func (t *LatencyTracker) Observe(name string, d time.Duration) {
+	t.mu.RLock()
+	h := t.histograms[name]
+	t.mu.RUnlock()
+
+	if h == nil {
+		t.mu.Lock()
+		if h = t.histograms[name]; h == nil {
+			h = &LatencyHistogram{}
+			t.histograms[name] = h
+		}
+		t.mu.Unlock()
+	}
+	h.Observe(d)
+}
+
+// Summary returns the named provider's percentiles, or false if none were observed.
+func (t *LatencyTracker) Summary(name string) (LatencySummary, bool) {
+	t.mu.RLock()
+	h := t.histograms[name]
+	t.mu.RUnlock()
+
+	if h == nil || h.Count() == 0 {
+		return LatencySummary{}, false
+	}
+	return summarize(name, h), true
+}
+
+// Summaries returns the percentiles of every observed provider, sorted by name.
+func (t *LatencyTracker) Summaries() []LatencySummary {
+	t.mu.RLock()
+	summaries := make([]LatencySummary, 0, len(t.histograms))
+	for name, h := range t.histograms {
+		summaries = append(summaries, summarize(name, h))
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Provider < summaries[j].Provider })
+	return summaries
+}
+
+// Reset drops every observation, starting a fresh measurement window.
+func (t *LatencyTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.histograms = make(map[string]*LatencyHistogram)
+}
+
+func summarize(name string, h *LatencyHistogram) LatencySummary {
+	return LatencySummary{
+		Provider: name,
+		Count:    h.Count(),
+		P50:      h.Percentile(0.50),
+		P95:      h.Percentile(0.95),
+		P99:      h.Percentile(0.99),
+	}
+}
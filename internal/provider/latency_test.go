@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	var h LatencyHistogram
+	if h.Percentile(0.5) != 0 {
+		t.Error("expected 0 for an empty histogram")
+	}
+
+	// 90 fast calls, 9 slow, 1 very slow
+	for i := 0; i < 90; i++ {
+		h.Observe(100 * time.Millisecond)
+	}
+	for i := 0; i < 9; i++ {
+		h.Observe(2 * time.Second)
+	}
+	h.Observe(30 * time.Second)
+
+	if h.Count() != 100 {
+		t.Errorf("Count() = %d, want 100", h.Count())
+	}
+	tests := []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.50, 100 * time.Millisecond},
+		{0.95, 2 * time.Second},
+		{0.99, 2 * time.Second},
+		{1.00, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		got := h.Percentile(tt.q)
+		// Bucket bounds are within 2^(1/4) of the observed latency
+		if got < tt.want || float64(got) > float64(tt.want)*1.19 {
+			t.Errorf("Percentile(%v) = %s, want about %s", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestLatencyBucketBounds(t *testing.T) {
+	if got := latencyBucket(0); got != 0 {
+		t.Errorf("latencyBucket(0) = %d, want 0", got)
+	}
+	if got := latencyBucket(24 * time.Hour); got != latencyBuckets-1 {
+		t.Errorf("expected very slow calls in the last bucket, got %d", got)
+	}
+	for _, d := range []time.Duration{3 * time.Millisecond, 850 * time.Millisecond, 12 * time.Second} {
+		if bound := latencyBucketBound(latencyBucket(d)); bound < d {
+			t.Errorf("bucket bound %s is below its latency %s", bound, d)
+		}
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := NewLatencyTracker()
+	tracker.Observe("zen", time.Second)
+	tracker.Observe("ollama", 200*time.Millisecond)
+	tracker.Observe("ollama", 200*time.Millisecond)
+
+	summaries := tracker.Summaries()
+	if len(summaries) != 2 || summaries[0].Provider != "ollama" || summaries[0].Count != 2 {
+		t.Fatalf("unexpected summaries: %+v", summaries)
+	}
+	if s, ok := tracker.Summary("zen"); !ok || s.P50 < time.Second {
+		t.Errorf("unexpected zen summary: %+v (ok=%v)", s, ok)
+	}
+	if _, ok := tracker.Summary("missing"); ok {
+		t.Error("expected no summary for an unobserved provider")
+	}
+
+	tracker.Reset()
+	if len(tracker.Summaries()) != 0 {
+		t.Error("expected no summaries after reset")
+	}
+	if _, ok := tracker.Summary("zen"); ok {
+		t.Error("expected reset to drop zen")
+	}
+}
+
+func TestLatencyTrackerObserveDoesNotAllocate(t *testing.T) {
+	tracker := NewLatencyTracker()
+	tracker.Observe("ollama", time.Second)
+	allocs := testing.AllocsPerRun(100, func() {
+		tracker.Observe("ollama", 250*time.Millisecond)
+	})
+	if allocs != 0 {
+		t.Errorf("Observe() allocated %v times per call, want 0", allocs)
+	}
+}
@@ -2,10 +2,22 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 )
 
+// ErrMockScriptExhausted is returned when a scripted mock has no steps left.
+var ErrMockScriptExhausted = errors.New("mock script exhausted")
+
+// MockStep is one scripted provider response: text, tool calls, or an error.
+type MockStep struct {
+	Response  string
+	ToolCalls []ToolCall
+	Reasoning string
+	Err       error
+}
+
 // MockProvider is a test provider that returns predefined responses.
 type MockProvider struct {
 	mu sync.RWMutex
@@ -16,7 +28,21 @@ type MockProvider struct {
 	streamErr error
 	chatErr   error
 	reasoning string
+	usage     Usage
 	delay     time.Duration
+	pingErr   error
+	pings     int
+	textOnly  bool
+	timeout   time.Duration
+	messages  []Message // Messages from the most recent Chat call
+	tools     []Tool    // Tools from the most recent ChatWithTools call
+	seed      *int64    // Seed from the most recent Chat or ChatWithTools call
+
+	// Scripted mode: each Chat/ChatWithTools call consumes the next step.
+	// scripted is fixed at construction.
+	scripted bool
+	script   []MockStep
+	requests [][]Message // Messages from every scripted call, in order
 }
 
 // NewMock creates a new mock provider.
@@ -27,6 +53,41 @@ func NewMock(name, response string) *MockProvider {
 	}
 }
 
+// NewScriptedMock creates a mock that returns the given steps in order, one per
+// Chat or ChatWithTools call, and returns ErrMockScriptExhausted once they run out.
+func NewScriptedMock(steps []MockStep) *MockProvider {
+	return &MockProvider{
+		name:     "mock",
+		scripted: true,
+		script:   steps,
+	}
+}
+
+// Requests returns the messages sent on each scripted call, in order.
+func (p *MockProvider) Requests() [][]Message {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([][]Message(nil), p.requests...)
+}
+
+// RemainingSteps returns how many scripted steps have not been consumed.
+func (p *MockProvider) RemainingSteps() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.script)
+}
+
+// nextStep consumes the next scripted step. Caller must hold p.mu.
+func (p *MockProvider) nextStep(messages []Message) (MockStep, error) {
+	p.requests = append(p.requests, messages)
+	if len(p.script) == 0 {
+		return MockStep{}, ErrMockScriptExhausted
+	}
+	step := p.script[0]
+	p.script = p.script[1:]
+	return step, step.Err
+}
+
 type MockFactory struct {
 	name     string
 	response string
@@ -73,6 +134,14 @@ func (p *MockProvider) WithReasoning(reasoning string) *MockProvider {
 	return p
 }
 
+// WithUsage sets the token usage to report from ChatWithTools.
+func (p *MockProvider) WithUsage(usage Usage) *MockProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.usage = usage
+	return p
+}
+
 func (p *MockProvider) SetDelay(delay time.Duration) *MockProvider {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -88,6 +157,80 @@ func (p *MockProvider) WithResponse(response string) *MockProvider {
 	return p
 }
 
+// WithTextOnly makes the mock report no native tool support.
+func (p *MockProvider) WithTextOnly(textOnly bool) *MockProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.textOnly = textOnly
+	return p
+}
+
+// SupportsTools reports whether the mock accepts native tool definitions.
+func (p *MockProvider) SupportsTools() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.textOnly
+}
+
+// WithRequestTimeout sets the per-turn timeout the mock reports.
+func (p *MockProvider) WithRequestTimeout(timeout time.Duration) *MockProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = timeout
+	return p
+}
+
+// RequestTimeout returns the per-turn timeout set with WithRequestTimeout.
+func (p *MockProvider) RequestTimeout() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.timeout
+}
+
+// LastChatMessages returns the messages passed to the most recent Chat call.
+func (p *MockProvider) LastChatMessages() []Message {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.messages
+}
+
+// LastSeed returns the seed the most recent Chat or ChatWithTools call carried (nil = none).
+func (p *MockProvider) LastSeed() *int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.seed
+}
+
+// LastTools returns the tools passed to the most recent ChatWithTools call.
+func (p *MockProvider) LastTools() []Tool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.tools
+}
+
+// WithPingError sets an error to return from Ping.
+func (p *MockProvider) WithPingError(err error) *MockProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pingErr = err
+	return p
+}
+
+// Ping records the warm-up call and returns the configured error.
+func (p *MockProvider) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pings++
+	return p.pingErr
+}
+
+// PingCount returns how many times Ping was called.
+func (p *MockProvider) PingCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.pings
+}
+
 // Name returns the provider identifier.
 func (p *MockProvider) Name() string {
 	return p.name
@@ -99,8 +242,17 @@ func (p *MockProvider) Chat(ctx context.Context, messages []Message) (string, er
 		return "", err
 	}
 
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = messages
+	p.seed = SeedFromContext(ctx)
+	if p.scripted {
+		step, err := p.nextStep(messages)
+		if err != nil {
+			return "", err
+		}
+		return step.Response, nil
+	}
 	if p.chatErr != nil {
 		return "", p.chatErr
 	}
@@ -113,8 +265,27 @@ func (p *MockProvider) ChatWithTools(ctx context.Context, messages []Message, to
 		return nil, err
 	}
 
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	if p.scripted {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.tools = tools
+		p.seed = SeedFromContext(ctx)
+		step, err := p.nextStep(messages)
+		if err != nil {
+			return nil, err
+		}
+		return &ChatResponse{
+			Content:   step.Response,
+			ToolCalls: step.ToolCalls,
+			Reasoning: step.Reasoning,
+			Usage:     p.usage,
+		}, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tools = tools
+	p.seed = SeedFromContext(ctx)
 	if p.chatErr != nil {
 		return nil, p.chatErr
 	}
@@ -122,9 +293,15 @@ func (p *MockProvider) ChatWithTools(ctx context.Context, messages []Message, to
 		Content:   p.response,
 		ToolCalls: p.toolCalls,
 		Reasoning: p.reasoning,
+		Usage:     p.usage,
 	}, nil
 }
 
+// CountTokens returns the heuristic estimate.
+func (p *MockProvider) CountTokens(ctx context.Context, messages []Message) (int, error) {
+	return EstimateTokens(messages), nil
+}
+
 // Stream returns the predefined response as a single chunk.
 func (p *MockProvider) Stream(ctx context.Context, messages []Message) (<-chan StreamChunk, error) {
 	if err := p.waitDelay(ctx); err != nil {
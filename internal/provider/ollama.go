@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -23,10 +24,18 @@ type OllamaProvider struct {
 	httpClient  *http.Client
 	model       string
 	temperature float64
+	textOnly    bool              // Model lacks native tool calling
+	timeout     time.Duration     // Per-turn timeout (0 = caller's default)
+	autoPull    bool              // Pull a missing model and retry the request
+	noTokenizer atomic.Bool       // Server has no /api/tokenize; estimate instead
+	limit       *ConcurrencyLimit // Shared with the factory's other providers (nil = unlimited)
 }
 
 var ollamaRetryDelays = []time.Duration{5 * time.Second, 10 * time.Second, 15 * time.Second}
 
+// ollamaWarmUpKeepAlive is how long Ollama keeps the model loaded after a warm-up ping.
+const ollamaWarmUpKeepAlive = "10m"
+
 // NewOllama creates a new Ollama provider.
 // Ollama exposes an OpenAI-compatible API at /v1.
 func NewOllama(endpoint, model string) *OllamaProvider {
@@ -37,12 +46,15 @@ func NewOllamaWithTemp(name string, endpoint, model string, temperature float64)
 	config := openai.DefaultConfig("")
 	baseURL := strings.TrimRight(endpoint, "/") + "/v1"
 	config.BaseURL = baseURL
+	// One client for both the OpenAI-compatible API and native endpoints
+	httpClient := &http.Client{}
+	config.HTTPClient = httpClient
 
 	return &OllamaProvider{
 		name:        name,
 		client:      openai.NewClientWithConfig(config),
 		baseURL:     baseURL,
-		httpClient:  &http.Client{},
+		httpClient:  httpClient,
 		model:       model,
 		temperature: temperature,
 	}
@@ -53,12 +65,98 @@ func (p *OllamaProvider) Name() string {
 	return p.name
 }
 
+// Model returns the model requests are sent to.
+func (p *OllamaProvider) Model() string {
+	return p.model
+}
+
+// Temperature returns the sampling temperature requests use.
+func (p *OllamaProvider) Temperature() float64 {
+	return p.temperature
+}
+
+// WithTextOnly marks the model as lacking native tool calling.
+func (p *OllamaProvider) WithTextOnly(textOnly bool) *OllamaProvider {
+	p.textOnly = textOnly
+	return p
+}
+
+// SupportsTools reports whether the model accepts native tool definitions.
+func (p *OllamaProvider) SupportsTools() bool {
+	return !p.textOnly
+}
+
+// WithHTTPTransport sends every request through rt (nil = http.DefaultTransport).
+func (p *OllamaProvider) WithHTTPTransport(rt http.RoundTripper) *OllamaProvider {
+	p.httpClient.Transport = rt
+	return p
+}
+
+// WithRequestTimeout sets the per-turn timeout (0 = caller's default).
+func (p *OllamaProvider) WithRequestTimeout(timeout time.Duration) *OllamaProvider {
+	p.timeout = timeout
+	return p
+}
+
+// RequestTimeout returns the configured per-turn timeout.
+func (p *OllamaProvider) RequestTimeout() time.Duration {
+	return p.timeout
+}
+
+// WithConcurrencyLimit makes chat requests wait for a slot in limit (nil = unlimited).
+func (p *OllamaProvider) WithConcurrencyLimit(limit *ConcurrencyLimit) *OllamaProvider {
+	p.limit = limit
+	return p
+}
+
+// ConcurrencyLimit returns the limit chat requests wait on, or nil if there is none.
+func (p *OllamaProvider) ConcurrencyLimit() *ConcurrencyLimit {
+	return p.limit
+}
+
+// WithAutoPull makes the provider pull a model Ollama reports as missing, then retry
+// the request once. The pull counts against the request's context deadline.
+func (p *OllamaProvider) WithAutoPull(autoPull bool) *OllamaProvider {
+	p.autoPull = autoPull
+	return p
+}
+
+// ModelNotFoundError is returned when Ollama doesn't have the model pulled.
+// It is classified as ErrBadRequest.
+type ModelNotFoundError struct {
+	Provider string
+	Model    string
+	Status   int
+	Body     string
+}
+
+func (e *ModelNotFoundError) Error() string {
+	return fmt.Sprintf("model %q is not pulled (chat completion status %d: %s) - run \"ollama pull %s\" or set auto_pull = true under [providers.%s]",
+		e.Model, e.Status, e.Body, e.Model, e.Provider)
+}
+
+// Is reports ModelNotFoundError as ErrBadRequest.
+func (e *ModelNotFoundError) Is(target error) bool {
+	return target == ErrBadRequest
+}
+
+// isOllamaModelNotFound reports whether a response is Ollama's "model not found, try
+// pulling it first" error.
+func isOllamaModelNotFound(status int, body []byte) bool {
+	if status != http.StatusNotFound {
+		return false
+	}
+	text := strings.ToLower(string(body))
+	return strings.Contains(text, "model") && strings.Contains(text, "not found")
+}
+
 // Chat sends messages and returns the complete response.
 func (p *OllamaProvider) Chat(ctx context.Context, messages []Message) (string, error) {
 	resp, err := p.createChatCompletion(ctx, ollamaChatRequest{
 		Model:       p.model,
 		Messages:    mergeConsecutiveSystemMessagesOllama(toOllamaMessages(messages)),
 		Temperature: float32(p.temperature),
+		Seed:        SeedFromContext(ctx),
 	})
 	if err != nil {
 		return "", err
@@ -78,6 +176,7 @@ func (p *OllamaProvider) ChatWithTools(ctx context.Context, messages []Message,
 		Messages:    mergeConsecutiveSystemMessagesOllama(toOllamaMessages(messages)),
 		Tools:       toOllamaTools(tools),
 		Temperature: float32(p.temperature),
+		Seed:        SeedFromContext(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -91,6 +190,7 @@ func (p *OllamaProvider) ChatWithTools(ctx context.Context, messages []Message,
 	result := &ChatResponse{
 		Content:   choice.Message.Content,
 		Reasoning: choice.Message.reasoning(),
+		Usage:     resp.Usage.toUsage(),
 	}
 
 	// Extract tool calls if present
@@ -108,8 +208,148 @@ func (p *OllamaProvider) ChatWithTools(ctx context.Context, messages []Message,
 	return result, nil
 }
 
+// ListModels returns the IDs of the models served by the endpoint.
+func (p *OllamaProvider) ListModels(ctx context.Context) ([]string, error) {
+	list, err := p.client.ListModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list models: %w", err)
+	}
+	models := make([]string, len(list.Models))
+	for i, m := range list.Models {
+		models[i] = m.ID
+	}
+	return models, nil
+}
+
+// Ping loads the model into memory by sending an empty prompt to Ollama's native
+// /api/generate endpoint. It does not generate any tokens.
+func (p *OllamaProvider) Ping(ctx context.Context) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":      p.model,
+		"prompt":     "",
+		"stream":     false,
+		"keep_alive": ollamaWarmUpKeepAlive,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(p.baseURL, "/v1") + "/api/generate"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("warm-up request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("warm-up status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	io.Copy(io.Discard, resp.Body)
+
+	log.Info().
+		Str("provider", "ollama").
+		Str("model", p.model).
+		Dur("duration", time.Since(start)).
+		Msg("Ollama model warmed up")
+	return nil
+}
+
+// ollamaPullProgressStep is how often, in percent, download progress is logged during a pull.
+const ollamaPullProgressStep = 10
+
+// ollamaPullStatus is one line of Ollama's streamed /api/pull response.
+type ollamaPullStatus struct {
+	Status    string `json:"status"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// pullModel downloads the model via Ollama's native /api/pull endpoint, logging progress.
+// It returns once the pull succeeds, fails, or ctx is done.
+func (p *OllamaProvider) pullModel(ctx context.Context) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":  p.model,
+		"stream": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(p.baseURL, "/v1") + "/api/pull"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	log.Info().
+		Str("provider", "ollama").
+		Str("model", p.model).
+		Msg("Ollama model not found - pulling")
+
+	start := time.Now()
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("pull request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("pull status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	lastStatus := ""
+	lastPercent := -ollamaPullProgressStep
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var status ollamaPullStatus
+		if err := decoder.Decode(&status); err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("pull ended before completing")
+			}
+			return fmt.Errorf("read pull progress: %w", err)
+		}
+		if status.Error != "" {
+			return errors.New(status.Error)
+		}
+		if status.Status == "success" {
+			log.Info().
+				Str("provider", "ollama").
+				Str("model", p.model).
+				Dur("duration", time.Since(start)).
+				Msg("Ollama model pulled")
+			return nil
+		}
+
+		// Log each new phase, and download progress every ollamaPullProgressStep percent
+		if status.Status != lastStatus {
+			lastStatus = status.Status
+			lastPercent = -ollamaPullProgressStep
+			log.Info().Str("provider", "ollama").Str("model", p.model).Str("status", status.Status).Msg("Ollama pull progress")
+		}
+		if status.Total > 0 {
+			percent := int(status.Completed * 100 / status.Total)
+			if percent >= lastPercent+ollamaPullProgressStep {
+				lastPercent = percent - percent%ollamaPullProgressStep
+				log.Info().Str("provider", "ollama").Str("model", p.model).Str("status", status.Status).Int("percent", percent).Msg("Ollama pull progress")
+			}
+		}
+	}
+}
+
 type chatCompletionResponse struct {
 	Choices []chatCompletionChoice `json:"choices"`
+	Usage   chatCompletionUsage    `json:"usage"`
 }
 
 type chatCompletionChoice struct {
@@ -144,6 +384,7 @@ type ollamaChatRequest struct {
 	Messages    []ollamaReqMessage `json:"messages"`
 	Tools       []ollamaReqTool    `json:"tools,omitempty"`
 	Temperature float32            `json:"temperature,omitempty"`
+	Seed        *int64             `json:"seed,omitempty"` // The OpenAI-compatible endpoint's form of options.seed
 }
 
 type ollamaReqMessage struct {
@@ -192,12 +433,32 @@ func (p *OllamaProvider) createChatCompletion(ctx context.Context, req ollamaCha
 		return nil, err
 	}
 
+	release, err := p.limit.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := p.sendChatCompletion(ctx, body, len(req.Messages))
+	var notFound *ModelNotFoundError
+	if !p.autoPull || !errors.As(err, &notFound) {
+		return resp, err
+	}
+
+	if pullErr := p.pullModel(ctx); pullErr != nil {
+		return nil, fmt.Errorf("auto-pull model %q: %w", p.model, pullErr)
+	}
+	return p.sendChatCompletion(ctx, body, len(req.Messages))
+}
+
+// sendChatCompletion posts a marshaled chat request, retrying transient errors.
+func (p *OllamaProvider) sendChatCompletion(ctx context.Context, body []byte, messageCount int) (*chatCompletionResponse, error) {
 	url := p.baseURL + "/chat/completions"
 
 	log.Debug().
 		Str("provider", "ollama").
 		Str("model", p.model).
-		Int("messages", len(req.Messages)).
+		Int("messages", messageCount).
 		Int("body_bytes", len(body)).
 		Msg("Sending request to Ollama")
 
@@ -269,6 +530,15 @@ func (p *OllamaProvider) createChatCompletion(ctx context.Context, req ollamaCha
 				Str("body", string(payload)).
 				Msg("Ollama non-retryable error")
 
+			if isOllamaModelNotFound(resp.StatusCode, payload) {
+				return nil, &ModelNotFoundError{
+					Provider: p.name,
+					Model:    p.model,
+					Status:   resp.StatusCode,
+					Body:     strings.TrimSpace(string(payload)),
+				}
+			}
+
 			return nil, fmt.Errorf("chat completion status %d: %s", resp.StatusCode, strings.TrimSpace(string(payload)))
 		}
 
@@ -304,19 +574,79 @@ func (p *OllamaProvider) createChatCompletion(ctx context.Context, req ollamaCha
 	return nil, fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
 }
 
+// CountTokens tokenizes messages with the model's tokenizer through Ollama's native
+// /api/tokenize endpoint. Servers without the endpoint get the heuristic estimate, and
+// are not asked again.
+func (p *OllamaProvider) CountTokens(ctx context.Context, messages []Message) (int, error) {
+	if p.noTokenizer.Load() {
+		return EstimateTokens(messages), nil
+	}
+
+	texts := make([]string, len(messages))
+	for i, msg := range messages {
+		texts[i] = messageText(msg)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"model":   p.model,
+		"content": strings.Join(texts, "\n"),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	url := strings.TrimSuffix(p.baseURL, "/v1") + "/api/tokenize"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("tokenize request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		// A missing model is also a 404, but the endpoint exists
+		if (resp.StatusCode == http.StatusNotFound && !isOllamaModelNotFound(resp.StatusCode, respBody)) ||
+			resp.StatusCode == http.StatusMethodNotAllowed {
+			p.noTokenizer.Store(true)
+			log.Debug().Str("provider", p.name).Msg("Ollama has no tokenize endpoint - estimating tokens")
+			return EstimateTokens(messages), nil
+		}
+		return 0, fmt.Errorf("tokenize status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		Tokens []int `json:"tokens"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decode tokenize response: %w", err)
+	}
+	return len(result.Tokens), nil
+}
+
 // Stream sends messages and returns a channel that streams response chunks.
 func (p *OllamaProvider) Stream(ctx context.Context, messages []Message) (<-chan StreamChunk, error) {
+	release, err := p.limit.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
 	stream, err := p.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
 		Model:       p.model,
 		Messages:    toOpenAIMessages(messages),
 		Temperature: float32(p.temperature),
 	})
 	if err != nil {
+		release()
 		return nil, err
 	}
 
 	ch := make(chan StreamChunk)
 	go func() {
+		defer release()
 		defer close(ch)
 		defer stream.Close()
 
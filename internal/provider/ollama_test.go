@@ -431,3 +431,201 @@ func TestOllama_SystemMessagesAtEnd(t *testing.T) {
 		t.Errorf("expected response to acknowledge final instruction, got %q", resp)
 	}
 }
+
+func TestOllama_UsageReported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		response := map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"role": "assistant", "content": "Answer"}},
+			},
+			"usage": map[string]interface{}{
+				"prompt_tokens":     42,
+				"completion_tokens": 7,
+				"total_tokens":      49,
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	baseURL := strings.TrimSuffix(server.URL, "/v1")
+	provider := NewOllama(baseURL, "test-model")
+
+	resp, err := provider.ChatWithTools(context.Background(), []Message{{Role: "user", Content: "Question?"}}, []Tool{{Name: "dummy"}})
+	if err != nil {
+		t.Fatalf("ChatWithTools() error: %v", err)
+	}
+
+	if resp.Usage.PromptTokens != 42 || resp.Usage.CompletionTokens != 7 {
+		t.Errorf("expected usage 42/7, got %+v", resp.Usage)
+	}
+	if resp.Usage.TotalTokens() != 49 {
+		t.Errorf("expected total 49, got %d", resp.Usage.TotalTokens())
+	}
+}
+
+func TestOllama_SeedInRequest(t *testing.T) {
+	var seeds []*int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Seed *int64 `json:"seed"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		seeds = append(seeds, req.Seed)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"role": "assistant", "content": "Answer"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	baseURL := strings.TrimSuffix(server.URL, "/v1")
+	provider := NewOllamaWithTemp("ollama", baseURL, "test-model", 0)
+	messages := []Message{{Role: "user", Content: "Question?"}}
+
+	if _, err := provider.ChatWithTools(WithSeed(context.Background(), 42), messages, []Tool{{Name: "dummy"}}); err != nil {
+		t.Fatalf("ChatWithTools() error: %v", err)
+	}
+	if _, err := provider.Chat(WithSeed(context.Background(), 0), messages); err != nil {
+		t.Fatalf("Chat() error: %v", err)
+	}
+	if _, err := provider.Chat(context.Background(), messages); err != nil {
+		t.Fatalf("Chat() error: %v", err)
+	}
+
+	if len(seeds) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(seeds))
+	}
+	if seeds[0] == nil || *seeds[0] != 42 {
+		t.Errorf("expected seed 42 in the ChatWithTools request, got %v", seeds[0])
+	}
+	if seeds[1] == nil || *seeds[1] != 0 {
+		t.Errorf("expected seed 0 to be sent, got %v", seeds[1])
+	}
+	if seeds[2] != nil {
+		t.Errorf("expected no seed without one on the context, got %d", *seeds[2])
+	}
+}
+
+func TestOllama_Ping(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test-model","response":"","done":true}`))
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL, "test-model")
+	if err := provider.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error: %v", err)
+	}
+
+	if gotPath != "/api/generate" {
+		t.Errorf("expected /api/generate, got %s", gotPath)
+	}
+	if gotBody["model"] != "test-model" || gotBody["prompt"] != "" {
+		t.Errorf("unexpected warm-up body: %v", gotBody)
+	}
+	if gotBody["keep_alive"] != ollamaWarmUpKeepAlive {
+		t.Errorf("expected keep_alive %s, got %v", ollamaWarmUpKeepAlive, gotBody["keep_alive"])
+	}
+}
+
+func TestOllama_PingError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"model not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL, "missing-model")
+	err := provider.Ping(context.Background())
+	if err == nil {
+		t.Fatal("expected error from Ping")
+	}
+	if !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected status in error, got %v", err)
+	}
+}
+
+func TestOllama_ListModels(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"object":"list","data":[{"id":"llama3:latest","object":"model"},{"id":"qwen3:8b","object":"model"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL, "llama3")
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error: %v", err)
+	}
+
+	if gotPath != "/v1/models" {
+		t.Errorf("expected /v1/models, got %s", gotPath)
+	}
+	if strings.Join(models, ",") != "llama3:latest,qwen3:8b" {
+		t.Errorf("unexpected models: %v", models)
+	}
+}
+
+func TestOllama_CountTokens(t *testing.T) {
+	var gotPath, gotContent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		gotContent = body["content"]
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tokens":[1,2,3,4,5]}`))
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL, "test-model")
+	messages := []Message{{Role: "system", Content: "Mine ore."}, {Role: "user", Content: "Go."}}
+	count, err := provider.CountTokens(context.Background(), messages)
+	if err != nil {
+		t.Fatalf("CountTokens() error: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("expected 5 tokens, got %d", count)
+	}
+	if gotPath != "/api/tokenize" || gotContent != "system: Mine ore.\nuser: Go." {
+		t.Errorf("unexpected tokenize request: path=%s content=%q", gotPath, gotContent)
+	}
+}
+
+func TestOllama_CountTokensFallback(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL, "test-model")
+	messages := []Message{{Role: "user", Content: strings.Repeat("x", 38)}}
+	for i := 0; i < 2; i++ {
+		count, err := provider.CountTokens(context.Background(), messages)
+		if err != nil {
+			t.Fatalf("CountTokens() error: %v", err)
+		}
+		if count != EstimateTokens(messages) {
+			t.Errorf("expected heuristic estimate %d, got %d", EstimateTokens(messages), count)
+		}
+	}
+	// A server without the endpoint is only asked once
+	if requests != 1 {
+		t.Errorf("expected 1 tokenize request, got %d", requests)
+	}
+}
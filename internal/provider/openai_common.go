@@ -13,7 +13,18 @@ import (
 // These types should NOT include provider-specific extensions.
 
 type openaiChatResponse struct {
-	Choices []openaiChatChoice `json:"choices"`
+	Choices []openaiChatChoice  `json:"choices"`
+	Usage   chatCompletionUsage `json:"usage"`
+}
+
+// chatCompletionUsage is the OpenAI-compatible usage block, also returned by Ollama.
+type chatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+func (u chatCompletionUsage) toUsage() Usage {
+	return Usage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens}
 }
 
 type openaiChatChoice struct {
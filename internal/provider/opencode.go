@@ -22,7 +22,8 @@ type openCodeRequest struct {
 	Messages    []openai.ChatCompletionMessage `json:"messages"`
 	Tools       []openai.Tool                  `json:"tools,omitempty"`
 	Temperature float32                        `json:"temperature,omitempty"`
-	Stream      bool                           `json:"stream"` // NO omitempty - always serialize
+	Seed        *int64                         `json:"seed,omitempty"` // Chat completions models only
+	Stream      bool                           `json:"stream"`         // NO omitempty - always serialize
 }
 
 // OpenCodeProvider implements the Provider interface for OpenCode Zen.
@@ -34,6 +35,9 @@ type OpenCodeProvider struct {
 	httpClient  *http.Client
 	model       string
 	temperature float64
+	textOnly    bool              // Model lacks native tool calling
+	timeout     time.Duration     // Per-turn timeout (0 = caller's default)
+	limit       *ConcurrencyLimit // Shared with the factory's other providers (nil = unlimited)
 }
 
 var opencodeRetryDelays = []time.Duration{5 * time.Second, 10 * time.Second, 15 * time.Second}
@@ -64,13 +68,16 @@ func NewOpenCodeWithTemp(name string, endpoint, model, apiKey string, temperatur
 	config := openai.DefaultConfig(apiKey)
 	baseURL := strings.TrimRight(endpoint, "/")
 	config.BaseURL = baseURL
+	// One client for both the OpenAI client and direct endpoint requests
+	httpClient := &http.Client{}
+	config.HTTPClient = httpClient
 
 	return &OpenCodeProvider{
 		name:        name,
 		client:      openai.NewClientWithConfig(config),
 		baseURL:     baseURL,
 		apiKey:      apiKey,
-		httpClient:  &http.Client{},
+		httpClient:  httpClient,
 		model:       model,
 		temperature: temperature,
 	}
@@ -81,6 +88,55 @@ func (p *OpenCodeProvider) Name() string {
 	return p.name
 }
 
+// Model returns the model requests are sent to.
+func (p *OpenCodeProvider) Model() string {
+	return p.model
+}
+
+// Temperature returns the sampling temperature requests use.
+func (p *OpenCodeProvider) Temperature() float64 {
+	return p.temperature
+}
+
+// WithTextOnly marks the model as lacking native tool calling.
+func (p *OpenCodeProvider) WithTextOnly(textOnly bool) *OpenCodeProvider {
+	p.textOnly = textOnly
+	return p
+}
+
+// WithHTTPTransport sends every request through rt (nil = http.DefaultTransport).
+func (p *OpenCodeProvider) WithHTTPTransport(rt http.RoundTripper) *OpenCodeProvider {
+	p.httpClient.Transport = rt
+	return p
+}
+
+// WithConcurrencyLimit makes chat requests wait for a slot in limit (nil = unlimited).
+func (p *OpenCodeProvider) WithConcurrencyLimit(limit *ConcurrencyLimit) *OpenCodeProvider {
+	p.limit = limit
+	return p
+}
+
+// ConcurrencyLimit returns the limit chat requests wait on, or nil if there is none.
+func (p *OpenCodeProvider) ConcurrencyLimit() *ConcurrencyLimit {
+	return p.limit
+}
+
+// SupportsTools reports whether the model accepts native tool definitions.
+func (p *OpenCodeProvider) SupportsTools() bool {
+	return !p.textOnly
+}
+
+// WithRequestTimeout sets the per-turn timeout (0 = caller's default).
+func (p *OpenCodeProvider) WithRequestTimeout(timeout time.Duration) *OpenCodeProvider {
+	p.timeout = timeout
+	return p
+}
+
+// RequestTimeout returns the configured per-turn timeout.
+func (p *OpenCodeProvider) RequestTimeout() time.Duration {
+	return p.timeout
+}
+
 // Chat sends messages and returns the complete response.
 func (p *OpenCodeProvider) Chat(ctx context.Context, messages []Message) (string, error) {
 	resp, err := p.createChatCompletion(ctx, openai.ChatCompletionRequest{
@@ -130,6 +186,7 @@ func (p *OpenCodeProvider) ChatWithTools(ctx context.Context, messages []Message
 	result := &ChatResponse{
 		Content:   choice.Message.Content,
 		Reasoning: "", // OpenAI standard doesn't provide reasoning field
+		Usage:     resp.Usage.toUsage(),
 	}
 
 	log.Debug().
@@ -159,6 +216,19 @@ func (p *OpenCodeProvider) ChatWithTools(ctx context.Context, messages []Message
 	return result, nil
 }
 
+// ListModels returns the IDs of the models served by the endpoint.
+func (p *OpenCodeProvider) ListModels(ctx context.Context) ([]string, error) {
+	list, err := p.client.ListModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list models: %w", err)
+	}
+	models := make([]string, len(list.Models))
+	for i, m := range list.Models {
+		models[i] = m.ID
+	}
+	return models, nil
+}
+
 func (p *OpenCodeProvider) createChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (*openaiChatResponse, error) {
 	// Use custom struct to ensure stream:false is serialized
 	customReq := openCodeRequest{
@@ -168,6 +238,10 @@ func (p *OpenCodeProvider) createChatCompletion(ctx context.Context, req openai.
 		Temperature: req.Temperature,
 		Stream:      req.Stream,
 	}
+	// Other endpoints reject unknown fields, so only chat completions get a seed
+	if opencodeEndpointForModel(p.model) == opencodeChatCompletionsEndpoint {
+		customReq.Seed = SeedFromContext(ctx)
+	}
 	body, err := json.Marshal(customReq)
 	if err != nil {
 		return nil, err
@@ -175,6 +249,12 @@ func (p *OpenCodeProvider) createChatCompletion(ctx context.Context, req openai.
 
 	url := p.baseURL + opencodeEndpointForModel(p.model)
 
+	release, err := p.limit.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Retry logic for transient errors (rate limits, server outages)
 	maxRetries := len(opencodeRetryDelays)
 
@@ -320,23 +400,34 @@ func (p *OpenCodeProvider) createChatCompletion(ctx context.Context, req openai.
 	return nil, fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
 }
 
+// CountTokens returns the heuristic estimate; OpenCode Zen has no tokenizer endpoint.
+func (p *OpenCodeProvider) CountTokens(ctx context.Context, messages []Message) (int, error) {
+	return EstimateTokens(messages), nil
+}
+
 // Stream sends messages and returns a channel that streams response chunks.
 func (p *OpenCodeProvider) Stream(ctx context.Context, messages []Message) (<-chan StreamChunk, error) {
 	if opencodeEndpointForModel(p.model) != opencodeChatCompletionsEndpoint {
 		return nil, fmt.Errorf("opencode model %q does not support streaming via chat completions endpoint", p.model)
 	}
 
+	release, err := p.limit.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
 	stream, err := p.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
 		Model:       p.model,
 		Messages:    mergeSystemMessagesOpenAI(toOpenAIMessages(messages)),
 		Temperature: float32(p.temperature),
 	})
 	if err != nil {
+		release()
 		return nil, err
 	}
 
 	ch := make(chan StreamChunk)
 	go func() {
+		defer release()
 		defer close(ch)
 		defer stream.Close()
 
@@ -447,3 +447,40 @@ func TestOpenCode_StreamParameterSetCorrectly(t *testing.T) {
 		t.Fatalf("Chat failed: %v", err)
 	}
 }
+
+func TestOpenCode_SeedOnlyForChatCompletions(t *testing.T) {
+	var seeds []*int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Seed *int64 `json:"seed"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		seeds = append(seeds, req.Seed)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"role": "assistant", "content": "ok"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	ctx := WithSeed(context.Background(), 7)
+	messages := []Message{{Role: "user", Content: "Test"}}
+	for _, model := range []string{"big-pickle", "minimax-m2.1-free"} {
+		provider := NewOpenCodeWithTemp("opencode_zen", server.URL, model, "test-key", 0)
+		// The messages endpoint may not answer in chat completions form; only the body matters
+		_, _ = provider.Chat(ctx, messages)
+	}
+
+	if len(seeds) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(seeds))
+	}
+	if seeds[0] == nil || *seeds[0] != 7 {
+		t.Errorf("expected seed 7 for a chat completions model, got %v", seeds[0])
+	}
+	if seeds[1] != nil {
+		t.Errorf("expected no seed for a messages model, got %d", *seeds[1])
+	}
+}
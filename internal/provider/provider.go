@@ -5,11 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
+	"time"
 )
 
 // ErrProviderNotFound is returned when a requested provider doesn't exist.
 var ErrProviderNotFound = errors.New("provider not found")
 
+// ErrBadRequest classifies provider errors caused by the request or configuration rather
+// than a transient failure. Retrying the same request will not help.
+var ErrBadRequest = errors.New("bad_request")
+
 // Message represents a chat message.
 type Message struct {
 	Role       string
@@ -32,11 +38,31 @@ type ToolCall struct {
 	Arguments json.RawMessage `json:"arguments"`
 }
 
+// Usage reports token counts for a chat completion, when the provider returns them.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// TotalTokens returns prompt plus completion tokens.
+func (u Usage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add returns the sum of two usage values.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+	}
+}
+
 // ChatResponse represents the response from a chat completion.
 type ChatResponse struct {
 	Content   string     // Text content (may be empty if tool calls)
 	ToolCalls []ToolCall // Tool calls (may be empty if text response)
 	Reasoning string     // Model reasoning content (optional)
+	Usage     Usage      // Token usage (zero if not reported)
 }
 
 // Provider defines the interface for LLM providers.
@@ -50,6 +76,17 @@ type Provider interface {
 	// ChatWithTools sends messages with available tools and returns response with potential tool calls.
 	ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*ChatResponse, error)
 
+	// SupportsTools reports whether the model accepts native tool definitions.
+	// Text-only providers are driven through ChatWithToolShim instead.
+	SupportsTools() bool
+
+	// RequestTimeout returns the configured per-turn timeout (0 = caller's default).
+	RequestTimeout() time.Duration
+
+	// CountTokens returns the number of prompt tokens messages would use, for checking a
+	// context budget before sending. Providers without a tokenizer use EstimateTokens.
+	CountTokens(ctx context.Context, messages []Message) (int, error)
+
 	// Stream sends messages and returns a channel that streams response chunks.
 	Stream(ctx context.Context, messages []Message) (<-chan StreamChunk, error)
 
@@ -57,6 +94,64 @@ type Provider interface {
 	Close() error
 }
 
+// estimatedCharsPerToken is the rough token size of English text and JSON for the
+// heuristic estimate.
+const estimatedCharsPerToken = 4
+
+// EstimateTokens returns a heuristic token count for messages (characters / 4), for
+// providers that cannot tokenize.
+func EstimateTokens(messages []Message) int {
+	chars := 0
+	for _, msg := range messages {
+		chars += len(messageText(msg))
+	}
+	return (chars + estimatedCharsPerToken - 1) / estimatedCharsPerToken
+}
+
+// messageText flattens a message to the text a model reads: role, content and tool calls.
+func messageText(msg Message) string {
+	var b strings.Builder
+	b.WriteString(msg.Role)
+	b.WriteString(": ")
+	b.WriteString(msg.Content)
+	for _, tc := range msg.ToolCalls {
+		b.WriteString("\n")
+		b.WriteString(tc.Name)
+		b.Write(tc.Arguments)
+	}
+	return b.String()
+}
+
+// seedKey carries a sampling seed on a request context.
+type seedKey struct{}
+
+// WithSeed returns ctx carrying a sampling seed for the provider calls made with it.
+// Providers that support seeds send it with the request, which together with
+// temperature 0 gives near-deterministic replies; others ignore it.
+func WithSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, seedKey{}, seed)
+}
+
+// SeedFromContext returns the seed set by WithSeed, or nil if there is none.
+func SeedFromContext(ctx context.Context) *int64 {
+	if seed, ok := ctx.Value(seedKey{}).(int64); ok {
+		return &seed
+	}
+	return nil
+}
+
+// Pinger is implemented by providers that support a warm-up request, such as loading a
+// model into memory, before the first chat request.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// ModelLister is implemented by providers that can list the models their endpoint serves.
+// It is cheap enough to use as a reachability check.
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]string, error)
+}
+
 type ProviderFactory interface {
 	Name() string
 	Create(model string, temperature float64) Provider
@@ -72,15 +167,22 @@ type StreamChunk struct {
 // Registry holds available providers.
 type Registry struct {
 	factories map[string]ProviderFactory
+	latencies *LatencyTracker
 }
 
 // NewRegistry creates a new provider registry.
 func NewRegistry() *Registry {
 	return &Registry{
 		factories: make(map[string]ProviderFactory),
+		latencies: NewLatencyTracker(),
 	}
 }
 
+// Latencies returns the call latencies observed per provider name.
+func (r *Registry) Latencies() *LatencyTracker {
+	return r.latencies
+}
+
 func (r *Registry) RegisterFactory(name string, f ProviderFactory) {
 	r.factories[name] = f
 }
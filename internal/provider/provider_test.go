@@ -160,6 +160,48 @@ func TestMockProviderStreamError(t *testing.T) {
 	}
 }
 
+func TestScriptedMock(t *testing.T) {
+	stepErr := errors.New("scripted failure")
+	mock := NewScriptedMock([]MockStep{
+		{ToolCalls: []ToolCall{{ID: "call_1", Name: "login", Arguments: json.RawMessage(`{}`)}}},
+		{Response: "logged in", Reasoning: "done"},
+		{Err: stepErr},
+	})
+	ctx := context.Background()
+
+	resp, err := mock.ChatWithTools(ctx, []Message{{Role: "user", Content: "go"}}, nil)
+	if err != nil {
+		t.Fatalf("step 1 error: %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "login" {
+		t.Errorf("step 1: unexpected tool calls %+v", resp.ToolCalls)
+	}
+
+	text, err := mock.Chat(ctx, []Message{{Role: "user", Content: "status"}})
+	if err != nil || text != "logged in" {
+		t.Errorf("step 2: got %q, %v", text, err)
+	}
+
+	if _, err := mock.ChatWithTools(ctx, nil, nil); !errors.Is(err, stepErr) {
+		t.Errorf("step 3: expected scripted error, got %v", err)
+	}
+	if mock.RemainingSteps() != 0 {
+		t.Errorf("expected script consumed, %d steps left", mock.RemainingSteps())
+	}
+
+	if _, err := mock.ChatWithTools(ctx, nil, nil); !errors.Is(err, ErrMockScriptExhausted) {
+		t.Errorf("expected ErrMockScriptExhausted, got %v", err)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 recorded requests, got %d", len(requests))
+	}
+	if requests[1][0].Content != "status" {
+		t.Errorf("expected second request to be recorded, got %+v", requests[1])
+	}
+}
+
 func TestOllamaProviderName(t *testing.T) {
 	p := NewOllama("http://localhost:11434", "llama3")
 	if p.Name() != "ollama" {
@@ -541,3 +583,16 @@ func TestOpenCodeProviderClose(t *testing.T) {
 		t.Errorf("second Close() error: %v", err)
 	}
 }
+
+func TestEstimateTokens(t *testing.T) {
+	messages := []Message{
+		{Role: "user", Content: "12345678"}, // "user: 12345678" = 14 chars
+		{Role: "assistant", ToolCalls: []ToolCall{{Name: "mine", Arguments: json.RawMessage(`{}`)}}}, // 11 + 1 + 4 + 2 = 18 chars
+	}
+	if got := EstimateTokens(messages); got != 8 {
+		t.Errorf("EstimateTokens() = %d, want 8 (32 chars / 4)", got)
+	}
+	if got := EstimateTokens(nil); got != 0 {
+		t.Errorf("EstimateTokens(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrMalformedToolCall is returned when a text response looks like a tool call but
+// cannot be parsed into one.
+var ErrMalformedToolCall = errors.New("malformed tool call")
+
+// toolShimCallsKey is the JSON key text-only models use to request tool calls.
+const toolShimCallsKey = "tool_calls"
+
+const toolShimInstructions = `You can call tools. To call one or more tools, reply with ONLY a JSON object in this exact format and nothing else:
+{"tool_calls": [{"name": "<tool name>", "arguments": {<arguments as a JSON object>}}]}
+Tool results are returned in the next user message. To answer without calling a tool, reply in plain text.
+
+Available tools:`
+
+// toolShimCall is a single tool call in the shim's JSON response format.
+type toolShimCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// ChatWithToolShim emulates tool calling for text-only models (ReAct-style): tool
+// definitions are described in a system prompt, prior tool calls and results are
+// rendered as text, and a JSON tool call in the reply is parsed back into ToolCalls.
+// A malformed tool call is returned as plain text content.
+func ChatWithToolShim(ctx context.Context, p Provider, messages []Message, tools []Tool) (*ChatResponse, error) {
+	text, err := p.Chat(ctx, toolShimMessages(messages, tools))
+	if err != nil {
+		return nil, err
+	}
+
+	calls, content, err := ParseToolCallResponse(text)
+	if err != nil {
+		log.Warn().
+			Str("provider", p.Name()).
+			Err(err).
+			Msg("Text-only model returned a malformed tool call - treating as text")
+		return &ChatResponse{Content: text}, nil
+	}
+
+	return &ChatResponse{Content: content, ToolCalls: calls}, nil
+}
+
+// toolShimMessages prepends the tool prompt and flattens tool calls and tool results
+// into plain assistant and user messages.
+func toolShimMessages(messages []Message, tools []Tool) []Message {
+	result := make([]Message, 0, len(messages)+1)
+	result = append(result, Message{Role: "system", Content: toolShimPrompt(tools)})
+
+	toolNames := make(map[string]string)
+	for _, msg := range messages {
+		switch {
+		case msg.Role == "assistant" && len(msg.ToolCalls) > 0:
+			calls := make([]toolShimCall, len(msg.ToolCalls))
+			for i, tc := range msg.ToolCalls {
+				toolNames[tc.ID] = tc.Name
+				calls[i] = toolShimCall{Name: tc.Name, Arguments: normalizeShimArguments(tc.Arguments)}
+			}
+			data, _ := json.Marshal(map[string][]toolShimCall{toolShimCallsKey: calls})
+			content := string(data)
+			if msg.Content != "" {
+				content = msg.Content + "\n" + content
+			}
+			result = append(result, Message{Role: "assistant", Content: content})
+		case msg.Role == "tool":
+			name := toolNames[msg.ToolCallID]
+			if name == "" {
+				name = "tool"
+			}
+			result = append(result, Message{Role: "user", Content: fmt.Sprintf("Result of %s:\n%s", name, msg.Content)})
+		default:
+			result = append(result, Message{Role: msg.Role, Content: msg.Content})
+		}
+	}
+
+	return result
+}
+
+// toolShimPrompt describes the available tools and the expected call format.
+func toolShimPrompt(tools []Tool) string {
+	var sb strings.Builder
+	sb.WriteString(toolShimInstructions)
+	for _, tool := range tools {
+		sb.WriteString("\n- ")
+		sb.WriteString(tool.Name)
+		if tool.Description != "" {
+			sb.WriteString(": ")
+			sb.WriteString(tool.Description)
+		}
+		if len(tool.Parameters) > 0 {
+			sb.WriteString("\n  parameters: ")
+			sb.Write(tool.Parameters)
+		}
+	}
+	return sb.String()
+}
+
+// ParseToolCallResponse extracts tool calls from a text-only model response.
+// Responses without a "tool_calls" object are plain text and return no calls.
+// Any text around the JSON object is returned as content. Returns
+// ErrMalformedToolCall if the object is present but invalid.
+func ParseToolCallResponse(text string) ([]ToolCall, string, error) {
+	keyIdx := strings.Index(text, `"`+toolShimCallsKey+`"`)
+	if keyIdx == -1 {
+		return nil, text, nil
+	}
+	start := strings.LastIndex(text[:keyIdx], "{")
+	if start == -1 {
+		return nil, text, fmt.Errorf("%w: no enclosing JSON object", ErrMalformedToolCall)
+	}
+
+	var payload struct {
+		ToolCalls []toolShimCall `json:"tool_calls"`
+	}
+	dec := json.NewDecoder(strings.NewReader(text[start:]))
+	if err := dec.Decode(&payload); err != nil {
+		return nil, text, fmt.Errorf("%w: %v", ErrMalformedToolCall, err)
+	}
+	if len(payload.ToolCalls) == 0 {
+		return nil, text, fmt.Errorf("%w: empty tool_calls", ErrMalformedToolCall)
+	}
+
+	calls := make([]ToolCall, len(payload.ToolCalls))
+	for i, call := range payload.ToolCalls {
+		if call.Name == "" {
+			return nil, text, fmt.Errorf("%w: tool call %d has no name", ErrMalformedToolCall, i)
+		}
+		args, err := parseShimArguments(call.Arguments)
+		if err != nil {
+			return nil, text, fmt.Errorf("%w: %s: %v", ErrMalformedToolCall, call.Name, err)
+		}
+		calls[i] = ToolCall{
+			ID:        "shim_" + uuid.New().String(),
+			Name:      call.Name,
+			Arguments: args,
+		}
+	}
+
+	end := start + int(dec.InputOffset())
+	content := stripCodeFence(text[:start]) + stripCodeFence(text[end:])
+	return calls, strings.TrimSpace(content), nil
+}
+
+// parseShimArguments accepts a JSON object, a JSON-encoded object string, or nothing.
+func parseShimArguments(raw json.RawMessage) (json.RawMessage, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if trimmed == "" || trimmed == "null" {
+		return json.RawMessage(`{}`), nil
+	}
+
+	// Some models encode arguments as a string, like the native OpenAI format
+	if strings.HasPrefix(trimmed, `"`) {
+		var inner string
+		if err := json.Unmarshal(raw, &inner); err != nil {
+			return nil, err
+		}
+		trimmed = strings.TrimSpace(inner)
+		if trimmed == "" {
+			return json.RawMessage(`{}`), nil
+		}
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &obj); err != nil {
+		return nil, fmt.Errorf("arguments must be a JSON object")
+	}
+	return json.RawMessage(trimmed), nil
+}
+
+// normalizeShimArguments renders stored arguments as a JSON object for the prompt.
+func normalizeShimArguments(raw json.RawMessage) json.RawMessage {
+	args, err := parseShimArguments(raw)
+	if err != nil {
+		return json.RawMessage(`{}`)
+	}
+	return args
+}
+
+// stripCodeFence removes markdown code fence markers left around an extracted JSON object.
+func stripCodeFence(s string) string {
+	s = strings.ReplaceAll(s, "```json", "")
+	return strings.ReplaceAll(s, "```", "")
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseToolCallResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		wantNames   []string
+		wantArgs    []string
+		wantContent string
+	}{
+		{
+			name:        "plain text",
+			text:        "I will wait for the next tick.",
+			wantContent: "I will wait for the next tick.",
+		},
+		{
+			name:      "single call",
+			text:      `{"tool_calls": [{"name": "travel", "arguments": {"target": "sol"}}]}`,
+			wantNames: []string{"travel"},
+			wantArgs:  []string{`{"target": "sol"}`},
+		},
+		{
+			name:        "multiple calls with surrounding text and fence",
+			text:        "Checking status first.\n```json\n{\"tool_calls\": [{\"name\": \"get_status\"}, {\"name\": \"mine\", \"arguments\": {}}]}\n```",
+			wantNames:   []string{"get_status", "mine"},
+			wantArgs:    []string{`{}`, `{}`},
+			wantContent: "Checking status first.",
+		},
+		{
+			name:      "string encoded arguments",
+			text:      `{"tool_calls": [{"name": "sell", "arguments": "{\"item\": \"iron\"}"}]}`,
+			wantNames: []string{"sell"},
+			wantArgs:  []string{`{"item": "iron"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, content, err := ParseToolCallResponse(tt.text)
+			if err != nil {
+				t.Fatalf("ParseToolCallResponse() error: %v", err)
+			}
+			if content != tt.wantContent {
+				t.Errorf("content = %q, want %q", content, tt.wantContent)
+			}
+			if len(calls) != len(tt.wantNames) {
+				t.Fatalf("got %d calls, want %d", len(calls), len(tt.wantNames))
+			}
+			seen := make(map[string]bool)
+			for i, call := range calls {
+				if call.Name != tt.wantNames[i] {
+					t.Errorf("call %d name = %s, want %s", i, call.Name, tt.wantNames[i])
+				}
+				if string(call.Arguments) != tt.wantArgs[i] {
+					t.Errorf("call %d args = %s, want %s", i, call.Arguments, tt.wantArgs[i])
+				}
+				if call.ID == "" || seen[call.ID] {
+					t.Errorf("call %d has empty or duplicate ID %q", i, call.ID)
+				}
+				seen[call.ID] = true
+			}
+		})
+	}
+}
+
+func TestParseToolCallResponseMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"truncated json", `{"tool_calls": [{"name": "travel", "arguments": {"target": "sol"`},
+		{"missing name", `{"tool_calls": [{"arguments": {}}]}`},
+		{"empty list", `{"tool_calls": []}`},
+		{"non-object arguments", `{"tool_calls": [{"name": "travel", "arguments": [1, 2]}]}`},
+		{"no enclosing object", `"tool_calls": []`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, content, err := ParseToolCallResponse(tt.text)
+			if !errors.Is(err, ErrMalformedToolCall) {
+				t.Fatalf("expected ErrMalformedToolCall, got %v", err)
+			}
+			if calls != nil {
+				t.Errorf("expected no calls, got %+v", calls)
+			}
+			if content != tt.text {
+				t.Errorf("expected original text as content, got %q", content)
+			}
+		})
+	}
+}
+
+func TestChatWithToolShim(t *testing.T) {
+	mock := NewMock("mock", `{"tool_calls": [{"name": "mine", "arguments": {"ore": "iron"}}]}`).WithTextOnly(true)
+	if mock.SupportsTools() {
+		t.Fatal("expected text-only mock to report no tool support")
+	}
+
+	tools := []Tool{{Name: "mine", Description: "Mine ore", Parameters: json.RawMessage(`{"type":"object"}`)}}
+	messages := []Message{
+		{Role: "system", Content: "You are a miner"},
+		{Role: "user", Content: "go"},
+		{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1", Name: "get_status", Arguments: json.RawMessage(`{}`)}}},
+		{Role: "tool", ToolCallID: "call_1", Content: `{"credits": 10}`},
+	}
+
+	resp, err := ChatWithToolShim(context.Background(), mock, messages, tools)
+	if err != nil {
+		t.Fatalf("ChatWithToolShim() error: %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "mine" {
+		t.Fatalf("unexpected tool calls: %+v", resp.ToolCalls)
+	}
+
+	sent := mock.LastChatMessages()
+	if len(sent) != 5 {
+		t.Fatalf("expected 5 messages sent, got %d", len(sent))
+	}
+	if sent[0].Role != "system" || !strings.Contains(sent[0].Content, "- mine: Mine ore") {
+		t.Errorf("expected tool prompt first, got %+v", sent[0])
+	}
+	if sent[3].Role != "assistant" || !strings.Contains(sent[3].Content, `"name":"get_status"`) {
+		t.Errorf("expected flattened tool call, got %+v", sent[3])
+	}
+	if sent[4].Role != "user" || sent[4].Content != "Result of get_status:\n{\"credits\": 10}" {
+		t.Errorf("expected tool result as user message, got %+v", sent[4])
+	}
+	for _, msg := range sent {
+		if len(msg.ToolCalls) > 0 || msg.ToolCallID != "" {
+			t.Errorf("expected no native tool fields, got %+v", msg)
+		}
+	}
+
+	// Malformed calls fall back to text
+	mock.WithResponse(`{"tool_calls": [{"name": `)
+	resp, err = ChatWithToolShim(context.Background(), mock, messages, tools)
+	if err != nil {
+		t.Fatalf("ChatWithToolShim() error: %v", err)
+	}
+	if len(resp.ToolCalls) != 0 || resp.Content != `{"tool_calls": [{"name": ` {
+		t.Errorf("expected text fallback, got %+v", resp)
+	}
+}
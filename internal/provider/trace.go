@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// redacted replaces credentials in traces.
+const redacted = "[REDACTED]"
+
+// secretHeaders are never written to a trace. Their values are also scrubbed from
+// URLs and bodies in case a provider echoes them back.
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"X-Api-Key":           true,
+	"Api-Key":             true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// TraceTransport is an http.RoundTripper that writes every request and response, bodies
+// included, to a trace for debugging provider traffic (e.g. tool call serialization).
+// Credentials are redacted. Response bodies are written once read to the end or closed,
+// so streaming responses still stream.
+type TraceTransport struct {
+	base http.RoundTripper
+	seq  atomic.Uint64
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewTraceTransport traces requests sent through base (nil = http.DefaultTransport) to w.
+func NewTraceTransport(base http.RoundTripper, w io.Writer) *TraceTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TraceTransport{base: base, w: w}
+}
+
+// RoundTrip sends req through the wrapped transport and traces both directions.
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := t.seq.Add(1)
+	secrets := headerSecrets(req.Header)
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("trace request body: %w", err)
+		}
+		body = data
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	t.write(fmt.Sprintf("=== #%d request %s %s %s", id, time.Now().UTC().Format(time.RFC3339Nano), req.Method, redactURL(req.URL, secrets)),
+		req.Header, body, secrets)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.write(fmt.Sprintf("=== #%d error after %s", id, time.Since(start).Round(time.Millisecond)), nil, []byte(err.Error()), secrets)
+		return nil, err
+	}
+
+	title := fmt.Sprintf("=== #%d response %s after %s", id, resp.Status, time.Since(start).Round(time.Millisecond))
+	header := resp.Header.Clone()
+	resp.Body = &tracedBody{ReadCloser: resp.Body, done: func(data []byte) {
+		t.write(title, header, data, secrets)
+	}}
+	return resp, nil
+}
+
+// write appends one trace entry. Write errors are ignored; tracing never fails a request.
+func (t *TraceTransport) write(title string, header http.Header, body []byte, secrets []string) {
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n")
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if secretHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(&b, "%s: %s\n", name, redactSecrets(value, secrets))
+	}
+	if len(body) > 0 {
+		b.WriteString("\n")
+		b.WriteString(redactSecrets(string(body), secrets))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.w, b.String())
+}
+
+// headerSecrets returns the credential values in header, including bare bearer tokens.
+func headerSecrets(header http.Header) []string {
+	var secrets []string
+	for name, values := range header {
+		if !secretHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		for _, value := range values {
+			if _, token, ok := strings.Cut(value, " "); ok && token != "" {
+				secrets = append(secrets, token)
+			}
+			if value != "" {
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	return secrets
+}
+
+// redactSecrets replaces every secret in s.
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// redactURL hides query parameters that look like credentials, then any known secret.
+func redactURL(u *url.URL, secrets []string) string {
+	clean := *u
+	clean.User = nil
+	query := clean.Query()
+	for name := range query {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "key") || strings.Contains(lower, "token") || strings.Contains(lower, "secret") {
+			query.Set(name, redacted)
+		}
+	}
+	if len(query) > 0 {
+		clean.RawQuery = strings.ReplaceAll(query.Encode(), url.QueryEscape(redacted), redacted)
+	}
+	return redactSecrets(clean.String(), secrets)
+}
+
+// tracedBody copies a response body as it is read and hands the copy to done once,
+// at EOF or on Close.
+type tracedBody struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	once sync.Once
+	done func([]byte)
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	b.finish()
+	return b.ReadCloser.Close()
+}
+
+func (b *tracedBody) finish() {
+	b.once.Do(func() { b.done(b.buf.Bytes()) })
+}
@@ -0,0 +1,217 @@
+package store
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+// maxUsernameAttempts bounds retries when a generated username is already taken.
+const maxUsernameAttempts = 20
+
+// AccountGenerator mints credentials for new game accounts.
+type AccountGenerator interface {
+	Username() string
+	Password() string
+}
+
+// usernameSeeder is implemented by generators whose usernames carry on from the
+// accounts already stored. existing is only called the first time.
+type usernameSeeder interface {
+	seedUsernames(existing func() ([]string, error)) error
+}
+
+// PasswordPolicy sets the complexity of generated passwords. Every password has at
+// least one lowercase letter, uppercase letter and digit.
+type PasswordPolicy struct {
+	Length  int  // 0 = constants.DefaultPasswordLength
+	Symbols bool // Also require a symbol
+}
+
+const (
+	passwordLower   = "abcdefghijkmnopqrstuvwxyz"
+	passwordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordDigits  = "23456789"
+	passwordSymbols = "!@#$%^&*-_=+"
+)
+
+// Generate returns a random password satisfying the policy.
+func (p PasswordPolicy) Generate() string {
+	length := p.Length
+	if length <= 0 {
+		length = constants.DefaultPasswordLength
+	}
+
+	classes := []string{passwordLower, passwordUpper, passwordDigits}
+	if p.Symbols {
+		classes = append(classes, passwordSymbols)
+	}
+	if length < len(classes) {
+		length = len(classes)
+	}
+
+	all := strings.Join(classes, "")
+	chars := make([]byte, 0, length)
+	for _, class := range classes {
+		chars = append(chars, class[randomInt(len(class))])
+	}
+	for len(chars) < length {
+		chars = append(chars, all[randomInt(len(all))])
+	}
+	for i := len(chars) - 1; i > 0; i-- {
+		j := randomInt(i + 1)
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+	return string(chars)
+}
+
+// randomInt returns a uniform random int in [0, n) from crypto/rand.
+func randomInt(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return int(v.Int64())
+}
+
+// NewAccountGenerator returns the generator for a [accounts] strategy.
+// An empty strategy selects random usernames.
+func NewAccountGenerator(strategy, prefix string, words []string, policy PasswordPolicy) (AccountGenerator, error) {
+	if prefix == "" {
+		prefix = constants.DefaultAccountPrefix
+	}
+	switch strategy {
+	case "", constants.AccountStrategyRandom:
+		return &randomGenerator{prefix: prefix, policy: policy}, nil
+	case constants.AccountStrategySequential:
+		return &sequentialGenerator{prefix: prefix, policy: policy}, nil
+	case constants.AccountStrategyWordlist:
+		if len(words) < 2 {
+			return nil, fmt.Errorf("wordlist strategy needs at least 2 words, got %d", len(words))
+		}
+		return &wordlistGenerator{words: words, policy: policy}, nil
+	default:
+		return nil, fmt.Errorf("unknown account strategy %q", strategy)
+	}
+}
+
+// randomGenerator produces usernames like "zoea_k3x9m2".
+type randomGenerator struct {
+	prefix string
+	policy PasswordPolicy
+}
+
+func (g *randomGenerator) Username() string {
+	const alphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+	suffix := make([]byte, 6)
+	for i := range suffix {
+		suffix[i] = alphabet[randomInt(len(alphabet))]
+	}
+	return g.prefix + "_" + string(suffix)
+}
+
+func (g *randomGenerator) Password() string { return g.policy.Generate() }
+
+// sequentialGenerator produces usernames like "zoea_001", "zoea_002". It starts after
+// the highest number already stored, and collisions advance the counter.
+type sequentialGenerator struct {
+	mu     sync.Mutex
+	prefix string
+	next   int
+	seeded bool
+	policy PasswordPolicy
+}
+
+func (g *sequentialGenerator) Username() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	return fmt.Sprintf("%s_%03d", g.prefix, g.next)
+}
+
+func (g *sequentialGenerator) seedUsernames(existing func() ([]string, error)) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.seeded {
+		return nil
+	}
+	usernames, err := existing()
+	if err != nil {
+		return err
+	}
+	for _, username := range usernames {
+		suffix, ok := strings.CutPrefix(username, g.prefix+"_")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(suffix); err == nil && n > g.next {
+			g.next = n
+		}
+	}
+	g.seeded = true
+	return nil
+}
+
+func (g *sequentialGenerator) Password() string { return g.policy.Generate() }
+
+// wordlistGenerator produces usernames like "amber_falcon_42".
+type wordlistGenerator struct {
+	words  []string
+	policy PasswordPolicy
+}
+
+func (g *wordlistGenerator) Username() string {
+	first := g.words[randomInt(len(g.words))]
+	second := g.words[randomInt(len(g.words))]
+	return fmt.Sprintf("%s_%s_%d", first, second, randomInt(100))
+}
+
+func (g *wordlistGenerator) Password() string { return g.policy.Generate() }
+
+// NewAccountCredentials returns a username not yet in the accounts table and a
+// password, retrying the generator on collision. Register calls that leave out
+// credentials get them from here.
+func (s *Store) NewAccountCredentials(gen AccountGenerator) (username, password string, err error) {
+	if seeder, ok := gen.(usernameSeeder); ok {
+		if err := seeder.seedUsernames(s.accountUsernames); err != nil {
+			return "", "", fmt.Errorf("seed usernames: %w", err)
+		}
+	}
+	for attempt := 0; attempt < maxUsernameAttempts; attempt++ {
+		username = gen.Username()
+		_, err := s.GetAccount(username)
+		if errors.Is(err, sql.ErrNoRows) {
+			return username, gen.Password(), nil
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("check username: %w", err)
+		}
+	}
+	return "", "", fmt.Errorf("no unique username after %d attempts", maxUsernameAttempts)
+}
+
+// accountUsernames returns the username of every stored account.
+func (s *Store) accountUsernames() ([]string, error) {
+	rows, err := s.query(`SELECT username FROM accounts`)
+	if err != nil {
+		return nil, fmt.Errorf("query usernames: %w", err)
+	}
+	defer rows.Close()
+
+	var usernames []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, fmt.Errorf("scan username: %w", err)
+		}
+		usernames = append(usernames, username)
+	}
+	return usernames, rows.Err()
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+func TestPasswordPolicyGenerate(t *testing.T) {
+	for _, policy := range []PasswordPolicy{{}, {Length: 10, Symbols: true}} {
+		password := policy.Generate()
+		wantLen := policy.Length
+		if wantLen == 0 {
+			wantLen = constants.DefaultPasswordLength
+		}
+		if len(password) != wantLen {
+			t.Errorf("len(%q) = %d, want %d", password, len(password), wantLen)
+		}
+		var lower, upper, digit, symbol bool
+		for _, r := range password {
+			switch {
+			case unicode.IsLower(r):
+				lower = true
+			case unicode.IsUpper(r):
+				upper = true
+			case unicode.IsDigit(r):
+				digit = true
+			default:
+				symbol = true
+			}
+		}
+		if !lower || !upper || !digit || symbol != policy.Symbols {
+			t.Errorf("password %q does not match policy %+v", password, policy)
+		}
+	}
+}
+
+func TestNewAccountGenerator(t *testing.T) {
+	if _, err := NewAccountGenerator("bogus", "", nil, PasswordPolicy{}); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+	if _, err := NewAccountGenerator(constants.AccountStrategyWordlist, "", []string{"amber"}, PasswordPolicy{}); err == nil {
+		t.Error("expected error for a one-word wordlist")
+	}
+
+	random, _ := NewAccountGenerator("", "", nil, PasswordPolicy{})
+	if name := random.Username(); !strings.HasPrefix(name, constants.DefaultAccountPrefix+"_") {
+		t.Errorf("random username %q missing default prefix", name)
+	}
+
+	words, _ := NewAccountGenerator(constants.AccountStrategyWordlist, "", []string{"amber", "falcon"}, PasswordPolicy{})
+	if name := words.Username(); !strings.Contains(name, "amber") && !strings.Contains(name, "falcon") {
+		t.Errorf("wordlist username %q uses no words", name)
+	}
+}
+
+func TestNewAccountCredentialsContinuesSequence(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	// More accounts than the collision retries could step over one by one, as after
+	// a restart with a long-running swarm
+	for i := 1; i <= maxUsernameAttempts+5; i++ {
+		if _, err := s.CreateAccount(fmt.Sprintf("pilot_%03d", i), "pw"); err != nil {
+			t.Fatalf("CreateAccount() error: %v", err)
+		}
+	}
+	s.CreateAccount("pilot_x", "pw")
+	s.CreateAccount("other_900", "pw")
+
+	gen, err := NewAccountGenerator(constants.AccountStrategySequential, "pilot", nil, PasswordPolicy{})
+	if err != nil {
+		t.Fatalf("NewAccountGenerator() error: %v", err)
+	}
+	username, password, err := s.NewAccountCredentials(gen)
+	if err != nil {
+		t.Fatalf("NewAccountCredentials() error: %v", err)
+	}
+	if want := fmt.Sprintf("pilot_%03d", maxUsernameAttempts+6); username != want {
+		t.Errorf("expected next free username %s, got %s", want, username)
+	}
+	if len(password) != constants.DefaultPasswordLength {
+		t.Errorf("expected generated password, got %q", password)
+	}
+
+	// Later calls keep counting without reading the accounts again
+	if username, _, _ = s.NewAccountCredentials(gen); username != fmt.Sprintf("pilot_%03d", maxUsernameAttempts+7) {
+		t.Errorf("expected the sequence to continue, got %s", username)
+	}
+}
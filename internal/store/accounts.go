@@ -25,7 +25,7 @@ func (s *Store) CreateAccount(username, password string, mysisID ...string) (*Ac
 		assignedToParam = nil
 	}
 
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		INSERT INTO accounts (username, password, assigned_to, created_at, last_used_at)
 		VALUES (?, ?, ?, ?, ?)
 	`, username, password, assignedToParam, now, now)
@@ -47,7 +47,7 @@ func (s *Store) GetAccount(username string) (*Account, error) {
 	var lastUsedAt sql.NullTime
 	var assignedTo sql.NullString
 
-	err := s.db.QueryRow(`
+	err := s.queryRow(`
 		SELECT username, password, assigned_to, last_used_at, created_at
 		FROM accounts WHERE username = ?
 	`, username).Scan(&acc.Username, &acc.Password, &assignedTo, &lastUsedAt, &acc.CreatedAt)
@@ -70,7 +70,7 @@ func (s *Store) GetAccountByMysisID(mysisID string) (*Account, error) {
 	var lastUsedAt sql.NullTime
 	var assignedTo sql.NullString
 
-	err := s.db.QueryRow(`
+	err := s.queryRow(`
 		SELECT username, password, assigned_to, last_used_at, created_at
 		FROM accounts WHERE assigned_to = ?
 	`, mysisID).Scan(&acc.Username, &acc.Password, &assignedTo, &lastUsedAt, &acc.CreatedAt)
@@ -89,7 +89,7 @@ func (s *Store) GetAccountByMysisID(mysisID string) (*Account, error) {
 }
 
 func (s *Store) ListAvailableAccounts() ([]*Account, error) {
-	rows, err := s.db.Query(`
+	rows, err := s.query(`
 		SELECT username, password, assigned_to, last_used_at, created_at
 		FROM accounts
 		WHERE assigned_to IS NULL
@@ -134,19 +134,22 @@ func (s *Store) ClaimAccount(mysisID string) (*Account, error) {
 	var lastUsedAt sql.NullTime
 
 	// Atomically assign an account permanently to this mysis
-	// This prevents race conditions where multiple myses claim the same account
-	err := s.db.QueryRow(`
-		UPDATE accounts
-		SET assigned_to = ?, last_used_at = ?
-		WHERE username = (
-			SELECT username
-			FROM accounts
-			WHERE assigned_to IS NULL
-			ORDER BY created_at ASC
-			LIMIT 1
-		)
-		RETURNING username, password, created_at, last_used_at
-	`, mysisID, now).Scan(&username, &password, &createdAt, &lastUsedAt)
+	// This prevents race conditions where multiple myses claim the same account.
+	// It is a write, so it is retried while the database is busy like exec.
+	err := s.withRetry(func() error {
+		return s.queryRow(`
+			UPDATE accounts
+			SET assigned_to = ?, last_used_at = ?
+			WHERE username = (
+				SELECT username
+				FROM accounts
+				WHERE assigned_to IS NULL
+				ORDER BY created_at ASC
+				LIMIT 1
+			)
+			RETURNING username, password, created_at, last_used_at
+		`, mysisID, now).Scan(&username, &password, &createdAt, &lastUsedAt)
+	})
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("no accounts available")
 	}
@@ -172,7 +175,7 @@ func (s *Store) ClaimAccount(mysisID string) (*Account, error) {
 func (s *Store) AssignAccount(username, mysisID string) error {
 	now := time.Now().UTC()
 
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		UPDATE accounts
 		SET assigned_to = ?, last_used_at = ?
 		WHERE username = ?
@@ -186,7 +189,7 @@ func (s *Store) AssignAccount(username, mysisID string) error {
 
 // ReleaseAccount clears the permanent assignment of an account (returns it to pool)
 func (s *Store) ReleaseAccount(username string) error {
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		UPDATE accounts
 		SET assigned_to = NULL
 		WHERE username = ?
@@ -200,7 +203,7 @@ func (s *Store) ReleaseAccount(username string) error {
 
 // ReleaseAccountByMysisID clears the permanent assignment for a mysis's account
 func (s *Store) ReleaseAccountByMysisID(mysisID string) error {
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		UPDATE accounts
 		SET assigned_to = NULL
 		WHERE assigned_to = ?
@@ -213,7 +216,7 @@ func (s *Store) ReleaseAccountByMysisID(mysisID string) error {
 }
 
 func (s *Store) ReleaseAllAccounts() error {
-	_, err := s.db.Exec(`UPDATE accounts SET assigned_to = NULL`)
+	_, err := s.exec(`UPDATE accounts SET assigned_to = NULL`)
 	if err != nil {
 		return fmt.Errorf("release all accounts: %w", err)
 	}
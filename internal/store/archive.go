@@ -0,0 +1,356 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// swarmArchiveFormat identifies a swarm archive file.
+const swarmArchiveFormat = "zoea-nova-swarm"
+
+// swarmArchiveVersion is the archive layout version. Bump it when fields change
+// meaning, and teach ImportSwarm to read the older layouts.
+const swarmArchiveVersion = 1
+
+// SwarmArchive is the portable JSON form of a swarm written by ExportSwarm. It is
+// independent of the database schema; SchemaVersion records the schema it came from.
+type SwarmArchive struct {
+	Format        string            `json:"format"`
+	Version       int               `json:"version"`
+	SchemaVersion int               `json:"schema_version"`
+	ExportedAt    time.Time         `json:"exported_at"`
+	Myses         []ArchivedMysis   `json:"myses"`
+	Accounts      []ArchivedAccount `json:"accounts"`
+}
+
+// ArchivedMysis is a mysis with its settings, usage totals and full memory.
+type ArchivedMysis struct {
+	ID                string           `json:"id"`
+	Name              string           `json:"name"`
+	Provider          string           `json:"provider"`
+	Model             string           `json:"model"`
+	Temperature       float64          `json:"temperature"`
+	State             MysisState       `json:"state"`
+	CompactSnapshots  bool             `json:"compact_snapshots"`
+	SnapshotSummaries bool             `json:"snapshot_summaries"`
+	ContextWindow     int              `json:"context_window"`
+	Autonomous        *bool            `json:"autonomous,omitempty"`       // nil in archives that predate the setting
+	SyntheticNudges   *bool            `json:"synthetic_nudges,omitempty"` // nil in archives that predate the setting
+	Seed              *int64           `json:"seed,omitempty"`
+	ToolAllow         []string         `json:"tool_allow,omitempty"`
+	ToolDeny          []string         `json:"tool_deny,omitempty"`
+	Goal              string           `json:"goal,omitempty"`
+	TurnCount         int              `json:"turn_count,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	Usage             CostStats        `json:"usage"`
+	Memories          []ArchivedMemory `json:"memories"`
+}
+
+// ArchivedMemory is one memory. Memory IDs are not kept; order is.
+type ArchivedMemory struct {
+	Role        MemoryRole   `json:"role"`
+	Source      MemorySource `json:"source"`
+	SenderID    string       `json:"sender_id,omitempty"`
+	Content     string       `json:"content"`
+	Reasoning   string       `json:"reasoning,omitempty"`
+	Pinned      bool         `json:"pinned,omitempty"`
+	BroadcastID string       `json:"broadcast_id,omitempty"` // Shared by every copy of a tracked broadcast
+	Acked       bool         `json:"acked,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// ArchivedAccount is a game account with its mysis assignment.
+type ArchivedAccount struct {
+	Username   string     `json:"username"`
+	Password   string     `json:"password"`
+	AssignedTo string     `json:"assigned_to,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ImportResult counts what ImportSwarm restored.
+type ImportResult struct {
+	Myses    int
+	Memories int
+	Accounts int
+	// Remapped is the number of myses given a new ID because theirs was taken.
+	Remapped int
+}
+
+// ExportSwarm writes every mysis with its memories (including the broadcasts it
+// received), usage totals and every account to a JSON archive at path. The archive
+// holds account passwords, so it is only readable by the owner. Game state snapshots
+// and templates are not exported.
+func (s *Store) ExportSwarm(path string) (*SwarmArchive, error) {
+	archive, err := s.buildArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return nil, fmt.Errorf("marshal archive: %w", err)
+	}
+	// Write then rename so a failed export never leaves a truncated archive
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return nil, fmt.Errorf("write archive: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("write archive: %w", err)
+	}
+	return archive, nil
+}
+
+func (s *Store) buildArchive() (*SwarmArchive, error) {
+	archive := &SwarmArchive{
+		Format:        swarmArchiveFormat,
+		Version:       swarmArchiveVersion,
+		SchemaVersion: currentSchemaVersion,
+		ExportedAt:    time.Now().UTC(),
+		Myses:         []ArchivedMysis{},
+		Accounts:      []ArchivedAccount{},
+	}
+
+	myses, err := s.ListMyses()
+	if err != nil {
+		return nil, fmt.Errorf("list myses: %w", err)
+	}
+	for _, m := range myses {
+		usage, err := s.GetCostStats(m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("usage for mysis %s: %w", m.ID, err)
+		}
+		memories, err := s.archivedMemories(m.ID)
+		if err != nil {
+			return nil, err
+		}
+		archive.Myses = append(archive.Myses, ArchivedMysis{
+			ID:                m.ID,
+			Name:              m.Name,
+			Provider:          m.Provider,
+			Model:             m.Model,
+			Temperature:       m.Temperature,
+			State:             m.State,
+			CompactSnapshots:  m.CompactSnapshots,
+			SnapshotSummaries: m.SnapshotSummaries,
+			ContextWindow:     m.ContextWindow,
+			Autonomous:        &m.Autonomous,
+			SyntheticNudges:   &m.SyntheticNudges,
+			Seed:              m.Seed,
+			ToolAllow:         m.ToolAllow,
+			ToolDeny:          m.ToolDeny,
+			Goal:              m.Goal,
+			TurnCount:         m.TurnCount,
+			CreatedAt:         m.CreatedAt,
+			UpdatedAt:         m.UpdatedAt,
+			Usage:             *usage,
+			Memories:          memories,
+		})
+	}
+
+	accounts, err := s.archivedAccounts()
+	if err != nil {
+		return nil, err
+	}
+	archive.Accounts = accounts
+	return archive, nil
+}
+
+// archivedMemories returns a mysis's memories in stored order. Ties on created_at are
+// broken by ID so tool calls stay ahead of their results.
+func (s *Store) archivedMemories(mysisID string) ([]ArchivedMemory, error) {
+	rows, err := s.query(`
+		SELECT role, source, sender_id, content, reasoning, pinned, broadcast_id, acked, created_at
+		FROM memories
+		WHERE mysis_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, mysisID)
+	if err != nil {
+		return nil, fmt.Errorf("query memories: %w", err)
+	}
+	defer rows.Close()
+
+	memories := []ArchivedMemory{}
+	for rows.Next() {
+		var m ArchivedMemory
+		var senderID, reasoning sql.NullString
+		if err := rows.Scan(&m.Role, &m.Source, &senderID, &m.Content, &reasoning, &m.Pinned, &m.BroadcastID, &m.Acked, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan memory: %w", err)
+		}
+		m.SenderID, m.Reasoning = senderID.String, reasoning.String
+		memories = append(memories, m)
+	}
+	return memories, rows.Err()
+}
+
+func (s *Store) archivedAccounts() ([]ArchivedAccount, error) {
+	rows, err := s.query(`
+		SELECT username, password, assigned_to, last_used_at, created_at
+		FROM accounts
+		ORDER BY created_at ASC, username ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []ArchivedAccount{}
+	for rows.Next() {
+		var acc ArchivedAccount
+		var assignedTo sql.NullString
+		var lastUsedAt sql.NullTime
+		if err := rows.Scan(&acc.Username, &acc.Password, &assignedTo, &lastUsedAt, &acc.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan account: %w", err)
+		}
+		acc.AssignedTo = assignedTo.String
+		if lastUsedAt.Valid {
+			acc.LastUsedAt = &lastUsedAt.Time
+		}
+		accounts = append(accounts, acc)
+	}
+	return accounts, rows.Err()
+}
+
+// ImportSwarm restores an archive written by ExportSwarm, in one transaction. A mysis
+// whose ID is already taken gets a new one, and sender IDs and account assignments that
+// point at it follow. Accounts that already exist are refused, since their credentials
+// can't be merged. Memories keep their order and timestamps, so tool calls and results
+// stay paired. Running myses are restored idle.
+func (s *Store) ImportSwarm(path string) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	var archive SwarmArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("parse archive: %w", err)
+	}
+	if archive.Format != swarmArchiveFormat {
+		return nil, fmt.Errorf("%s is not a swarm archive", path)
+	}
+	if archive.Version < 1 || archive.Version > swarmArchiveVersion {
+		return nil, fmt.Errorf("swarm archive version %d is not supported (current: %d) - upgrade Zoea Nova", archive.Version, swarmArchiveVersion)
+	}
+	for i, m := range archive.Myses {
+		if m.ID == "" {
+			return nil, fmt.Errorf("archived mysis %d has no id", i)
+		}
+	}
+
+	var result *ImportResult
+	err = s.withRetry(func() error {
+		var err error
+		result, err = s.importArchive(&archive)
+		return err
+	})
+	return result, err
+}
+
+func (s *Store) importArchive(archive *SwarmArchive) (*ImportResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &ImportResult{}
+	ids := make(map[string]string, len(archive.Myses)) // Archived mysis ID -> stored ID
+	for _, m := range archive.Myses {
+		var exists int
+		err := tx.QueryRow(s.backend.Rebind(`SELECT COUNT(*) FROM myses WHERE id = ?`), m.ID).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("check mysis %s: %w", m.ID, err)
+		}
+		ids[m.ID] = m.ID
+		if exists > 0 {
+			ids[m.ID] = uuid.New().String()
+			result.Remapped++
+		}
+	}
+	// Senders outside the archive (e.g. the commander) keep their IDs
+	remap := func(id string) string {
+		if mapped, ok := ids[id]; ok {
+			return mapped
+		}
+		return id
+	}
+
+	for _, m := range archive.Myses {
+		id := ids[m.ID]
+		state := m.State
+		if state == MysisStateRunning {
+			state = MysisStateIdle
+		}
+		autonomous := m.Autonomous == nil || *m.Autonomous
+		syntheticNudges := m.SyntheticNudges == nil || *m.SyntheticNudges
+		if _, err := tx.Exec(s.backend.Rebind(`
+			INSERT INTO myses (id, name, provider, model, temperature, state, compact_snapshots, snapshot_summaries, context_window, autonomous, synthetic_nudges, seed, tool_allow, tool_deny, goal, turn_count, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`), id, m.Name, m.Provider, m.Model, m.Temperature, state, m.CompactSnapshots, m.SnapshotSummaries, m.ContextWindow, autonomous, syntheticNudges, m.Seed,
+			strings.Join(m.ToolAllow, ","), strings.Join(m.ToolDeny, ","), m.Goal, m.TurnCount, m.CreatedAt, m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("insert mysis %s: %w", m.Name, err)
+		}
+
+		// Inserted in order, so IDs break created_at ties the same way as before
+		for i, mem := range m.Memories {
+			if _, err := tx.Exec(s.backend.Rebind(`
+				INSERT INTO memories (mysis_id, role, source, sender_id, content, reasoning, pinned, broadcast_id, acked, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`), id, mem.Role, mem.Source, remap(mem.SenderID), mem.Content, mem.Reasoning, mem.Pinned, mem.BroadcastID, mem.Acked, mem.CreatedAt); err != nil {
+				return nil, fmt.Errorf("insert memory %d of mysis %s: %w", i, m.Name, err)
+			}
+		}
+		result.Memories += len(m.Memories)
+
+		if m.Usage.TotalTokens() > 0 || m.Usage.Cost > 0 {
+			if _, err := tx.Exec(s.backend.Rebind(`
+				INSERT INTO mysis_usage (mysis_id, prompt_tokens, completion_tokens, cost, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+			`), id, m.Usage.PromptTokens, m.Usage.CompletionTokens, m.Usage.Cost, m.UpdatedAt); err != nil {
+				return nil, fmt.Errorf("insert usage of mysis %s: %w", m.Name, err)
+			}
+		}
+		result.Myses++
+	}
+
+	for _, acc := range archive.Accounts {
+		var exists int
+		err := tx.QueryRow(s.backend.Rebind(`SELECT COUNT(*) FROM accounts WHERE username = ?`), acc.Username).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("check account %s: %w", acc.Username, err)
+		}
+		if exists > 0 {
+			return nil, fmt.Errorf("account %s already exists - import into a fresh database", acc.Username)
+		}
+
+		var assignedTo interface{}
+		if acc.AssignedTo != "" {
+			assignedTo = remap(acc.AssignedTo)
+		}
+		var lastUsedAt interface{}
+		if acc.LastUsedAt != nil {
+			lastUsedAt = *acc.LastUsedAt
+		}
+		if _, err := tx.Exec(s.backend.Rebind(`
+			INSERT INTO accounts (username, password, assigned_to, last_used_at, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`), acc.Username, acc.Password, assignedTo, lastUsedAt, acc.CreatedAt); err != nil {
+			return nil, fmt.Errorf("insert account %s: %w", acc.Username, err)
+		}
+		result.Accounts++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
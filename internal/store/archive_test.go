@@ -0,0 +1,134 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportImportSwarm(t *testing.T) {
+	src, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	a, _ := src.CreateMysis("alpha", "mock", "model", 0.7)
+	b, _ := src.CreateMysis("beta", "mock", "model", 0.5)
+	src.UpdateMysisState(a.ID, MysisStateRunning)
+	src.SetMysisToolPolicy(b.ID, []string{"mine"}, nil)
+	src.AddMemory(a.ID, MemoryRoleUser, MemorySourceDirect, "Check status", "", "")
+	src.AddMemory(a.ID, MemoryRoleAssistant, MemorySourceLLM, "[TOOL_CALLS]call_1:get_status:{}", "", "")
+	src.AddMemory(a.ID, MemoryRoleTool, MemorySourceTool, "call_1:ok", "", "")
+	src.AddMemory(b.ID, MemoryRoleUser, MemorySourceBroadcast, "regroup", "", a.ID)
+	src.AddBroadcastMemory(b.ID, "hold position", "", "b-1")
+	if received, err := src.GetRecentMemories(b.ID, 1); err == nil {
+		src.AckBroadcast(b.ID, received[0].ID)
+	}
+	if toolCall, err := src.GetMemories(a.ID); err == nil {
+		src.SetMemoryPinned(toolCall[1].ID, true)
+	}
+	src.RecordUsage(a.ID, 100, 20, 0.5)
+	src.CreateAccount("pilot", "secret", b.ID)
+
+	path := filepath.Join(t.TempDir(), "swarm.json")
+	archive, err := src.ExportSwarm(path)
+	if err != nil {
+		t.Fatalf("ExportSwarm() error: %v", err)
+	}
+	if len(archive.Myses) != 2 || len(archive.Accounts) != 1 || archive.SchemaVersion != currentSchemaVersion {
+		t.Errorf("unexpected archive: %d myses, %d accounts, schema %d", len(archive.Myses), len(archive.Accounts), archive.SchemaVersion)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected owner-only archive, got %v, %v", info, err)
+	}
+
+	dst, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory() error: %v", err)
+	}
+	defer dst.Close()
+
+	result, err := dst.ImportSwarm(path)
+	if err != nil {
+		t.Fatalf("ImportSwarm() error: %v", err)
+	}
+	if *result != (ImportResult{Myses: 2, Memories: 5, Accounts: 1}) {
+		t.Errorf("unexpected import result: %+v", result)
+	}
+
+	got, err := dst.GetMysis(a.ID)
+	if err != nil {
+		t.Fatalf("GetMysis() error: %v", err)
+	}
+	if got.Name != "alpha" || got.State != MysisStateIdle {
+		t.Errorf("expected alpha restored idle, got %+v", got)
+	}
+	memories, _ := dst.GetMemories(a.ID)
+	if len(memories) != 3 || memories[1].Content != "[TOOL_CALLS]call_1:get_status:{}" || memories[2].Content != "call_1:ok" {
+		t.Errorf("expected tool call before its result, got %+v", memories)
+	}
+	if len(memories) == 3 && (memories[0].Pinned || !memories[1].Pinned) {
+		t.Error("expected only the pinned tool call to stay pinned")
+	}
+	if acks, _ := dst.BroadcastAcks("b-1"); len(acks) != 1 || acks[0] != (BroadcastAck{MysisID: b.ID, Acked: true}) {
+		t.Errorf("expected the broadcast ack restored, got %+v", acks)
+	}
+	if stats, _ := dst.GetCostStats(a.ID); stats.PromptTokens != 100 || stats.Cost != 0.5 {
+		t.Errorf("unexpected usage: %+v", stats)
+	}
+	if beta, _ := dst.GetMysis(b.ID); strings.Join(beta.ToolAllow, ",") != "mine" {
+		t.Errorf("expected tool policy restored, got %+v", beta)
+	}
+	if acc, err := dst.GetAccountByMysisID(b.ID); err != nil || acc.Username != "pilot" || acc.Password != "secret" {
+		t.Errorf("GetAccountByMysisID() = %+v, %v", acc, err)
+	}
+
+	// Importing into a store that already has the accounts is refused as a whole
+	if _, err := src.ImportSwarm(path); err == nil || !strings.Contains(err.Error(), "account pilot already exists") {
+		t.Errorf("expected account collision error, got %v", err)
+	}
+	if count, _ := src.CountMyses(); count != 2 {
+		t.Errorf("expected failed import to roll back, got %d myses", count)
+	}
+}
+
+func TestImportSwarmRemapsTakenIDs(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	a, _ := s.CreateMysis("alpha", "mock", "model", 0.7)
+	b, _ := s.CreateMysis("beta", "mock", "model", 0.7)
+	s.AddMemory(b.ID, MemoryRoleUser, MemorySourceBroadcast, "regroup", "", a.ID)
+	s.AddMemory(b.ID, MemoryRoleUser, MemorySourceBroadcast, "from commander", "", "")
+
+	path := filepath.Join(t.TempDir(), "swarm.json")
+	if _, err := s.ExportSwarm(path); err != nil {
+		t.Fatalf("ExportSwarm() error: %v", err)
+	}
+	result, err := s.ImportSwarm(path)
+	if err != nil {
+		t.Fatalf("ImportSwarm() error: %v", err)
+	}
+	if result.Remapped != 2 {
+		t.Errorf("expected both myses remapped, got %d", result.Remapped)
+	}
+
+	myses, _ := s.ListMyses()
+	ids := make(map[string]string) // name of the copy -> its ID
+	for _, m := range myses {
+		if m.ID != a.ID && m.ID != b.ID {
+			ids[m.Name] = m.ID
+		}
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 copies, got %+v", ids)
+	}
+	memories, _ := s.GetMemories(ids["beta"])
+	if len(memories) != 2 || memories[0].SenderID != ids["alpha"] || memories[1].SenderID != "" {
+		t.Errorf("expected sender remapped to the copy of alpha, got %+v", memories)
+	}
+
+	os.WriteFile(path, []byte(`{"format":"zoea-nova-swarm","version":99}`), 0600)
+	if _, err := s.ImportSwarm(path); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package store
+
+import (
+	"cmp"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+//go:embed schema_postgres.sql
+var postgresSchema string
+
+// Backend is the SQL engine behind a Store. Store queries are written once, in SQLite
+// syntax with ? placeholders; a backend opens its driver, supplies the schema and
+// rewrites queries for its dialect, so Store methods behave the same on every engine.
+type Backend interface {
+	// Name is the [store] driver name.
+	Name() string
+	// Open connects to the database described by dsn.
+	Open(dsn string) (*sql.DB, error)
+	// Schema creates every table at currentSchemaVersion.
+	Schema() string
+	// Rebind rewrites a SQLite-syntax query for this engine.
+	Rebind(query string) string
+	// IsBusy reports whether err is a transient lock or contention error worth retrying.
+	IsBusy(err error) bool
+	// Vacuum releases free space back to the filesystem.
+	Vacuum(db *sql.DB) error
+	// SizeBytes returns the size of the database on disk.
+	SizeBytes(db *sql.DB) (int64, error)
+}
+
+// BackendFor returns the backend selected by [store] (driver "" = sqlite).
+func BackendFor(cfg config.StoreConfig) (Backend, error) {
+	switch cfg.Driver {
+	case "", constants.StoreDriverSQLite:
+		return sqliteBackend{
+			journalMode:       cfg.JournalMode,
+			synchronous:       cfg.Synchronous,
+			walAutocheckpoint: cfg.WALAutocheckpoint,
+		}, nil
+	case constants.StoreDriverPostgres:
+		return postgresBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown store driver: %s", cfg.Driver)
+	}
+}
+
+// sqliteBackend stores everything in a single SQLite file (modernc.org/sqlite).
+// Zero fields use the defaults.
+type sqliteBackend struct {
+	journalMode       string // "" = constants.DefaultSQLiteJournalMode
+	synchronous       string // "" = constants.DefaultSQLiteSynchronous
+	walAutocheckpoint int    // WAL pages between checkpoints (0 = SQLite's default)
+}
+
+func (sqliteBackend) Name() string { return constants.StoreDriverSQLite }
+
+// pragmas returns the per-connection settings added to the DSN.
+func (b sqliteBackend) pragmas() []string {
+	pragmas := []string{
+		// Busy timeout handles concurrent access
+		fmt.Sprintf("busy_timeout(%d)", busyTimeoutMs),
+		fmt.Sprintf("synchronous(%s)", strings.ToUpper(cmp.Or(b.synchronous, constants.DefaultSQLiteSynchronous))),
+	}
+	if b.walAutocheckpoint > 0 {
+		pragmas = append(pragmas, fmt.Sprintf("wal_autocheckpoint(%d)", b.walAutocheckpoint))
+	}
+	return pragmas
+}
+
+func (b sqliteBackend) Open(path string) (*sql.DB, error) {
+	// modernc.org/sqlite applies _pragma parameters to every connection it opens. A
+	// path with its own query string keeps its own settings.
+	dsn := path
+	if !strings.Contains(path, "?") {
+		params := make([]string, 0, 3)
+		for _, pragma := range b.pragmas() {
+			params = append(params, "_pragma="+pragma)
+		}
+		dsn += "?" + strings.Join(params, "&")
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	// Limit to 1 connection to avoid "database is locked" errors with modernc.org/sqlite
+	// especially during tests with concurrent writes.
+	db.SetMaxOpenConns(1)
+
+	// The journal mode is stored in the database file, so setting it once is enough.
+	// WAL (default) lets readers in other processes run alongside the writer.
+	journalMode := strings.ToUpper(cmp.Or(b.journalMode, constants.DefaultSQLiteJournalMode))
+	if _, err := db.Exec("PRAGMA journal_mode=" + journalMode); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("set journal mode %s: %w", journalMode, err)
+	}
+
+	// Enable foreign keys
+	if _, err := db.Exec("PRAGMA foreign_keys=ON"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("enable foreign keys: %w", err)
+	}
+
+	return db, nil
+}
+
+func (sqliteBackend) Schema() string { return schema }
+
+func (sqliteBackend) Rebind(query string) string { return query }
+
+// IsBusy matches SQLITE_BUSY and SQLITE_LOCKED, including their extended result codes.
+func (sqliteBackend) IsBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+	return false
+}
+
+// Vacuum rebuilds the database file, then truncates the WAL.
+func (sqliteBackend) Vacuum(db *sql.DB) error {
+	if _, err := db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("vacuum: %w", err)
+	}
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint WAL: %w", err)
+	}
+	return nil
+}
+
+// SizeBytes returns the size of the main database from its page count.
+func (sqliteBackend) SizeBytes(db *sql.DB) (int64, error) {
+	var pageCount, pageSize int64
+	if err := db.QueryRow("PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, err
+	}
+	if err := db.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, err
+	}
+	return pageCount * pageSize, nil
+}
+
+// postgresBackend stores everything in a PostgreSQL database (github.com/lib/pq),
+// which lets several Zoea Nova instances share one swarm.
+type postgresBackend struct{}
+
+func (postgresBackend) Name() string { return constants.StoreDriverPostgres }
+
+func (postgresBackend) Open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	return db, nil
+}
+
+func (postgresBackend) Schema() string { return postgresSchema }
+
+// Rebind numbers ? placeholders ($1, $2, ...) and turns LIKE into ILIKE, which
+// matches SQLite's case-insensitive LIKE. Queries never contain a literal ?.
+func (postgresBackend) Rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.ReplaceAll(b.String(), " LIKE ", " ILIKE ")
+}
+
+// IsBusy matches serialization failures and deadlocks, which succeed when retried.
+func (postgresBackend) IsBusy(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return true
+	}
+	return false
+}
+
+// Vacuum rewrites every table to return free space to the operating system. It takes
+// exclusive locks, so other instances sharing the database should be stopped.
+func (postgresBackend) Vacuum(db *sql.DB) error {
+	if _, err := db.Exec("VACUUM FULL"); err != nil {
+		return fmt.Errorf("vacuum: %w", err)
+	}
+	return nil
+}
+
+func (postgresBackend) SizeBytes(db *sql.DB) (int64, error) {
+	var size int64
+	err := db.QueryRow("SELECT pg_database_size(current_database())").Scan(&size)
+	return size, err
+}
@@ -24,7 +24,7 @@ func (s *Store) StoreGameStateSnapshot(username, toolName, content string, gameT
 	id := uuid.New().String()
 	capturedAt := time.Now().UTC().Unix()
 
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		INSERT INTO game_state_snapshots (id, username, tool_name, content, game_tick, captured_at)
 		VALUES (?, ?, ?, ?, ?, ?)
 		ON CONFLICT(username, tool_name) DO UPDATE SET
@@ -43,7 +43,7 @@ func (s *Store) GetGameStateSnapshot(username, toolName string) (*GameStateSnaps
 	var gameTick sql.NullInt64
 	var capturedAtUnix int64
 
-	err := s.db.QueryRow(`
+	err := s.queryRow(`
 		SELECT id, username, tool_name, content, game_tick, captured_at
 		FROM game_state_snapshots
 		WHERE username = ? AND tool_name = ?
@@ -70,7 +70,7 @@ func (s *Store) GetGameStateSnapshot(username, toolName string) (*GameStateSnaps
 
 // GetAllGameStateSnapshots retrieves all snapshots for a username, ordered by tool name.
 func (s *Store) GetAllGameStateSnapshots(username string) ([]*GameStateSnapshot, error) {
-	rows, err := s.db.Query(`
+	rows, err := s.query(`
 		SELECT id, username, tool_name, content, game_tick, captured_at
 		FROM game_state_snapshots
 		WHERE username = ?
@@ -111,7 +111,7 @@ func (s *Store) GetAllGameStateSnapshots(username string) ([]*GameStateSnapshot,
 
 // DeleteGameStateSnapshotsForUsername deletes all snapshots for a username (e.g., on logout).
 func (s *Store) DeleteGameStateSnapshotsForUsername(username string) error {
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		DELETE FROM game_state_snapshots
 		WHERE username = ?
 	`, username)
@@ -120,7 +120,7 @@ func (s *Store) DeleteGameStateSnapshotsForUsername(username string) error {
 
 // DeleteGameStateSnapshot deletes a specific snapshot for a username+tool combination.
 func (s *Store) DeleteGameStateSnapshot(username, toolName string) error {
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		DELETE FROM game_state_snapshots
 		WHERE username = ? AND tool_name = ?
 	`, username, toolName)
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -23,11 +24,13 @@ const (
 type MemorySource string
 
 const (
-	MemorySourceDirect    MemorySource = "direct"    // Direct message to specific mysis
-	MemorySourceBroadcast MemorySource = "broadcast" // Broadcast message to all myses
-	MemorySourceSystem    MemorySource = "system"    // System prompts
-	MemorySourceLLM       MemorySource = "llm"       // LLM-generated responses
-	MemorySourceTool      MemorySource = "tool"      // Tool call results
+	MemorySourceDirect        MemorySource = "direct"         // Direct message to specific mysis
+	MemorySourceBroadcast     MemorySource = "broadcast"      // Broadcast message to all myses
+	MemorySourceSystem        MemorySource = "system"         // System prompts
+	MemorySourceLLM           MemorySource = "llm"            // LLM-generated responses
+	MemorySourceTool          MemorySource = "tool"           // Tool call results
+	MemorySourceNote          MemorySource = "note"           // Durable mysis notes (never compacted)
+	MemorySourceNudgeResponse MemorySource = "nudge_response" // LLM responses to synthetic nudges (autonomous turns)
 )
 
 // Memory represents a stored conversation message.
@@ -40,12 +43,13 @@ type Memory struct {
 	Content   string
 	Reasoning string
 	CreatedAt time.Time
+	Pinned    bool // Marked for review in the TUI; never affects context
 }
 
 // AddMemory adds a memory entry for a mysis.
 func (s *Store) AddMemory(mysisID string, role MemoryRole, source MemorySource, content string, reasoning string, senderID string) error {
 	now := time.Now().UTC()
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		INSERT INTO memories (mysis_id, role, source, sender_id, content, reasoning, created_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`, mysisID, role, source, senderID, content, reasoning, now)
@@ -54,8 +58,8 @@ func (s *Store) AddMemory(mysisID string, role MemoryRole, source MemorySource,
 
 // GetMemories retrieves all memories for a mysis, ordered by creation time.
 func (s *Store) GetMemories(mysisID string) ([]*Memory, error) {
-	rows, err := s.db.Query(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
 		WHERE mysis_id = ?
 		ORDER BY created_at ASC
@@ -69,7 +73,7 @@ func (s *Store) GetMemories(mysisID string) ([]*Memory, error) {
 	for rows.Next() {
 		var m Memory
 		var senderID sql.NullString
-		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
 			return nil, fmt.Errorf("scan memory: %w", err)
 		}
 		if senderID.Valid {
@@ -81,17 +85,19 @@ func (s *Store) GetMemories(mysisID string) ([]*Memory, error) {
 	return memories, rows.Err()
 }
 
-// GetSystemMemory retrieves the initial system prompt for a mysis.
+// GetSystemMemory retrieves the system prompt for a mysis.
+// If more than one system memory exists (e.g. the prompt was re-added after an edit),
+// the most recently created one is returned.
 func (s *Store) GetSystemMemory(mysisID string) (*Memory, error) {
 	var m Memory
 	var senderID sql.NullString
-	err := s.db.QueryRow(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	err := s.queryRow(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
 		WHERE mysis_id = ? AND role = 'system' AND source = 'system'
-		ORDER BY created_at ASC
+		ORDER BY created_at DESC, id DESC
 		LIMIT 1
-	`, mysisID).Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt)
+	`, mysisID).Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned)
 	if err != nil {
 		return nil, err
 	}
@@ -101,16 +107,148 @@ func (s *Store) GetSystemMemory(mysisID string) (*Memory, error) {
 	return &m, nil
 }
 
-// DeleteSystemMemory deletes the system memory for a mysis
+// ReplaceSystemMemory sets the system prompt for a mysis, leaving exactly one system memory.
+// The latest existing system memory is updated in place (keeping its position in history)
+// and any older duplicates are removed. If none exists, a new one is added.
+func (s *Store) ReplaceSystemMemory(mysisID, content string) error {
+	return s.withRetry(func() error {
+		return s.replaceSystemMemory(mysisID, content)
+	})
+}
+
+func (s *Store) replaceSystemMemory(mysisID, content string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRow(s.backend.Rebind(`
+		SELECT id FROM memories
+		WHERE mysis_id = ? AND role = 'system' AND source = 'system'
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`), mysisID).Scan(&id)
+	switch {
+	case err == sql.ErrNoRows:
+		if _, err := tx.Exec(s.backend.Rebind(`
+			INSERT INTO memories (mysis_id, role, source, sender_id, content, reasoning, created_at)
+			VALUES (?, ?, ?, '', ?, '', ?)
+		`), mysisID, MemoryRoleSystem, MemorySourceSystem, content, time.Now().UTC()); err != nil {
+			return fmt.Errorf("insert system memory: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("query system memory: %w", err)
+	default:
+		if _, err := tx.Exec(s.backend.Rebind(`UPDATE memories SET content = ? WHERE id = ?`), content, id); err != nil {
+			return fmt.Errorf("update system memory: %w", err)
+		}
+		if _, err := tx.Exec(s.backend.Rebind(`
+			DELETE FROM memories
+			WHERE mysis_id = ? AND role = 'system' AND source = 'system' AND id != ?
+		`), mysisID, id); err != nil {
+			return fmt.Errorf("delete stale system memories: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteSystemMemory deletes the system memory for a mysis (notes are kept).
 func (s *Store) DeleteSystemMemory(mysisID string) error {
-	_, err := s.db.Exec(`DELETE FROM memories WHERE mysis_id = ? AND role = 'system'`, mysisID)
+	_, err := s.exec(`DELETE FROM memories WHERE mysis_id = ? AND role = 'system' AND source = 'system'`, mysisID)
 	return err
 }
 
+// AddNote stores a durable note for a mysis.
+func (s *Store) AddNote(mysisID, content string) error {
+	return s.AddMemory(mysisID, MemoryRoleSystem, MemorySourceNote, content, "", "")
+}
+
+// GetNotes returns all notes for a mysis, oldest first.
+func (s *Store) GetNotes(mysisID string) ([]*Memory, error) {
+	return s.GetRecentNotes(mysisID, -1)
+}
+
+// GetRecentNotes returns the most recent notes for a mysis in chronological order.
+// A negative limit returns all notes.
+func (s *Store) GetRecentNotes(mysisID string, limit int) ([]*Memory, error) {
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
+		FROM memories
+		WHERE mysis_id = ? AND source = 'note'
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, mysisID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*Memory
+	for rows.Next() {
+		var m Memory
+		var senderID sql.NullString
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+		if senderID.Valid {
+			m.SenderID = senderID.String
+		}
+		notes = append(notes, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse to chronological order
+	for i, j := 0, len(notes)-1; i < j; i, j = i+1, j-1 {
+		notes[i], notes[j] = notes[j], notes[i]
+	}
+	return notes, nil
+}
+
+// GetRecentPeerBroadcasts returns the most recent broadcasts a mysis received from other
+// myses, in chronological order. Commander broadcasts and its own are excluded.
+func (s *Store) GetRecentPeerBroadcasts(mysisID string, limit int) ([]*Memory, error) {
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
+		FROM memories
+		WHERE mysis_id = ? AND source = 'broadcast' AND sender_id IS NOT NULL AND sender_id != '' AND sender_id != ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, mysisID, mysisID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query peer broadcasts: %w", err)
+	}
+	defer rows.Close()
+
+	var broadcasts []*Memory
+	for rows.Next() {
+		var m Memory
+		var senderID sql.NullString
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
+			return nil, fmt.Errorf("scan peer broadcast: %w", err)
+		}
+		m.SenderID = senderID.String
+		broadcasts = append(broadcasts, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse to chronological order
+	for i, j := 0, len(broadcasts)-1; i < j; i, j = i+1, j-1 {
+		broadcasts[i], broadcasts[j] = broadcasts[j], broadcasts[i]
+	}
+	return broadcasts, nil
+}
+
 // GetRecentMemories retrieves the most recent N memories for a mysis.
 func (s *Store) GetRecentMemories(mysisID string, limit int) ([]*Memory, error) {
-	rows, err := s.db.Query(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
 		WHERE mysis_id = ?
 		ORDER BY created_at DESC
@@ -125,7 +263,7 @@ func (s *Store) GetRecentMemories(mysisID string, limit int) ([]*Memory, error)
 	for rows.Next() {
 		var m Memory
 		var senderID sql.NullString
-		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
 			return nil, fmt.Errorf("scan memory: %w", err)
 		}
 		if senderID.Valid {
@@ -144,22 +282,55 @@ func (s *Store) GetRecentMemories(mysisID string, limit int) ([]*Memory, error)
 
 // DeleteMemories deletes all memories for a mysis.
 func (s *Store) DeleteMemories(mysisID string) error {
-	_, err := s.db.Exec(`DELETE FROM memories WHERE mysis_id = ?`, mysisID)
+	_, err := s.exec(`DELETE FROM memories WHERE mysis_id = ?`, mysisID)
+	if err != nil {
+		return fmt.Errorf("delete memories: %w", err)
+	}
+	return nil
+}
+
+// DeleteMemoriesByID deletes the given memories of a mysis in one statement. IDs that
+// belong to another mysis are left alone.
+func (s *Store) DeleteMemoriesByID(mysisID string, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, mysisID)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	_, err := s.exec(`DELETE FROM memories WHERE mysis_id = ? AND id IN (`+placeholders+`)`, args...)
 	if err != nil {
 		return fmt.Errorf("delete memories: %w", err)
 	}
 	return nil
 }
 
+// SetMemoryPinned marks or unmarks a memory for review. Pins are a TUI aid and never
+// change what goes into a mysis's context.
+func (s *Store) SetMemoryPinned(memoryID int64, pinned bool) error {
+	result, err := s.exec(`UPDATE memories SET pinned = ? WHERE id = ?`, pinned, memoryID)
+	if err != nil {
+		return fmt.Errorf("set memory pinned: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("memory %d not found", memoryID)
+	}
+	return nil
+}
+
 // CountMemories returns the number of memories for a mysis.
 func (s *Store) CountMemories(mysisID string) (int, error) {
 	var count int
-	err := s.db.QueryRow(`SELECT COUNT(*) FROM memories WHERE mysis_id = ?`, mysisID).Scan(&count)
+	err := s.queryRow(`SELECT COUNT(*) FROM memories WHERE mysis_id = ?`, mysisID).Scan(&count)
 	return count, err
 }
 
 // BroadcastMessage represents a unique broadcast message across all myses.
 type BroadcastMessage struct {
+	ID        string // Shared by every copy of a tracked broadcast ("" = untracked)
 	SenderID  string
 	Content   string
 	CreatedAt time.Time
@@ -187,13 +358,15 @@ func parseBroadcastTime(value string) (time.Time, error) {
 }
 
 // GetRecentBroadcasts retrieves the most recent N unique broadcast messages.
-// Since broadcasts are stored per-mysis, this groups by content to get unique messages.
+// Since broadcasts are stored per-mysis, this groups the copies by broadcast ID, so
+// repeating an earlier text is its own message. Untracked broadcasts have no ID and
+// are grouped by content.
 func (s *Store) GetRecentBroadcasts(limit int) ([]*BroadcastMessage, error) {
-	rows, err := s.db.Query(`
-		SELECT sender_id, content, MIN(created_at) as created_at
+	rows, err := s.query(`
+		SELECT sender_id, MIN(content), MIN(created_at) as created_at, broadcast_id
 		FROM memories
 		WHERE source = 'broadcast'
-		GROUP BY content, sender_id
+		GROUP BY broadcast_id, sender_id, CASE WHEN broadcast_id = '' THEN content ELSE '' END
 		ORDER BY created_at DESC
 		LIMIT ?
 	`, limit)
@@ -204,9 +377,9 @@ func (s *Store) GetRecentBroadcasts(limit int) ([]*BroadcastMessage, error) {
 
 	var messages []*BroadcastMessage
 	for rows.Next() {
-		var content, createdAtStr string
+		var content, createdAtStr, broadcastID string
 		var senderID sql.NullString
-		if err := rows.Scan(&senderID, &content, &createdAtStr); err != nil {
+		if err := rows.Scan(&senderID, &content, &createdAtStr, &broadcastID); err != nil {
 			return nil, fmt.Errorf("scan broadcast: %w", err)
 		}
 		createdAt, err := parseBroadcastTime(createdAtStr)
@@ -217,6 +390,7 @@ func (s *Store) GetRecentBroadcasts(limit int) ([]*BroadcastMessage, error) {
 				Msg("failed to parse broadcast time")
 		}
 		message := &BroadcastMessage{
+			ID:        broadcastID,
 			Content:   content,
 			CreatedAt: createdAt,
 		}
@@ -243,13 +417,13 @@ func (s *Store) GetMostRecentBroadcast(mysisID string) (*Memory, error) {
 	var senderID sql.NullString
 
 	// First, try to find a broadcast for this specific mysis
-	err := s.db.QueryRow(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	err := s.queryRow(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
 		WHERE mysis_id = ? AND source = 'broadcast'
 		ORDER BY created_at DESC
 		LIMIT 1
-	`, mysisID).Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt)
+	`, mysisID).Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned)
 
 	if err == nil {
 		// Found a broadcast for this mysis
@@ -266,13 +440,13 @@ func (s *Store) GetMostRecentBroadcast(mysisID string) (*Memory, error) {
 
 	// No broadcast for this mysis - fall back to most recent global broadcast
 	// This handles new myses created after a broadcast was sent
-	err = s.db.QueryRow(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	err = s.queryRow(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
 		WHERE source = 'broadcast'
 		ORDER BY created_at DESC
 		LIMIT 1
-	`).Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt)
+	`).Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned)
 
 	if err == sql.ErrNoRows {
 		// No broadcasts anywhere in the system
@@ -289,13 +463,83 @@ func (s *Store) GetMostRecentBroadcast(mysisID string) (*Memory, error) {
 	return &m, nil
 }
 
+// BroadcastAck is one recipient of a tracked broadcast.
+type BroadcastAck struct {
+	MysisID string
+	Acked   bool // The mysis finished a turn prompted by the broadcast
+}
+
+// AddBroadcastMemory stores a broadcast received by a mysis, tagged with the ID shared
+// by every copy of the same broadcast so acknowledgments can be tracked.
+func (s *Store) AddBroadcastMemory(mysisID, content, senderID, broadcastID string) error {
+	now := time.Now().UTC()
+	_, err := s.exec(`
+		INSERT INTO memories (mysis_id, role, source, sender_id, content, reasoning, broadcast_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, mysisID, MemoryRoleUser, MemorySourceBroadcast, senderID, content, "", broadcastID, now)
+	return err
+}
+
+// AckBroadcast marks a mysis's copy of a tracked broadcast as acknowledged. It reports
+// false if the memory is not a tracked broadcast held by that mysis.
+func (s *Store) AckBroadcast(mysisID string, memoryID int64) (bool, error) {
+	result, err := s.exec(`
+		UPDATE memories SET acked = ?
+		WHERE id = ? AND mysis_id = ? AND source = 'broadcast' AND broadcast_id <> ''
+	`, true, memoryID, mysisID)
+	if err != nil {
+		return false, fmt.Errorf("ack broadcast: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// BroadcastAcks returns the recipients of a tracked broadcast in delivery order.
+func (s *Store) BroadcastAcks(broadcastID string) ([]BroadcastAck, error) {
+	if broadcastID == "" {
+		return nil, nil
+	}
+	rows, err := s.query(`
+		SELECT mysis_id, acked
+		FROM memories
+		WHERE broadcast_id = ? AND source = 'broadcast'
+		ORDER BY created_at ASC, id ASC
+	`, broadcastID)
+	if err != nil {
+		return nil, fmt.Errorf("query broadcast acks: %w", err)
+	}
+	defer rows.Close()
+
+	var acks []BroadcastAck
+	for rows.Next() {
+		var ack BroadcastAck
+		if err := rows.Scan(&ack.MysisID, &ack.Acked); err != nil {
+			return nil, fmt.Errorf("scan broadcast ack: %w", err)
+		}
+		acks = append(acks, ack)
+	}
+	return acks, rows.Err()
+}
+
+// HasBroadcast reports whether a mysis already holds a broadcast with this content.
+func (s *Store) HasBroadcast(mysisID, content string) (bool, error) {
+	var exists bool
+	err := s.queryRow(`
+		SELECT EXISTS(SELECT 1 FROM memories WHERE mysis_id = ? AND source = 'broadcast' AND content = ?)
+	`, mysisID, content).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check broadcast: %w", err)
+	}
+	return exists, nil
+}
+
 // SearchMemories searches memories for a mysis by content text.
 // Returns memories where content contains the query string (case-sensitive).
 func (s *Store) SearchMemories(mysisID, query string, limit int) ([]*Memory, error) {
-	rows, err := s.db.Query(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
-		WHERE mysis_id = ? AND content LIKE '%' || ? || '%'
+		WHERE mysis_id = ? AND `+memoryContentMatch+`
 		ORDER BY created_at DESC
 		LIMIT ?
 	`, mysisID, query, limit)
@@ -308,7 +552,7 @@ func (s *Store) SearchMemories(mysisID, query string, limit int) ([]*Memory, err
 	for rows.Next() {
 		var m Memory
 		var senderID sql.NullString
-		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
 			return nil, fmt.Errorf("scan memory: %w", err)
 		}
 		if senderID.Valid {
@@ -325,9 +569,87 @@ func (s *Store) SearchMemories(mysisID, query string, limit int) ([]*Memory, err
 	return memories, rows.Err()
 }
 
+// memoryContentMatch is the content filter shared by per-mysis and swarm-wide search.
+const memoryContentMatch = `content LIKE '%' || ? || '%'`
+
+// SwarmMemory is a memory annotated with the name of the mysis that owns it.
+type SwarmMemory struct {
+	*Memory
+	MysisName string
+}
+
+// SearchAllMemories searches memory content across every mysis, using the same
+// matching as SearchMemories. Results are ordered newest first.
+func (s *Store) SearchAllMemories(query string, limit int) ([]*SwarmMemory, error) {
+	rows, err := s.query(`
+		SELECT m.id, m.mysis_id, my.name, m.role, m.source, m.sender_id, m.content, m.reasoning, m.created_at, m.pinned
+		FROM memories m
+		JOIN myses my ON my.id = m.mysis_id
+		WHERE m.`+memoryContentMatch+`
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT ?
+	`, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search all memories: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*SwarmMemory
+	for rows.Next() {
+		var m Memory
+		var name string
+		var senderID sql.NullString
+		if err := rows.Scan(&m.ID, &m.MysisID, &name, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
+			return nil, fmt.Errorf("scan memory: %w", err)
+		}
+		if senderID.Valid {
+			m.SenderID = senderID.String
+		}
+		results = append(results, &SwarmMemory{Memory: &m, MysisName: name})
+	}
+
+	return results, rows.Err()
+}
+
+// GetRecentReasoning returns the most recent memories with non-empty reasoning
+// for a single mysis, in chronological order.
+func (s *Store) GetRecentReasoning(mysisID string, limit int) ([]*Memory, error) {
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
+		FROM memories
+		WHERE mysis_id = ? AND reasoning != ''
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, mysisID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent reasoning: %w", err)
+	}
+	defer rows.Close()
+
+	var memories []*Memory
+	for rows.Next() {
+		var m Memory
+		var senderID sql.NullString
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
+			return nil, fmt.Errorf("scan memory: %w", err)
+		}
+		if senderID.Valid {
+			m.SenderID = senderID.String
+		}
+		memories = append(memories, &m)
+	}
+
+	// Reverse to get chronological order
+	for i, j := 0, len(memories)-1; i < j; i, j = i+1, j-1 {
+		memories[i], memories[j] = memories[j], memories[i]
+	}
+
+	return memories, rows.Err()
+}
+
 func (s *Store) SearchReasoning(mysisID, query string, limit int) ([]*Memory, error) {
-	rows, err := s.db.Query(`
-		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at
+	rows, err := s.query(`
+		SELECT id, mysis_id, role, source, sender_id, content, reasoning, created_at, pinned
 		FROM memories
 		WHERE mysis_id = ? AND reasoning LIKE '%' || ? || '%'
 		ORDER BY created_at DESC
@@ -342,7 +664,7 @@ func (s *Store) SearchReasoning(mysisID, query string, limit int) ([]*Memory, er
 	for rows.Next() {
 		var m Memory
 		var senderID sql.NullString
-		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.MysisID, &m.Role, &m.Source, &senderID, &m.Content, &m.Reasoning, &m.CreatedAt, &m.Pinned); err != nil {
 			return nil, fmt.Errorf("scan memory: %w", err)
 		}
 		if senderID.Valid {
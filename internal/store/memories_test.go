@@ -1,8 +1,12 @@
 package store
 
 import (
+	"context"
+	"database/sql"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func setupMemoriesTest(t *testing.T) (*Store, func()) {
@@ -44,6 +48,200 @@ func TestGetSystemMemory(t *testing.T) {
 	}
 }
 
+func TestGetSystemMemoryReturnsLatest(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+
+	s.AddMemory(mysis.ID, MemoryRoleSystem, MemorySourceSystem, "old prompt", "", "")
+	s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, "hello", "", "")
+	s.AddMemory(mysis.ID, MemoryRoleSystem, MemorySourceSystem, "new prompt", "", "")
+
+	system, err := s.GetSystemMemory(mysis.ID)
+	if err != nil {
+		t.Fatalf("GetSystemMemory() error: %v", err)
+	}
+	if system.Content != "new prompt" {
+		t.Errorf("expected latest prompt, got %q", system.Content)
+	}
+}
+
+func TestReplaceSystemMemory(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+
+	countSystem := func() int {
+		t.Helper()
+		memories, err := s.GetMemories(mysis.ID)
+		if err != nil {
+			t.Fatalf("GetMemories() error: %v", err)
+		}
+		n := 0
+		for _, m := range memories {
+			if m.Role == MemoryRoleSystem && m.Source == MemorySourceSystem {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Inserts when none exists
+	if err := s.ReplaceSystemMemory(mysis.ID, "first"); err != nil {
+		t.Fatalf("ReplaceSystemMemory() error: %v", err)
+	}
+	if n := countSystem(); n != 1 {
+		t.Fatalf("expected 1 system memory, got %d", n)
+	}
+	first, _ := s.GetSystemMemory(mysis.ID)
+
+	// Collapses duplicates and updates the newest in place
+	s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, "hello", "", "")
+	s.AddMemory(mysis.ID, MemoryRoleSystem, MemorySourceSystem, "duplicate", "", "")
+	if err := s.ReplaceSystemMemory(mysis.ID, "second"); err != nil {
+		t.Fatalf("ReplaceSystemMemory() error: %v", err)
+	}
+	if n := countSystem(); n != 1 {
+		t.Fatalf("expected 1 system memory after replace, got %d", n)
+	}
+
+	system, err := s.GetSystemMemory(mysis.ID)
+	if err != nil {
+		t.Fatalf("GetSystemMemory() error: %v", err)
+	}
+	if system.Content != "second" {
+		t.Errorf("expected content %q, got %q", "second", system.Content)
+	}
+	if system.ID == first.ID {
+		t.Error("expected the newest system memory to be kept, got the oldest")
+	}
+
+	// Non-system memories are untouched
+	count, _ := s.CountMemories(mysis.ID)
+	if count != 2 {
+		t.Errorf("expected 2 memories, got %d", count)
+	}
+}
+
+func TestNotes(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+
+	s.AddMemory(mysis.ID, MemoryRoleSystem, MemorySourceSystem, "System prompt", "", "")
+	for _, note := range []string{"first", "second", "third"} {
+		if err := s.AddNote(mysis.ID, note); err != nil {
+			t.Fatalf("AddNote() error: %v", err)
+		}
+	}
+
+	all, err := s.GetNotes(mysis.ID)
+	if err != nil {
+		t.Fatalf("GetNotes() error: %v", err)
+	}
+	if len(all) != 3 || all[0].Content != "first" || all[2].Content != "third" {
+		t.Fatalf("expected 3 notes oldest first, got %v", all)
+	}
+	if all[0].Source != MemorySourceNote {
+		t.Errorf("expected note source, got %s", all[0].Source)
+	}
+
+	recent, err := s.GetRecentNotes(mysis.ID, 2)
+	if err != nil {
+		t.Fatalf("GetRecentNotes() error: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Content != "second" || recent[1].Content != "third" {
+		t.Errorf("expected [second third], got %v", recent)
+	}
+
+	// Replacing the system prompt must not remove notes
+	if err := s.DeleteSystemMemory(mysis.ID); err != nil {
+		t.Fatalf("DeleteSystemMemory() error: %v", err)
+	}
+	all, _ = s.GetNotes(mysis.ID)
+	if len(all) != 3 {
+		t.Errorf("expected notes to survive system memory deletion, got %d", len(all))
+	}
+}
+
+func TestBroadcastAcks(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	scout, _ := s.CreateMysis("scout", "mock", "model", 0.7)
+	miner, _ := s.CreateMysis("miner", "mock", "model", 0.7)
+	s.AddBroadcastMemory(scout.ID, "Hold at Sol", "", "b-1")
+	s.AddBroadcastMemory(miner.ID, "Hold at Sol", "", "b-1")
+	s.AddMemory(scout.ID, MemoryRoleUser, MemorySourceBroadcast, "Untracked", "", "")
+	s.AddBroadcastMemory(miner.ID, "Hold at Sol", "", "b-2")
+
+	// Sending the same text again is a separate broadcast
+	broadcasts, err := s.GetRecentBroadcasts(10)
+	if err != nil {
+		t.Fatalf("GetRecentBroadcasts() error: %v", err)
+	}
+	if len(broadcasts) != 3 || broadcasts[0].ID != "b-1" || broadcasts[1].ID != "" || broadcasts[2].ID != "b-2" {
+		t.Fatalf("unexpected broadcasts: %+v", broadcasts)
+	}
+
+	scoutMemories, _ := s.GetRecentMemories(scout.ID, 10)
+	if acked, err := s.AckBroadcast(miner.ID, scoutMemories[0].ID); err != nil || acked {
+		t.Errorf("expected no ack for another mysis's broadcast, got %v, %v", acked, err)
+	}
+	if acked, err := s.AckBroadcast(scout.ID, scoutMemories[1].ID); err != nil || acked {
+		t.Errorf("expected no ack for an untracked broadcast, got %v, %v", acked, err)
+	}
+	if acked, err := s.AckBroadcast(scout.ID, scoutMemories[0].ID); err != nil || !acked {
+		t.Fatalf("AckBroadcast() = %v, %v", acked, err)
+	}
+
+	acks, err := s.BroadcastAcks("b-1")
+	if err != nil {
+		t.Fatalf("BroadcastAcks() error: %v", err)
+	}
+	want := []BroadcastAck{{MysisID: scout.ID, Acked: true}, {MysisID: miner.ID}}
+	if len(acks) != len(want) || acks[0] != want[0] || acks[1] != want[1] {
+		t.Errorf("BroadcastAcks() = %+v, want %+v", acks, want)
+	}
+}
+
+func TestSetMemoryPinned(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+	s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, "mine the belt", "", "")
+	s.AddMemory(mysis.ID, MemoryRoleAssistant, MemorySourceLLM, "heading out", "", "")
+
+	memories, _ := s.GetRecentMemories(mysis.ID, 10)
+	if len(memories) != 2 || memories[0].Pinned || memories[1].Pinned {
+		t.Fatalf("expected 2 unpinned memories, got %v", memories)
+	}
+
+	if err := s.SetMemoryPinned(memories[1].ID, true); err != nil {
+		t.Fatalf("SetMemoryPinned() error: %v", err)
+	}
+	memories, _ = s.GetRecentMemories(mysis.ID, 10)
+	if memories[0].Pinned || !memories[1].Pinned {
+		t.Errorf("expected only the second memory pinned, got %v", memories)
+	}
+
+	if err := s.SetMemoryPinned(memories[1].ID, false); err != nil {
+		t.Fatalf("SetMemoryPinned() error: %v", err)
+	}
+	memories, _ = s.GetRecentMemories(mysis.ID, 10)
+	if memories[1].Pinned {
+		t.Error("expected memory to be unpinned")
+	}
+
+	if err := s.SetMemoryPinned(-1, true); err == nil {
+		t.Error("expected error pinning a missing memory")
+	}
+}
+
 func TestSearchMemories(t *testing.T) {
 	s, cleanup := setupMemoriesTest(t)
 	defer cleanup()
@@ -73,6 +271,47 @@ func TestSearchMemories(t *testing.T) {
 	}
 }
 
+func TestSearchAllMemories(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	alpha, _ := s.CreateMysis("alpha", "mock", "model", 0.7)
+	beta, _ := s.CreateMysis("beta", "mock", "model", 0.7)
+
+	s.AddMemory(alpha.ID, MemoryRoleAssistant, MemorySourceLLM, "Mining Iron ore", "", "")
+	s.AddMemory(beta.ID, MemoryRoleUser, MemorySourceDirect, "Any copper?", "", "")
+	s.AddMemory(beta.ID, MemoryRoleTool, MemorySourceTool, "call_1:found iron at Sol", "", "")
+
+	results, err := s.SearchAllMemories("iron", 10)
+	if err != nil {
+		t.Fatalf("SearchAllMemories() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	// Newest first, annotated with the owning mysis
+	if results[0].MysisName != "beta" || results[0].MysisID != beta.ID {
+		t.Errorf("expected newest result from beta, got %s (%s)", results[0].MysisName, results[0].Content)
+	}
+	if results[1].MysisName != "alpha" {
+		t.Errorf("expected older result from alpha, got %s", results[1].MysisName)
+	}
+
+	// Matches the same memories as per-mysis search
+	perMysis, _ := s.SearchMemories(alpha.ID, "iron", 10)
+	if len(perMysis) != 1 || perMysis[0].ID != results[1].ID {
+		t.Errorf("expected per-mysis search to match swarm result, got %+v", perMysis)
+	}
+
+	limited, err := s.SearchAllMemories("iron", 1)
+	if err != nil {
+		t.Fatalf("SearchAllMemories() error: %v", err)
+	}
+	if len(limited) != 1 || limited[0].MysisName != "beta" {
+		t.Errorf("expected limit to keep newest result, got %+v", limited)
+	}
+}
+
 func TestSearchReasoning(t *testing.T) {
 	s, cleanup := setupMemoriesTest(t)
 	defer cleanup()
@@ -92,6 +331,44 @@ func TestSearchReasoning(t *testing.T) {
 	}
 }
 
+func TestGetRecentReasoning(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+	other, _ := s.CreateMysis("other", "mock", "model", 0.7)
+
+	s.AddMemory(mysis.ID, MemoryRoleAssistant, MemorySourceLLM, "a1", "first thought", "")
+	s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, "hello", "", "")
+	s.AddMemory(mysis.ID, MemoryRoleAssistant, MemorySourceLLM, "a2", "second thought", "")
+	s.AddMemory(mysis.ID, MemoryRoleAssistant, MemorySourceLLM, "a3", "third thought", "")
+	s.AddMemory(other.ID, MemoryRoleAssistant, MemorySourceLLM, "b1", "private thought", "")
+
+	results, err := s.GetRecentReasoning(mysis.ID, 2)
+	if err != nil {
+		t.Fatalf("GetRecentReasoning() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Reasoning != "second thought" || results[1].Reasoning != "third thought" {
+		t.Errorf("expected latest reasoning in chronological order, got %q, %q", results[0].Reasoning, results[1].Reasoning)
+	}
+
+	all, err := s.GetRecentReasoning(mysis.ID, 10)
+	if err != nil {
+		t.Fatalf("GetRecentReasoning() error: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 results, got %d", len(all))
+	}
+	for _, m := range all {
+		if m.MysisID != mysis.ID {
+			t.Errorf("leaked reasoning from mysis %s", m.MysisID)
+		}
+	}
+}
+
 func TestGetRecentBroadcasts(t *testing.T) {
 	s, cleanup := setupMemoriesTest(t)
 	defer cleanup()
@@ -124,6 +401,25 @@ func TestGetRecentBroadcasts(t *testing.T) {
 	}
 }
 
+func TestHasBroadcast(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+	s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceBroadcast, "B1", "", "")
+	s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, "D1", "", "")
+
+	for content, want := range map[string]bool{"B1": true, "D1": false, "B2": false} {
+		got, err := s.HasBroadcast(mysis.ID, content)
+		if err != nil {
+			t.Fatalf("HasBroadcast(%q) error: %v", content, err)
+		}
+		if got != want {
+			t.Errorf("HasBroadcast(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
 func TestMemoryWithSenderID(t *testing.T) {
 	s, cleanup := setupMemoriesTest(t)
 	defer cleanup()
@@ -149,3 +445,133 @@ func TestMemoryWithSenderID(t *testing.T) {
 		t.Errorf("expected sender_id %q, got %q", senderID, memories[0].SenderID)
 	}
 }
+
+func TestAddMemoryRetriesWhileLocked(t *testing.T) {
+	// Keep SQLite's own wait short so the write has to go through the retry path
+	origTimeout := busyTimeoutMs
+	busyTimeoutMs = 20
+	defer func() { busyTimeoutMs = origTimeout }()
+
+	dbPath := filepath.Join(t.TempDir(), "locked.db")
+	s, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer s.Close()
+
+	mysis, err := s.CreateMysis("locked", "mock", "model", 0.7)
+	if err != nil {
+		t.Fatalf("CreateMysis() error: %v", err)
+	}
+
+	holdWriteLock(t, dbPath, 300*time.Millisecond)
+
+	// Without retries the write fails while the lock is held
+	_, err = s.db.Exec(`UPDATE myses SET name = name WHERE id = ?`, mysis.ID)
+	if !s.backend.IsBusy(err) {
+		t.Fatalf("expected busy error from unretried write, got %v", err)
+	}
+
+	start := time.Now()
+	if err := s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, "hello", "", ""); err != nil {
+		t.Fatalf("AddMemory() error while locked: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("AddMemory() returned after %v, expected it to wait for the lock", elapsed)
+	}
+
+	memories, err := s.GetMemories(mysis.ID)
+	if err != nil {
+		t.Fatalf("GetMemories() error: %v", err)
+	}
+	if len(memories) != 1 || memories[0].Content != "hello" {
+		t.Errorf("expected stored memory, got %+v", memories)
+	}
+}
+
+func TestClaimAccountRetriesWhileLocked(t *testing.T) {
+	origTimeout := busyTimeoutMs
+	busyTimeoutMs = 20
+	defer func() { busyTimeoutMs = origTimeout }()
+
+	dbPath := filepath.Join(t.TempDir(), "locked.db")
+	s, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer s.Close()
+	mysis, _ := s.CreateMysis("locked", "mock", "model", 0.7)
+	if _, err := s.CreateAccount("pilot", "secret"); err != nil {
+		t.Fatalf("CreateAccount() error: %v", err)
+	}
+
+	holdWriteLock(t, dbPath, 300*time.Millisecond)
+	acc, err := s.ClaimAccount(mysis.ID)
+	if err != nil {
+		t.Fatalf("ClaimAccount() error while locked: %v", err)
+	}
+	if acc.Username != "pilot" || acc.AssignedTo != mysis.ID {
+		t.Errorf("unexpected claimed account: %+v", acc)
+	}
+}
+
+// holdWriteLock takes the database's write lock from a second connection, as another
+// process would, and releases it after d.
+func holdWriteLock(t *testing.T, dbPath string, d time.Duration) {
+	t.Helper()
+	other, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open second connection: %v", err)
+	}
+	t.Cleanup(func() { other.Close() })
+	ctx := context.Background()
+	conn, err := other.Conn(ctx)
+	if err != nil {
+		t.Fatalf("get connection: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("begin immediate: %v", err)
+	}
+	released := make(chan struct{})
+	t.Cleanup(func() { <-released })
+	go func() {
+		defer close(released)
+		time.Sleep(d)
+		conn.ExecContext(ctx, "COMMIT")
+		conn.Close()
+	}()
+}
+
+func TestVacuumReclaimsDeletedMemories(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	mysis, _ := s.CreateMysis("test", "mock", "model", 0.7)
+	content := strings.Repeat("x", 4096)
+	for i := 0; i < 200; i++ {
+		if err := s.AddMemory(mysis.ID, MemoryRoleUser, MemorySourceDirect, content, "", ""); err != nil {
+			t.Fatalf("AddMemory() error: %v", err)
+		}
+	}
+	if err := s.DeleteMemories(mysis.ID); err != nil {
+		t.Fatalf("DeleteMemories() error: %v", err)
+	}
+
+	result, err := s.Vacuum()
+	if err != nil {
+		t.Fatalf("Vacuum() error: %v", err)
+	}
+	if result.Reclaimed() < 200*4096/2 {
+		t.Errorf("expected most of the deleted content reclaimed, got %d bytes (before %d, after %d)",
+			result.Reclaimed(), result.BeforeBytes, result.AfterBytes)
+	}
+
+	// Nothing left to reclaim on a second pass
+	result, err = s.Vacuum()
+	if err != nil {
+		t.Fatalf("second Vacuum() error: %v", err)
+	}
+	if result.Reclaimed() != 0 {
+		t.Errorf("expected nothing reclaimed on second vacuum, got %d", result.Reclaimed())
+	}
+}
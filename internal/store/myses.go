@@ -3,6 +3,7 @@ package store
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,10 +13,11 @@ import (
 type MysisState string
 
 const (
-	MysisStateIdle    MysisState = "idle"
-	MysisStateRunning MysisState = "running"
-	MysisStateStopped MysisState = "stopped"
-	MysisStateErrored MysisState = "errored"
+	MysisStateIdle        MysisState = "idle"
+	MysisStateRunning     MysisState = "running"
+	MysisStateStopped     MysisState = "stopped"
+	MysisStateErrored     MysisState = "errored"
+	MysisStateQuarantined MysisState = "quarantined"
 )
 
 // Mysis represents a stored mysis record.
@@ -26,8 +28,29 @@ type Mysis struct {
 	Model       string
 	Temperature float64
 	State       MysisState
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// CompactSnapshots controls whether stale snapshot tool results are dropped from context.
+	CompactSnapshots bool
+	// SnapshotSummaries replaces compacted snapshot results with a one-line marker.
+	SnapshotSummaries bool
+	// ContextWindow overrides the number of recent memories scanned for context (0 = default).
+	ContextWindow int
+	// Autonomous myses nudge themselves between turns; others only turn when messaged.
+	Autonomous bool
+	// SyntheticNudges lets an autonomous mysis with nothing to answer prompt itself with
+	// a nudge; without it the mysis goes idle until messaged.
+	SyntheticNudges bool
+	// Seed is the sampling seed sent with every provider call (nil = none).
+	Seed *int64
+	// ToolAllow, when non-empty, is the only set of tools the mysis may use.
+	ToolAllow []string
+	// ToolDeny lists tools the mysis may never use.
+	ToolDeny []string
+	// Goal is a standing objective injected into every system prompt ("" = none).
+	Goal string
+	// TurnCount is how many turns the mysis has completed over its lifetime.
+	TurnCount int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // CreateMysis creates a new mysis record.
@@ -35,7 +58,7 @@ func (s *Store) CreateMysis(name, provider, model string, temperature float64) (
 	id := uuid.New().String()
 	now := time.Now().UTC()
 
-	_, err := s.db.Exec(`
+	_, err := s.exec(`
 		INSERT INTO myses (id, name, provider, model, temperature, state, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`, id, name, provider, model, temperature, MysisStateIdle, now, now)
@@ -44,21 +67,24 @@ func (s *Store) CreateMysis(name, provider, model string, temperature float64) (
 	}
 
 	return &Mysis{
-		ID:          id,
-		Name:        name,
-		Provider:    provider,
-		Model:       model,
-		Temperature: temperature,
-		State:       MysisStateIdle,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:               id,
+		Name:             name,
+		Provider:         provider,
+		Model:            model,
+		Temperature:      temperature,
+		State:            MysisStateIdle,
+		CompactSnapshots: true,
+		Autonomous:       true,
+		SyntheticNudges:  true,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}, nil
 }
 
 // GetMysis retrieves a mysis by ID.
 func (s *Store) GetMysis(id string) (*Mysis, error) {
-	row := s.db.QueryRow(`
-		SELECT id, name, provider, model, temperature, state, created_at, updated_at
+	row := s.queryRow(`
+		SELECT id, name, provider, model, temperature, state, compact_snapshots, snapshot_summaries, context_window, autonomous, synthetic_nudges, seed, tool_allow, tool_deny, goal, turn_count, created_at, updated_at
 		FROM myses WHERE id = ?
 	`, id)
 
@@ -67,8 +93,8 @@ func (s *Store) GetMysis(id string) (*Mysis, error) {
 
 // ListMyses returns all myses.
 func (s *Store) ListMyses() ([]*Mysis, error) {
-	rows, err := s.db.Query(`
-		SELECT id, name, provider, model, temperature, state, created_at, updated_at
+	rows, err := s.query(`
+		SELECT id, name, provider, model, temperature, state, compact_snapshots, snapshot_summaries, context_window, autonomous, synthetic_nudges, seed, tool_allow, tool_deny, goal, turn_count, created_at, updated_at
 		FROM myses ORDER BY created_at ASC
 	`)
 	if err != nil {
@@ -90,7 +116,7 @@ func (s *Store) ListMyses() ([]*Mysis, error) {
 
 // UpdateMysisState updates a mysis state.
 func (s *Store) UpdateMysisState(id string, state MysisState) error {
-	result, err := s.db.Exec(`
+	result, err := s.exec(`
 		UPDATE myses SET state = ?, updated_at = ? WHERE id = ?
 	`, state, time.Now().UTC(), id)
 	if err != nil {
@@ -106,7 +132,7 @@ func (s *Store) UpdateMysisState(id string, state MysisState) error {
 
 // UpdateMysisConfig updates a mysis provider and model.
 func (s *Store) UpdateMysisConfig(id, provider, model string, temperature float64) error {
-	result, err := s.db.Exec(`
+	result, err := s.exec(`
 		UPDATE myses SET provider = ?, model = ?, temperature = ?, updated_at = ? WHERE id = ?
 	`, provider, model, temperature, time.Now().UTC(), id)
 	if err != nil {
@@ -120,9 +146,179 @@ func (s *Store) UpdateMysisConfig(id, provider, model string, temperature float6
 	return nil
 }
 
+// RenameMysis changes the name of a mysis.
+func (s *Store) RenameMysis(id, name string) error {
+	result, err := s.exec(`
+		UPDATE myses SET name = ?, updated_at = ? WHERE id = ?
+	`, name, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("rename mysis: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisCompactSnapshots enables or disables snapshot compaction for a mysis.
+func (s *Store) SetMysisCompactSnapshots(id string, enabled bool) error {
+	result, err := s.exec(`
+		UPDATE myses SET compact_snapshots = ?, updated_at = ? WHERE id = ?
+	`, enabled, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("update mysis compact snapshots: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisSnapshotSummaries enables or disables snapshot summary markers for a mysis.
+func (s *Store) SetMysisSnapshotSummaries(id string, enabled bool) error {
+	result, err := s.exec(`
+		UPDATE myses SET snapshot_summaries = ?, updated_at = ? WHERE id = ?
+	`, enabled, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("update mysis snapshot summaries: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisContextWindow sets the context window override for a mysis (0 = default).
+func (s *Store) SetMysisContextWindow(id string, window int) error {
+	result, err := s.exec(`
+		UPDATE myses SET context_window = ?, updated_at = ? WHERE id = ?
+	`, window, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("update mysis context window: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisAutonomous sets whether a mysis takes turns without being messaged.
+func (s *Store) SetMysisAutonomous(id string, autonomous bool) error {
+	result, err := s.exec(`
+		UPDATE myses SET autonomous = ?, updated_at = ? WHERE id = ?
+	`, autonomous, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("update mysis autonomous: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisToolPolicy restricts the tools a mysis may use. An empty allow list permits
+// every tool not in deny.
+func (s *Store) SetMysisToolPolicy(mysisID string, allow, deny []string) error {
+	result, err := s.exec(`
+		UPDATE myses SET tool_allow = ?, tool_deny = ?, updated_at = ? WHERE id = ?
+	`, strings.Join(allow, ","), strings.Join(deny, ","), time.Now().UTC(), mysisID)
+	if err != nil {
+		return fmt.Errorf("update mysis tool policy: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisSyntheticNudges sets whether a mysis nudges itself when it has nothing to answer.
+func (s *Store) SetMysisSyntheticNudges(id string, enabled bool) error {
+	result, err := s.exec(`
+		UPDATE myses SET synthetic_nudges = ?, updated_at = ? WHERE id = ?
+	`, enabled, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("update mysis synthetic nudges: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisSeed sets the sampling seed sent with a mysis's provider calls (nil clears it).
+func (s *Store) SetMysisSeed(id string, seed *int64) error {
+	result, err := s.exec(`
+		UPDATE myses SET seed = ?, updated_at = ? WHERE id = ?
+	`, seed, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("update mysis seed: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisGoal sets a mysis's standing goal ("" clears it).
+func (s *Store) SetMysisGoal(mysisID, goal string) error {
+	result, err := s.exec(`
+		UPDATE myses SET goal = ?, updated_at = ? WHERE id = ?
+	`, goal, time.Now().UTC(), mysisID)
+	if err != nil {
+		return fmt.Errorf("update mysis goal: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetMysisTurnCount records how many turns a mysis has completed. It leaves
+// updated_at alone, since turns aren't settings changes.
+func (s *Store) SetMysisTurnCount(mysisID string, count int) error {
+	result, err := s.exec(`
+		UPDATE myses SET turn_count = ? WHERE id = ?
+	`, count, mysisID)
+	if err != nil {
+		return fmt.Errorf("update mysis turn count: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// splitToolList parses a stored comma-separated tool list.
+func splitToolList(stored string) []string {
+	if stored == "" {
+		return nil
+	}
+	return strings.Split(stored, ",")
+}
+
 // DeleteMysis deletes a mysis and its memories (via CASCADE).
 func (s *Store) DeleteMysis(id string) error {
-	result, err := s.db.Exec(`DELETE FROM myses WHERE id = ?`, id)
+	result, err := s.exec(`DELETE FROM myses WHERE id = ?`, id)
 	if err != nil {
 		return fmt.Errorf("delete mysis: %w", err)
 	}
@@ -137,24 +333,28 @@ func (s *Store) DeleteMysis(id string) error {
 // CountMyses returns the total number of myses.
 func (s *Store) CountMyses() (int, error) {
 	var count int
-	err := s.db.QueryRow(`SELECT COUNT(*) FROM myses`).Scan(&count)
+	err := s.queryRow(`SELECT COUNT(*) FROM myses`).Scan(&count)
 	return count, err
 }
 
 func scanMysis(row *sql.Row) (*Mysis, error) {
 	var m Mysis
-	err := row.Scan(&m.ID, &m.Name, &m.Provider, &m.Model, &m.Temperature, &m.State, &m.CreatedAt, &m.UpdatedAt)
+	var allow, deny string
+	err := row.Scan(&m.ID, &m.Name, &m.Provider, &m.Model, &m.Temperature, &m.State, &m.CompactSnapshots, &m.SnapshotSummaries, &m.ContextWindow, &m.Autonomous, &m.SyntheticNudges, &m.Seed, &allow, &deny, &m.Goal, &m.TurnCount, &m.CreatedAt, &m.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
+	m.ToolAllow, m.ToolDeny = splitToolList(allow), splitToolList(deny)
 	return &m, nil
 }
 
 func scanMysisRows(rows *sql.Rows) (*Mysis, error) {
 	var m Mysis
-	err := rows.Scan(&m.ID, &m.Name, &m.Provider, &m.Model, &m.Temperature, &m.State, &m.CreatedAt, &m.UpdatedAt)
+	var allow, deny string
+	err := rows.Scan(&m.ID, &m.Name, &m.Provider, &m.Model, &m.Temperature, &m.State, &m.CompactSnapshots, &m.SnapshotSummaries, &m.ContextWindow, &m.Autonomous, &m.SyntheticNudges, &m.Seed, &allow, &deny, &m.Goal, &m.TurnCount, &m.CreatedAt, &m.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
+	m.ToolAllow, m.ToolDeny = splitToolList(allow), splitToolList(deny)
 	return &m, nil
 }
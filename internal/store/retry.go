@@ -0,0 +1,60 @@
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// busyTimeoutMs is how long SQLite itself waits on a locked database before
+// returning SQLITE_BUSY. Writes that still fail are retried with writeRetryDelays.
+var busyTimeoutMs = 5000
+
+// writeRetryDelays is the backoff between attempts of a write that failed with
+// SQLITE_BUSY or SQLITE_LOCKED (e.g. another process holding the database).
+var writeRetryDelays = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	400 * time.Millisecond,
+	800 * time.Millisecond,
+}
+
+// withRetry runs fn, retrying with short backoff while it fails on a locked database
+// (see Backend.IsBusy). fn must be safe to repeat, i.e. a single statement or a whole
+// transaction.
+func (s *Store) withRetry(fn func() error) error {
+	err := fn()
+	for attempt, delay := range writeRetryDelays {
+		if err == nil || !s.backend.IsBusy(err) {
+			return err
+		}
+		log.Debug().Err(err).Int("attempt", attempt+1).Dur("delay", delay).Msg("Database busy, retrying write")
+		time.Sleep(delay)
+		err = fn()
+	}
+	return err
+}
+
+// exec runs a write statement, retrying while the database is busy.
+func (s *Store) exec(query string, args ...any) (sql.Result, error) {
+	query = s.backend.Rebind(query)
+	var result sql.Result
+	err := s.withRetry(func() error {
+		var err error
+		result, err = s.db.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+// query runs a read statement written in SQLite syntax.
+func (s *Store) query(query string, args ...any) (*sql.Rows, error) {
+	return s.db.Query(s.backend.Rebind(query), args...)
+}
+
+// queryRow runs a single-row statement written in SQLite syntax.
+func (s *Store) queryRow(query string, args ...any) *sql.Row {
+	return s.db.QueryRow(s.backend.Rebind(query), args...)
+}
@@ -8,7 +8,37 @@ CREATE TABLE IF NOT EXISTS schema_version (
 -- Renamed in_use_by to assigned_to (permanent account assignment)
 -- Removed in_use flag (redundant - assigned_to IS NOT NULL means in use)
 -- BREAKING CHANGE: Requires fresh database (make db-reset-accounts)
-INSERT OR REPLACE INTO schema_version (version) VALUES (12);
+-- Schema v12 → v13 Migration:
+-- Added mysis_usage table (cumulative token usage and estimated cost per mysis)
+-- Schema v13 → v14 Migration:
+-- Added myses.compact_snapshots (per-mysis snapshot compaction toggle, default on)
+-- Schema v14 → v15 Migration:
+-- Added myses.context_window (per-mysis context message window, 0 = default)
+-- Schema v15 → v16 Migration:
+-- Added myses.tool_allow and myses.tool_deny (per-mysis tool policy, comma-separated names)
+-- Schema v16 → v17 Migration:
+-- Added myses.snapshot_summaries (per-mysis summary markers for compacted snapshots, default off)
+-- Schema v17 → v18 Migration:
+-- Added memory_templates table (template conversations copied into new myses)
+-- Schema v18 → v19 Migration:
+-- Added myses.autonomous (per-mysis self-nudging between turns, default on)
+-- Schema v19 → v20 Migration:
+-- Added tool_calls table (tool call audit trail, kept after its mysis is deleted)
+-- Schema v20 → v21 Migration:
+-- Added memories.pinned (review marker set from the TUI, default off)
+-- Schema v21 → v22 Migration:
+-- Added memories.broadcast_id and memories.acked (commander broadcast acknowledgments)
+-- Schema v22 → v23 Migration:
+-- Added myses.goal (per-mysis standing goal injected into the system prompt)
+-- Schema v23 → v24 Migration:
+-- Added myses.turn_count (turns completed over the mysis's lifetime)
+-- Schema v24 → v25 Migration:
+-- Added myses.synthetic_nudges (per-mysis nudges when there is nothing to answer, default on)
+-- Schema v25 → v26 Migration:
+-- Added myses.seed (per-mysis sampling seed sent to providers, NULL = none)
+-- Schema v26 → v27 Migration:
+-- Added idx_tool_calls_created_at (swarm-wide tool call stats and pruning)
+INSERT OR REPLACE INTO schema_version (version) VALUES (27);
 
 CREATE TABLE IF NOT EXISTS myses (
     id TEXT PRIMARY KEY,
@@ -17,6 +47,16 @@ CREATE TABLE IF NOT EXISTS myses (
     model TEXT NOT NULL,
     temperature REAL NOT NULL DEFAULT 0.7,
     state TEXT NOT NULL DEFAULT 'idle',
+    compact_snapshots INTEGER NOT NULL DEFAULT 1,
+    snapshot_summaries INTEGER NOT NULL DEFAULT 0,
+    context_window INTEGER NOT NULL DEFAULT 0,
+    autonomous INTEGER NOT NULL DEFAULT 1,
+    synthetic_nudges INTEGER NOT NULL DEFAULT 1,
+    seed INTEGER,
+    tool_allow TEXT NOT NULL DEFAULT '',
+    tool_deny TEXT NOT NULL DEFAULT '',
+    goal TEXT NOT NULL DEFAULT '',
+    turn_count INTEGER NOT NULL DEFAULT 0,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -29,6 +69,9 @@ CREATE TABLE IF NOT EXISTS memories (
 	sender_id TEXT,
 	content TEXT NOT NULL,
 	reasoning TEXT,
+	pinned INTEGER NOT NULL DEFAULT 0,
+	broadcast_id TEXT NOT NULL DEFAULT '',
+	acked INTEGER NOT NULL DEFAULT 0,
 	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	FOREIGN KEY (mysis_id) REFERENCES myses(id) ON DELETE CASCADE
 );
@@ -36,6 +79,7 @@ CREATE TABLE IF NOT EXISTS memories (
 CREATE INDEX IF NOT EXISTS idx_memories_mysis_id ON memories(mysis_id);
 CREATE INDEX IF NOT EXISTS idx_memories_created_at ON memories(created_at);
 CREATE INDEX IF NOT EXISTS idx_memories_source ON memories(source);
+CREATE INDEX IF NOT EXISTS idx_memories_broadcast_id ON memories(broadcast_id);
 
 CREATE TABLE IF NOT EXISTS accounts (
 	username TEXT PRIMARY KEY,
@@ -59,3 +103,37 @@ CREATE TABLE IF NOT EXISTS game_state_snapshots (
 );
 
 CREATE INDEX IF NOT EXISTS idx_game_state_username ON game_state_snapshots(username);
+
+CREATE TABLE IF NOT EXISTS mysis_usage (
+	mysis_id TEXT PRIMARY KEY,
+	prompt_tokens INTEGER NOT NULL DEFAULT 0,
+	completion_tokens INTEGER NOT NULL DEFAULT 0,
+	cost REAL NOT NULL DEFAULT 0,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (mysis_id) REFERENCES myses(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS memory_templates (
+	template_id TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	role TEXT NOT NULL,
+	source TEXT NOT NULL DEFAULT 'direct',
+	sender_id TEXT NOT NULL DEFAULT '',
+	content TEXT NOT NULL,
+	reasoning TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (template_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS tool_calls (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	mysis_id TEXT NOT NULL,
+	tool_name TEXT NOT NULL,
+	success INTEGER NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	duration_ms INTEGER NOT NULL DEFAULT 0,
+	created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tool_calls_tool_name_created_at ON tool_calls(tool_name, created_at);
+CREATE INDEX IF NOT EXISTS idx_tool_calls_created_at ON tool_calls(created_at);
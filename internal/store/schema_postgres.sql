@@ -0,0 +1,106 @@
+-- Zoea Nova database schema (PostgreSQL)
+-- Mirrors schema.sql; keep both at the same version. Migration notes live in schema.sql.
+
+CREATE TABLE IF NOT EXISTS schema_version (
+    version INTEGER PRIMARY KEY
+);
+
+INSERT INTO schema_version (version) VALUES (27) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS myses (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    temperature DOUBLE PRECISION NOT NULL DEFAULT 0.7,
+    state TEXT NOT NULL DEFAULT 'idle',
+    compact_snapshots BOOLEAN NOT NULL DEFAULT TRUE,
+    snapshot_summaries BOOLEAN NOT NULL DEFAULT FALSE,
+    context_window INTEGER NOT NULL DEFAULT 0,
+    autonomous BOOLEAN NOT NULL DEFAULT TRUE,
+    synthetic_nudges BOOLEAN NOT NULL DEFAULT TRUE,
+    seed BIGINT,
+    tool_allow TEXT NOT NULL DEFAULT '',
+    tool_deny TEXT NOT NULL DEFAULT '',
+    goal TEXT NOT NULL DEFAULT '',
+    turn_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS memories (
+	id BIGSERIAL PRIMARY KEY,
+	mysis_id TEXT NOT NULL,
+	role TEXT NOT NULL,
+	source TEXT NOT NULL DEFAULT 'direct',
+	sender_id TEXT,
+	content TEXT NOT NULL,
+	reasoning TEXT,
+	pinned BOOLEAN NOT NULL DEFAULT FALSE,
+	broadcast_id TEXT NOT NULL DEFAULT '',
+	acked BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (mysis_id) REFERENCES myses(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_memories_mysis_id ON memories(mysis_id);
+CREATE INDEX IF NOT EXISTS idx_memories_created_at ON memories(created_at);
+CREATE INDEX IF NOT EXISTS idx_memories_source ON memories(source);
+CREATE INDEX IF NOT EXISTS idx_memories_broadcast_id ON memories(broadcast_id);
+
+CREATE TABLE IF NOT EXISTS accounts (
+	username TEXT PRIMARY KEY,
+	password TEXT NOT NULL,
+	assigned_to TEXT,
+	last_used_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (assigned_to) REFERENCES myses(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_assigned_to ON accounts(assigned_to);
+
+CREATE TABLE IF NOT EXISTS game_state_snapshots (
+	id TEXT PRIMARY KEY,
+	username TEXT NOT NULL,
+	tool_name TEXT NOT NULL,
+	content TEXT NOT NULL,
+	game_tick BIGINT,
+	captured_at BIGINT NOT NULL,
+	UNIQUE(username, tool_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_state_username ON game_state_snapshots(username);
+
+CREATE TABLE IF NOT EXISTS mysis_usage (
+	mysis_id TEXT PRIMARY KEY,
+	prompt_tokens BIGINT NOT NULL DEFAULT 0,
+	completion_tokens BIGINT NOT NULL DEFAULT 0,
+	cost DOUBLE PRECISION NOT NULL DEFAULT 0,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (mysis_id) REFERENCES myses(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS memory_templates (
+	template_id TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	role TEXT NOT NULL,
+	source TEXT NOT NULL DEFAULT 'direct',
+	sender_id TEXT NOT NULL DEFAULT '',
+	content TEXT NOT NULL,
+	reasoning TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (template_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS tool_calls (
+	id BIGSERIAL PRIMARY KEY,
+	mysis_id TEXT NOT NULL,
+	tool_name TEXT NOT NULL,
+	success BOOLEAN NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	duration_ms BIGINT NOT NULL DEFAULT 0,
+	created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tool_calls_tool_name_created_at ON tool_calls(tool_name, created_at);
+CREATE INDEX IF NOT EXISTS idx_tool_calls_created_at ON tool_calls(created_at);
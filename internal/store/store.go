@@ -1,4 +1,5 @@
-// Package store provides SQLite-based persistence for Myses and memories.
+// Package store provides SQL persistence for Myses and memories, backed by SQLite
+// (default) or PostgreSQL.
 package store
 
 import (
@@ -6,63 +7,59 @@ import (
 	_ "embed"
 	"fmt"
 	"path/filepath"
-	"strings"
 
 	"github.com/xonecas/zoea-nova/internal/config"
-	_ "modernc.org/sqlite"
+	"github.com/xonecas/zoea-nova/internal/constants"
 )
 
 //go:embed schema.sql
 var schema string
 
-const currentSchemaVersion = 12
+const currentSchemaVersion = 27
 
-// Store provides access to the SQLite database.
+// Store provides access to the database.
 type Store struct {
-	db *sql.DB
+	db      *sql.DB
+	backend Backend
 }
 
 // New creates a new Store with the database at the default location.
 func New() (*Store, error) {
-	dir, err := config.EnsureDataDir()
+	return NewFromConfig(config.StoreConfig{})
+}
+
+// NewFromConfig opens the store selected by [store]. An empty sqlite DSN uses the
+// default location.
+func NewFromConfig(cfg config.StoreConfig) (*Store, error) {
+	backend, err := BackendFor(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("ensure data dir: %w", err)
+		return nil, err
 	}
-
-	dbPath := filepath.Join(dir, "zoea.db")
-	return Open(dbPath)
+	dsn := cfg.DSN
+	if dsn == "" && backend.Name() == constants.StoreDriverSQLite {
+		dir, err := config.EnsureDataDir()
+		if err != nil {
+			return nil, fmt.Errorf("ensure data dir: %w", err)
+		}
+		dsn = filepath.Join(dir, "zoea.db")
+	}
+	return OpenBackend(backend, dsn)
 }
 
-// Open opens a database at the given path.
+// Open opens a SQLite database at the given path.
 func Open(path string) (*Store, error) {
-	// Add busy timeout to handle concurrent access
-	dsn := path
-	if !strings.Contains(path, "?") {
-		dsn += "?_busy_timeout=5000"
-	}
+	return OpenBackend(sqliteBackend{}, path)
+}
 
-	db, err := sql.Open("sqlite", dsn)
+// OpenBackend opens the database described by dsn on the given backend and brings its
+// schema up to date.
+func OpenBackend(backend Backend, dsn string) (*Store, error) {
+	db, err := backend.Open(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("open database: %w", err)
-	}
-
-	// Limit to 1 connection to avoid "database is locked" errors with modernc.org/sqlite
-	// especially during tests with concurrent writes.
-	db.SetMaxOpenConns(1)
-
-	// Enable WAL mode for better concurrent access (though limited by MaxOpenConns=1)
-	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("enable WAL: %w", err)
-	}
-
-	// Enable foreign keys
-	if _, err := db.Exec("PRAGMA foreign_keys=ON"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("enable foreign keys: %w", err)
+		return nil, err
 	}
 
-	s := &Store{db: db}
+	s := &Store{db: db, backend: backend}
 	if err := s.migrate(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("migrate: %w", err)
@@ -88,7 +85,7 @@ func (s *Store) migrate() error {
 	err := s.db.QueryRow("SELECT version FROM schema_version LIMIT 1").Scan(&version)
 	if err != nil {
 		// Table doesn't exist or is empty, create fresh schema
-		if _, err := s.db.Exec(schema); err != nil {
+		if _, err := s.db.Exec(s.backend.Schema()); err != nil {
 			return fmt.Errorf("create schema: %w", err)
 		}
 		return nil
@@ -122,7 +119,13 @@ func (s *Store) migrate() error {
 	return nil
 }
 
-// DB returns the underlying database connection for advanced queries.
+// Backend returns the engine the store runs on.
+func (s *Store) Backend() Backend {
+	return s.backend
+}
+
+// DB returns the underlying database connection for advanced queries. Queries run
+// directly on it must use the backend's placeholder syntax (see Backend.Rebind).
 func (s *Store) DB() *sql.DB {
 	return s.db
 }
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// Template summarizes a stored template conversation.
+type Template struct {
+	ID        string
+	Memories  int
+	CreatedAt time.Time
+}
+
+// SaveTemplate stores a template conversation, replacing any template with the same ID.
+// Only role, source, sender, content and reasoning are kept from each memory.
+func (s *Store) SaveTemplate(id string, memories []*Memory) error {
+	if id == "" {
+		return fmt.Errorf("template id cannot be empty")
+	}
+	if len(memories) == 0 {
+		return fmt.Errorf("template %s has no memories", id)
+	}
+	return s.withRetry(func() error {
+		return s.saveTemplate(id, memories)
+	})
+}
+
+func (s *Store) saveTemplate(id string, memories []*Memory) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(s.backend.Rebind(`DELETE FROM memory_templates WHERE template_id = ?`), id); err != nil {
+		return fmt.Errorf("delete old template: %w", err)
+	}
+	now := time.Now().UTC()
+	for i, m := range memories {
+		if _, err := tx.Exec(s.backend.Rebind(`
+			INSERT INTO memory_templates (template_id, position, role, source, sender_id, content, reasoning, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`), id, i, m.Role, m.Source, m.SenderID, m.Content, m.Reasoning, now); err != nil {
+			return fmt.Errorf("insert template memory %d: %w", i, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTemplate returns a template's memories in order. Only Role, Source, SenderID,
+// Content and Reasoning are set.
+func (s *Store) GetTemplate(id string) ([]*Memory, error) {
+	rows, err := s.query(`
+		SELECT role, source, sender_id, content, reasoning
+		FROM memory_templates
+		WHERE template_id = ?
+		ORDER BY position ASC
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("query template: %w", err)
+	}
+	defer rows.Close()
+
+	var memories []*Memory
+	for rows.Next() {
+		var m Memory
+		if err := rows.Scan(&m.Role, &m.Source, &m.SenderID, &m.Content, &m.Reasoning); err != nil {
+			return nil, fmt.Errorf("scan template memory: %w", err)
+		}
+		memories = append(memories, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(memories) == 0 {
+		return nil, fmt.Errorf("template not found: %s", id)
+	}
+	return memories, nil
+}
+
+// ListTemplates returns the stored templates ordered by ID.
+func (s *Store) ListTemplates() ([]*Template, error) {
+	rows, err := s.query(`
+		SELECT t.template_id, t.created_at,
+			(SELECT COUNT(*) FROM memory_templates m WHERE m.template_id = t.template_id)
+		FROM memory_templates t
+		WHERE t.position = 0
+		ORDER BY t.template_id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*Template
+	for rows.Next() {
+		var t Template
+		if err := rows.Scan(&t.ID, &t.CreatedAt, &t.Memories); err != nil {
+			return nil, fmt.Errorf("scan template: %w", err)
+		}
+		templates = append(templates, &t)
+	}
+	return templates, rows.Err()
+}
+
+// DeleteTemplate removes a template. Myses already created from it keep their memories.
+func (s *Store) DeleteTemplate(id string) error {
+	result, err := s.exec(`DELETE FROM memory_templates WHERE template_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("template not found: %s", id)
+	}
+	return nil
+}
+
+// SeedMemories adds memories to a mysis in one transaction, keeping their order.
+func (s *Store) SeedMemories(mysisID string, memories []*Memory) error {
+	return s.withRetry(func() error {
+		return s.seedMemories(mysisID, memories)
+	})
+}
+
+func (s *Store) seedMemories(mysisID string, memories []*Memory) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Step timestamps so memories sort in template order on every backend
+	now := time.Now().UTC()
+	for i, m := range memories {
+		if _, err := tx.Exec(s.backend.Rebind(`
+			INSERT INTO memories (mysis_id, role, source, sender_id, content, reasoning, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`), mysisID, m.Role, m.Source, m.SenderID, m.Content, m.Reasoning, now.Add(time.Duration(i)*time.Microsecond)); err != nil {
+			return fmt.Errorf("insert memory %d: %w", i, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// ToolCallEvent is one entry in the tool call audit trail.
+type ToolCallEvent struct {
+	MysisID    string
+	ToolName   string
+	Success    bool
+	Error      string
+	DurationMs int64
+	CreatedAt  time.Time
+}
+
+// ToolCallStat counts the calls of one tool.
+type ToolCallStat struct {
+	ToolName string `json:"tool_name"`
+	Calls    int    `json:"calls"`
+	Errors   int    `json:"errors"`
+}
+
+// RecordToolCalls appends events to the audit trail in one transaction.
+func (s *Store) RecordToolCalls(events []ToolCallEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return s.withRetry(func() error {
+		return s.recordToolCalls(events)
+	})
+}
+
+func (s *Store) recordToolCalls(events []ToolCallEvent) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(s.backend.Rebind(`
+		INSERT INTO tool_calls (mysis_id, tool_name, success, error, duration_ms, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`))
+	if err != nil {
+		return fmt.Errorf("prepare tool call insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		if _, err := stmt.Exec(e.MysisID, e.ToolName, e.Success, e.Error, e.DurationMs, e.CreatedAt.UnixMilli()); err != nil {
+			return fmt.Errorf("insert tool call: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PruneToolCalls deletes tool calls made before the given time and returns how many
+// were removed.
+func (s *Store) PruneToolCalls(before time.Time) (int64, error) {
+	result, err := s.exec(`DELETE FROM tool_calls WHERE created_at < ?`, before.UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("prune tool calls: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ToolCallStats counts tool calls made since the given time, most called first. An
+// empty toolName counts every tool; otherwise only that one.
+func (s *Store) ToolCallStats(since time.Time, toolName string) ([]ToolCallStat, error) {
+	query := `
+		SELECT tool_name, COUNT(*), SUM(CASE WHEN success THEN 0 ELSE 1 END)
+		FROM tool_calls
+		WHERE created_at >= ?`
+	args := []any{since.UnixMilli()}
+	if toolName != "" {
+		query += ` AND tool_name = ?`
+		args = append(args, toolName)
+	}
+	query += `
+		GROUP BY tool_name
+		ORDER BY COUNT(*) DESC, tool_name ASC`
+
+	rows, err := s.query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tool call stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []ToolCallStat
+	for rows.Next() {
+		var stat ToolCallStat
+		if err := rows.Scan(&stat.ToolName, &stat.Calls, &stat.Errors); err != nil {
+			return nil, fmt.Errorf("scan tool call stat: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToolCallStats(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+	events := []ToolCallEvent{
+		{MysisID: "a", ToolName: "attack", Success: true, CreatedAt: now},
+		{MysisID: "b", ToolName: "attack", Success: false, Error: "out of range", CreatedAt: now},
+		{MysisID: "a", ToolName: "mine", Success: true, CreatedAt: now},
+		{MysisID: "a", ToolName: "attack", Success: true, CreatedAt: old},
+	}
+	if err := s.RecordToolCalls(events); err != nil {
+		t.Fatalf("RecordToolCalls() error: %v", err)
+	}
+
+	stats, err := s.ToolCallStats(now.Add(-time.Hour), "")
+	if err != nil {
+		t.Fatalf("ToolCallStats() error: %v", err)
+	}
+	want := []ToolCallStat{
+		{ToolName: "attack", Calls: 2, Errors: 1},
+		{ToolName: "mine", Calls: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("expected %d stats, got %+v", len(want), stats)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("stat %d: expected %+v, got %+v", i, want[i], stats[i])
+		}
+	}
+
+	// Filtering by tool includes older calls when since allows it
+	stats, err = s.ToolCallStats(old, "attack")
+	if err != nil {
+		t.Fatalf("ToolCallStats() error: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Calls != 3 || stats[0].Errors != 1 {
+		t.Errorf("unexpected attack stats: %+v", stats)
+	}
+
+	stats, err = s.ToolCallStats(old, "scan")
+	if err != nil {
+		t.Fatalf("ToolCallStats() error: %v", err)
+	}
+	if len(stats) != 0 {
+		t.Errorf("expected no stats for an unused tool, got %+v", stats)
+	}
+
+	// Pruning drops only the calls before the cutoff
+	pruned, err := s.PruneToolCalls(now.Add(-time.Hour))
+	if err != nil || pruned != 1 {
+		t.Fatalf("PruneToolCalls() = %d, %v; want 1 row pruned", pruned, err)
+	}
+	stats, _ = s.ToolCallStats(old, "attack")
+	if len(stats) != 1 || stats[0].Calls != 2 {
+		t.Errorf("expected 2 attack calls left after pruning, got %+v", stats)
+	}
+}
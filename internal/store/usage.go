@@ -0,0 +1,64 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// CostStats holds cumulative token usage and estimated cost.
+type CostStats struct {
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// TotalTokens returns prompt plus completion tokens.
+func (c CostStats) TotalTokens() int {
+	return c.PromptTokens + c.CompletionTokens
+}
+
+// RecordUsage adds token usage and estimated cost to a mysis's running totals.
+func (s *Store) RecordUsage(mysisID string, promptTokens, completionTokens int, cost float64) error {
+	now := time.Now().UTC()
+	_, err := s.exec(`
+		INSERT INTO mysis_usage (mysis_id, prompt_tokens, completion_tokens, cost, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(mysis_id) DO UPDATE SET
+			prompt_tokens = mysis_usage.prompt_tokens + excluded.prompt_tokens,
+			completion_tokens = mysis_usage.completion_tokens + excluded.completion_tokens,
+			cost = mysis_usage.cost + excluded.cost,
+			updated_at = excluded.updated_at
+	`, mysisID, promptTokens, completionTokens, cost, now)
+	if err != nil {
+		return fmt.Errorf("record usage: %w", err)
+	}
+	return nil
+}
+
+// GetCostStats returns cumulative usage for a mysis.
+// Myses with no recorded usage return zero stats.
+func (s *Store) GetCostStats(mysisID string) (*CostStats, error) {
+	var stats CostStats
+	err := s.queryRow(`
+		SELECT COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(cost), 0)
+		FROM mysis_usage
+		WHERE mysis_id = ?
+	`, mysisID).Scan(&stats.PromptTokens, &stats.CompletionTokens, &stats.Cost)
+	if err != nil {
+		return nil, fmt.Errorf("get cost stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// GetTotalCostStats returns cumulative usage across all myses.
+func (s *Store) GetTotalCostStats() (*CostStats, error) {
+	var stats CostStats
+	err := s.queryRow(`
+		SELECT COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(cost), 0)
+		FROM mysis_usage
+	`).Scan(&stats.PromptTokens, &stats.CompletionTokens, &stats.Cost)
+	if err != nil {
+		return nil, fmt.Errorf("get total cost stats: %w", err)
+	}
+	return &stats, nil
+}
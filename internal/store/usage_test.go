@@ -0,0 +1,46 @@
+package store
+
+import "testing"
+
+func TestRecordUsageAccumulates(t *testing.T) {
+	s, cleanup := setupMemoriesTest(t)
+	defer cleanup()
+
+	a, _ := s.CreateMysis("alpha", "mock", "model", 0.7)
+	b, _ := s.CreateMysis("beta", "mock", "model", 0.7)
+
+	// No usage yet reports zero
+	stats, err := s.GetCostStats(a.ID)
+	if err != nil {
+		t.Fatalf("GetCostStats() error: %v", err)
+	}
+	if stats.TotalTokens() != 0 || stats.Cost != 0 {
+		t.Errorf("expected zero stats, got %+v", stats)
+	}
+
+	if err := s.RecordUsage(a.ID, 100, 20, 0.25); err != nil {
+		t.Fatalf("RecordUsage() error: %v", err)
+	}
+	if err := s.RecordUsage(a.ID, 50, 10, 0.25); err != nil {
+		t.Fatalf("RecordUsage() error: %v", err)
+	}
+	if err := s.RecordUsage(b.ID, 10, 5, 1.0); err != nil {
+		t.Fatalf("RecordUsage() error: %v", err)
+	}
+
+	stats, err = s.GetCostStats(a.ID)
+	if err != nil {
+		t.Fatalf("GetCostStats() error: %v", err)
+	}
+	if stats.PromptTokens != 150 || stats.CompletionTokens != 30 || stats.Cost != 0.5 {
+		t.Errorf("unexpected stats for alpha: %+v", stats)
+	}
+
+	total, err := s.GetTotalCostStats()
+	if err != nil {
+		t.Fatalf("GetTotalCostStats() error: %v", err)
+	}
+	if total.TotalTokens() != 195 || total.Cost != 1.5 {
+		t.Errorf("unexpected total stats: %+v", total)
+	}
+}
@@ -0,0 +1,44 @@
+package store
+
+import "fmt"
+
+// VacuumResult reports the database size before and after a vacuum.
+type VacuumResult struct {
+	BeforeBytes int64
+	AfterBytes  int64
+}
+
+// Reclaimed returns how many bytes the vacuum freed.
+func (r VacuumResult) Reclaimed() int64 {
+	if r.AfterBytes > r.BeforeBytes {
+		return 0
+	}
+	return r.BeforeBytes - r.AfterBytes
+}
+
+// Vacuum rebuilds the database to release free pages (see Backend.Vacuum).
+// VACUUM rewrites the whole database, so callers should make sure no myses are writing.
+func (s *Store) Vacuum() (VacuumResult, error) {
+	var result VacuumResult
+	before, err := s.sizeBytes()
+	if err != nil {
+		return result, fmt.Errorf("measure database: %w", err)
+	}
+	result.BeforeBytes = before
+
+	if err := s.withRetry(func() error { return s.backend.Vacuum(s.db) }); err != nil {
+		return result, err
+	}
+
+	after, err := s.sizeBytes()
+	if err != nil {
+		return result, fmt.Errorf("measure database: %w", err)
+	}
+	result.AfterBytes = after
+	return result, nil
+}
+
+// sizeBytes returns the size of the database in bytes.
+func (s *Store) sizeBytes() (int64, error) {
+	return s.backend.SizeBytes(s.db)
+}
@@ -2,8 +2,12 @@
 package tui
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/xonecas/zoea-nova/internal/config"
 	"github.com/xonecas/zoea-nova/internal/core"
+	"github.com/xonecas/zoea-nova/internal/mcp"
 	"github.com/xonecas/zoea-nova/internal/store"
 )
 
@@ -23,6 +28,7 @@ type View int
 const (
 	ViewDashboard View = iota
 	ViewFocus
+	ViewCompare
 )
 
 // InputStage represents stages in multi-step input flows.
@@ -52,12 +58,15 @@ type Model struct {
 	logs    []LogEntry
 	focusID string
 
+	runningIDs []string // running mysis IDs in dashboard order, for focus cycling
+
 	// Multi-stage input for mysis creation
 	inputStage           InputStage
 	pendingMysisName     string
 	pendingMysisProvider string
 
 	pendingProvider string
+	pendingModel    string
 	startSwarm      bool // auto-start idle myses on launch
 
 	// Swarm broadcast history
@@ -78,6 +87,27 @@ type Model struct {
 	viewport           viewport.Model
 	viewportTotalLines int // total lines in viewport content
 
+	// System prompt overlay (focus view, nil when hidden)
+	promptPreview  *core.SystemPromptPreview
+	promptViewport viewport.Model
+
+	// Provider/model quick-switch overlay (nil when hidden)
+	modelPicker *modelPicker
+
+	// Compare view: two myses side by side in one shared viewport
+	compareMarkID     string // First mysis picked on the dashboard, waiting for a second
+	compareIDs        [2]string
+	compareLogs       [2][]LogEntry
+	compareStats      [2]CompareStats
+	compareViewport   viewport.Model
+	compareTotalLines int
+
+	toolStats []store.ToolCallStat // Swarm tool calls within toolStatsWindow, for the dashboard
+
+	pinnedOnly     bool  // Focus view shows pinned log entries only
+	expandTurns    bool  // Focus view shows earlier turns in full instead of collapsed
+	logEntryStarts []int // First viewport line of each entry in logs
+
 	// Sidebar scroll state
 	sidebarScrollOffset int // current scroll position for game state sidebar
 	sidebarTotalLines   int // total lines in sidebar content
@@ -88,12 +118,21 @@ type Model struct {
 
 	providerErrorTimes []time.Time
 
+	// Transient status message shown in the status bar (e.g. bulk operation summaries)
+	status      string
+	statusUntil time.Time
+
+	// Persisted selection and view (see SetStateFile)
+	statePath        string
+	pendingViewState *viewState // Loaded state waiting for the first mysis refresh
+
 	onQuit func() // Callback to run before quitting
 	err    error
 }
 
 const (
 	providerErrorWindow     = 10 * time.Minute
+	statusMessageDuration   = 5 * time.Second
 	maxConversationEntries  = 500 // Maximum conversation log entries to load for performance
 	MaxConversationMessages = 500 // Maximum conversation messages to display in focus view
 )
@@ -103,6 +142,7 @@ type SwarmMessage struct {
 	SenderID  string
 	Content   string
 	CreatedAt time.Time
+	Acks      []core.BroadcastAck // Recipients of a tracked commander broadcast
 }
 
 // EventMsg wraps a core event for the TUI.
@@ -127,19 +167,20 @@ func New(commander *core.Commander, s *store.Store, eventCh <-chan core.Event, s
 	vp.Style = logStyle
 
 	return Model{
-		commander:    commander,
-		store:        s,
-		eventCh:      eventCh,
-		config:       cfg,
-		view:         ViewDashboard,
-		input:        NewInputModel(),
-		myses:        []MysisInfo{},
-		inputStage:   InputStageName,
-		spinner:      sp,
-		loadingSet:   make(map[string]bool),
-		viewport:     vp,
-		netIndicator: NewNetIndicator(),
-		startSwarm:   startSwarm,
+		commander:       commander,
+		store:           s,
+		eventCh:         eventCh,
+		config:          cfg,
+		view:            ViewDashboard,
+		input:           NewInputModel(),
+		myses:           []MysisInfo{},
+		inputStage:      InputStageName,
+		spinner:         sp,
+		loadingSet:      make(map[string]bool),
+		viewport:        vp,
+		compareViewport: viewport.New(80, 20),
+		netIndicator:    NewNetIndicator(),
+		startSwarm:      startSwarm,
 	}
 }
 
@@ -158,6 +199,7 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.netIndicator.Init(),
 		m.refreshMyses(),
+		m.loadToolStats(0),
 		m.listenForEvents(),
 		m.spinner.Tick,
 	)
@@ -215,16 +257,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport.Width = conversationWidth - 2 // -2 for scrollbar
 		m.viewport.Height = vpHeight
 
+		// The compare view has the same chrome as the focus view, across the full width
+		m.compareViewport.Width = 2*compareColumnWidth(msg.Width) + compareColumnGap
+		m.compareViewport.Height = vpHeight
+
 		// Re-render content if in focus view
 		if m.view == ViewFocus {
 			m.updateViewportContent()
 		}
+		if m.view == ViewCompare {
+			m.updateCompareContent()
+		}
+		if m.promptPreview != nil {
+			m.promptViewport.Width, m.promptViewport.Height = promptViewportSize(m.width, m.height)
+			setPromptContent(&m.promptViewport, m.promptPreview)
+		}
 
 	case tea.MouseMsg:
+		if m.promptPreview != nil {
+			var cmd tea.Cmd
+			m.promptViewport, cmd = m.promptViewport.Update(msg)
+			return m, cmd
+		}
 		// Handle mouse wheel scrolling in focus view
 		if m.view == ViewFocus {
 			return m.handleMouseInFocus(msg)
 		}
+		if m.view == ViewCompare {
+			var cmd tea.Cmd
+			m.compareViewport, cmd = m.compareViewport.Update(msg)
+			return m, cmd
+		}
 		// For other views, let viewport handle it
 		var cmd tea.Cmd
 		m.viewport, cmd = m.viewport.Update(msg)
@@ -248,9 +311,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// System prompt overlay takes scroll keys until closed
+		if m.promptPreview != nil {
+			return m.handlePromptKey(msg)
+		}
+
+		if m.modelPicker != nil {
+			return m.handleModelPickerKey(msg)
+		}
+
 		// Handle global keys
 		switch {
 		case key.Matches(msg, keys.Quit):
+			m.SaveViewState()
 			// Call cleanup callback before quitting
 			if m.onQuit != nil {
 				m.onQuit()
@@ -262,13 +335,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.view = ViewDashboard
 				m.focusID = ""
 			}
+			if m.view == ViewCompare {
+				m.closeCompare()
+			}
 			return m, nil
 		}
 
 		// View-specific keys
-		if m.view == ViewDashboard {
+		switch m.view {
+		case ViewDashboard:
 			return m.handleDashboardKey(msg)
-		} else {
+		case ViewCompare:
+			return m.handleCompareKey(msg)
+		default:
 			return m.handleFocusKey(msg)
 		}
 
@@ -282,6 +361,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case refreshMysesMsg:
 		m.refreshMysisList()
+		m.restoreViewState()
 		m.refreshSwarmMessages()
 		m.refreshTick()
 
@@ -305,6 +385,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Don't refresh logs here - EventMysisMessage and EventMysisResponse handle that
 
+	case bulkResultMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.setStatus(formatBulkStatus(msg.action, msg.result))
+		}
+		m.refreshMysisList()
+
+	case providerSwitchedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.setStatus(fmt.Sprintf("Switched to %s %s", msg.provider, msg.model))
+		}
+
+	case modelChoicesMsg:
+		if m.modelPicker != nil && m.modelPicker.mysisID == msg.mysisID {
+			m.modelPicker.setChoices(msg.choices)
+		}
+
+	case toolRetriedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.setStatus(formatRetryStatus(msg.name, msg.result))
+		}
+
+	case toolStatsMsg:
+		if msg.err == nil {
+			m.toolStats = msg.stats
+		}
+		cmds = append(cmds, m.loadToolStats(toolStatsRefresh))
+
+	case vacuumResultMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.setStatus(formatVacuumStatus(msg.result))
+		}
+
 	case broadcastResult:
 		// Broadcast finished - clear all loading states
 		m.loadingSet = make(map[string]bool)
@@ -312,7 +432,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.sending = false
 		m.sendingMode = InputModeNone
 		m.input.Reset()
-		if msg.err != nil {
+		if errors.Is(msg.err, core.ErrBroadcastDuplicate) {
+			m.setStatus("Duplicate broadcast suppressed")
+		} else if msg.err != nil {
 			m.err = msg.err
 		}
 		// Refresh swarm messages to show the new broadcast
@@ -355,6 +477,10 @@ func (m Model) View() string {
 
 	if m.showHelp {
 		content = RenderHelp(m.width, contentHeight)
+	} else if m.promptPreview != nil {
+		content = RenderSystemPrompt(m.promptPreview, m.promptViewport, m.width, contentHeight)
+	} else if m.modelPicker != nil {
+		content = RenderModelPicker(m.modelPicker, m.width, contentHeight)
 	} else if m.view == ViewFocus {
 		focusIndex, totalMyses := m.focusPosition(m.focusID)
 
@@ -369,19 +495,30 @@ func (m Model) View() string {
 		}
 
 		content = RenderFocusViewWithViewport(focusMysis, m.viewport, m.width, isLoading, m.spinner.View(), m.verboseJSON, m.viewportTotalLines, focusIndex, totalMyses, m.currentTick, gameStateSnapshots, m.sidebarScrollOffset, m.err)
+	} else if m.view == ViewCompare {
+		left := CompareColumn{Mysis: m.mysisByID(m.compareIDs[0]), Stats: m.compareStats[0]}
+		right := CompareColumn{Mysis: m.mysisByID(m.compareIDs[1]), Stats: m.compareStats[1]}
+		content = RenderCompareView(left, right, m.compareViewport, m.width, m.verboseJSON, m.compareTotalLines, m.err)
 	} else {
 		// Convert swarm messages for display (reversed so most recent is first)
 		swarmInfos := make([]SwarmMessageInfo, len(m.swarmMessages))
 		for i, msg := range m.swarmMessages {
 			// Reverse order: most recent first
-			swarmInfos[len(m.swarmMessages)-1-i] = SwarmMessageInfo{
+			info := SwarmMessageInfo{
 				SenderID:   msg.SenderID,
 				SenderName: m.mysisNameByID(msg.SenderID),
 				Content:    msg.Content,
 				CreatedAt:  msg.CreatedAt,
+				Recipients: len(msg.Acks),
 			}
+			for _, ack := range msg.Acks {
+				if ack.Acked {
+					info.Acked++
+				}
+			}
+			swarmInfos[len(m.swarmMessages)-1-i] = info
 		}
-		content = RenderDashboard(m.myses, swarmInfos, m.selectedIdx, m.width, contentHeight-3, m.loadingSet, m.spinner.View(), m.currentTick, m.err)
+		content = RenderDashboard(m.myses, swarmInfos, m.selectedIdx, m.width, contentHeight-3, m.loadingSet, m.spinner.View(), m.currentTick, m.toolStats, m.err)
 	}
 
 	// Always show message bar
@@ -407,14 +544,27 @@ func (m Model) View() string {
 // renderStatusBar renders the bottom status bar with activity indicator, tick, and state counts.
 // Layout: [activity indicator]  |  T#### ⬡ [HH:MM]  |  [state icons + counts]
 func (m Model) renderStatusBar() string {
-	// Left segment: Activity indicator (LLM/MCP/IDLE)
+	// Left segment: Activity indicator (LLM/MCP/IDLE), plus why the focused mysis is waiting
 	leftSegment := m.netIndicator.View()
+	if m.view == ViewFocus {
+		if label := activityLabel(m.mysisByID(m.focusID)); label != "" {
+			leftSegment += " " + lipgloss.NewStyle().Foreground(colorTeal).Render(label)
+		}
+	}
 
-	// Middle segment: Tick + timestamp
+	// Middle segment: Tick + timestamp, or a transient status message
 	middleSegment := m.renderTickTimestamp()
+	if m.statusActive() {
+		middleSegment = lipgloss.NewStyle().Foreground(colorTeal).Render(truncateToWidth(m.status, m.width/2))
+	}
 
-	// Right segment: State counts with animated icons
+	// Right segment: State counts with animated icons, plus turn slots when capped
 	rightSegment := m.renderStateCounts()
+	if m.commander != nil {
+		if inFlight, limit := m.commander.InFlightTurns(); limit > 0 {
+			rightSegment = dimmedStyle.Render(fmt.Sprintf("turns %d/%d", inFlight, limit)) + "  " + rightSegment
+		}
+	}
 
 	// Calculate widths
 	leftWidth := lipgloss.Width(leftSegment)
@@ -462,14 +612,15 @@ func (m Model) renderStatusBar() string {
 }
 
 // renderStateCounts renders the state counts with animated icons.
-// Format: ⬡ 3  ◦ 2  ◌ 1  ✖ 0
+// Format: ⬡ 3  ◦ 2  ◌ 1  ✖ 0  ⊘ 0
 func (m Model) renderStateCounts() string {
 	// Count states
 	counts := map[string]int{
-		"running": 0,
-		"idle":    0,
-		"stopped": 0,
-		"errored": 0,
+		"running":     0,
+		"idle":        0,
+		"stopped":     0,
+		"errored":     0,
+		"quarantined": 0,
 	}
 
 	for _, mysis := range m.myses {
@@ -510,6 +661,13 @@ func (m Model) renderStateCounts() string {
 		parts = append(parts, icon+" "+count)
 	}
 
+	// Quarantined: ⊘
+	if counts["quarantined"] > 0 {
+		icon := stateQuarantinedStyle.Render("⊘")
+		count := fmt.Sprintf("%d", counts["quarantined"])
+		parts = append(parts, icon+" "+count)
+	}
+
 	if len(parts) == 0 {
 		return dimmedStyle.Render("(no myses)")
 	}
@@ -519,17 +677,9 @@ func (m Model) renderStateCounts() string {
 
 // renderTickTimestamp renders the tick + timestamp in format: T#### ⬡ [HH:MM]
 func (m Model) renderTickTimestamp() string {
-	// Get current time (or test time override)
-	var now time.Time
-	if m.testTime != nil {
-		now = *m.testTime
-	} else {
-		now = time.Now()
-	}
-
 	// Use the shared formatter from styles.go
 	// formatTickTimestamp returns pre-styled string with colors
-	return formatTickTimestamp(m.currentTick, now)
+	return formatTickTimestamp(m.currentTick, m.now())
 }
 
 func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -554,6 +704,11 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case key.Matches(msg, keys.NewMysis):
+		// Don't ask for a name the commander would refuse
+		if ok, _ := m.commander.CanCreateMysis(); !ok {
+			m.err = &core.MaxMysesError{Current: m.commander.MysisCount(), Max: m.commander.MaxMyses()}
+			return m, nil
+		}
 		m.input.SetMode(InputModeNewMysis, "")
 		m.inputStage = InputStageName // Reset to name stage
 		m.pendingMysisName = ""
@@ -562,12 +717,10 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, keys.Delete):
 		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
-			id := m.myses[m.selectedIdx].ID
-			m.err = m.commander.DeleteMysis(id, true)
-			m.refreshMysisList()
-			if m.selectedIdx >= len(m.myses) && m.selectedIdx > 0 {
-				m.selectedIdx--
-			}
+			mysis := m.myses[m.selectedIdx]
+			m.input.SetMode(InputModeConfirmDelete, mysis.ID)
+			m.input.textInput.Placeholder = fmt.Sprintf("Delete %s and its memories? Type y to confirm...", mysis.Name)
+			return m, m.input.Focus()
 		}
 
 	case key.Matches(msg, keys.Relaunch):
@@ -576,12 +729,35 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.err = m.commander.StartMysis(id)
 		}
 
+	case key.Matches(msg, keys.Recover):
+		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
+			id := m.myses[m.selectedIdx].ID
+			m.err = m.commander.RecoverMysis(id)
+		}
+
 	case key.Matches(msg, keys.Stop):
 		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
 			id := m.myses[m.selectedIdx].ID
 			m.err = m.commander.StopMysis(id)
 		}
 
+	case key.Matches(msg, keys.StartAll):
+		return m, m.runBulk("Start idle", m.commander.StartAllIdle)
+
+	case key.Matches(msg, keys.StopAll):
+		return m, m.runBulk("Stop running", m.commander.StopRunning)
+
+	case key.Matches(msg, keys.RelaunchAll):
+		return m, m.runBulk("Relaunch errored", m.commander.RelaunchErrored)
+
+	case key.Matches(msg, keys.RelaunchBroadcast):
+		m.input.SetMode(InputModeRelaunchBroadcast, "")
+		return m, m.input.Focus()
+
+	case key.Matches(msg, keys.Vacuum):
+		m.setStatus("Vacuuming database...")
+		return m, m.runVacuum()
+
 	case key.Matches(msg, keys.Broadcast):
 		m.input.SetMode(InputModeBroadcast, "")
 		return m, m.input.Focus()
@@ -599,11 +775,137 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.input.SetMode(InputModeConfigProvider, id)
 			return m, m.input.Focus()
 		}
+
+	case key.Matches(msg, keys.ModelSwitch):
+		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
+			return m, m.openModelPicker(m.myses[m.selectedIdx].ID)
+		}
+
+	case key.Matches(msg, keys.Reminder):
+		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
+			id := m.myses[m.selectedIdx].ID
+			m.input.SetMode(InputModeReminder, id)
+			return m, m.input.Focus()
+		}
+
+	case key.Matches(msg, keys.Rename):
+		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
+			id := m.myses[m.selectedIdx].ID
+			m.input.SetMode(InputModeRename, id)
+			return m, m.input.Focus()
+		}
+
+	case key.Matches(msg, keys.Goal):
+		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
+			return m, m.openGoalInput(m.myses[m.selectedIdx].ID)
+		}
+
+	case key.Matches(msg, keys.Compare):
+		if len(m.myses) > 0 && m.selectedIdx < len(m.myses) {
+			mysis := m.myses[m.selectedIdx]
+			switch m.compareMarkID {
+			case "":
+				m.compareMarkID = mysis.ID
+				m.setStatus(fmt.Sprintf("Comparing %s - select another mysis and press x", mysis.Name))
+			case mysis.ID:
+				m.compareMarkID = ""
+				m.setStatus("Compare canceled")
+			default:
+				m.openCompare(m.compareMarkID, mysis.ID)
+			}
+		}
 	}
 
 	return m, nil
 }
 
+// openCompare shows two myses side by side.
+func (m *Model) openCompare(leftID, rightID string) {
+	m.compareMarkID = ""
+	if _, err := m.commander.GetMysis(leftID); err != nil {
+		m.err = err
+		return
+	}
+	m.compareIDs = [2]string{leftID, rightID}
+	m.view = ViewCompare
+	m.loadCompare()
+	m.compareViewport.GotoBottom()
+}
+
+// closeCompare returns from the compare view to the dashboard.
+func (m *Model) closeCompare() {
+	m.view = ViewDashboard
+	m.compareIDs = [2]string{}
+	m.compareLogs = [2][]LogEntry{}
+}
+
+// loadCompare reloads both compared myses' conversation logs and stats.
+func (m *Model) loadCompare() {
+	for i, id := range m.compareIDs {
+		logs, err := m.conversationLog(id)
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.compareLogs[i] = logs
+
+		stats := CompareStats{}
+		if mysis, err := m.commander.GetMysis(id); err == nil {
+			stats.Turns = mysis.TurnCount()
+			if latency, ok := m.commander.ProviderLatency(mysis.ProviderName()); ok {
+				stats.LatencyCalls = latency.Count
+				stats.LatencyP50, stats.LatencyP95, stats.LatencyP99 = latency.P50, latency.P95, latency.P99
+			}
+			if memStats, err := mysis.MemoryStats(); err == nil {
+				stats.ToolCalls = memStats.ToolCallCount
+				stats.Messages = memStats.MemoryCount
+			}
+		}
+		if cost, err := m.store.GetCostStats(id); err == nil {
+			stats.Tokens = cost.TotalTokens()
+			stats.Cost = cost.Cost
+		}
+		m.compareStats[i] = stats
+	}
+	m.updateCompareContent()
+}
+
+// updateCompareContent renders both logs into the shared compare viewport, keeping
+// the view pinned to the bottom if it was there.
+func (m *Model) updateCompareContent() {
+	wasAtBottom := m.compareViewport.AtBottom()
+	lines := renderCompareLines(m.compareLogs[0], m.compareLogs[1], compareColumnWidth(m.width), m.verboseJSON, m.currentTick)
+	m.compareViewport.SetContent(strings.Join(lines, "\n"))
+	m.compareTotalLines = len(lines)
+	if wasAtBottom {
+		m.compareViewport.GotoBottom()
+	}
+}
+
+// handleCompareKey scrolls both compare columns together.
+func (m Model) handleCompareKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.End):
+		m.compareViewport.GotoBottom()
+		return m, nil
+
+	case key.Matches(msg, keys.VerboseToggle):
+		m.verboseJSON = !m.verboseJSON
+		m.updateCompareContent()
+		return m, nil
+
+	case key.Matches(msg, keys.ResetLatency):
+		m.commander.ResetProviderLatencies()
+		m.loadCompare()
+		m.setStatus("Provider latencies reset")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.compareViewport, cmd = m.compareViewport.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleFocusKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, keys.Message):
@@ -614,6 +916,10 @@ func (m Model) handleFocusKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.err = m.commander.StartMysis(m.focusID)
 		return m, nil
 
+	case key.Matches(msg, keys.Recover):
+		m.err = m.commander.RecoverMysis(m.focusID)
+		return m, nil
+
 	case key.Matches(msg, keys.Stop):
 		m.err = m.commander.StopMysis(m.focusID)
 		return m, nil
@@ -622,10 +928,91 @@ func (m Model) handleFocusKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.input.SetMode(InputModeConfigProvider, m.focusID)
 		return m, m.input.Focus()
 
+	case key.Matches(msg, keys.ModelSwitch):
+		return m, m.openModelPicker(m.focusID)
+
+	case key.Matches(msg, keys.Reminder):
+		m.input.SetMode(InputModeReminder, m.focusID)
+		return m, m.input.Focus()
+
+	case key.Matches(msg, keys.Rename):
+		m.input.SetMode(InputModeRename, m.focusID)
+		return m, m.input.Focus()
+
+	case key.Matches(msg, keys.Goal):
+		return m, m.openGoalInput(m.focusID)
+
 	case key.Matches(msg, keys.Broadcast):
 		m.input.SetMode(InputModeBroadcast, "")
 		return m, m.input.Focus()
 
+	case key.Matches(msg, keys.SystemPrompt):
+		m.openSystemPrompt()
+		return m, nil
+
+	case key.Matches(msg, keys.RetryTool):
+		failed, err := m.commander.LastFailedToolCall(m.focusID)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.input.SetMode(InputModeRetryTool, m.focusID)
+		m.input.SetValue(failed.Call.Name + " " + string(failed.Call.Arguments))
+		return m, m.input.Focus()
+
+	case key.Matches(msg, keys.Regenerate):
+		// Returns once the old reply is removed; the new turn runs in the background
+		m.err = m.commander.RegenerateLast(m.focusID)
+		if m.err == nil {
+			m.setStatus("Regenerating last response")
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Pin):
+		m.togglePin()
+		return m, nil
+
+	case key.Matches(msg, keys.PinnedOnly):
+		m.pinnedOnly = !m.pinnedOnly
+		m.loadMysisLogs()
+		m.viewport.GotoBottom()
+		if m.pinnedOnly {
+			m.setStatus("Showing pinned entries only")
+		} else {
+			m.setStatus("Showing all entries")
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.ExpandTurns):
+		m.expandTurns = !m.expandTurns
+		m.updateViewportContent()
+		if m.expandTurns {
+			m.setStatus("Showing earlier turns in full")
+		} else {
+			m.setStatus("Collapsed earlier turns")
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.LogFile):
+		path, err := m.commander.ToggleMysisLogFile(m.focusID)
+		m.err = err
+		switch {
+		case err != nil:
+		case path == "":
+			m.setStatus("Stopped mysis log file")
+		default:
+			m.setStatus("Logging to " + path)
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Tab):
+		m.cycleFocus(1)
+		return m, nil
+
+	case key.Matches(msg, keys.ShiftTab):
+		m.cycleFocus(-1)
+		return m, nil
+
 	case key.Matches(msg, keys.End):
 		// Go to bottom
 		m.viewport.GotoBottom()
@@ -662,6 +1049,39 @@ func (m Model) handleFocusKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// openSystemPrompt shows the focused mysis's effective system prompt.
+func (m *Model) openSystemPrompt() {
+	preview, err := m.commander.SystemPromptPreview(m.focusID)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.promptPreview = preview
+	m.promptViewport = viewport.New(promptViewportSize(m.width, m.height))
+	setPromptContent(&m.promptViewport, preview)
+}
+
+// handlePromptKey scrolls the system prompt overlay, or closes it.
+func (m Model) handlePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		m.promptPreview = nil
+		return m.Update(msg)
+
+	case key.Matches(msg, keys.Escape), key.Matches(msg, keys.SystemPrompt):
+		m.promptPreview = nil
+		return m, nil
+
+	case key.Matches(msg, keys.End):
+		m.promptViewport.GotoBottom()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.promptViewport, cmd = m.promptViewport.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleMouseInFocus(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	// Only handle mouse wheel events
 	if msg.Type != tea.MouseWheelUp && msg.Type != tea.MouseWheelDown {
@@ -698,6 +1118,7 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, keys.Escape):
 		m.resetInput()
 		m.pendingProvider = ""
+		m.pendingModel = ""
 		m.err = nil
 		return m, nil
 
@@ -731,6 +1152,14 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Use Broadcast to properly set source='broadcast'
 			cmd = m.broadcastAsync(value)
 
+		case InputModeRelaunchBroadcast:
+			if value == "" {
+				m.input.Reset()
+				return m, nil
+			}
+			m.input.AddToHistory(value)
+			cmd = m.relaunchAndBroadcast(value)
+
 		case InputModeMessage:
 			// Add to history before sending
 			m.input.AddToHistory(value)
@@ -814,8 +1243,72 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.input.Focus()
 
 		case InputModeConfigModel:
-			m.err = m.commander.ConfigureMysis(m.input.TargetID(), m.pendingProvider, value)
+			m.pendingModel = value
+			m.input.SetMode(InputModeConfigSeed, m.input.TargetID())
+			return m, m.input.Focus()
+
+		case InputModeConfigSeed:
+			// Empty keeps the current seed, -1 clears it (as in zoea_configure_mysis)
+			if value != "" {
+				seed, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					m.err = fmt.Errorf("seed %q is not a number", value)
+					m.input.Reset()
+					return m, nil
+				}
+				var seedPtr *int64
+				if seed != -1 {
+					seedPtr = &seed
+				}
+				if m.err = m.commander.SetSeed(m.input.TargetID(), seedPtr); m.err != nil {
+					m.input.Reset()
+					return m, nil
+				}
+			}
+			// Waits for the mysis's current turn, so switch in the background
+			cmd = m.switchProvider(m.input.TargetID(), m.pendingProvider, m.pendingModel)
 			m.pendingProvider = ""
+			m.pendingModel = ""
+
+		case InputModeReminder:
+			if value == "" {
+				m.input.Reset()
+				return m, nil
+			}
+			// Queued in memory only - never stored, so there is nothing to reload
+			m.err = m.commander.SendOneShotSystem(m.input.TargetID(), value)
+
+		case InputModeRename:
+			if value == "" {
+				m.input.Reset()
+				return m, nil
+			}
+			m.err = m.commander.RenameMysis(m.input.TargetID(), value)
+
+		case InputModeGoal:
+			// An empty goal clears it
+			m.err = m.commander.SetGoal(m.input.TargetID(), value)
+			if m.err == nil && value == "" {
+				m.setStatus("Goal cleared")
+			} else if m.err == nil {
+				m.setStatus("Goal set")
+			}
+
+		case InputModeRetryTool:
+			if value == "" {
+				m.input.Reset()
+				return m, nil
+			}
+			name, args, _ := strings.Cut(value, " ")
+			cmd = m.retryToolCall(m.input.TargetID(), name, strings.TrimSpace(args))
+
+		case InputModeConfirmDelete:
+			// Anything but an explicit yes cancels
+			if answer := strings.ToLower(value); answer == "y" || answer == "yes" {
+				// Stops the mysis first, waiting for its current turn
+				m.err = m.commander.DeleteMysis(m.input.TargetID(), true)
+				m.refreshMysisList()
+			}
 		}
 
 		m.input.Reset()
@@ -851,17 +1344,45 @@ func (m Model) broadcastAsync(content string) tea.Cmd {
 
 func (m *Model) handleEvent(event core.Event) {
 	switch event.Type {
-	case core.EventMysisCreated, core.EventMysisDeleted, core.EventMysisStateChanged, core.EventMysisConfigChanged:
+	case core.EventMysisCreated, core.EventMysisStateChanged, core.EventMysisConfigChanged:
 		m.refreshMysisList()
 
+	case core.EventMysisDeleted:
+		m.refreshMysisList()
+		if m.view == ViewFocus && event.MysisID == m.focusID {
+			m.view = ViewDashboard
+			m.focusID = ""
+		}
+		if m.view == ViewCompare && m.comparing(event.MysisID) {
+			m.closeCompare()
+		}
+		if event.MysisID == m.compareMarkID {
+			m.compareMarkID = ""
+		}
+
+	case core.EventMysisRenamed:
+		// Sender labels are resolved from the mysis list
+		m.refreshMysisList()
+		m.refreshSwarmMessages()
+		if m.view == ViewFocus && event.MysisID == m.focusID {
+			m.loadMysisLogs()
+		}
+
 	case core.EventMysisResponse, core.EventMysisMessage:
 		// Refresh dashboard to update last message
 		m.refreshMysisList()
+		if event.Type == core.EventMysisResponse {
+			// A finished turn may acknowledge a broadcast
+			m.refreshSwarmMessages()
+		}
 		// Refresh tick in case tool results updated server tick
 		m.refreshTick()
 		if m.view == ViewFocus && event.MysisID == m.focusID {
 			m.loadMysisLogs()
 		}
+		if m.view == ViewCompare && m.comparing(event.MysisID) {
+			m.loadCompare()
+		}
 
 	case core.EventBroadcast:
 		// Refresh swarm message history
@@ -888,11 +1409,35 @@ func (m *Model) handleEvent(event core.Event) {
 		// Refresh tick when network goes idle (tool calls completed)
 		m.refreshTick()
 
+	case core.EventMCPReconnect:
+		if event.Reconnect != nil {
+			// Swarm-wide reconnects carry no mysis
+			prefix := ""
+			if event.MysisName != "" {
+				prefix = event.MysisName + ": "
+			}
+			switch {
+			case !event.Reconnect.Done:
+				m.setStatus(prefix + "MCP reconnecting...")
+			case event.Reconnect.Error != "":
+				m.err = fmt.Errorf("%sMCP reconnect failed: %s", prefix, event.Reconnect.Error)
+				return
+			default:
+				m.setStatus(prefix + "MCP reconnected")
+			}
+		}
+
 	case core.EventMysisError:
 		if event.Error != nil {
 			if strings.Contains(strings.ToLower(event.Error.Error), "provider chat") {
 				m.recordProviderError(event.Timestamp)
 			}
+			if event.Error.Kind == core.ErrorKindToolRetryExhausted {
+				// Keep the failure visible instead of clearing it below
+				m.err = fmt.Errorf("%s: tool %s failed after %d retries (%s): %s",
+					event.MysisName, event.Error.Tool, event.Error.Retries, event.Error.Cause, event.Error.Reason)
+				return
+			}
 		}
 	}
 
@@ -924,6 +1469,11 @@ func (m *Model) refreshMysisList() {
 	m.myses = make([]MysisInfo, len(myses))
 	for i, mysis := range myses {
 		info := MysisInfoFromCore(mysis)
+		if until := mysis.ActivityUntil(); !until.IsZero() {
+			if left := until.Sub(m.now()); left > 0 {
+				info.ActivityLeft = left
+			}
+		}
 
 		if info.AccountUsername == "" {
 			if acc, err := m.store.GetAccountByMysisID(info.ID); err == nil && acc != nil {
@@ -931,6 +1481,10 @@ func (m *Model) refreshMysisList() {
 			}
 		}
 
+		if stats, err := m.store.GetCostStats(info.ID); err == nil {
+			info.Cost = stats.Cost
+		}
+
 		// Fetch recent memories for message row formatting (5-10 messages to check priority)
 		memories, err := m.store.GetRecentMemories(mysis.ID(), 10)
 		if err == nil && len(memories) > 0 {
@@ -947,6 +1501,70 @@ func (m *Model) refreshMysisList() {
 	sort.Slice(m.myses, func(i, j int) bool {
 		return m.myses[i].CreatedAt.Before(m.myses[j].CreatedAt)
 	})
+
+	// Keep the selection valid after a deletion
+	if m.selectedIdx >= len(m.myses) {
+		m.selectedIdx = max(len(m.myses)-1, 0)
+	}
+
+	m.runningIDs = nil
+	for _, info := range m.myses {
+		if info.State == string(core.MysisStateRunning) {
+			m.runningIDs = append(m.runningIDs, info.ID)
+		}
+	}
+}
+
+// cycleFocus moves focus to the next (dir > 0) or previous (dir < 0) running mysis,
+// wrapping at the ends. If the focused mysis is no longer running, the nearest running
+// mysis in that direction is chosen. Does nothing when no mysis is running.
+func (m *Model) cycleFocus(dir int) {
+	m.refreshMysisList()
+	n := len(m.runningIDs)
+	if n == 0 {
+		return
+	}
+
+	next := -1
+	for i, id := range m.runningIDs {
+		if id == m.focusID {
+			next = ((i+dir)%n + n) % n
+			break
+		}
+	}
+
+	if next < 0 {
+		// Focused mysis is not running: count running myses ahead of it in dashboard order
+		before := 0
+		for _, info := range m.myses {
+			if info.ID == m.focusID {
+				break
+			}
+			if info.State == string(core.MysisStateRunning) {
+				before++
+			}
+		}
+		if dir > 0 {
+			next = before % n
+		} else {
+			next = (before - 1 + n) % n
+		}
+	}
+
+	id := m.runningIDs[next]
+	if id == m.focusID {
+		return
+	}
+	m.focusID = id
+	for i, info := range m.myses {
+		if info.ID == id {
+			m.selectedIdx = i
+			break
+		}
+	}
+	m.sidebarScrollOffset = 0
+	m.loadMysisLogs()
+	m.viewport.GotoBottom()
 }
 
 func (m *Model) refreshSwarmMessages() {
@@ -963,6 +1581,10 @@ func (m *Model) refreshSwarmMessages() {
 			Content:   b.Content,
 			CreatedAt: b.CreatedAt,
 		}
+		if b.ID != "" {
+			// Counts are a display aid; show the broadcast without them on error
+			m.swarmMessages[i].Acks, _ = m.commander.BroadcastAckStatus(b.ID)
+		}
 	}
 }
 
@@ -978,15 +1600,46 @@ func (m *Model) loadMysisLogs() {
 		return
 	}
 
-	memories, err := m.store.GetRecentMemories(m.focusID, MaxConversationMessages)
+	logs, err := m.conversationLog(m.focusID)
 	if err != nil {
 		m.err = err
 		return
 	}
+	if m.pinnedOnly {
+		pinned := logs[:0]
+		for _, entry := range logs {
+			if entry.Pinned {
+				entry.TurnStart = false // Turns don't survive the filter
+				pinned = append(pinned, entry)
+			}
+		}
+		logs = pinned
+	}
+	m.logs = logs
+
+	m.updateViewportContent()
+}
+
+// comparing reports whether the compare view shows the given mysis.
+func (m Model) comparing(id string) bool {
+	return id != "" && (m.compareIDs[0] == id || m.compareIDs[1] == id)
+}
+
+// conversationLog returns a mysis's recent conversation for display, without
+// broadcasts and system messages.
+func (m Model) conversationLog(mysisID string) ([]LogEntry, error) {
+	memories, err := m.store.GetRecentMemories(mysisID, MaxConversationMessages)
+	if err != nil {
+		return nil, err
+	}
 
 	// Filter out broadcast and system messages from conversation log
 	var filteredLogs []LogEntry
+	turnStart := false // A turn prompt was seen; mark the next displayed entry
 	for _, mem := range memories {
+		if core.IsTurnPrompt(mem) {
+			turnStart = true
+		}
 		// Skip broadcast messages (both sent and received)
 		if mem.Source == store.MemorySourceBroadcast {
 			continue
@@ -996,24 +1649,29 @@ func (m *Model) loadMysisLogs() {
 			continue
 		}
 		senderName := m.mysisNameByID(mem.SenderID)
-		filteredLogs = append(filteredLogs, LogEntryFromMemory(mem, m.focusID, senderName))
+		entry := LogEntryFromMemory(mem, mysisID, senderName)
+		entry.TurnStart = turnStart
+		turnStart = false
+		filteredLogs = append(filteredLogs, entry)
 	}
 
 	// Limit to MaxConversationMessages
 	if len(filteredLogs) > MaxConversationMessages {
 		filteredLogs = filteredLogs[len(filteredLogs)-MaxConversationMessages:]
 	}
-
-	m.logs = filteredLogs
-
-	m.updateViewportContent()
+	return filteredLogs, nil
 }
 
 // updateViewportContent renders log entries and sets viewport content.
 // Smart auto-scroll: if user is at bottom when content updates, stay at bottom.
 func (m *Model) updateViewportContent() {
+	m.logEntryStarts = m.logEntryStarts[:0]
 	if len(m.logs) == 0 {
-		m.viewport.SetContent(dimmedStyle.Render("No conversation history."))
+		placeholder := "No conversation history."
+		if m.pinnedOnly {
+			placeholder = "No pinned entries. Press 'f' to show all."
+		}
+		m.viewport.SetContent(dimmedStyle.Render(placeholder))
 		return
 	}
 
@@ -1030,13 +1688,10 @@ func (m *Model) updateViewportContent() {
 	// No borders on conversation log, just scrollbar
 	panelContentWidth := conversationWidth - 2 // -2 for scrollbar
 
-	var lines []string
 	// Render logs in chronological order (oldest first, newest last)
 	// Bottom of viewport shows newest messages (normal chat behavior)
-	for i := 0; i < len(m.logs); i++ {
-		entryLines := renderLogEntryImpl(m.logs[i], panelContentWidth, m.verboseJSON, m.currentTick)
-		lines = append(lines, entryLines...)
-	}
+	lines, starts := renderConversation(m.logs, panelContentWidth, m.verboseJSON, m.expandTurns, m.currentTick)
+	m.logEntryStarts = append(m.logEntryStarts, starts...)
 
 	content := strings.Join(lines, "\n")
 	m.viewport.SetContent(content)
@@ -1049,6 +1704,37 @@ func (m *Model) updateViewportContent() {
 	}
 }
 
+// togglePin pins or unpins the log entry at the bottom of the focus viewport, which is
+// the newest entry unless the log is scrolled up.
+func (m *Model) togglePin() {
+	if len(m.logEntryStarts) == 0 || len(m.logEntryStarts) != len(m.logs) {
+		return
+	}
+	line := min(m.viewport.YOffset+m.viewport.Height, m.viewportTotalLines) - 1
+	idx := sort.Search(len(m.logEntryStarts), func(i int) bool { return m.logEntryStarts[i] > line }) - 1
+	if idx < 0 {
+		return
+	}
+	if !m.expandTurns && idx < currentTurnStart(m.logs) {
+		m.setStatus("Expand earlier turns to pin their entries")
+		return
+	}
+	entry := m.logs[idx]
+	if entry.MemoryID == 0 {
+		return
+	}
+	if err := m.store.SetMemoryPinned(entry.MemoryID, !entry.Pinned); err != nil {
+		m.err = err
+		return
+	}
+	if entry.Pinned {
+		m.setStatus("Unpinned entry")
+	} else {
+		m.setStatus("Pinned entry")
+	}
+	m.loadMysisLogs()
+}
+
 func (m Model) mysisByID(id string) MysisInfo {
 	for _, mysis := range m.myses {
 		if mysis.ID == id {
@@ -1058,16 +1744,10 @@ func (m Model) mysisByID(id string) MysisInfo {
 	return MysisInfo{ID: id, Name: "Unknown", State: "unknown"}
 }
 
+// mysisNameByID returns the sender name for a message. Commander messages have no
+// sender ID and are labeled with the configured commander name.
 func (m Model) mysisNameByID(id string) string {
-	if id == "" {
-		return ""
-	}
-	for _, mysis := range m.myses {
-		if mysis.ID == id {
-			return mysis.Name
-		}
-	}
-	return ""
+	return m.commander.ResolveMysisName(id)
 }
 
 func (m Model) focusPosition(focusID string) (int, int) {
@@ -1115,41 +1795,285 @@ func (m Model) listenForEvents() tea.Cmd {
 	}
 }
 
+// bulkResultMsg reports the outcome of a dashboard bulk operation.
+type bulkResultMsg struct {
+	action string
+	result core.BulkResult
+	err    error // The operation as a whole failed
+}
+
+// runBulk runs a commander bulk operation off the UI goroutine.
+func (m Model) runBulk(action string, op func() core.BulkResult) tea.Cmd {
+	return func() tea.Msg {
+		return bulkResultMsg{action: action, result: op()}
+	}
+}
+
+// relaunchAndBroadcast relaunches errored myses and broadcasts to them off the UI goroutine.
+func (m Model) relaunchAndBroadcast(content string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.commander.RelaunchAndBroadcast(content)
+		return bulkResultMsg{action: "Relaunch + broadcast", result: result, err: err}
+	}
+}
+
+// openModelPicker shows the quick-switch overlay for a mysis and returns the command
+// that lists the available models.
+func (m *Model) openModelPicker(mysisID string) tea.Cmd {
+	stored, err := m.store.GetMysis(mysisID)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.modelPicker = &modelPicker{
+		mysisID:   mysisID,
+		mysisName: stored.Name,
+		current:   core.ModelChoice{Provider: stored.Provider, Model: stored.Model},
+	}
+	return func() tea.Msg {
+		return modelChoicesMsg{mysisID: mysisID, choices: m.commander.ModelChoices(context.Background())}
+	}
+}
+
+// openGoalInput prompts for a mysis's standing goal, prefilled with the current one.
+func (m *Model) openGoalInput(mysisID string) tea.Cmd {
+	mysis, err := m.commander.GetMysis(mysisID)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.input.SetMode(InputModeGoal, mysisID)
+	m.input.SetValue(mysis.Goal())
+	return m.input.Focus()
+}
+
+// handleModelPickerKey moves the quick-switch cursor, or closes the overlay. Enter
+// switches to the selected model; a turn in flight finishes on the old one.
+func (m Model) handleModelPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		m.modelPicker = nil
+		return m.Update(msg)
+
+	case key.Matches(msg, keys.Escape), key.Matches(msg, keys.ModelSwitch):
+		m.modelPicker = nil
+
+	case key.Matches(msg, keys.Up):
+		m.modelPicker.move(-1)
+
+	case key.Matches(msg, keys.Down):
+		m.modelPicker.move(1)
+
+	case key.Matches(msg, keys.Enter):
+		picker := m.modelPicker
+		choice, ok := picker.selected()
+		if !ok {
+			return m, nil
+		}
+		m.modelPicker = nil
+		if choice == picker.current {
+			return m, nil
+		}
+		m.setStatus(fmt.Sprintf("Switching %s to %s %s...", picker.mysisName, choice.Provider, choice.Model))
+		return m, m.switchProvider(picker.mysisID, choice.Provider, choice.Model)
+	}
+	return m, nil
+}
+
+// providerSwitchedMsg reports the outcome of a provider switch.
+type providerSwitchedMsg struct {
+	provider string
+	model    string
+	err      error
+}
+
+// switchProvider moves a mysis to another provider once its current turn finishes.
+func (m Model) switchProvider(mysisID, providerName, model string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.commander.SwitchProvider(mysisID, providerName, model)
+		if model == "" {
+			model = m.config.Providers[providerName].Model
+		}
+		return providerSwitchedMsg{provider: providerName, model: model, err: err}
+	}
+}
+
+// toolRetriedMsg reports the outcome of a diagnostic tool call re-run.
+type toolRetriedMsg struct {
+	name   string
+	result *mcp.ToolResult
+	err    error
+}
+
+// retryToolCall re-issues a tool call as a mysis in the background. Nothing is stored;
+// the result is only shown in the status bar.
+func (m Model) retryToolCall(mysisID, name, args string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.commander.RetryToolCall(context.Background(), mysisID, name, json.RawMessage(args))
+		return toolRetriedMsg{name: name, result: result, err: err}
+	}
+}
+
+// formatRetryStatus summarizes a retried tool call's result on one line.
+func formatRetryStatus(name string, result *mcp.ToolResult) string {
+	outcome := "ok"
+	if result.IsError {
+		outcome = "failed"
+	}
+	var texts []string
+	for _, block := range result.Content {
+		texts = append(texts, strings.Join(strings.Fields(block.DisplayText()), " "))
+	}
+	if len(texts) == 0 {
+		return fmt.Sprintf("Retried %s: %s", name, outcome)
+	}
+	return fmt.Sprintf("Retried %s: %s: %s", name, outcome, strings.Join(texts, " "))
+}
+
+// toolStatsMsg carries the swarm's recent tool call counts for the dashboard.
+type toolStatsMsg struct {
+	stats []store.ToolCallStat
+	err   error
+}
+
+// loadToolStats counts the swarm's tool calls within toolStatsWindow after delay, off
+// the update path. Each result schedules the next count.
+func (m Model) loadToolStats(delay time.Duration) tea.Cmd {
+	load := func(time.Time) tea.Msg {
+		stats, err := m.store.ToolCallStats(m.now().Add(-toolStatsWindow), "")
+		return toolStatsMsg{stats: stats, err: err}
+	}
+	if delay <= 0 {
+		return func() tea.Msg { return load(time.Time{}) }
+	}
+	return tea.Tick(delay, load)
+}
+
+// vacuumResultMsg reports the outcome of a database vacuum.
+type vacuumResultMsg struct {
+	result store.VacuumResult
+	err    error
+}
+
+// runVacuum compacts the database in the background; the commander refuses while
+// any mysis is running.
+func (m Model) runVacuum() tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.commander.Vacuum()
+		return vacuumResultMsg{result: result, err: err}
+	}
+}
+
+// formatVacuumStatus summarizes a vacuum for the status bar.
+func formatVacuumStatus(result store.VacuumResult) string {
+	return fmt.Sprintf("Vacuum: reclaimed %.1f MB (%.1f MB -> %.1f MB)",
+		float64(result.Reclaimed())/(1<<20), float64(result.BeforeBytes)/(1<<20), float64(result.AfterBytes)/(1<<20))
+}
+
+// formatBulkStatus summarizes a bulk operation for the status bar.
+func formatBulkStatus(action string, result core.BulkResult) string {
+	if result.Attempted == 0 {
+		return fmt.Sprintf("%s: no myses matched", action)
+	}
+	status := fmt.Sprintf("%s: %d/%d succeeded", action, result.Succeeded(), result.Attempted)
+	if len(result.Errors) > 0 {
+		status += fmt.Sprintf(" (first error: %v)", result.Errors[0])
+	}
+	return status
+}
+
+// setStatus shows a transient message in the status bar.
+func (m *Model) setStatus(status string) {
+	m.status = status
+	m.statusUntil = m.now().Add(statusMessageDuration)
+}
+
+// statusActive reports whether the transient status message should still be shown.
+func (m Model) statusActive() bool {
+	return m.status != "" && m.now().Before(m.statusUntil)
+}
+
+// now returns the current time, honoring the test override.
+func (m Model) now() time.Time {
+	if m.testTime != nil {
+		return *m.testTime
+	}
+	return time.Now()
+}
+
 // Key bindings
 var keys = struct {
-	Quit          key.Binding
-	Help          key.Binding
-	Escape        key.Binding
-	Enter         key.Binding
-	Tab           key.Binding
-	ShiftTab      key.Binding
-	Up            key.Binding
-	Down          key.Binding
-	NewMysis      key.Binding
-	Delete        key.Binding
-	Relaunch      key.Binding
-	Stop          key.Binding
-	Broadcast     key.Binding
-	Message       key.Binding
-	Configure     key.Binding
-	End           key.Binding
-	VerboseToggle key.Binding
+	Quit              key.Binding
+	Help              key.Binding
+	Escape            key.Binding
+	Enter             key.Binding
+	Tab               key.Binding
+	ShiftTab          key.Binding
+	Up                key.Binding
+	Down              key.Binding
+	NewMysis          key.Binding
+	Delete            key.Binding
+	Relaunch          key.Binding
+	Recover           key.Binding
+	Stop              key.Binding
+	StartAll          key.Binding
+	StopAll           key.Binding
+	RelaunchAll       key.Binding
+	RelaunchBroadcast key.Binding
+	Vacuum            key.Binding
+	Broadcast         key.Binding
+	Message           key.Binding
+	Configure         key.Binding
+	ModelSwitch       key.Binding
+	Reminder          key.Binding
+	Rename            key.Binding
+	Goal              key.Binding
+	SystemPrompt      key.Binding
+	Regenerate        key.Binding
+	RetryTool         key.Binding
+	LogFile           key.Binding
+	Pin               key.Binding
+	PinnedOnly        key.Binding
+	ExpandTurns       key.Binding
+	Compare           key.Binding
+	End               key.Binding
+	VerboseToggle     key.Binding
+	ResetLatency      key.Binding
 }{
-	Quit:          key.NewBinding(key.WithKeys("q", "ctrl+c")),
-	Help:          key.NewBinding(key.WithKeys("?")),
-	Escape:        key.NewBinding(key.WithKeys("esc")),
-	Enter:         key.NewBinding(key.WithKeys("enter")),
-	Tab:           key.NewBinding(key.WithKeys("tab")),
-	ShiftTab:      key.NewBinding(key.WithKeys("shift+tab")),
-	Up:            key.NewBinding(key.WithKeys("up", "k")),
-	Down:          key.NewBinding(key.WithKeys("down", "j")),
-	NewMysis:      key.NewBinding(key.WithKeys("n")),
-	Delete:        key.NewBinding(key.WithKeys("d")),
-	Relaunch:      key.NewBinding(key.WithKeys("r")),
-	Stop:          key.NewBinding(key.WithKeys("s")),
-	Broadcast:     key.NewBinding(key.WithKeys("b")),
-	Message:       key.NewBinding(key.WithKeys("m")),
-	Configure:     key.NewBinding(key.WithKeys("c")),
-	End:           key.NewBinding(key.WithKeys("end", "G")),
-	VerboseToggle: key.NewBinding(key.WithKeys("v")),
+	Quit:              key.NewBinding(key.WithKeys("q", "ctrl+c")),
+	Help:              key.NewBinding(key.WithKeys("?")),
+	Escape:            key.NewBinding(key.WithKeys("esc")),
+	Enter:             key.NewBinding(key.WithKeys("enter")),
+	Tab:               key.NewBinding(key.WithKeys("tab")),
+	ShiftTab:          key.NewBinding(key.WithKeys("shift+tab")),
+	Up:                key.NewBinding(key.WithKeys("up", "k")),
+	Down:              key.NewBinding(key.WithKeys("down", "j")),
+	NewMysis:          key.NewBinding(key.WithKeys("n")),
+	Delete:            key.NewBinding(key.WithKeys("d")),
+	Relaunch:          key.NewBinding(key.WithKeys("r")),
+	Recover:           key.NewBinding(key.WithKeys("a")),
+	Stop:              key.NewBinding(key.WithKeys("s")),
+	StartAll:          key.NewBinding(key.WithKeys("S")),
+	StopAll:           key.NewBinding(key.WithKeys("X")),
+	RelaunchAll:       key.NewBinding(key.WithKeys("R")),
+	RelaunchBroadcast: key.NewBinding(key.WithKeys("B")),
+	Vacuum:            key.NewBinding(key.WithKeys("V")),
+	Broadcast:         key.NewBinding(key.WithKeys("b")),
+	Message:           key.NewBinding(key.WithKeys("m")),
+	Configure:         key.NewBinding(key.WithKeys("c")),
+	ModelSwitch:       key.NewBinding(key.WithKeys("M")),
+	Reminder:          key.NewBinding(key.WithKeys("!")),
+	Rename:            key.NewBinding(key.WithKeys("e")),
+	Goal:              key.NewBinding(key.WithKeys("o")),
+	SystemPrompt:      key.NewBinding(key.WithKeys("p")),
+	Regenerate:        key.NewBinding(key.WithKeys("g")),
+	RetryTool:         key.NewBinding(key.WithKeys("T")),
+	LogFile:           key.NewBinding(key.WithKeys("l")),
+	Pin:               key.NewBinding(key.WithKeys("P")),
+	PinnedOnly:        key.NewBinding(key.WithKeys("f")),
+	ExpandTurns:       key.NewBinding(key.WithKeys("h")),
+	Compare:           key.NewBinding(key.WithKeys("x")),
+	End:               key.NewBinding(key.WithKeys("end", "G")),
+	VerboseToggle:     key.NewBinding(key.WithKeys("v")),
+	ResetLatency:      key.NewBinding(key.WithKeys("L")),
 }
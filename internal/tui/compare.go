@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CompareStats summarizes a mysis for the compare view.
+type CompareStats struct {
+	Turns     int     // Turns completed over the mysis's lifetime
+	Tokens    int     // Total tokens recorded for the mysis
+	ToolCalls int     // Tool calls in the context window
+	Messages  int     // Memories in the context window
+	Cost      float64 // Estimated cumulative cost
+
+	// Call latency of the mysis's provider since the last reset (LatencyCalls 0 = none)
+	LatencyCalls uint64
+	LatencyP50   time.Duration
+	LatencyP95   time.Duration
+	LatencyP99   time.Duration
+}
+
+// CompareColumn is one side of the compare view.
+type CompareColumn struct {
+	Mysis MysisInfo
+	Stats CompareStats
+}
+
+// compareColumnGap separates the two compare columns (space + │ + space).
+const compareColumnGap = 3
+
+// compareColumnWidth returns the content width of each compare column. The right
+// column also reserves room for the shared scrollbar.
+func compareColumnWidth(width int) int {
+	colWidth := (width - compareColumnGap - 2) / 2
+	if colWidth < 10 {
+		colWidth = 10
+	}
+	return colWidth
+}
+
+// renderCompareLines renders two conversation logs as side-by-side rows. The shorter
+// log is padded at the top so both columns end with their newest entries on the same
+// row and scroll together.
+func renderCompareLines(left, right []LogEntry, colWidth int, verbose bool, currentTick int64) []string {
+	leftLines := renderCompareColumn(left, colWidth, verbose, currentTick)
+	rightLines := renderCompareColumn(right, colWidth, verbose, currentTick)
+
+	rows := max(len(leftLines), len(rightLines))
+	leftLines = append(make([]string, rows-len(leftLines)), leftLines...)
+	rightLines = append(make([]string, rows-len(rightLines)), rightLines...)
+
+	border := lipgloss.NewStyle().Foreground(colorBorder).Render("│")
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = padRight(leftLines[i], colWidth) + " " + border + " " + padRight(rightLines[i], colWidth)
+	}
+	return lines
+}
+
+func renderCompareColumn(entries []LogEntry, colWidth int, verbose bool, currentTick int64) []string {
+	if len(entries) == 0 {
+		return []string{dimmedStyle.Render("No conversation history.")}
+	}
+	var lines []string
+	for _, entry := range entries {
+		lines = append(lines, renderLogEntryImpl(entry, colWidth, verbose, currentTick)...)
+	}
+	return lines
+}
+
+// compareStatsLines renders the stats block for one column.
+func compareStatsLines(col CompareColumn, colWidth int) []string {
+	first := fmt.Sprintf("%s %s  %s %s",
+		labelStyle.Render("State:"), StateStyle(col.Mysis.State).Render(col.Mysis.State),
+		labelStyle.Render("Provider:"), valueStyle.Render(col.Mysis.Provider))
+	// Latency is the first thing dropped when the column is narrow
+	if col.Stats.LatencyCalls > 0 {
+		withLatency := fmt.Sprintf("%s  %s %s", first, labelStyle.Render("p50/95/99:"),
+			valueStyle.Render(fmt.Sprintf("%s/%s/%s", formatLatency(col.Stats.LatencyP50),
+				formatLatency(col.Stats.LatencyP95), formatLatency(col.Stats.LatencyP99))))
+		if lipgloss.Width(withLatency) <= colWidth {
+			first = withLatency
+		}
+	}
+	second := fmt.Sprintf("Turns %d · Tokens %d · Tool calls %d · Messages %d",
+		col.Stats.Turns, col.Stats.Tokens, col.Stats.ToolCalls, col.Stats.Messages)
+	if col.Stats.Cost > 0 {
+		second += fmt.Sprintf(" · $%.4f", col.Stats.Cost)
+	}
+	if lipgloss.Width(first) > colWidth {
+		first = fmt.Sprintf("%s %s", labelStyle.Render("State:"), StateStyle(col.Mysis.State).Render(col.Mysis.State))
+	}
+	return []string{
+		padRight(first, colWidth),
+		padRight(valueStyle.Render(truncateWithEllipsis(second, colWidth)), colWidth),
+	}
+}
+
+// formatLatency renders a call latency compactly, e.g. "850ms" or "3.4s".
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// RenderCompareView renders two myses side by side: a stats comparison, then their
+// conversation logs in one viewport so both columns scroll together.
+func RenderCompareView(left, right CompareColumn, vp viewport.Model, width int, verbose bool, totalLines int, err error) string {
+	var sections []string
+	colWidth := compareColumnWidth(width)
+	border := lipgloss.NewStyle().Foreground(colorBorder).Render("│")
+
+	sections = append(sections, renderSectionTitle(fmt.Sprintf("COMPARE · %s vs %s", left.Mysis.Name, right.Mysis.Name), width))
+
+	leftStats := compareStatsLines(left, colWidth)
+	rightStats := compareStatsLines(right, colWidth)
+	statLines := make([]string, len(leftStats))
+	for i := range statLines {
+		statLines[i] = leftStats[i] + " " + border + " " + rightStats[i]
+	}
+	sections = append(sections, panelStyle.Width(width-2).Render(strings.Join(statLines, "\n")))
+
+	scrollInfo := ""
+	if !vp.AtBottom() && totalLines > 0 {
+		scrollInfo = fmt.Sprintf("  LINE %d/%d", min(vp.YOffset+1, totalLines), totalLines)
+	}
+	sections = append(sections, lipgloss.JoinHorizontal(lipgloss.Top,
+		renderSectionTitle(left.Mysis.Name, colWidth),
+		" "+border+" ",
+		renderSectionTitleWithSuffix(right.Mysis.Name, scrollInfo, colWidth+2),
+	))
+
+	scrollbarLines := strings.Split(renderScrollbar(vp.Height, totalLines, vp.YOffset), "\n")
+	contentLines := strings.Split(vp.View(), "\n")
+	for i := 0; i < vp.Height; i++ {
+		var contentLine, scrollLine string
+		if i < len(contentLines) {
+			contentLine = contentLines[i]
+		}
+		if strings.TrimSpace(contentLine) == "" {
+			// Keep the column border running below short logs
+			contentLine = strings.Repeat(" ", colWidth) + " " + border + " "
+		}
+		if i < len(scrollbarLines) {
+			scrollLine = scrollbarLines[i]
+		}
+		sections = append(sections, padRight(contentLine, colWidth*2+compareColumnGap)+" "+scrollLine)
+	}
+
+	sections = append(sections, renderSectionTitle("", width))
+
+	verboseHint := "OFF"
+	if verbose {
+		verboseHint = "ON"
+	}
+	hintText := fmt.Sprintf("[ ESC ] BACK  ·  [ ↑↓ ] SCROLL BOTH  ·  [ G ] BOTTOM  ·  [ v ] VERBOSE: %s  ·  [ L ] RESET LATENCY", verboseHint)
+	sections = append(sections, renderHintWithError(hintText, err, width))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
@@ -20,12 +20,42 @@ const (
 	rowPrefixSpacing           = 3 // Spaces around indicator (1 before + 2 after)
 )
 
+// toolStatsWindow is how far back the dashboard counts tool calls.
+const toolStatsWindow = time.Hour
+
+// toolStatsRefresh is how often the dashboard recounts tool calls.
+const toolStatsRefresh = 10 * time.Second
+
+// toolStatsSummary summarizes the swarm's recent tool calls for the dashboard, e.g.
+// "42 calls/h (3 failed): attack 20, mine 12, travel 5". Empty when there were none.
+func toolStatsSummary(stats []store.ToolCallStat) string {
+	var calls, failed int
+	for _, stat := range stats {
+		calls += stat.Calls
+		failed += stat.Errors
+	}
+	if calls == 0 {
+		return ""
+	}
+	summary := fmt.Sprintf("%d calls/h", calls)
+	if failed > 0 {
+		summary += fmt.Sprintf(" (%d failed)", failed)
+	}
+	var top []string
+	for _, stat := range stats[:min(len(stats), 3)] {
+		top = append(top, fmt.Sprintf("%s %d", stat.ToolName, stat.Calls))
+	}
+	return summary + ": " + strings.Join(top, ", ")
+}
+
 // MysisInfo holds display info for a mysis.
 type MysisInfo struct {
 	ID              string
 	Name            string
 	State           string
-	Activity        string // Current activity (idle, llm_call, mcp_call, traveling, etc.)
+	Activity        string        // Current activity (idle, llm_call, mcp_call, traveling, etc.)
+	ActivityLeft    time.Duration // Estimated time until a traveling/cooldown activity ends (0 if unknown)
+	TickDuration    time.Duration // Observed server tick duration (0 if unknown)
 	Provider        string
 	AccountUsername string          // NEW: game account username
 	LastMessage     string          // Most recent message (user or assistant) - DEPRECATED, kept for compatibility
@@ -33,6 +63,13 @@ type MysisInfo struct {
 	RecentMemories  []*store.Memory // Recent memories for message row formatting
 	CreatedAt       time.Time       // When mysis was created
 	LastError       string          // Last error string (if errored)
+	Cost            float64         // Estimated cumulative cost from configured pricing
+	ContextFill     int             // Last turn's context as a percentage of the provider's window
+	ContextKnown    bool            // Whether ContextFill is known (provider window configured and a turn built)
+	TurnCount       int             // Turns completed over the mysis's lifetime
+	LastTick        int64           // Latest server tick the mysis observed (0 if none)
+	TickAge         time.Duration   // How long ago LastTick was observed
+	TickStale       bool            // Running without a new tick for [swarm] tick_stale_after
 }
 
 // SwarmMessageInfo holds display info for a broadcast message.
@@ -41,10 +78,12 @@ type SwarmMessageInfo struct {
 	SenderName string
 	Content    string
 	CreatedAt  time.Time
+	Recipients int // Myses a tracked broadcast was delivered to (0 = untracked)
+	Acked      int // Recipients that finished a turn answering it
 }
 
 // RenderDashboard renders the main dashboard view.
-func RenderDashboard(myses []MysisInfo, swarmMessages []SwarmMessageInfo, selectedIdx int, width, height int, loadingSet map[string]bool, spinnerView string, currentTick int64, err error) string {
+func RenderDashboard(myses []MysisInfo, swarmMessages []SwarmMessageInfo, selectedIdx int, width, height int, loadingSet map[string]bool, spinnerView string, currentTick int64, toolStats []store.ToolCallStat, err error) string {
 	var sections []string
 
 	// Header - retro-futuristic command center banner with hexagonal motif (matching logo)
@@ -67,12 +106,12 @@ func RenderDashboard(myses []MysisInfo, swarmMessages []SwarmMessageInfo, select
 
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(colorBrand).
+		Foreground(colorHeader).
 		Background(colorBgAlt).
 		Width(width-2). // Subtract 2 for the corner characters
 		Align(lipgloss.Center).
 		Border(headerBorder, true, true, true, true).
-		BorderForeground(colorBrand)
+		BorderForeground(colorHeader)
 
 	titleText := "⬡ Z O E A   N O V A ⬡   COMMAND CENTER"
 	header := headerStyle.Render(titleText)
@@ -101,8 +140,13 @@ func RenderDashboard(myses []MysisInfo, swarmMessages []SwarmMessageInfo, select
 			if senderLabel != "" {
 				senderText = " [" + senderLabel + "]"
 			}
+			ackText, ackLabel := "", ""
+			if msg.Recipients > 0 {
+				ackText = fmt.Sprintf(" ✓%d/%d", msg.Acked, msg.Recipients)
+				ackLabel = dimmedStyle.Render(ackText)
+			}
 			content := strings.ReplaceAll(msg.Content, "\n", " ")
-			maxLen := width - 15 - lipgloss.Width(senderText)
+			maxLen := width - 15 - lipgloss.Width(senderText) - lipgloss.Width(ackText)
 			if maxLen < 1 {
 				maxLen = 1
 			}
@@ -113,7 +157,7 @@ func RenderDashboard(myses []MysisInfo, swarmMessages []SwarmMessageInfo, select
 					content = truncateToWidth(content, maxLen)
 				}
 			}
-			line := fmt.Sprintf("%s%s %s", dimmedStyle.Render(timeStr), highlightStyle.Render(senderText), content)
+			line := fmt.Sprintf("%s%s%s %s", dimmedStyle.Render(timeStr), highlightStyle.Render(senderText), ackLabel, content)
 			msgLines = append(msgLines, line)
 		}
 	}
@@ -121,7 +165,22 @@ func RenderDashboard(myses []MysisInfo, swarmMessages []SwarmMessageInfo, select
 	sections = append(sections, swarmContent)
 
 	// Mysis list header
-	mysisHeader := renderSectionTitle("MYSIS SWARM", width)
+	// Only show cost when pricing is configured and usage has accrued
+	mysisTitle := "MYSIS SWARM"
+	var totalCost float64
+	for _, m := range myses {
+		totalCost += m.Cost
+	}
+	if totalCost > 0 {
+		mysisTitle = fmt.Sprintf("MYSIS SWARM · $%.4f", totalCost)
+	}
+	if summary := toolStatsSummary(toolStats); summary != "" {
+		mysisTitle += " · " + summary
+	}
+	if lipgloss.Width(mysisTitle) > width-8 {
+		mysisTitle = truncateWithEllipsis(mysisTitle, width-8)
+	}
+	mysisHeader := renderSectionTitle(mysisTitle, width)
 	sections = append(sections, mysisHeader)
 
 	// Calculate height used by other elements to fill remaining space
@@ -217,6 +276,8 @@ func buildInfoRow(m MysisInfo, selected, isLoading bool, spinnerView string, wid
 			stateIndicator = stateStoppedStyle.Render("◌")
 		case "errored":
 			stateIndicator = stateErroredStyle.Render("✖")
+		case "quarantined":
+			stateIndicator = stateQuarantinedStyle.Render("⊘")
 		default:
 			stateIndicator = "?"
 		}
@@ -264,6 +325,23 @@ func buildInfoRow(m MysisInfo, selected, isLoading bool, spinnerView string, wid
 	// Content width: width - prefix
 	contentStyleWidth := width - prefixWidth
 
+	// Turn counter, only if it fits (1 char right padding)
+	if label := turnCountLabel(m.TurnCount); lipgloss.Width(contentPart)+1+lipgloss.Width(label)+1 <= contentStyleWidth {
+		contentPart += " " + dimmedStyle.Render(label)
+	}
+
+	// Context fill gauge, only if it fits (1 char right padding)
+	if label := contextFillLabel(m.ContextFill, m.ContextKnown); lipgloss.Width(contentPart)+1+lipgloss.Width(label)+1 <= contentStyleWidth {
+		contentPart += " " + dimmedStyle.Render(label)
+	}
+
+	// Waiting activity (traveling/cooldown) label, only if it fits (1 char right padding)
+	if label := activityLabel(m); label != "" {
+		if lipgloss.Width(contentPart)+1+lipgloss.Width(label)+1 <= contentStyleWidth {
+			contentPart += " " + dimmedStyle.Render(label)
+		}
+	}
+
 	if selected {
 		// Dim purple background, bright purple foreground on [→ ] (4 chars), then normal space
 		cursorStyle := lipgloss.NewStyle().Background(colorBrandDim).Foreground(colorBrand)
@@ -280,6 +358,53 @@ func buildInfoRow(m MysisInfo, selected, isLoading bool, spinnerView string, wid
 	return unselectedCursor + " " + stateIndicator + "  " + mysisItemStyle.PaddingLeft(0).PaddingRight(1).Width(contentStyleWidth).Render(contentPart)
 }
 
+// turnCountLabel renders a turn count right-aligned so counts up to 99999 line
+// up across rows, e.g. "   12 turns".
+func turnCountLabel(count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%5d turn ", count)
+	}
+	return fmt.Sprintf("%5d turns", count)
+}
+
+// contextFillLabel renders a context fill percentage as a five-cell gauge, e.g.
+// "ctx ▰▰▱▱▱  42%", or "ctx --" when the window is unknown.
+func contextFillLabel(percent int, known bool) string {
+	if !known {
+		return "ctx --"
+	}
+	cells := min((percent+10)/20, 5)
+	return fmt.Sprintf("ctx %s%s %3d%%", strings.Repeat("▰", cells), strings.Repeat("▱", 5-cells), percent)
+}
+
+// activityLabel describes why a running mysis is waiting instead of taking turns,
+// e.g. "→ traveling (≈T+12)". Returns "" for non-waiting activities.
+func activityLabel(m MysisInfo) string {
+	var glyph string
+	switch m.Activity {
+	case "traveling":
+		glyph = "→"
+	case "cooldown":
+		glyph = "⏳"
+	default:
+		return ""
+	}
+	if m.State != "running" {
+		return ""
+	}
+
+	label := glyph + " " + m.Activity
+	switch {
+	case m.ActivityLeft <= 0:
+		return label
+	case m.TickDuration > 0:
+		ticks := (m.ActivityLeft + m.TickDuration - 1) / m.TickDuration
+		return fmt.Sprintf("%s (≈T+%d)", label, ticks)
+	default:
+		return fmt.Sprintf("%s (≈%s)", label, m.ActivityLeft.Round(time.Second))
+	}
+}
+
 func buildMessageRow(m MysisInfo, width int, currentTick int64) string {
 	prefix := "  └─ " // 2 spaces + corner + dash + space = 5 chars
 	prefixWidth := 5
@@ -304,8 +429,8 @@ func buildMessageRow(m MysisInfo, width int, currentTick int64) string {
 // formatMessageRow formats the message row based on message type and priority.
 // Priority order: 1) Errors, 2) AI replies, 3) Tool calls, 4) User messages/broadcasts
 func formatMessageRow(m MysisInfo, currentTick int64, maxWidth int) string {
-	// Priority 1: Errors (if state is errored and LastError is set)
-	if m.State == "errored" && m.LastError != "" {
+	// Priority 1: Errors (if state is errored or quarantined and LastError is set)
+	if (m.State == "errored" || m.State == "quarantined") && m.LastError != "" {
 		return formatErrorMessage(m.LastError, currentTick, m.LastMessageAt, maxWidth)
 	}
 
@@ -511,16 +636,26 @@ func formatUserMessage(mem *store.Memory, currentTick int64, maxWidth int) strin
 
 // MysisInfoFromCore converts a core.Mysis to MysisInfo.
 func MysisInfoFromCore(m *core.Mysis) MysisInfo {
-	return MysisInfo{
+	info := MysisInfo{
 		ID:              m.ID(),
 		Name:            m.Name(),
 		State:           string(m.State()),
 		Activity:        string(m.ActivityState()), // NEW: copy activity state
+		TickDuration:    m.TickDuration(),
 		Provider:        m.ProviderName(),
 		AccountUsername: m.CurrentAccountUsername(), // NEW: copy account username
 		CreatedAt:       m.CreatedAt(),
 		LastError:       formatCoreError(m.LastError()),
 	}
+	info.ContextFill, info.ContextKnown = m.ContextFill()
+	info.TurnCount = m.TurnCount()
+	now := time.Now()
+	if tick, at := m.LastServerTick(); tick > 0 {
+		info.LastTick = tick
+		info.TickAge = now.Sub(at)
+		info.TickStale = m.TickStale(now)
+	}
+	return info
 }
 
 func formatCoreError(err error) string {
@@ -22,6 +22,7 @@ func TestErrorDisplayInDashboard(t *testing.T) {
 		make(map[string]bool),
 		"⠋",
 		0,
+		nil,
 		testErr,
 	)
 
@@ -106,6 +107,7 @@ func TestNoErrorDisplay(t *testing.T) {
 		"⠋",
 		0,
 		nil,
+		nil,
 	)
 
 	// Should not contain "Error:"
@@ -126,6 +128,7 @@ func TestLongErrorTruncation(t *testing.T) {
 		make(map[string]bool),
 		"⠋",
 		0,
+		nil,
 		longErr,
 	)
 
@@ -3,18 +3,22 @@ package tui
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/xonecas/zoea-nova/internal/constants"
+	"github.com/xonecas/zoea-nova/internal/core"
 	"github.com/xonecas/zoea-nova/internal/gamestate"
 	"github.com/xonecas/zoea-nova/internal/store"
 )
 
 // LogEntry represents a log entry for display.
 type LogEntry struct {
+	MemoryID   int64 // Source memory (0 if not stored)
+	Pinned     bool  // Marked for review (see store.SetMemoryPinned)
 	Role       string
 	Source     string
 	SenderID   string
@@ -22,6 +26,7 @@ type LogEntry struct {
 	Content    string
 	Reasoning  string // NEW: reasoning content from LLM
 	Timestamp  time.Time
+	TurnStart  bool // First entry of a turn (see core.IsTurnPrompt); the prompt itself may be hidden
 }
 
 // wrapText wraps text to fit within maxWidth display columns, preserving words.
@@ -144,10 +149,7 @@ func RenderFocusView(mysis MysisInfo, logs []LogEntry, width, height int, isLoad
 		logLines = append(logLines, dimmedStyle.Render("No conversation history."))
 	} else {
 		// Render all log entries to fill panel content area
-		for _, entry := range logs {
-			entryLines := renderLogEntryImpl(entry, panelContentWidth, verbose, currentTick)
-			logLines = append(logLines, entryLines...)
-		}
+		logLines, _ = renderConversation(logs, panelContentWidth, verbose, false, currentTick)
 
 		// Show most recent lines that fit
 		if len(logLines) > logHeight {
@@ -375,12 +377,16 @@ func RenderFocusViewWithViewport(mysis MysisInfo, vp viewport.Model, width int,
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
-// renderToolCallsCompact formats tool calls in compact format.
-// Format: ⚙ tool_name(args...) - truncated, no huge JSON
-func renderToolCallsCompact(content string, contentWidth int) []string {
-	// Parse tool calls from storage format
-	stored := strings.TrimPrefix(content, constants.ToolCallStoragePrefix)
-	if stored == "" {
+// toolResultSummaryFields is the number of top-level fields shown for a JSON tool result
+// when verbose mode is off.
+const toolResultSummaryFields = 3
+
+// renderToolCallsCompact formats tool calls as a structured block.
+// Format: ⚙ tool_name followed by one "key: value" line per argument.
+// In verbose mode the arguments are shown as full indented JSON instead.
+func renderToolCallsCompact(content string, contentWidth int, verbose bool) []string {
+	calls := core.ParseStoredToolCalls(content)
+	if len(calls) == 0 {
 		return []string{dimmedStyle.Render("⚠ Empty tool call")}
 	}
 
@@ -389,84 +395,81 @@ func renderToolCallsCompact(content string, contentWidth int) []string {
 	toolNameStyle := lipgloss.NewStyle().Foreground(colorTool).Bold(true)
 	toolArgStyle := lipgloss.NewStyle().Foreground(colorMuted)
 
-	// Parse each tool call record
-	parts := strings.Split(stored, constants.ToolCallStorageRecordDelimiter)
-	for _, part := range parts {
-		fields := strings.SplitN(part, constants.ToolCallStorageFieldDelimiter, constants.ToolCallStorageFieldCount)
-		if len(fields) < constants.ToolCallStorageFieldCount {
+	for _, call := range calls {
+		header := toolIconStyle.Render("⚙") + " " + toolNameStyle.Render(call.Name)
+
+		var args map[string]interface{}
+		if err := json.Unmarshal(call.Arguments, &args); err != nil {
+			// Not a JSON object: show the raw arguments inline
+			result = append(result, header+toolArgStyle.Render(truncateWithEllipsis("("+string(call.Arguments)+")", contentWidth-lipgloss.Width(call.Name)-2)))
 			continue
 		}
-
-		toolName := fields[1]
-		argsJSON := fields[2]
-
-		// Format: ⚙ tool_name(args...)
-		var argsDisplay string
-		if argsJSON == "{}" {
-			argsDisplay = "()"
-		} else {
-			// Parse JSON and create compact inline format
-			var args map[string]interface{}
-			if err := json.Unmarshal([]byte(argsJSON), &args); err == nil && len(args) > 0 {
-				// Sort keys for deterministic output
-				var keys []string
-				for k := range args {
-					keys = append(keys, k)
-				}
-				// Simple lexicographic sort
-				for i := 0; i < len(keys); i++ {
-					for j := i + 1; j < len(keys); j++ {
-						if keys[i] > keys[j] {
-							keys[i], keys[j] = keys[j], keys[i]
-						}
-					}
-				}
-
-				var argParts []string
-				for _, k := range keys {
-					v := args[k]
-					// Format value based on type
-					var valStr string
-					switch v := v.(type) {
-					case string:
-						// Truncate long strings
-						if len(v) > 20 {
-							valStr = fmt.Sprintf("%q", v[:20]+"...")
-						} else {
-							valStr = fmt.Sprintf("%q", v)
-						}
-					case float64, int, bool:
-						valStr = fmt.Sprintf("%v", v)
-					default:
-						// Complex types: use ellipsis
-						valStr = "{...}"
-					}
-					argParts = append(argParts, fmt.Sprintf("%s=%s", k, valStr))
-				}
-				argsDisplay = "(" + strings.Join(argParts, ", ") + ")"
-			} else {
-				argsDisplay = "(...)"
-			}
+		if len(args) == 0 {
+			result = append(result, header+toolArgStyle.Render("()"))
+			continue
 		}
 
-		// Truncate if too long
-		toolLine := toolIconStyle.Render("⚙") + " " + toolNameStyle.Render(toolName) + toolArgStyle.Render(argsDisplay)
-		if lipgloss.Width(toolLine) > contentWidth {
-			// Truncate args
-			maxArgsWidth := contentWidth - lipgloss.Width(toolIconStyle.Render("⚙")+" "+toolName) - 5
-			if maxArgsWidth < 5 {
-				maxArgsWidth = 5
+		result = append(result, header)
+		if verbose {
+			for _, line := range indentedJSONLines(args, contentWidth-2) {
+				result = append(result, "  "+toolArgStyle.Render(line))
 			}
-			argsDisplay = truncateWithEllipsis(argsDisplay, maxArgsWidth)
-			toolLine = toolIconStyle.Render("⚙") + " " + toolNameStyle.Render(toolName) + toolArgStyle.Render(argsDisplay)
+			continue
+		}
+		for _, line := range keyValueLines(args, contentWidth-2) {
+			result = append(result, "  "+toolArgStyle.Render(line))
 		}
-
-		result = append(result, toolLine)
 	}
 
 	return result
 }
 
+// keyValueLines renders the top-level fields of a JSON object as "key: value" lines,
+// sorted by key and truncated to width.
+func keyValueLines(obj map[string]interface{}, width int) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, truncateWithEllipsis(k+": "+formatJSONValue(obj[k]), width))
+	}
+	return lines
+}
+
+// indentedJSONLines renders a JSON value as indented JSON, wrapping long lines to width.
+func indentedJSONLines(value interface{}, width int) []string {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		// wrapText drops leading whitespace, so re-apply the JSON indentation
+		body := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(body)]
+		for _, wrapped := range wrapText(body, width-len(indent)) {
+			lines = append(lines, indent+wrapped)
+		}
+	}
+	return lines
+}
+
+// formatJSONValue renders a decoded JSON value on a single line.
+func formatJSONValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
 // renderToolResultCompact formats tool results compactly.
 // Success: just ✓ Success
 // Error: ✗ Error: message
@@ -527,10 +530,40 @@ func renderToolResultCompact(content string, contentWidth int, verbose bool) []s
 		return []string{errorStyle.Render("✗ Error")}
 	}
 
-	// Success: just show checkmark
-	return []string{successStyle.Render("✓ Success")}
+	// Success: checkmark, followed by the parsed payload when it's JSON
+	lines := []string{successStyle.Render("✓ Success")}
+	var payload interface{}
+	if !isJSON(trimmed) || json.Unmarshal([]byte(trimmed), &payload) != nil {
+		return lines
+	}
+
+	payloadStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	if verbose {
+		for _, line := range indentedJSONLines(payload, contentWidth-2) {
+			lines = append(lines, "  "+payloadStyle.Render(line))
+		}
+		return lines
+	}
+
+	switch v := payload.(type) {
+	case map[string]interface{}:
+		fields := keyValueLines(v, contentWidth-2)
+		if len(fields) > toolResultSummaryFields {
+			more := len(fields) - toolResultSummaryFields
+			fields = append(fields[:toolResultSummaryFields], fmt.Sprintf("… %d more (v for full payload)", more))
+		}
+		for _, line := range fields {
+			lines = append(lines, "  "+payloadStyle.Render(line))
+		}
+	case []interface{}:
+		lines = append(lines, "  "+payloadStyle.Render(fmt.Sprintf("[%d items]", len(v))))
+	}
+	return lines
 }
 
+// pinnedGlyph marks pinned entries in the conversation log.
+const pinnedGlyph = "⬥"
+
 func renderLogEntryImpl(entry LogEntry, maxWidth int, verbose bool, currentTick int64) []string {
 	// Get the role's foreground color
 	roleColor := RoleColor(entry.Role)
@@ -559,7 +592,11 @@ func renderLogEntryImpl(entry LogEntry, maxWidth int, verbose bool, currentTick
 	case "assistant":
 		rolePrefix = "AI:"
 	case "system":
-		rolePrefix = "SYS:"
+		if entry.Source == string(store.MemorySourceNote) {
+			rolePrefix = "NOTE:"
+		} else {
+			rolePrefix = "SYS:"
+		}
 	case "tool":
 		rolePrefix = "TOOL:"
 	default:
@@ -574,6 +611,9 @@ func renderLogEntryImpl(entry LogEntry, maxWidth int, verbose bool, currentTick
 	prefix := fmt.Sprintf("%s %s", timePrefix, rolePrefix)
 	// Update prefix style with final role color
 	prefixStyle = prefixStyle.Foreground(roleColor)
+	if entry.Pinned {
+		prefix = pinnedGlyph + " " + prefix
+	}
 
 	// Inside padding: 1 space on left and right of all content
 	const padLeft = 1
@@ -592,7 +632,7 @@ func renderLogEntryImpl(entry LogEntry, maxWidth int, verbose bool, currentTick
 	// Check for tool calls (assistant messages with [TOOL_CALLS] prefix)
 	if entry.Role == "assistant" && strings.HasPrefix(entry.Content, constants.ToolCallStoragePrefix) {
 		// Render tool calls with compact format
-		wrappedLines = renderToolCallsCompact(entry.Content, contentWidth)
+		wrappedLines = renderToolCallsCompact(entry.Content, contentWidth, verbose)
 	} else if entry.Role == "tool" {
 		// Tool results: only show errors, success is just a checkmark
 		wrappedLines = renderToolResultCompact(entry.Content, contentWidth, verbose)
@@ -748,6 +788,76 @@ func renderLogEntryImpl(entry LogEntry, maxWidth int, verbose bool, currentTick
 	return result
 }
 
+// currentTurnStart returns the index of the first entry of the current turn, or -1
+// when no entry starts a turn.
+func currentTurnStart(logs []LogEntry) int {
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].TurnStart {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderConversation renders log entries oldest first, with a separator where the
+// current turn starts. Unless expanded, each earlier turn is collapsed to a one-line
+// summary. starts holds the first line of each entry; the entries of a collapsed turn
+// share their summary line.
+func renderConversation(logs []LogEntry, width int, verbose, expanded bool, currentTick int64) (lines []string, starts []int) {
+	current := currentTurnStart(logs)
+	starts = make([]int, 0, len(logs))
+
+	for i := 0; i < len(logs); i++ {
+		if i == current {
+			lines = append(lines, renderTurnSeparator(logs[i], width))
+		}
+		if i < current && !expanded {
+			// Collapse the earlier turn up to the next turn start
+			end := i + 1
+			for end < current && !logs[end].TurnStart {
+				end++
+			}
+			for range logs[i:end] {
+				starts = append(starts, len(lines))
+			}
+			lines = append(lines, renderCollapsedTurn(logs[i:end], width))
+			i = end - 1
+			continue
+		}
+		starts = append(starts, len(lines))
+		lines = append(lines, renderLogEntryImpl(logs[i], width, verbose, currentTick)...)
+	}
+	return lines, starts
+}
+
+// renderTurnSeparator renders the rule marking the start of the current turn.
+func renderTurnSeparator(first LogEntry, width int) string {
+	label := "── CURRENT TURN "
+	if !first.Timestamp.IsZero() {
+		label += "· " + first.Timestamp.Local().Format("15:04:05") + " "
+	}
+	label = truncateToWidth(label, width)
+	return dimmedStyle.Render(label + strings.Repeat("─", max(width-lipgloss.Width(label), 0)))
+}
+
+// renderCollapsedTurn summarizes an earlier turn on one line: when it started, how
+// many entries it holds and the start of its first entry.
+func renderCollapsedTurn(turn []LogEntry, width int) string {
+	summary := "▸"
+	if !turn[0].Timestamp.IsZero() {
+		summary += " " + turn[0].Timestamp.Local().Format("15:04:05")
+	}
+	if len(turn) == 1 {
+		summary += " · 1 entry"
+	} else {
+		summary += fmt.Sprintf(" · %d entries", len(turn))
+	}
+	if preview := strings.Join(strings.Fields(turn[0].Content), " "); preview != "" {
+		summary += " · " + preview
+	}
+	return dimmedStyle.Render(truncateWithEllipsis(summary, width))
+}
+
 // LogEntryFromMemory converts a store.Memory to LogEntry.
 func LogEntryFromMemory(m *store.Memory, currentMysisID, senderName string) LogEntry {
 	source := string(m.Source)
@@ -755,6 +865,8 @@ func LogEntryFromMemory(m *store.Memory, currentMysisID, senderName string) LogE
 		source = "broadcast_self"
 	}
 	return LogEntry{
+		MemoryID:   m.ID,
+		Pinned:     m.Pinned,
 		Role:       string(m.Role),
 		Source:     source,
 		SenderID:   m.SenderID,
@@ -767,7 +879,7 @@ func LogEntryFromMemory(m *store.Memory, currentMysisID, senderName string) LogE
 
 // renderFocusHeader renders the focus view header spanning full width (2 lines).
 // Line 1: Mysis name and position
-// Line 2: ID and Created timestamp
+// Line 2: ID, Created timestamp and last seen tick
 func renderFocusHeader(mysis MysisInfo, focusIndex, totalMyses int, width int, spinnerView string) string {
 	// Line 1: Mysis name with position
 	countText := ""
@@ -788,7 +900,7 @@ func renderFocusHeader(mysis MysisInfo, focusIndex, totalMyses int, width int, s
 
 	// Line 2: ID and Created (or error)
 	var line2 string
-	if mysis.State == "errored" && mysis.LastError != "" {
+	if (mysis.State == "errored" || mysis.State == "quarantined") && mysis.LastError != "" {
 		// Show simplified error with animated red icon
 		errorIcon := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Render(spinnerView)
 		line2 = "  " + labelStyle.Render("ERROR") + " " + errorIcon
@@ -801,6 +913,16 @@ func renderFocusHeader(mysis MysisInfo, focusIndex, totalMyses int, width int, s
 		}
 		createdDisplay := fmt.Sprintf("%s %s", labelStyle.Render("Created:"), createdText)
 		line2 = "  " + idText + "    " + createdDisplay
+
+		if tickText := lastTickText(mysis); tickText != "" {
+			switch {
+			case lipgloss.Width(line2+"    "+tickText) <= width:
+				line2 += "    " + tickText
+			case mysis.TickStale:
+				// A possible disconnect matters more than the creation date
+				line2 = "  " + idText + "    " + tickText
+			}
+		}
 	}
 
 	// Combine both lines
@@ -808,6 +930,23 @@ func renderFocusHeader(mysis MysisInfo, focusIndex, totalMyses int, width int, s
 	return headerStyle.Width(width).Render(header)
 }
 
+// lastTickText describes the latest server tick a mysis observed, its estimated tick
+// duration and age, with a warning once it has gone stale ("" before any tick).
+func lastTickText(mysis MysisInfo) string {
+	if mysis.LastTick <= 0 {
+		return ""
+	}
+	detail := mysis.TickAge.Round(time.Second).String() + " ago"
+	if mysis.TickDuration > 0 {
+		detail = mysis.TickDuration.Round(100*time.Millisecond).String() + "/tick, " + detail
+	}
+	text := fmt.Sprintf("%s %s %s", labelStyle.Render("Tick:"), valueStyle.Render(fmt.Sprintf("%d", mysis.LastTick)), dimmedStyle.Render("("+detail+")"))
+	if mysis.TickStale {
+		text += " " + stateErroredStyle.Render("STALE - disconnected?")
+	}
+	return text
+}
+
 // isJSON checks if a string appears to be JSON.
 // Handles tool result format: "tool_call_id:json_content"
 func isJSON(s string) bool {
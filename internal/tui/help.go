@@ -16,11 +16,27 @@ var helpItems = []helpItem{
 	{"n", "New mysis"},
 	{"d", "Delete selected mysis"},
 	{"r", "Relaunch selected mysis"},
+	{"a", "Acknowledge error and resume in place"},
 	{"s", "Stop selected mysis"},
+	{"S", "Start all idle myses"},
+	{"X", "Stop all running myses"},
+	{"R / B", "Relaunch all errored myses (B: with broadcast)"},
+	{"V", "Vacuum database (myses stopped)"},
 	{"b", "Broadcast message to all"},
 	{"m", "Message selected mysis"},
 	{"c", "Configure selected mysis"},
-	{"Tab / Shift+Tab", "Navigate myses"},
+	{"M", "Quick-switch provider/model"},
+	{"!", "One-shot reminder for next turn"},
+	{"e", "Rename selected mysis"},
+	{"o", "Set standing goal of selected mysis"},
+	{"p", "Show system prompt (focus)"},
+	{"g", "Regenerate reply (focus)"},
+	{"T", "Retry last failed tool call (focus)"},
+	{"l", "Toggle mysis log file (focus)"},
+	{"P", "Pin/unpin entry at bottom of log (focus)"},
+	{"f / h", "Pinned only / expand earlier turns (focus)"},
+	{"x", "Compare two myses (press on each)"},
+	{"Tab / Shift+Tab", "Navigate myses (focus: cycle running)"},
 	{"Enter", "Focus selected mysis"},
 	{"Esc", "Back / Cancel"},
 	{"↑ / ↓ / k / j", "Scroll / Browse history"},
@@ -14,10 +14,17 @@ type InputMode int
 const (
 	InputModeNone InputMode = iota
 	InputModeBroadcast
+	InputModeRelaunchBroadcast
 	InputModeMessage
 	InputModeNewMysis
 	InputModeConfigProvider
 	InputModeConfigModel
+	InputModeReminder
+	InputModeRename
+	InputModeGoal
+	InputModeConfirmDelete
+	InputModeRetryTool
+	InputModeConfigSeed
 )
 
 const maxHistorySize = 100
@@ -57,6 +64,9 @@ func (m *InputModel) SetMode(mode InputMode, targetID string) {
 	case InputModeBroadcast:
 		m.textInput.Placeholder = "Broadcast message to all myses..."
 		m.textInput.Prompt = inputPromptStyle.Render("⬧") + "  "
+	case InputModeRelaunchBroadcast:
+		m.textInput.Placeholder = "Relaunch errored myses and broadcast to them..."
+		m.textInput.Prompt = inputPromptStyle.Render("⬧") + "  "
 	case InputModeMessage:
 		m.textInput.Placeholder = "Message to mysis..."
 		m.textInput.Prompt = inputPromptStyle.Render("⬥") + "  "
@@ -67,8 +77,26 @@ func (m *InputModel) SetMode(mode InputMode, targetID string) {
 		m.textInput.Placeholder = "Enter provider (ollama-qwen/zen-nano/zen-pickle)..."
 		m.textInput.Prompt = inputPromptStyle.Render("⚙") + "  "
 	case InputModeConfigModel:
-		m.textInput.Placeholder = "Enter model name..."
+		m.textInput.Placeholder = "Enter model name (empty for provider default)..."
+		m.textInput.Prompt = inputPromptStyle.Render("cfg") + "  "
+	case InputModeConfigSeed:
+		m.textInput.Placeholder = "Sampling seed (empty keeps the current one, -1 clears it)..."
 		m.textInput.Prompt = inputPromptStyle.Render("cfg") + "  "
+	case InputModeReminder:
+		m.textInput.Placeholder = "One-shot reminder for the next turn..."
+		m.textInput.Prompt = inputPromptStyle.Render("!") + "  "
+	case InputModeRename:
+		m.textInput.Placeholder = "New mysis name..."
+		m.textInput.Prompt = inputPromptStyle.Render("⬡") + "  "
+	case InputModeGoal:
+		m.textInput.Placeholder = "Standing goal, e.g. control sector 7 (empty clears it)..."
+		m.textInput.Prompt = inputPromptStyle.Render("⬢") + "  "
+	case InputModeConfirmDelete:
+		m.textInput.Placeholder = "Type y to delete the mysis and its memories..."
+		m.textInput.Prompt = inputPromptStyle.Render("✕") + "  "
+	case InputModeRetryTool:
+		m.textInput.Placeholder = "tool_name {\"arg\": \"value\"}"
+		m.textInput.Prompt = inputPromptStyle.Render("↻") + "  "
 	default:
 		m.textInput.Placeholder = ""
 		m.textInput.Prompt = ""
@@ -96,6 +124,12 @@ func (m InputModel) Value() string {
 	return m.textInput.Value()
 }
 
+// SetValue prefills the input, e.g. with a value to edit.
+func (m *InputModel) SetValue(value string) {
+	m.textInput.SetValue(value)
+	m.textInput.CursorEnd()
+}
+
 // IsActive returns true if input is active.
 func (m InputModel) IsActive() bool {
 	return m.mode != InputModeNone
@@ -119,7 +153,7 @@ var historyKeys = struct {
 func (m InputModel) Update(msg tea.Msg) (InputModel, tea.Cmd) {
 	// Handle history navigation for message modes
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		if m.mode == InputModeBroadcast || m.mode == InputModeMessage {
+		if m.mode == InputModeBroadcast || m.mode == InputModeRelaunchBroadcast || m.mode == InputModeMessage {
 			switch {
 			case key.Matches(keyMsg, historyKeys.Up):
 				m.navigateHistory(1) // Go back in history
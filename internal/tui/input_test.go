@@ -24,6 +24,7 @@ func TestInputPromptIndicators(t *testing.T) {
 		{"new_mysis_mode", InputModeNewMysis, "", "⬡"},
 		{"config_provider_mode", InputModeConfigProvider, "mysis-1", "⚙"},
 		{"config_model_mode", InputModeConfigModel, "mysis-1", "cfg"},
+		{"config_seed_mode", InputModeConfigSeed, "mysis-1", "cfg"},
 	}
 
 	for _, tt := range tests {
@@ -413,13 +413,13 @@ func TestIntegration_ConfigProviderInput(t *testing.T) {
 	}
 }
 
-// TestIntegration_ConfigModelInput tests continue from provider, set model
+// TestIntegration_ConfigModelInput tests continue from provider, set model and seed
 func TestIntegration_ConfigModelInput(t *testing.T) {
 	m, cleanup := setupTestModel(t)
 	defer cleanup()
 
 	// Create a mysis
-	_, _ = m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	mysis, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
 	m.refreshMysisList()
 
 	tm := teatest.NewTestModel(
@@ -467,6 +467,24 @@ func TestIntegration_ConfigModelInput(t *testing.T) {
 		tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
 	}
 
+	// Press Enter to continue to seed
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Wait for seed prompt
+	teatest.WaitFor(
+		t,
+		tm.Output(),
+		func(bts []byte) bool {
+			return bytes.Contains(bts, []byte("Sampling seed"))
+		},
+		teatest.WithDuration(2*time.Second),
+	)
+
+	// Type seed
+	for _, r := range "42" {
+		tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
 	// Press Enter to save config
 	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
 
@@ -475,18 +493,21 @@ func TestIntegration_ConfigModelInput(t *testing.T) {
 		t,
 		tm.Output(),
 		func(bts []byte) bool {
-			return !bytes.Contains(bts, []byte("Enter model name"))
+			return !bytes.Contains(bts, []byte("Sampling seed"))
 		},
 		teatest.WithDuration(2*time.Second),
 	)
 
-	// Verify input cleared and pendingProvider cleared
+	// Verify input cleared, pending values cleared and seed applied
 	finalModel := quitAndFinalModel(t, tm, time.Second)
 	if finalModel.input.IsActive() {
-		t.Error("after model input: input should be inactive")
+		t.Error("after seed input: input should be inactive")
+	}
+	if finalModel.pendingProvider != "" || finalModel.pendingModel != "" {
+		t.Errorf("after seed input: pending provider/model = %q/%q, want empty", finalModel.pendingProvider, finalModel.pendingModel)
 	}
-	if finalModel.pendingProvider != "" {
-		t.Errorf("after model input: pendingProvider = %s, want empty", finalModel.pendingProvider)
+	if seed := mysis.Seed(); seed == nil || *seed != 42 {
+		t.Errorf("after seed input: seed = %v, want 42", seed)
 	}
 }
 
@@ -560,6 +581,7 @@ func TestIntegration_ViewportScroll(t *testing.T) {
 	m.refreshMysisList()
 	m.focusID = mysis.ID()
 	m.view = ViewFocus
+	m.expandTurns = true // Each message is its own turn; show them in full
 	m.loadMysisLogs()
 
 	tm := teatest.NewTestModel(
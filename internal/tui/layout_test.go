@@ -86,7 +86,7 @@ func TestDashboardLayoutCalculations(t *testing.T) {
 
 			// Render dashboard (account for input bar height: -3)
 			contentHeight := tt.termHeight - 3
-			output := RenderDashboard(myses, swarmMsgs, 0, tt.termWidth, contentHeight, loadingSet, spinnerView, 0, nil)
+			output := RenderDashboard(myses, swarmMsgs, 0, tt.termWidth, contentHeight, loadingSet, spinnerView, 0, nil, nil)
 
 			// Basic validations
 			if output == "" {
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/xonecas/zoea-nova/internal/core"
+)
+
+// modelPickerChrome is the overlay height outside the choice list: helpStyle margin,
+// border and padding (6), title, current model and a blank (3), footer and blank (2),
+// message bar (1) and status bar (1).
+const modelPickerChrome = 13
+
+// modelPicker is the provider/model quick-switch overlay for one mysis.
+type modelPicker struct {
+	mysisID   string
+	mysisName string
+	current   core.ModelChoice
+	choices   []core.ModelChoice // nil while the providers are queried
+	cursor    int
+}
+
+// modelChoicesMsg delivers the choices for the picker opened on mysisID.
+type modelChoicesMsg struct {
+	mysisID string
+	choices []core.ModelChoice
+}
+
+// setChoices fills the picker and puts the cursor on the current model.
+func (p *modelPicker) setChoices(choices []core.ModelChoice) {
+	p.choices = choices
+	p.cursor = 0
+	for i, choice := range choices {
+		if choice == p.current {
+			p.cursor = i
+			break
+		}
+	}
+}
+
+// move moves the cursor by delta, staying within the list.
+func (p *modelPicker) move(delta int) {
+	p.cursor = max(0, min(p.cursor+delta, len(p.choices)-1))
+}
+
+// selected returns the choice under the cursor.
+func (p *modelPicker) selected() (core.ModelChoice, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.choices) {
+		return core.ModelChoice{}, false
+	}
+	return p.choices[p.cursor], true
+}
+
+// RenderModelPicker renders the quick-switch overlay: every configured provider's
+// models, with the cursor and the mysis's current model highlighted.
+func RenderModelPicker(p *modelPicker, width, height int) string {
+	var lines []string
+	lines = append(lines, titleStyle.Render(" ⬥═══ ⬡ SWITCH MODEL ⬡ ═══⬥"))
+	lines = append(lines, helpKeyStyle.Render(p.mysisName)+"  "+helpDescStyle.Render(p.current.Provider+" / "+p.current.Model))
+	lines = append(lines, "")
+
+	switch {
+	case p.choices == nil:
+		lines = append(lines, dimmedStyle.Render("Loading models..."))
+	case len(p.choices) == 0:
+		lines = append(lines, dimmedStyle.Render("No providers configured."))
+	default:
+		// Scroll the list so the cursor stays visible
+		rows := max(height-modelPickerChrome, 3)
+		start := max(0, min(p.cursor-rows/2, len(p.choices)-rows))
+		end := min(start+rows, len(p.choices))
+		for i := start; i < end; i++ {
+			choice := p.choices[i]
+			text := fmt.Sprintf("%-14s %s", choice.Provider, choice.Model)
+			if choice == p.current {
+				text += " (current)"
+			}
+			text = truncateWithEllipsis(text, max(width-14, 10))
+			switch {
+			case i == p.cursor:
+				lines = append(lines, highlightStyle.Render("▸ "+text))
+			case choice == p.current:
+				lines = append(lines, helpKeyStyle.Render("  "+text))
+			default:
+				lines = append(lines, helpDescStyle.Render("  "+text))
+			}
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, dimmedStyle.Render("↑/↓ select · Enter switch after the current turn · Esc close"))
+
+	box := helpStyle.Render(strings.Join(lines, "\n"))
+
+	// Center the box like the help overlay
+	padLeft := (width - lipgloss.Width(box)) / 2
+	padTop := (height - lipgloss.Height(box)) / 2
+	if padLeft < 0 {
+		padLeft = 0
+	}
+	if padTop < 0 {
+		padTop = 0
+	}
+
+	leftPad := strings.Repeat(" ", padLeft)
+	boxLines := strings.Split(box, "\n")
+	for i, line := range boxLines {
+		boxLines[i] = leftPad + line
+	}
+	return strings.Repeat("\n", padTop) + strings.Join(boxLines, "\n")
+}
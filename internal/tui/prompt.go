@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/xonecas/zoea-nova/internal/core"
+)
+
+// promptChrome is the overlay height outside the viewport: helpStyle margin, border and
+// padding (6), title, broadcast and stale lines plus a blank (4), footer and blank (2),
+// message bar (1) and status bar (1).
+const promptChrome = 14
+
+// promptViewportSize returns the viewport size for the system prompt overlay.
+func promptViewportSize(width, height int) (int, int) {
+	// helpStyle margin, border and padding (8) + gap and scrollbar (2)
+	vpWidth := width - 10
+	if vpWidth < 20 {
+		vpWidth = 20
+	}
+	vpHeight := height - promptChrome
+	if vpHeight < 5 {
+		vpHeight = 5
+	}
+	return vpWidth, vpHeight
+}
+
+// setPromptContent wraps the previewed prompt to the viewport width.
+func setPromptContent(vp *viewport.Model, preview *core.SystemPromptPreview) {
+	vp.SetContent(strings.Join(wrapText(preview.Prompt, vp.Width), "\n"))
+}
+
+// RenderSystemPrompt renders the effective system prompt overlay: the prompt rebuilt
+// with the latest commander broadcast, in a scrollable box.
+func RenderSystemPrompt(preview *core.SystemPromptPreview, vp viewport.Model, width, height int) string {
+	var lines []string
+	lines = append(lines, titleStyle.Render(" ⬥═══ ⬡ SYSTEM PROMPT ⬡ ═══⬥"))
+
+	broadcast := dimmedStyle.Render("none - fallback section")
+	if preview.Broadcast != "" {
+		broadcast = helpDescStyle.Render(truncateToWidth(strings.ReplaceAll(preview.Broadcast, "\n", " "), vp.Width-11))
+	}
+	lines = append(lines, helpKeyStyle.Render("Broadcast")+"  "+broadcast)
+
+	switch {
+	case preview.Stored == "":
+		lines = append(lines, dimmedStyle.Render("Not stored yet - set when the mysis starts"))
+	case preview.Stale():
+		lines = append(lines, dimmedStyle.Render("Stored prompt differs - refreshed on next login"))
+	default:
+		lines = append(lines, dimmedStyle.Render("Matches the stored prompt"))
+	}
+	lines = append(lines, "")
+
+	scrollbar := renderScrollbar(vp.Height, vp.TotalLineCount(), vp.YOffset)
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, vp.View(), " ", scrollbar))
+
+	lines = append(lines, "")
+	lines = append(lines, dimmedStyle.Render("↑/↓ PgUp/PgDn scroll · p/Esc close"))
+
+	box := helpStyle.Render(strings.Join(lines, "\n"))
+
+	// Center the box like the help overlay
+	padLeft := (width - lipgloss.Width(box)) / 2
+	padTop := (height - lipgloss.Height(box)) / 2
+	if padLeft < 0 {
+		padLeft = 0
+	}
+	if padTop < 0 {
+		padTop = 0
+	}
+
+	leftPad := strings.Repeat(" ", padLeft)
+	boxLines := strings.Split(box, "\n")
+	for i, line := range boxLines {
+		boxLines[i] = leftPad + line
+	}
+	return strings.Repeat("\n", padTop) + strings.Join(boxLines, "\n")
+}
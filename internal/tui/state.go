@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+)
+
+// viewState is the selection and view persisted across restarts.
+type viewState struct {
+	View       string `json:"view"`
+	SelectedID string `json:"selected_id,omitempty"`
+	FocusID    string `json:"focus_id,omitempty"`
+}
+
+const (
+	viewStateDashboard = "dashboard"
+	viewStateFocus     = "focus"
+)
+
+// loadViewState reads a persisted view state. A missing file is not an error.
+func loadViewState(path string) (*viewState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state viewState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return &state, nil
+}
+
+// saveViewState writes the view state atomically so a crash never leaves a torn file.
+func saveViewState(path string, state viewState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// SetStateFile enables persisting the selection and view to path. The saved state
+// is loaded now and applied once the mysis list is first refreshed.
+func (m *Model) SetStateFile(path string) {
+	m.statePath = path
+	state, err := loadViewState(path)
+	if err != nil {
+		log.Warn().Err(err).Str("path", path).Msg("Ignoring unreadable TUI state")
+		return
+	}
+	m.pendingViewState = state
+}
+
+// SaveViewState writes the current selection and view to the state file, if set.
+func (m Model) SaveViewState() {
+	if m.statePath == "" {
+		return
+	}
+	state := viewState{View: viewStateDashboard}
+	if m.selectedIdx >= 0 && m.selectedIdx < len(m.myses) {
+		state.SelectedID = m.myses[m.selectedIdx].ID
+	}
+	if m.view == ViewFocus && m.focusID != "" {
+		state.View = viewStateFocus
+		state.FocusID = m.focusID
+	}
+	if err := saveViewState(m.statePath, state); err != nil {
+		log.Warn().Err(err).Str("path", m.statePath).Msg("Failed to save TUI state")
+	}
+}
+
+// restoreViewState applies the loaded state once. Myses that no longer exist
+// fall back to the dashboard with the first mysis selected.
+func (m *Model) restoreViewState() {
+	state := m.pendingViewState
+	m.pendingViewState = nil
+	if state == nil {
+		return
+	}
+
+	m.selectedIdx = 0
+	for i, info := range m.myses {
+		if info.ID == state.SelectedID {
+			m.selectedIdx = i
+			break
+		}
+	}
+
+	if state.View != viewStateFocus {
+		return
+	}
+	for i, info := range m.myses {
+		if info.ID == state.FocusID {
+			m.selectedIdx = i
+			m.focusID = info.ID
+			m.view = ViewFocus
+			m.loadMysisLogs()
+			m.viewport.GotoBottom()
+			return
+		}
+	}
+}
@@ -34,6 +34,9 @@ var (
 	colorBgPanel = lipgloss.Color("#14141F") // Panel background
 	colorBorder  = lipgloss.Color("#2A2A55") // Purple-tinted border
 
+	// Dashboard banner and its border ([theme] header)
+	colorHeader = colorBrand
+
 	// Legacy aliases for compatibility
 	colorPrimary   = colorBrand
 	colorSecondary = colorTeal
@@ -85,6 +88,10 @@ var (
 				Foreground(colorError).
 				Bold(true)
 
+	stateQuarantinedStyle = lipgloss.NewStyle().
+				Foreground(colorTool).
+				Bold(true)
+
 	// Logs/Messages - conversation styling per design doc
 	// No padding here - padding is added to content lines directly
 	// No border around viewport - clean look
@@ -171,6 +178,8 @@ func StateStyle(state string) lipgloss.Style {
 		return stateStoppedStyle
 	case "errored":
 		return stateErroredStyle
+	case "quarantined":
+		return stateQuarantinedStyle
 	default:
 		return stateIdleStyle
 	}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rs/zerolog/log"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+// Theme maps message roles, mysis states and the dashboard header to colors.
+type Theme struct {
+	Roles  map[string]lipgloss.Color
+	States map[string]lipgloss.Color
+	Header lipgloss.Color
+}
+
+// darkTheme is the original palette defined in styles.go.
+var darkTheme = Theme{
+	Roles: map[string]lipgloss.Color{
+		"user":      colorUser,
+		"assistant": colorAssistant,
+		"system":    colorSystem,
+		"tool":      colorTool,
+	},
+	States: map[string]lipgloss.Color{
+		"running":     colorSuccess,
+		"idle":        colorTeal,
+		"stopped":     colorMuted,
+		"errored":     colorError,
+		"quarantined": colorTool,
+	},
+	Header: colorBrand,
+}
+
+// builtinThemes are selectable by [theme] name.
+var builtinThemes = map[string]Theme{
+	constants.ThemeDark: darkTheme,
+	// Darker, saturated colors that stay readable on a white background
+	constants.ThemeLight: {
+		Roles: map[string]lipgloss.Color{
+			"user":      "#007A33",
+			"assistant": "#A0007F",
+			"system":    "#00618C",
+			"tool":      "#7A5C00",
+		},
+		States: map[string]lipgloss.Color{
+			"running":     "#007A33",
+			"idle":        "#00665C",
+			"stopped":     "#5C5C7A",
+			"errored":     "#C8102E",
+			"quarantined": "#7A5C00",
+		},
+		Header: "#6B00B3",
+	},
+	// Pure primaries for low-quality displays and colorblind-friendly contrast
+	constants.ThemeHighContrast: {
+		Roles: map[string]lipgloss.Color{
+			"user":      "#00FF00",
+			"assistant": "#FF00FF",
+			"system":    "#00FFFF",
+			"tool":      "#FFFF00",
+		},
+		States: map[string]lipgloss.Color{
+			"running":     "#00FF00",
+			"idle":        "#FFFFFF",
+			"stopped":     "#AAAAAA",
+			"errored":     "#FF0000",
+			"quarantined": "#FFFF00",
+		},
+		Header: "#FFFFFF",
+	},
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor reports whether value is a hex color or an ANSI 256 color number.
+func validColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ApplyTheme sets the TUI colors from [theme]: the named built-in theme, overridden by
+// any role, state or header colors. Invalid colors and unknown keys are logged and
+// ignored. Call it before the TUI starts.
+func ApplyTheme(cfg config.ThemeConfig) {
+	base, ok := builtinThemes[cfg.Name]
+	if !ok {
+		if cfg.Name != "" {
+			log.Warn().Str("theme", cfg.Name).Msg("Unknown theme - using dark")
+		}
+		base = darkTheme
+	}
+
+	theme := Theme{
+		Roles:  overrideColors("theme.roles", base.Roles, cfg.Roles),
+		States: overrideColors("theme.states", base.States, cfg.States),
+		Header: base.Header,
+	}
+	if cfg.Header != "" {
+		if validColor(cfg.Header) {
+			theme.Header = lipgloss.Color(cfg.Header)
+		} else {
+			log.Warn().Str("color", cfg.Header).Msg("Ignoring invalid theme.header color")
+		}
+	}
+	applyTheme(theme)
+}
+
+// overrideColors returns base with valid overrides applied.
+func overrideColors(section string, base map[string]lipgloss.Color, overrides map[string]string) map[string]lipgloss.Color {
+	result := make(map[string]lipgloss.Color, len(base))
+	for key, color := range base {
+		result[key] = color
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := overrides[key]
+		switch {
+		case base[key] == "":
+			log.Warn().Str("section", section).Str("key", key).Msg("Ignoring unknown theme entry")
+		case !validColor(value):
+			log.Warn().Str("section", section).Str("key", key).Str("color", value).Msg("Ignoring invalid theme color")
+		default:
+			result[key] = lipgloss.Color(value)
+		}
+	}
+	return result
+}
+
+// applyTheme rebuilds the role and state styles consulted by RoleColor, RoleStyle
+// and StateStyle, and the header color.
+func applyTheme(t Theme) {
+	colorUser = t.Roles["user"]
+	colorAssistant = t.Roles["assistant"]
+	colorSystem = t.Roles["system"]
+	colorTool = t.Roles["tool"]
+	logUserStyle = logUserStyle.Foreground(colorUser)
+	logAssistantStyle = logAssistantStyle.Foreground(colorAssistant)
+	logSystemStyle = logSystemStyle.Foreground(colorSystem)
+	logToolStyle = logToolStyle.Foreground(colorTool)
+
+	stateRunningStyle = stateRunningStyle.Foreground(t.States["running"])
+	stateIdleStyle = stateIdleStyle.Foreground(t.States["idle"])
+	stateStoppedStyle = stateStoppedStyle.Foreground(t.States["stopped"])
+	stateErroredStyle = stateErroredStyle.Foreground(t.States["errored"])
+	stateQuarantinedStyle = stateQuarantinedStyle.Foreground(t.States["quarantined"])
+
+	colorHeader = t.Header
+}
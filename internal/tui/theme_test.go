@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
+)
+
+func TestApplyTheme(t *testing.T) {
+	defer ApplyTheme(config.ThemeConfig{})
+
+	ApplyTheme(config.ThemeConfig{
+		Name:   constants.ThemeLight,
+		Roles:  map[string]string{"tool": "208", "assistant": "not-a-color", "narrator": "#FFFFFF"},
+		States: map[string]string{"errored": "#F00"},
+		Header: "#12345",
+	})
+
+	light := builtinThemes[constants.ThemeLight]
+	tests := []struct {
+		name string
+		got  lipgloss.TerminalColor
+		want lipgloss.Color
+	}{
+		{"theme role", RoleColor("user"), light.Roles["user"]},
+		{"role override", RoleColor("tool"), "208"},
+		{"invalid role ignored", RoleColor("assistant"), light.Roles["assistant"]},
+		{"role style", RoleStyle("user").GetForeground(), light.Roles["user"]},
+		{"state override", StateStyle("errored").GetForeground(), "#F00"},
+		{"theme state", StateStyle("idle").GetForeground(), light.States["idle"]},
+		{"invalid header ignored", colorHeader, light.Header},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// An empty config restores the default palette
+	ApplyTheme(config.ThemeConfig{})
+	if RoleColor("user") != darkTheme.Roles["user"] || colorHeader != colorBrand {
+		t.Errorf("expected dark theme restored, got user=%v header=%v", RoleColor("user"), colorHeader)
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	for value, want := range map[string]bool{
+		"#FFF": true, "#00ffcc": true, "0": true, "255": true,
+		"": false, "#12345": false, "256": false, "red": false, "-1": false,
+	} {
+		if got := validColor(value); got != want {
+			t.Errorf("validColor(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -105,6 +105,20 @@ func TestToolCallsRendering(t *testing.T) {
 			verbose: false,
 			width:   120,
 		},
+		{
+			name: "complex_nested_json_verbose",
+			content: constants.ToolCallStoragePrefix +
+				"call_complex:execute:{\"plan\":{\"steps\":[{\"action\":\"travel\",\"target\":\"sol\"}],\"priority\":\"high\"},\"timeout\":3600}",
+			verbose: true,
+			width:   120,
+		},
+		{
+			name: "tool_call_with_invalid_json",
+			content: constants.ToolCallStoragePrefix +
+				"call_bad:travel:{destination:sol",
+			verbose: false,
+			width:   120,
+		},
 	}
 
 	for _, tc := range testCases {
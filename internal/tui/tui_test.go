@@ -1,6 +1,9 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -10,7 +13,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 	"github.com/xonecas/zoea-nova/internal/config"
+	"github.com/xonecas/zoea-nova/internal/constants"
 	"github.com/xonecas/zoea-nova/internal/core"
+	"github.com/xonecas/zoea-nova/internal/mcp"
 	"github.com/xonecas/zoea-nova/internal/provider"
 	"github.com/xonecas/zoea-nova/internal/store"
 )
@@ -186,6 +191,60 @@ func TestModelNavigation(t *testing.T) {
 	}
 }
 
+func TestModelFocusCycleRunning(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m1, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m2, _ := m.commander.CreateMysis("mysis-2", "ollama-qwen")
+	m3, _ := m.commander.CreateMysis("mysis-3", "ollama-qwen")
+	m.commander.StartMysis(m1.ID())
+	m.commander.StartMysis(m3.ID())
+	m.refreshMysisList()
+
+	m.view = ViewFocus
+	m.focusID = m1.ID()
+
+	press := func(keyType tea.KeyType) {
+		t.Helper()
+		newModel, _ := m.Update(tea.KeyMsg{Type: keyType})
+		m = newModel.(Model)
+	}
+
+	// Tab skips the idle mysis-2
+	press(tea.KeyTab)
+	if m.focusID != m3.ID() {
+		t.Errorf("after Tab: expected focus on mysis-3, got %s", m.mysisNameByID(m.focusID))
+	}
+
+	// Wraps around at the end
+	press(tea.KeyTab)
+	if m.focusID != m1.ID() {
+		t.Errorf("after second Tab: expected focus on mysis-1, got %s", m.mysisNameByID(m.focusID))
+	}
+
+	press(tea.KeyShiftTab)
+	if m.focusID != m3.ID() {
+		t.Errorf("after Shift+Tab: expected focus on mysis-3, got %s", m.mysisNameByID(m.focusID))
+	}
+
+	// From a non-running mysis, pick the nearest running one in each direction
+	m.focusID = m2.ID()
+	press(tea.KeyTab)
+	if m.focusID != m3.ID() {
+		t.Errorf("Tab from idle: expected focus on mysis-3, got %s", m.mysisNameByID(m.focusID))
+	}
+
+	m.focusID = m2.ID()
+	press(tea.KeyShiftTab)
+	if m.focusID != m1.ID() {
+		t.Errorf("Shift+Tab from idle: expected focus on mysis-1, got %s", m.mysisNameByID(m.focusID))
+	}
+	if m.view != ViewFocus {
+		t.Errorf("expected to stay in focus view, got %v", m.view)
+	}
+}
+
 func TestModelFocusView(t *testing.T) {
 	m, cleanup := setupTestModel(t)
 	defer cleanup()
@@ -214,6 +273,493 @@ func TestModelFocusView(t *testing.T) {
 	}
 }
 
+func TestModelSystemPromptOverlay(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m.commander.CreateMysis("test-mysis", "ollama-qwen")
+	m.refreshMysisList()
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	newModel, _ = newModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	newModel, _ = newModel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = newModel.(Model)
+
+	if m.promptPreview == nil {
+		t.Fatal("expected system prompt overlay after pressing p")
+	}
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "SYSTEM PROMPT") || !strings.Contains(view, "none - fallback section") {
+		t.Errorf("expected overlay with fallback broadcast, got:\n%s", view)
+	}
+
+	// Esc closes the overlay but stays in focus view
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.promptPreview != nil || m.view != ViewFocus {
+		t.Errorf("expected overlay closed in focus view, got preview=%v view=%d", m.promptPreview != nil, m.view)
+	}
+}
+
+func TestModelNewMysisAtCap(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	for i := 0; i < m.commander.MaxMyses(); i++ {
+		m.commander.CreateMysis(fmt.Sprintf("mysis-%d", i), "ollama-qwen")
+	}
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(Model)
+
+	if m.input.IsActive() {
+		t.Error("expected no new mysis prompt at the cap")
+	}
+	if !errors.Is(m.err, core.ErrMaxMysesReached) {
+		t.Errorf("expected ErrMaxMysesReached, got %v", m.err)
+	}
+}
+
+func TestRenderDashboardToolStats(t *testing.T) {
+	stats := []store.ToolCallStat{
+		{ToolName: "attack", Calls: 20, Errors: 3},
+		{ToolName: "mine", Calls: 12},
+		{ToolName: "travel", Calls: 5},
+		{ToolName: "scan", Calls: 1},
+	}
+	output := stripANSI(RenderDashboard(nil, nil, 0, 120, 30, map[string]bool{}, "⠋", 0, stats, nil))
+	if !strings.Contains(output, "38 calls/h (3 failed): attack 20, mine 12, travel 5") {
+		t.Errorf("expected tool call summary in dashboard, got:\n%s", output)
+	}
+
+	// No summary without recent calls
+	output = stripANSI(RenderDashboard(nil, nil, 0, 120, 30, map[string]bool{}, "⠋", 0, nil, nil))
+	if strings.Contains(output, "calls/h") {
+		t.Error("expected no tool call summary without calls")
+	}
+}
+
+func TestModelCommanderBroadcastLabel(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m.config.Swarm.CommanderName = "Admiral"
+	m.width = 120
+	m.swarmMessages = []SwarmMessage{{Content: "Hold at Sol", CreatedAt: testTime()}}
+
+	output := stripANSI(m.View())
+	if !strings.Contains(output, "[Admiral]") {
+		t.Errorf("expected commander broadcast labeled with the configured name, got:\n%s", output)
+	}
+}
+
+func TestRenderDashboardBroadcastAcks(t *testing.T) {
+	swarmMsgs := []SwarmMessageInfo{
+		{SenderName: "Commander", Content: "Hold at Sol", CreatedAt: testTime(), Recipients: 3, Acked: 2},
+		{SenderID: "mysis-1", SenderName: "alpha", Content: "Found ore", CreatedAt: testTime()},
+	}
+	output := stripANSI(RenderDashboard(nil, swarmMsgs, 0, 100, 30, map[string]bool{}, "⠋", 0, nil, nil))
+	if !strings.Contains(output, "[Commander] ✓2/3 Hold at Sol") {
+		t.Errorf("expected ack count on the commander broadcast, got:\n%s", output)
+	}
+	if !strings.Contains(output, "[alpha] Found ore") {
+		t.Errorf("expected untracked broadcasts without an ack count, got:\n%s", output)
+	}
+}
+
+func TestModelPinEntries(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	mysis, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "mine the belt", "", "")
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "heading to the belt", "", "")
+	m.refreshMysisList()
+
+	press := func(msg tea.Msg) {
+		t.Helper()
+		newModel, _ := m.Update(msg)
+		m = newModel.(Model)
+	}
+	press(tea.WindowSizeMsg{Width: 120, Height: 40})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != ViewFocus {
+		t.Fatalf("expected focus view, got %v", m.view)
+	}
+
+	// At the bottom of the log, pin marks the newest entry
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if len(m.logs) != 2 || m.logs[0].Pinned || !m.logs[1].Pinned {
+		t.Fatalf("expected only the newest entry pinned, got %+v", m.logs)
+	}
+	if !strings.Contains(stripANSI(m.View()), pinnedGlyph+" ") {
+		t.Error("expected pinned glyph in focus view")
+	}
+
+	// Pins are stored, so a fresh load sees them
+	memories, _ := m.store.GetRecentMemories(mysis.ID(), 10)
+	if !memories[len(memories)-1].Pinned {
+		t.Error("expected pin to be persisted")
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if len(m.logs) != 1 || m.logs[0].Content != "heading to the belt" {
+		t.Fatalf("expected only the pinned entry, got %+v", m.logs)
+	}
+
+	// Unpinning the last pinned entry leaves the filtered log empty
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if len(m.logs) != 0 {
+		t.Errorf("expected no pinned entries, got %+v", m.logs)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if len(m.logs) != 2 || m.logs[1].Pinned {
+		t.Errorf("expected both entries unpinned, got %+v", m.logs)
+	}
+}
+
+func TestModelRecoverKey(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m.refreshMysisList()
+
+	// Only errored myses can be recovered in place
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(Model)
+	if m.err == nil || !strings.Contains(m.err.Error(), "not errored") {
+		t.Errorf("expected not-errored error, got %v", m.err)
+	}
+}
+
+func TestModelRetryToolKey(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	mysis, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m.refreshMysisList()
+	m.view = ViewFocus
+	m.focusID = mysis.ID()
+
+	pressT := func() {
+		t.Helper()
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+		m = newModel.(Model)
+	}
+
+	pressT()
+	if m.err == nil || m.input.Mode() != InputModeNone {
+		t.Fatalf("expected an error without failed tool calls, got err %v, mode %v", m.err, m.input.Mode())
+	}
+
+	m.err = nil
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, constants.ToolCallStoragePrefix+"call_1"+constants.ToolCallStorageFieldDelimiter+"travel"+constants.ToolCallStorageFieldDelimiter+`{"poi":"belt"}`, "", "")
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleTool, store.MemorySourceTool, "call_1"+constants.ToolCallStorageFieldDelimiter+"Error calling travel: unknown poi", "", "")
+	pressT()
+	if m.input.Mode() != InputModeRetryTool || m.input.Value() != `travel {"poi":"belt"}` {
+		t.Errorf("expected the failed call prefilled, got mode %v, value %q", m.input.Mode(), m.input.Value())
+	}
+
+	status := formatRetryStatus("travel", &mcp.ToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "unknown\npoi"}}, IsError: true})
+	if status != "Retried travel: failed: unknown poi" {
+		t.Errorf("unexpected status %q", status)
+	}
+}
+
+func TestModelGoalKey(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	mysis, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m.commander.SetGoal(mysis.ID(), "Mine ore")
+	m.refreshMysisList()
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = newModel.(Model)
+	if m.input.Mode() != InputModeGoal || m.input.Value() != "Mine ore" {
+		t.Fatalf("expected the current goal prefilled, got mode %v, value %q", m.input.Mode(), m.input.Value())
+	}
+
+	m.input.SetValue("Control sector 7")
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.err != nil || mysis.Goal() != "Control sector 7" {
+		t.Errorf("expected goal set, got %q (err %v)", mysis.Goal(), m.err)
+	}
+
+	// Submitting an empty goal clears it
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = newModel.(Model)
+	m.input.SetValue("")
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if mysis.Goal() != "" {
+		t.Errorf("expected goal cleared, got %q", mysis.Goal())
+	}
+}
+
+func TestModelRelaunchBroadcastKey(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	mysis, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	mysis.SetErrorState(errors.New("provider down"))
+	m.refreshMysisList()
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	m = newModel.(Model)
+	if m.input.Mode() != InputModeRelaunchBroadcast {
+		t.Fatalf("expected relaunch broadcast input, got mode %v", m.input.Mode())
+	}
+
+	m.input.SetValue("Regroup at the station")
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if cmd == nil || m.input.IsActive() {
+		t.Fatal("expected input closed and the relaunch queued")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	defer m.commander.StopAll()
+
+	if want := "Relaunch + broadcast: 1/1 succeeded"; m.status != want {
+		t.Errorf("status = %q, want %q (err %v)", m.status, want, m.err)
+	}
+	if mysis.State() == core.MysisStateErrored {
+		t.Error("expected the errored mysis relaunched")
+	}
+}
+
+func TestModelPickerSwitch(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+	m.width, m.height = 100, 30
+
+	mysis, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m.refreshMysisList()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	m = newModel.(Model)
+	if m.modelPicker == nil || cmd == nil {
+		t.Fatalf("expected the picker to open and list models")
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "Loading models...") {
+		t.Errorf("expected a loading placeholder, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	view := stripANSI(m.View())
+	for _, want := range []string{"SWITCH MODEL", "▸ ollama-qwen", "qwen3:8b (current)", "zen-nano", "big-pickle"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected picker to contain %q, got:\n%s", want, view)
+		}
+	}
+
+	// Enter on the current model just closes
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.modelPicker != nil || cmd != nil {
+		t.Fatalf("expected enter on the current model to close without switching")
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = newModel.(Model)
+	choice, _ := m.modelPicker.selected()
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.modelPicker != nil || cmd == nil {
+		t.Fatalf("expected enter to close the picker and switch")
+	}
+	if msg, ok := cmd().(providerSwitchedMsg); !ok || msg.err != nil {
+		t.Fatalf("expected a successful switch, got %+v", msg)
+	}
+	stored, _ := m.store.GetMysis(mysis.ID())
+	if stored.Provider != choice.Provider || stored.Model != choice.Model {
+		t.Errorf("expected mysis on %+v, got %s %s", choice, stored.Provider, stored.Model)
+	}
+}
+
+func TestModelDeleteConfirmation(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m1, _ := m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m2, _ := m.commander.CreateMysis("mysis-2", "ollama-qwen")
+	m.refreshMysisList()
+	m.selectedIdx = 1
+
+	deleteSelected := func(answer string) {
+		t.Helper()
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+		m = newModel.(Model)
+		if m.input.Mode() != InputModeConfirmDelete {
+			t.Fatalf("expected delete confirmation prompt, got mode %v", m.input.Mode())
+		}
+		if answer != "" {
+			newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(answer)})
+			m = newModel.(Model)
+		}
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = newModel.(Model)
+	}
+
+	// Anything but yes cancels
+	for _, answer := range []string{"", "n", "maybe"} {
+		deleteSelected(answer)
+		if m.commander.MysisCount() != 2 {
+			t.Fatalf("answer %q: expected no deletion, have %d myses", answer, m.commander.MysisCount())
+		}
+	}
+
+	deleteSelected("Y")
+	if _, err := m.commander.GetMysis(m2.ID()); err == nil {
+		t.Fatal("expected mysis-2 to be deleted")
+	}
+	if m.selectedIdx != 0 {
+		t.Errorf("expected selection to move to index 0, got %d", m.selectedIdx)
+	}
+
+	// Deletions from elsewhere leave the focus view of the deleted mysis
+	m.view = ViewFocus
+	m.focusID = m1.ID()
+	if err := m.commander.DeleteMysis(m1.ID(), true); err != nil {
+		t.Fatalf("DeleteMysis() error: %v", err)
+	}
+	m.handleEvent(core.Event{Type: core.EventMysisDeleted, MysisID: m1.ID()})
+	if m.view != ViewDashboard || m.focusID != "" || m.selectedIdx != 0 {
+		t.Errorf("expected dashboard at index 0, got view=%v focus=%q idx=%d", m.view, m.focusID, m.selectedIdx)
+	}
+}
+
+func TestModelCompareView(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m1, _ := m.commander.CreateMysis("scout", "ollama-qwen")
+	m2, _ := m.commander.CreateMysis("miner", "zen-nano")
+	s := m.commander.Store()
+	s.AddMemory(m1.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "Report in.", "", "")
+	for i := 0; i < 30; i++ {
+		s.AddMemory(m2.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, fmt.Sprintf("Mining pass %d.", i), "", "")
+	}
+	m.refreshMysisList()
+
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = newModel.(Model)
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		newModel, _ := m.Update(msg)
+		m = newModel.(Model)
+	}
+	compareKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}
+
+	// Pick the first mysis, then the second
+	press(compareKey)
+	if m.view != ViewDashboard || m.compareMarkID != m1.ID() {
+		t.Fatalf("expected scout marked on the dashboard, got view=%v mark=%q", m.view, m.compareMarkID)
+	}
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(compareKey)
+	if m.view != ViewCompare || m.compareIDs != [2]string{m1.ID(), m2.ID()} {
+		t.Fatalf("expected compare view of scout and miner, got view=%v ids=%v", m.view, m.compareIDs)
+	}
+	if m.compareStats[1].Messages == 0 {
+		t.Errorf("expected miner stats, got %+v", m.compareStats[1])
+	}
+
+	view := stripANSI(m.View())
+	for _, want := range []string{"COMPARE · scout vs miner", "Report in.", "Mining pass 29.", "Tool calls"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("compare view missing %q", want)
+		}
+	}
+	for i, line := range strings.Split(m.View(), "\n") {
+		if w := lipgloss.Width(line); w > 120 {
+			t.Errorf("line %d is %d wide, want <= 120", i, w)
+		}
+	}
+
+	// Both columns scroll together; the short log keeps its newest entry at the bottom
+	press(tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.compareViewport.AtBottom() {
+		t.Fatal("expected page up to scroll the compare view")
+	}
+	if !strings.Contains(stripANSI(m.View()), "LINE ") {
+		t.Error("expected a scroll position while scrolled up")
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if !m.compareViewport.AtBottom() {
+		t.Error("expected G to return to the bottom")
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if m.view != ViewCompare || m.status != "Provider latencies reset" {
+		t.Errorf("expected latencies reset in the compare view, got view=%v status=%q", m.view, m.status)
+	}
+
+	// Deleting a compared mysis leaves the view
+	if err := m.commander.DeleteMysis(m2.ID(), true); err != nil {
+		t.Fatalf("DeleteMysis() error: %v", err)
+	}
+	m.handleEvent(core.Event{Type: core.EventMysisDeleted, MysisID: m2.ID()})
+	if m.view != ViewDashboard {
+		t.Errorf("expected dashboard after deleting a compared mysis, got %v", m.view)
+	}
+}
+
+func TestCompareStatsLatency(t *testing.T) {
+	col := CompareColumn{
+		Mysis: MysisInfo{Name: "scout", State: "running", Provider: "ollama"},
+		Stats: CompareStats{LatencyCalls: 12, LatencyP50: 850 * time.Millisecond, LatencyP95: 3400 * time.Millisecond, LatencyP99: 5 * time.Second},
+	}
+	if first := stripANSI(compareStatsLines(col, 80)[0]); !strings.Contains(first, "p50/95/99: 850ms/3.4s/5.0s") {
+		t.Errorf("expected latency percentiles, got %q", first)
+	}
+	// Narrow columns drop the latency before the provider
+	if first := stripANSI(compareStatsLines(col, 40)[0]); strings.Contains(first, "p50") || !strings.Contains(first, "ollama") {
+		t.Errorf("expected provider without latency, got %q", first)
+	}
+	col.Stats.LatencyCalls = 0
+	if first := stripANSI(compareStatsLines(col, 80)[0]); strings.Contains(first, "p50") {
+		t.Errorf("expected no latency without calls, got %q", first)
+	}
+}
+
+func TestRenderCompareLinesAlignsNewest(t *testing.T) {
+	short := []LogEntry{{Role: "assistant", Content: "Only entry."}}
+	long := []LogEntry{
+		{Role: "assistant", Content: "First."},
+		{Role: "assistant", Content: "Second."},
+		{Role: "assistant", Content: "Third."},
+	}
+	lines := renderCompareLines(short, long, 30, false, 0)
+
+	last := stripANSI(lines[len(lines)-1])
+	if !strings.Contains(last, "Only entry.") || !strings.Contains(last, "Third.") {
+		t.Errorf("expected newest entries on the last row, got %q", last)
+	}
+	rowOf := func(text string) int {
+		for i, line := range lines {
+			if strings.Contains(line, text) {
+				return i
+			}
+		}
+		return -1
+	}
+	if first, only := rowOf("First."), rowOf("Only entry."); first < 0 || only <= first {
+		t.Errorf("expected the short log padded at the top, got First. on row %d and Only entry. on row %d", first, only)
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != 30*2+compareColumnGap {
+			t.Errorf("row %d is %d wide, want %d", i, w, 30*2+compareColumnGap)
+		}
+	}
+}
+
 func TestInputModel(t *testing.T) {
 	input := NewInputModel()
 
@@ -250,21 +796,21 @@ func TestRenderDashboard(t *testing.T) {
 
 	loadingSet := make(map[string]bool)
 	swarmMsgs := []SwarmMessageInfo{}
-	dashboard := RenderDashboard(myses, swarmMsgs, 0, 80, 24, loadingSet, "⠋", 0, nil)
+	dashboard := RenderDashboard(myses, swarmMsgs, 0, 80, 24, loadingSet, "⠋", 0, nil, nil)
 	if dashboard == "" {
 		t.Error("expected non-empty dashboard")
 	}
 
 	// Test with loading state
 	loadingSet["1"] = true
-	dashboardWithLoading := RenderDashboard(myses, swarmMsgs, 0, 80, 24, loadingSet, "⠋", 0, nil)
+	dashboardWithLoading := RenderDashboard(myses, swarmMsgs, 0, 80, 24, loadingSet, "⠋", 0, nil, nil)
 	if dashboardWithLoading == "" {
 		t.Error("expected non-empty dashboard with loading")
 	}
 }
 
 func TestRenderDashboardEmpty(t *testing.T) {
-	dashboard := RenderDashboard([]MysisInfo{}, []SwarmMessageInfo{}, 0, 80, 24, make(map[string]bool), "⠋", 0, nil)
+	dashboard := RenderDashboard([]MysisInfo{}, []SwarmMessageInfo{}, 0, 80, 24, make(map[string]bool), "⠋", 0, nil, nil)
 	if dashboard == "" {
 		t.Error("expected non-empty dashboard even with no myses")
 	}
@@ -295,6 +841,7 @@ func TestStateStyle(t *testing.T) {
 	_ = StateStyle("idle")
 	_ = StateStyle("stopped")
 	_ = StateStyle("errored")
+	_ = StateStyle("quarantined")
 	_ = StateStyle("unknown")
 }
 
@@ -399,6 +946,7 @@ func TestInputModelPrompts(t *testing.T) {
 		{InputModeNewMysis, "⬡", 3, "new mysis prompt"},
 		{InputModeConfigProvider, "⚙", 3, "config provider prompt"},
 		{InputModeConfigModel, "cfg", 5, "config model prompt"},
+		{InputModeConfirmDelete, "✕", 3, "confirm delete prompt"},
 	}
 
 	for _, tt := range tests {
@@ -516,7 +1064,7 @@ func TestRenderDashboardWithSwarmMessages(t *testing.T) {
 		{SenderID: "mysis-2", SenderName: "beta", Content: "Do the thing", CreatedAt: time.Now()},
 	}
 
-	dashboard := RenderDashboard(myses, swarmMsgs, 0, 100, 30, make(map[string]bool), "⠋", 0, nil)
+	dashboard := RenderDashboard(myses, swarmMsgs, 0, 100, 30, make(map[string]bool), "⠋", 0, nil, nil)
 	if dashboard == "" {
 		t.Error("expected non-empty dashboard with swarm messages")
 	}
@@ -625,6 +1173,193 @@ func TestRenderMysisLineStates(t *testing.T) {
 	}
 }
 
+func TestActivityLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		info MysisInfo
+		want string
+	}{
+		{"llm call", MysisInfo{State: "running", Activity: "llm_call"}, ""},
+		{"not running", MysisInfo{State: "idle", Activity: "traveling", ActivityLeft: time.Minute}, ""},
+		{"unknown remaining", MysisInfo{State: "running", Activity: "traveling"}, "→ traveling"},
+		{"ticks", MysisInfo{State: "running", Activity: "traveling", ActivityLeft: 115 * time.Second, TickDuration: 10 * time.Second}, "→ traveling (≈T+12)"},
+		{"time only", MysisInfo{State: "running", Activity: "cooldown", ActivityLeft: 44600 * time.Millisecond}, "⏳ cooldown (≈45s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activityLabel(tt.info); got != tt.want {
+				t.Errorf("activityLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	traveling := MysisInfo{ID: "m1", Name: "alpha", State: "running", Provider: "ollama", Activity: "traveling", ActivityLeft: 30 * time.Second, TickDuration: 10 * time.Second}
+	lines := renderMysisLine(traveling, false, false, "⠋", 100, 0)
+	if !strings.Contains(stripANSI(lines[0]), "traveling (≈T+3)") {
+		t.Errorf("expected activity label in info row, got %q", stripANSI(lines[0]))
+	}
+	lines = renderMysisLine(traveling, false, false, "⠋", 50, 0)
+	if strings.Contains(stripANSI(lines[0]), "traveling") {
+		t.Errorf("expected label dropped when it does not fit, got %q", stripANSI(lines[0]))
+	}
+
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+	m.width = 120
+	m.myses = []MysisInfo{traveling}
+	m.view = ViewFocus
+	m.focusID = "m1"
+	if bar := stripANSI(m.renderStatusBar()); !strings.Contains(bar, "→ traveling (≈T+3)") {
+		t.Errorf("expected activity label in focus status bar, got %q", bar)
+	}
+	m.view = ViewDashboard
+	if bar := stripANSI(m.renderStatusBar()); strings.Contains(bar, "traveling") {
+		t.Errorf("expected no activity label on dashboard status bar, got %q", bar)
+	}
+}
+
+func TestTurnCountLabel(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{0, "    0 turns"},
+		{1, "    1 turn "},
+		{12, "   12 turns"},
+		{123456, "123456 turns"},
+	}
+	for _, tt := range tests {
+		if got := turnCountLabel(tt.count); got != tt.want {
+			t.Errorf("turnCountLabel(%d) = %q, want %q", tt.count, got, tt.want)
+		}
+	}
+
+	info := MysisInfo{ID: "m1", Name: "alpha", State: "running", Provider: "ollama", TurnCount: 12}
+	lines := renderMysisLine(info, false, false, "⠋", 100, 0)
+	if !strings.Contains(stripANSI(lines[0]), "12 turns") {
+		t.Errorf("expected turn count in info row, got %q", stripANSI(lines[0]))
+	}
+}
+
+func TestFocusHeaderLastTick(t *testing.T) {
+	info := MysisInfo{ID: "m1", Name: "alpha", State: "running", Provider: "ollama"}
+	if got := lastTickText(info); got != "" {
+		t.Errorf("expected no tick text before a tick, got %q", got)
+	}
+
+	info.LastTick = 48213
+	info.TickAge = 12 * time.Second
+	info.TickDuration = 9876 * time.Millisecond
+	header := stripANSI(renderFocusHeader(info, 1, 1, 120, "⠋"))
+	if !strings.Contains(header, "Tick: 48213 (9.9s/tick, 12s ago)") {
+		t.Errorf("expected last tick in header, got %q", header)
+	}
+	if strings.Contains(header, "STALE") {
+		t.Errorf("expected no warning for a fresh tick, got %q", header)
+	}
+
+	// A stale tick is shown even when it displaces the creation date
+	info.TickAge = 5 * time.Minute
+	info.TickStale = true
+	header = stripANSI(renderFocusHeader(info, 1, 1, 60, "⠋"))
+	if !strings.Contains(header, "5m0s ago") || !strings.Contains(header, "STALE") {
+		t.Errorf("expected stale warning in header, got %q", header)
+	}
+}
+
+func TestRenderConversationTurns(t *testing.T) {
+	logs := []LogEntry{
+		{Role: "user", Source: "direct", Content: "Check the ship", TurnStart: true},
+		{Role: "assistant", Source: "llm", Content: "Hull at 100%"},
+		{Role: "user", Source: "direct", Content: "Scan the belt", TurnStart: true},
+		{Role: "assistant", Source: "llm", Content: "Found iron ore"},
+		{Role: "assistant", Source: "llm", Content: "Mining now", TurnStart: true},
+	}
+
+	lines, starts := renderConversation(logs, 80, false, false, 0)
+	view := stripANSI(strings.Join(lines, "\n"))
+	if !strings.Contains(view, "▸ · 2 entries · Check the ship") || !strings.Contains(view, "▸ · 2 entries · Scan the belt") {
+		t.Errorf("expected earlier turns collapsed to summaries, got:\n%s", view)
+	}
+	if strings.Contains(view, "Hull at 100%") || !strings.Contains(view, "Mining now") {
+		t.Errorf("expected only the current turn in full, got:\n%s", view)
+	}
+	if !strings.Contains(view, "CURRENT TURN") {
+		t.Errorf("expected a current turn separator, got:\n%s", view)
+	}
+	if len(starts) != len(logs) || starts[0] != starts[1] || starts[2] == starts[1] {
+		t.Errorf("expected collapsed entries to share their summary line, got %v", starts)
+	}
+
+	lines, _ = renderConversation(logs, 80, false, true, 0)
+	view = stripANSI(strings.Join(lines, "\n"))
+	if strings.Contains(view, "▸") || !strings.Contains(view, "Hull at 100%") || !strings.Contains(view, "CURRENT TURN") {
+		t.Errorf("expected earlier turns in full when expanded, got:\n%s", view)
+	}
+
+	// Without turn starts there is nothing to mark or collapse
+	for i := range logs {
+		logs[i].TurnStart = false
+	}
+	lines, _ = renderConversation(logs, 80, false, false, 0)
+	view = stripANSI(strings.Join(lines, "\n"))
+	if strings.Contains(view, "CURRENT TURN") || !strings.Contains(view, "Hull at 100%") {
+		t.Errorf("expected a plain log without turn starts, got:\n%s", view)
+	}
+}
+
+func TestConversationLogMarksTurnStarts(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	mysis, _ := m.commander.CreateMysis("turns", "ollama-qwen")
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleUser, store.MemorySourceDirect, "mine the belt", "", "")
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "heading to the belt", "", "")
+	m.store.AddBroadcastMemory(mysis.ID(), "regroup at Sol", "", "")
+	m.store.AddMemory(mysis.ID(), store.MemoryRoleAssistant, store.MemorySourceLLM, "heading to Sol", "", "")
+
+	logs, err := m.conversationLog(mysis.ID())
+	if err != nil {
+		t.Fatalf("conversationLog() error: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("expected the broadcast hidden, got %d entries", len(logs))
+	}
+	// The hidden broadcast starts a turn at the reply that follows it
+	if !logs[0].TurnStart || logs[1].TurnStart || !logs[2].TurnStart {
+		t.Errorf("unexpected turn starts: %v %v %v", logs[0].TurnStart, logs[1].TurnStart, logs[2].TurnStart)
+	}
+}
+
+func TestContextFillLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent int
+		known   bool
+		want    string
+	}{
+		{"unknown", 0, false, "ctx --"},
+		{"empty", 0, true, "ctx ▱▱▱▱▱   0%"},
+		{"partial", 42, true, "ctx ▰▰▱▱▱  42%"},
+		{"over window", 130, true, "ctx ▰▰▰▰▰ 130%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contextFillLabel(tt.percent, tt.known); got != tt.want {
+				t.Errorf("contextFillLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	info := MysisInfo{ID: "m1", Name: "alpha", State: "running", Provider: "ollama", ContextFill: 42, ContextKnown: true}
+	lines := renderMysisLine(info, false, false, "⠋", 100, 0)
+	if !strings.Contains(stripANSI(lines[0]), "ctx ▰▰▱▱▱  42%") {
+		t.Errorf("expected context fill in info row, got %q", stripANSI(lines[0]))
+	}
+}
+
 func TestMysisLineWidthFill(t *testing.T) {
 	// Test that mysis lines are styled with width to fill the panel
 	mysis := MysisInfo{
@@ -774,6 +1509,8 @@ func TestUnicodeAmbiguousWidthSafety(t *testing.T) {
 		"errored":        "✖", // U+2716 - heavy multiplication X (SAFE)
 		"gear":           "⚙", // U+2699 - gear (SAFE)
 		"braille":        "⠋", // U+280B - braille pattern (SAFE)
+		"gauge_filled":   "▰", // U+25B0 - black parallelogram (SAFE)
+		"gauge_empty":    "▱", // U+25B1 - white parallelogram (SAFE)
 	}
 
 	for name, char := range chars {
@@ -1073,3 +1810,156 @@ func TestNetworkIndicator_NegativeCounterProtection(t *testing.T) {
 		t.Errorf("activeNetworkOps = %d, want 0", m.activeNetworkOps)
 	}
 }
+
+func TestHandleEventToolRetryExhausted(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m.handleEvent(core.Event{
+		Type:      core.EventMysisError,
+		MysisID:   "test-mysis",
+		MysisName: "alpha",
+		Error: &core.ErrorData{
+			Error:   "tool get_status failed after 3 retries",
+			Kind:    core.ErrorKindToolRetryExhausted,
+			Tool:    "get_status",
+			Retries: 3,
+			Cause:   "server_error",
+			Reason:  "http error 500: boom",
+		},
+		Timestamp: time.Now(),
+	})
+
+	if m.err == nil {
+		t.Fatal("expected retry failure to be surfaced")
+	}
+	want := "alpha: tool get_status failed after 3 retries (server_error): http error 500: boom"
+	if m.err.Error() != want {
+		t.Errorf("err = %q, want %q", m.err.Error(), want)
+	}
+}
+
+func TestFormatBulkStatus(t *testing.T) {
+	if got := formatBulkStatus("Start idle", core.BulkResult{}); got != "Start idle: no myses matched" {
+		t.Errorf("unexpected empty status: %q", got)
+	}
+	result := core.BulkResult{Attempted: 3, Errors: []error{errors.New("alpha: mysis already running")}}
+	want := "Start idle: 2/3 succeeded (first error: alpha: mysis already running)"
+	if got := formatBulkStatus("Start idle", result); got != want {
+		t.Errorf("formatBulkStatus() = %q, want %q", got, want)
+	}
+}
+
+func TestBulkResultShowsTransientStatus(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.testTime = &now
+	m.width = 120
+
+	updated, _ := m.Update(bulkResultMsg{action: "Stop running", result: core.BulkResult{Attempted: 2}})
+	m = updated.(Model)
+	if !m.statusActive() {
+		t.Fatal("expected status to be active")
+	}
+	if !strings.Contains(m.renderStatusBar(), "Stop running: 2/2 succeeded") {
+		t.Error("expected status bar to show bulk summary")
+	}
+
+	later := now.Add(statusMessageDuration + time.Second)
+	m.testTime = &later
+	if m.statusActive() {
+		t.Error("expected status to expire")
+	}
+}
+
+func TestToolStatsLoadInBackground(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	now := time.Now()
+	m.testTime = &now
+	m.store.RecordToolCalls([]store.ToolCallEvent{
+		{MysisID: "a", ToolName: "mine", Success: true, CreatedAt: now},
+		{MysisID: "a", ToolName: "mine", Success: false, CreatedAt: now},
+	})
+
+	// Refreshing the mysis list leaves the stats to the background load
+	m.refreshMysisList()
+	if m.toolStats != nil {
+		t.Fatalf("expected no stats before the load runs, got %+v", m.toolStats)
+	}
+
+	msg := m.loadToolStats(0)()
+	updated, cmd := m.Update(msg)
+	m = updated.(Model)
+	if len(m.toolStats) != 1 || m.toolStats[0] != (store.ToolCallStat{ToolName: "mine", Calls: 2, Errors: 1}) {
+		t.Errorf("unexpected tool stats: %+v", m.toolStats)
+	}
+	if cmd == nil {
+		t.Error("expected the next load to be scheduled")
+	}
+}
+
+func TestBroadcastDuplicateShowsStatus(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	updated, _ := m.Update(broadcastResult{err: core.ErrBroadcastDuplicate})
+	m = updated.(Model)
+	if m.err != nil {
+		t.Errorf("expected no error for suppressed duplicate, got %v", m.err)
+	}
+	if !m.statusActive() || m.status != "Duplicate broadcast suppressed" {
+		t.Errorf("expected duplicate status, got %q", m.status)
+	}
+}
+
+func TestViewStatePersistence(t *testing.T) {
+	m, cleanup := setupTestModel(t)
+	defer cleanup()
+
+	m.commander.CreateMysis("mysis-1", "ollama-qwen")
+	m2, _ := m.commander.CreateMysis("mysis-2", "ollama-qwen")
+	m.refreshMysisList()
+
+	path := filepath.Join(t.TempDir(), "tui_state.json")
+	m.SetStateFile(path)
+	m.selectedIdx = 1
+	m.view = ViewFocus
+	m.focusID = m2.ID()
+	m.SaveViewState()
+
+	restart := func() Model {
+		t.Helper()
+		restored := m
+		restored.view = ViewDashboard
+		restored.selectedIdx = 0
+		restored.focusID = ""
+		restored.SetStateFile(path)
+		newModel, _ := restored.Update(refreshMysesMsg{})
+		return newModel.(Model)
+	}
+
+	restored := restart()
+	if restored.view != ViewFocus || restored.focusID != m2.ID() || restored.selectedIdx != 1 {
+		t.Errorf("expected focus on mysis-2 at index 1, got view=%v focus=%q idx=%d", restored.view, restored.focusID, restored.selectedIdx)
+	}
+
+	// Restoring happens once; later refreshes keep the user's selection
+	restored.selectedIdx = 0
+	newModel, _ := restored.Update(refreshMysesMsg{})
+	if newModel.(Model).selectedIdx != 0 {
+		t.Error("expected later refreshes not to reapply saved state")
+	}
+
+	// A deleted mysis falls back to the dashboard at index 0
+	if err := m.commander.DeleteMysis(m2.ID(), true); err != nil {
+		t.Fatalf("DeleteMysis() error: %v", err)
+	}
+	restored = restart()
+	if restored.view != ViewDashboard || restored.focusID != "" || restored.selectedIdx != 0 {
+		t.Errorf("expected dashboard at index 0, got view=%v focus=%q idx=%d", restored.view, restored.focusID, restored.selectedIdx)
+	}
+}